@@ -0,0 +1,225 @@
+// Package filter builds and checks data.v1.Filter values on the client, so that invalid filters are caught
+// before they reach the server.
+//
+// A Builder produces a Filter, a DataRequest or a DeleteTabularFilter. Filters can be stored as compact
+// query strings with Encode and Parse, and converted to the equivalent MQL $match stage with Match so that
+// filter-based and MQL-based queries select the same tabular data.
+package filter
+
+import (
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// Builder accumulates the fields of a Filter. Its methods return the Builder so that calls can be chained;
+// the result is only checked by Filter, DataRequest and DeleteTabularFilter.
+type Builder struct {
+	f *datapb.Filter
+}
+
+// New returns an empty Builder, which matches all data.
+func New() *Builder {
+	return &Builder{f: &datapb.Filter{}}
+}
+
+// From returns a Builder starting from a copy of f.
+func From(f *datapb.Filter) *Builder {
+	if f == nil {
+		return New()
+	}
+	return &Builder{f: proto.Clone(f).(*datapb.Filter)}
+}
+
+// ComponentName matches data captured from the named component.
+func (b *Builder) ComponentName(name string) *Builder {
+	b.f.ComponentName = name
+	return b
+}
+
+// ComponentType matches data captured from components of the given type, e.g. "rdk:component:camera".
+func (b *Builder) ComponentType(typ string) *Builder {
+	b.f.ComponentType = typ
+	return b
+}
+
+// Method matches data captured by the named method.
+func (b *Builder) Method(method string) *Builder {
+	b.f.Method = method
+	return b
+}
+
+// RobotName matches data from the named machine.
+func (b *Builder) RobotName(name string) *Builder {
+	b.f.RobotName = name
+	return b
+}
+
+// RobotID matches data from the machine with the given id.
+func (b *Builder) RobotID(id string) *Builder {
+	b.f.RobotId = id
+	return b
+}
+
+// PartName matches data from the named machine part.
+func (b *Builder) PartName(name string) *Builder {
+	b.f.PartName = name
+	return b
+}
+
+// PartID matches data from the machine part with the given id.
+func (b *Builder) PartID(id string) *Builder {
+	b.f.PartId = id
+	return b
+}
+
+// LocationIDs matches data from any of the given locations. It adds to any locations already set.
+func (b *Builder) LocationIDs(ids ...string) *Builder {
+	b.f.LocationIds = append(b.f.LocationIds, ids...)
+	return b
+}
+
+// OrganizationIDs matches data from any of the given organizations. It adds to any organizations already
+// set.
+func (b *Builder) OrganizationIDs(ids ...string) *Builder {
+	b.f.OrganizationIds = append(b.f.OrganizationIds, ids...)
+	return b
+}
+
+// MimeTypes matches data of any of the given MIME types. It adds to any MIME types already set.
+func (b *Builder) MimeTypes(types ...string) *Builder {
+	b.f.MimeType = append(b.f.MimeType, types...)
+	return b
+}
+
+// Interval matches data captured between start and end. A zero time leaves that side open.
+func (b *Builder) Interval(start, end time.Time) *Builder {
+	return b.Since(start).Until(end)
+}
+
+// Since matches data captured at or after start. A zero time clears the bound.
+func (b *Builder) Since(start time.Time) *Builder {
+	b.interval().Start = timestamp(start)
+	return b
+}
+
+// Until matches data captured before end. A zero time clears the bound.
+func (b *Builder) Until(end time.Time) *Builder {
+	b.interval().End = timestamp(end)
+	return b
+}
+
+func (b *Builder) interval() *datapb.CaptureInterval {
+	if b.f.Interval == nil {
+		b.f.Interval = &datapb.CaptureInterval{}
+	}
+	return b.f.Interval
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// Tags matches data with any of the given tags. It adds to any tags already set and replaces Tagged or
+// Untagged.
+func (b *Builder) Tags(tags ...string) *Builder {
+	var existing []string
+	if b.f.GetTagsFilter().GetType() == datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR {
+		existing = b.f.GetTagsFilter().GetTags()
+	}
+	b.f.TagsFilter = &datapb.TagsFilter{
+		Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR,
+		Tags: append(slices.Clone(existing), tags...),
+	}
+	return b
+}
+
+// Tagged matches all data that has at least one tag.
+func (b *Builder) Tagged() *Builder {
+	b.f.TagsFilter = &datapb.TagsFilter{Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED}
+	return b
+}
+
+// Untagged matches all data that has no tags.
+func (b *Builder) Untagged() *Builder {
+	b.f.TagsFilter = &datapb.TagsFilter{Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_UNTAGGED}
+	return b
+}
+
+// BBoxLabels matches binary data with a bounding box carrying any of the given labels. It adds to any
+// labels already set.
+func (b *Builder) BBoxLabels(labels ...string) *Builder {
+	b.f.BboxLabels = append(b.f.BboxLabels, labels...)
+	return b
+}
+
+// DatasetID matches binary data in the given dataset.
+func (b *Builder) DatasetID(id string) *Builder {
+	b.f.DatasetId = id
+	return b
+}
+
+// Filter checks the accumulated fields and returns a copy of the resulting Filter.
+func (b *Builder) Filter() (*datapb.Filter, error) {
+	f := proto.Clone(b.f).(*datapb.Filter)
+	if f.GetInterval().GetStart() == nil && f.GetInterval().GetEnd() == nil {
+		f.Interval = nil
+	}
+	if err := Validate(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// DataRequest returns a DataRequest for the first page of data matching the filter. A limit of zero leaves
+// the page size to the server.
+func (b *Builder) DataRequest(limit uint64, order datapb.Order) (*datapb.DataRequest, error) {
+	f, err := b.Filter()
+	if err != nil {
+		return nil, err
+	}
+	return &datapb.DataRequest{Filter: f, Limit: limit, SortOrder: order}, nil
+}
+
+// DeleteTabularFilter returns the DeleteTabularFilter equivalent to the filter. It fails if the filter sets
+// fields that DeleteTabularFilter cannot express, rather than deleting more data than was asked for.
+func (b *Builder) DeleteTabularFilter() (*datapb.DeleteTabularFilter, error) {
+	f, err := b.Filter()
+	if err != nil {
+		return nil, err
+	}
+	if err := unsupported(f, "DeleteTabularFilter", deleteFields); err != nil {
+		return nil, err
+	}
+	return &datapb.DeleteTabularFilter{
+		LocationIds:   f.GetLocationIds(),
+		RobotId:       f.GetRobotId(),
+		PartId:        f.GetPartId(),
+		ComponentType: f.GetComponentType(),
+		ComponentName: f.GetComponentName(),
+		Method:        f.GetMethod(),
+		TagsFilter:    f.GetTagsFilter(),
+	}, nil
+}
+
+// FromDeleteTabularFilter returns a Builder holding the fields of a DeleteTabularFilter.
+func FromDeleteTabularFilter(d *datapb.DeleteTabularFilter) *Builder {
+	b := New()
+	b.f.LocationIds = slices.Clone(d.GetLocationIds())
+	b.f.RobotId = d.GetRobotId()
+	b.f.PartId = d.GetPartId()
+	b.f.ComponentType = d.GetComponentType()
+	b.f.ComponentName = d.GetComponentName()
+	b.f.Method = d.GetMethod()
+	if d.GetTagsFilter() != nil {
+		b.f.TagsFilter = proto.Clone(d.GetTagsFilter()).(*datapb.TagsFilter)
+	}
+	return b
+}
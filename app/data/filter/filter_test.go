@@ -0,0 +1,252 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	datapb "go.viam.com/api/app/data/v1"
+	"go.viam.com/api/app/mql"
+)
+
+const (
+	robotID = "0f7b3c1e-2a4d-4e5f-8a9b-0c1d2e3f4a5b"
+	orgID   = "11111111-2222-3333-4444-555555555555"
+)
+
+var (
+	start = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	end   = start.Add(time.Hour)
+)
+
+func invalidFields(err error) []string {
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ve *ValidationError
+		if errors.As(e, &ve) {
+			fields = append(fields, ve.Field)
+		}
+	}
+	return fields
+}
+
+func TestBuilder(t *testing.T) {
+	f, err := New().
+		ComponentName("cam").
+		ComponentType("rdk:component:camera").
+		Method("ReadImage").
+		RobotID(robotID).
+		LocationIDs("loc1").LocationIDs("loc2").
+		Tags("a").Tags("b").
+		Since(start).
+		Filter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &datapb.Filter{
+		ComponentName: "cam",
+		ComponentType: "rdk:component:camera",
+		Method:        "ReadImage",
+		RobotId:       robotID,
+		LocationIds:   []string{"loc1", "loc2"},
+		TagsFilter:    &datapb.TagsFilter{Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR, Tags: []string{"a", "b"}},
+		Interval:      &datapb.CaptureInterval{Start: timestamppb.New(start)},
+	}
+	if !proto.Equal(f, want) {
+		t.Errorf("Filter = %v, want %v", f, want)
+	}
+
+	if f, err := New().Tags("a").Tagged().Filter(); err != nil || f.GetTagsFilter().GetTags() != nil {
+		t.Errorf("Tagged after Tags = %v, %v", f, err)
+	}
+	if f, err := New().Since(start).Since(time.Time{}).Filter(); err != nil || f.GetInterval() != nil {
+		t.Errorf("cleared interval = %v, %v", f, err)
+	}
+
+	orig := &datapb.Filter{LocationIds: []string{"a"}}
+	From(orig).LocationIDs("b")
+	if len(orig.GetLocationIds()) != 1 {
+		t.Error("From modified its argument")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		f    *datapb.Filter
+		want []string
+	}{
+		{"empty", &datapb.Filter{}, nil},
+		{"valid ids", &datapb.Filter{RobotId: robotID, OrganizationIds: []string{orgID}, DatasetId: "0123456789abcdef01234567"}, nil},
+		{"bad robot id", &datapb.Filter{RobotId: "robot"}, []string{"robot_id"}},
+		{"bad ids", &datapb.Filter{PartId: "x", OrganizationIds: []string{"y"}, LocationIds: []string{"a-b"}, DatasetId: "z"},
+			[]string{"part_id", "organization_ids", "location_ids", "dataset_id"}},
+		{"bad mime type", &datapb.Filter{MimeType: []string{"image/jpeg", "jpeg", "image/"}}, []string{"mime_type", "mime_type"}},
+		{"backwards interval", &datapb.Filter{Interval: &datapb.CaptureInterval{
+			Start: timestamppb.New(end), End: timestamppb.New(start),
+		}}, []string{"interval"}},
+		{"invalid timestamp", &datapb.Filter{Interval: &datapb.CaptureInterval{
+			Start: &timestamppb.Timestamp{Nanos: -1},
+		}}, []string{"interval"}},
+		{"or without tags", &datapb.Filter{TagsFilter: &datapb.TagsFilter{Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR}},
+			[]string{"tags_filter"}},
+		{"tagged with tags", &datapb.Filter{TagsFilter: &datapb.TagsFilter{
+			Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED, Tags: []string{"a"},
+		}}, []string{"tags_filter"}},
+		{"empty tag", &datapb.Filter{TagsFilter: &datapb.TagsFilter{
+			Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR, Tags: []string{""},
+		}}, []string{"tags_filter"}},
+		{"unknown tags type", &datapb.Filter{TagsFilter: &datapb.TagsFilter{Type: 99}}, []string{"tags_filter"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.f)
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("Validate = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate succeeded")
+			}
+			if got := invalidFields(err); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("invalid fields = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryRoundTrip(t *testing.T) {
+	for _, b := range []*Builder{
+		New(),
+		New().ComponentName("cam").RobotName("r").PartName("p").PartID(robotID).MimeTypes("image/jpeg", "image/png"),
+		New().OrganizationIDs(orgID).LocationIDs("a", "b").Interval(start, end.Add(time.Nanosecond)).Tags("x", "y"),
+		New().Untagged().BBoxLabels("dog").DatasetID("0123456789abcdef01234567"),
+		New().Tagged().Until(end),
+	} {
+		want, err := b.Filter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := b.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := Parse(q)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", q, err)
+		}
+		got, err := parsed.Filter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("Parse(%q) = %v, want %v", q, got, want)
+		}
+		if again := Encode(got); again != q {
+			t.Errorf("Encode is not stable: %q, then %q", q, again)
+		}
+	}
+	if q := Encode(&datapb.Filter{ComponentName: "c", LocationIds: []string{"l"}}); q != "component_name=c&location_id=l" {
+		t.Errorf("Encode = %q", q)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, q := range []string{
+		"%zz",
+		"component=cam",
+		"component_name=a&component_name=b",
+		"start=yesterday",
+		"end=2024-01-01T00:00:00Z&end=2024-01-02T00:00:00Z",
+		"tags=some",
+		"tags=tagged&tag=a",
+	} {
+		if _, err := Parse(q); err == nil {
+			t.Errorf("Parse(%q) succeeded", q)
+		}
+	}
+}
+
+func TestDeleteTabularFilter(t *testing.T) {
+	b := New().LocationIDs("loc").RobotID(robotID).ComponentName("cam").Method("m").Tags("t")
+	d, err := b.DeleteTabularFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := FromDeleteTabularFilter(d).Filter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := b.Filter()
+	if !proto.Equal(back, want) {
+		t.Errorf("FromDeleteTabularFilter = %v, want %v", back, want)
+	}
+
+	_, err = New().RobotName("r").Since(start).DeleteTabularFilter()
+	if got := invalidFields(err); !reflect.DeepEqual(got, []string{"robot_name", "interval"}) {
+		t.Errorf("unsupported fields = %v", got)
+	}
+	if _, err := New().RobotID("bad").DeleteTabularFilter(); err == nil {
+		t.Error("DeleteTabularFilter accepted an invalid filter")
+	}
+}
+
+func TestDataRequest(t *testing.T) {
+	req, err := New().ComponentName("cam").DataRequest(10, datapb.Order_ORDER_DESCENDING)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetLimit() != 10 || req.GetSortOrder() != datapb.Order_ORDER_DESCENDING || req.GetFilter().GetComponentName() != "cam" {
+		t.Errorf("DataRequest = %v", req)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		b    *Builder
+		want mql.D
+	}{
+		{"empty", New(), mql.D{}},
+		{
+			"fields",
+			New().OrganizationIDs(orgID).LocationIDs("a", "b").ComponentName("cam").Method("m"),
+			mql.D{
+				{Key: "organization_id", Value: orgID},
+				{Key: "location_id", Value: mql.In("a", "b")},
+				{Key: "component_name", Value: "cam"},
+				{Key: "method_name", Value: "m"},
+			},
+		},
+		{"interval", New().Interval(start, end), mql.D{{Key: "time_received", Value: mql.D{
+			{Key: "$gte", Value: start}, {Key: "$lt", Value: end},
+		}}}},
+		{"open interval", New().Until(end), mql.D{{Key: "time_received", Value: mql.Lt(end)}}},
+		{"tags", New().Tags("a"), mql.D{{Key: "tags", Value: mql.In("a")}}},
+		{"tagged", New().Tagged(), mql.D{{Key: "tags", Value: mql.Nin(nil, mql.A{})}}},
+		{"untagged", New().Untagged(), mql.D{{Key: "tags", Value: mql.In(nil, mql.A{})}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.b.Match()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Match = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := New().MimeTypes("image/jpeg").Match(); err == nil {
+		t.Error("Match accepted mime_type")
+	}
+	p, err := New().ComponentName("cam").Pipeline()
+	if err != nil || len(p) != 1 || p[0][0].Key != "$match" {
+		t.Errorf("Pipeline = %v, %v", p, err)
+	}
+}
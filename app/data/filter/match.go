@@ -0,0 +1,97 @@
+package filter
+
+import (
+	datapb "go.viam.com/api/app/data/v1"
+	"go.viam.com/api/app/mql"
+)
+
+// mqlFields are the Filter fields that have a counterpart in the documents of the tabular data collection.
+var mqlFields = map[string]bool{
+	"component_name":   true,
+	"component_type":   true,
+	"method":           true,
+	"robot_id":         true,
+	"part_id":          true,
+	"location_ids":     true,
+	"organization_ids": true,
+	"interval":         true,
+	"tags_filter":      true,
+}
+
+// Match checks the filter and returns the MQL $match filter that selects the same tabular data. The
+// interval applies to time_received. Fields with no counterpart in tabular data, such as robot_name,
+// mime_type or bbox_labels, are rejected.
+func (b *Builder) Match() (mql.D, error) {
+	f, err := b.Filter()
+	if err != nil {
+		return nil, err
+	}
+	if err := unsupported(f, "MQL", mqlFields); err != nil {
+		return nil, err
+	}
+
+	m := mql.D{}
+	eq := func(field, value string) {
+		if value != "" {
+			m = append(m, mql.E{Key: field, Value: value})
+		}
+	}
+	in := func(field string, values []string) {
+		switch len(values) {
+		case 0:
+		case 1:
+			m = append(m, mql.E{Key: field, Value: values[0]})
+		default:
+			m = append(m, mql.E{Key: field, Value: mql.In(anys(values)...)})
+		}
+	}
+	in("organization_id", f.GetOrganizationIds())
+	in("location_id", f.GetLocationIds())
+	eq("robot_id", f.GetRobotId())
+	eq("part_id", f.GetPartId())
+	eq("component_type", f.GetComponentType())
+	eq("component_name", f.GetComponentName())
+	eq("method_name", f.GetMethod())
+
+	if iv := f.GetInterval(); iv != nil {
+		var bounds mql.D
+		if iv.GetStart() != nil {
+			bounds = append(bounds, mql.Gte(iv.GetStart().AsTime())...)
+		}
+		if iv.GetEnd() != nil {
+			bounds = append(bounds, mql.Lt(iv.GetEnd().AsTime())...)
+		}
+		m = append(m, mql.E{Key: "time_received", Value: bounds})
+	}
+
+	tf := f.GetTagsFilter()
+	switch tf.GetType() {
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED:
+		m = append(m, mql.E{Key: "tags", Value: mql.Nin(nil, mql.A{})})
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_UNTAGGED:
+		m = append(m, mql.E{Key: "tags", Value: mql.In(nil, mql.A{})})
+	default:
+		if len(tf.GetTags()) > 0 {
+			m = append(m, mql.E{Key: "tags", Value: mql.In(anys(tf.GetTags())...)})
+		}
+	}
+	return m, nil
+}
+
+// Pipeline returns a pipeline holding the $match stage built by Match.
+func (b *Builder) Pipeline() (mql.Pipeline, error) {
+	m, err := b.Match()
+	if err != nil {
+		return nil, err
+	}
+	return mql.Pipeline{}.Match(m), nil
+}
+
+// anys converts values for use with mql.In.
+func anys(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package filter
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// Query string keys. Repeated fields repeat their key, and times use RFC 3339 with nanoseconds.
+const (
+	keyComponentName  = "component_name"
+	keyComponentType  = "component_type"
+	keyMethod         = "method"
+	keyRobotName      = "robot_name"
+	keyRobotID        = "robot_id"
+	keyPartName       = "part_name"
+	keyPartID         = "part_id"
+	keyLocationID     = "location_id"
+	keyOrganizationID = "organization_id"
+	keyMimeType       = "mime_type"
+	keyStart          = "start"
+	keyEnd            = "end"
+	keyTag            = "tag"
+	keyTags           = "tags"
+	keyBBoxLabel      = "bbox_label"
+	keyDatasetID      = "dataset_id"
+
+	tagsTagged   = "tagged"
+	tagsUntagged = "untagged"
+)
+
+// Encode returns f as a query string, e.g. "component_name=cam&start=2024-01-02T00%3A00%3A00Z&tag=a&tag=b".
+// Keys are sorted, so equal filters encode identically.
+func Encode(f *datapb.Filter) string {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	set(keyComponentName, f.GetComponentName())
+	set(keyComponentType, f.GetComponentType())
+	set(keyMethod, f.GetMethod())
+	set(keyRobotName, f.GetRobotName())
+	set(keyRobotID, f.GetRobotId())
+	set(keyPartName, f.GetPartName())
+	set(keyPartID, f.GetPartId())
+	set(keyDatasetID, f.GetDatasetId())
+	v[keyLocationID] = f.GetLocationIds()
+	v[keyOrganizationID] = f.GetOrganizationIds()
+	v[keyMimeType] = f.GetMimeType()
+	v[keyBBoxLabel] = f.GetBboxLabels()
+	if start := f.GetInterval().GetStart(); start != nil {
+		v.Set(keyStart, start.AsTime().Format(time.RFC3339Nano))
+	}
+	if end := f.GetInterval().GetEnd(); end != nil {
+		v.Set(keyEnd, end.AsTime().Format(time.RFC3339Nano))
+	}
+	switch f.GetTagsFilter().GetType() {
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED:
+		v.Set(keyTags, tagsTagged)
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_UNTAGGED:
+		v.Set(keyTags, tagsUntagged)
+	default:
+		v[keyTag] = f.GetTagsFilter().GetTags()
+	}
+	for key, vals := range v {
+		if len(vals) == 0 {
+			delete(v, key)
+		}
+	}
+	return v.Encode()
+}
+
+// Encode checks the filter and returns it as a query string.
+func (b *Builder) Encode() (string, error) {
+	f, err := b.Filter()
+	if err != nil {
+		return "", err
+	}
+	return Encode(f), nil
+}
+
+// Parse returns a Builder holding the filter encoded in query. Unknown keys are rejected so that a typo
+// does not silently widen the filter.
+func Parse(query string) (*Builder, error) {
+	v, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+	b := New()
+	single := map[string]func(string) *Builder{
+		keyComponentName: b.ComponentName,
+		keyComponentType: b.ComponentType,
+		keyMethod:        b.Method,
+		keyRobotName:     b.RobotName,
+		keyRobotID:       b.RobotID,
+		keyPartName:      b.PartName,
+		keyPartID:        b.PartID,
+		keyDatasetID:     b.DatasetID,
+	}
+	repeated := map[string]func(...string) *Builder{
+		keyLocationID:     b.LocationIDs,
+		keyOrganizationID: b.OrganizationIDs,
+		keyMimeType:       b.MimeTypes,
+		keyBBoxLabel:      b.BBoxLabels,
+		keyTag:            b.Tags,
+	}
+	for key, vals := range v {
+		switch {
+		case single[key] != nil:
+			if len(vals) > 1 {
+				return nil, &ValidationError{Field: key, Reason: "repeated in query"}
+			}
+			single[key](vals[0])
+		case repeated[key] != nil:
+			repeated[key](vals...)
+		case key == keyStart || key == keyEnd:
+			if len(vals) > 1 {
+				return nil, &ValidationError{Field: key, Reason: "repeated in query"}
+			}
+			t, err := time.Parse(time.RFC3339Nano, vals[0])
+			if err != nil {
+				return nil, &ValidationError{Field: "interval", Reason: err.Error()}
+			}
+			if key == keyStart {
+				b.Since(t)
+			} else {
+				b.Until(t)
+			}
+		case key == keyTags:
+			if len(vals) > 1 || (vals[0] != tagsTagged && vals[0] != tagsUntagged) {
+				return nil, &ValidationError{Field: key, Reason: fmt.Sprintf("must be %q or %q", tagsTagged, tagsUntagged)}
+			}
+		default:
+			return nil, &ValidationError{Field: key, Reason: "unknown query key"}
+		}
+	}
+	if tags, ok := v[keyTags]; ok {
+		if len(v[keyTag]) > 0 {
+			return nil, &ValidationError{Field: keyTags, Reason: "cannot be combined with " + keyTag}
+		}
+		if tags[0] == tagsTagged {
+			b.Tagged()
+		} else {
+			b.Untagged()
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	locationPattern = regexp.MustCompile(`^[0-9a-zA-Z]+$`)
+	objectIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+)
+
+// deleteFields are the Filter fields that a DeleteTabularFilter can express.
+var deleteFields = map[string]bool{
+	"location_ids":   true,
+	"robot_id":       true,
+	"part_id":        true,
+	"component_type": true,
+	"component_name": true,
+	"method":         true,
+	"tags_filter":    true,
+}
+
+// ValidationError describes one invalid field of a Filter.
+type ValidationError struct {
+	// Field is the proto name of the field, e.g. "robot_id" or "interval".
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("filter: %s: %s", e.Field, e.Reason)
+}
+
+// Validate checks f for mistakes the server would reject or silently ignore: malformed ids, an interval
+// that ends before it starts, tags combined with a tags filter type that ignores them, and malformed MIME
+// types. Every problem is reported, joined with errors.Join.
+func Validate(f *datapb.Filter) error {
+	var errs []error
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	if id := f.GetRobotId(); id != "" && !uuidPattern.MatchString(id) {
+		invalid("robot_id", "%q is not a UUID", id)
+	}
+	if id := f.GetPartId(); id != "" && !uuidPattern.MatchString(id) {
+		invalid("part_id", "%q is not a UUID", id)
+	}
+	for _, id := range f.GetOrganizationIds() {
+		if !uuidPattern.MatchString(id) {
+			invalid("organization_ids", "%q is not a UUID", id)
+		}
+	}
+	for _, id := range f.GetLocationIds() {
+		if !locationPattern.MatchString(id) {
+			invalid("location_ids", "%q is not a location id", id)
+		}
+	}
+	if id := f.GetDatasetId(); id != "" && !objectIDPattern.MatchString(id) {
+		invalid("dataset_id", "%q is not a dataset id", id)
+	}
+	for _, mt := range f.GetMimeType() {
+		if typ, sub, ok := strings.Cut(mt, "/"); !ok || typ == "" || sub == "" {
+			invalid("mime_type", "%q is not a MIME type", mt)
+		}
+	}
+
+	if iv := f.GetInterval(); iv != nil {
+		start, end := iv.GetStart(), iv.GetEnd()
+		if start != nil {
+			if err := start.CheckValid(); err != nil {
+				invalid("interval", "start: %v", err)
+			}
+		}
+		if end != nil {
+			if err := end.CheckValid(); err != nil {
+				invalid("interval", "end: %v", err)
+			}
+		}
+		if start != nil && end != nil && end.AsTime().Before(start.AsTime()) {
+			invalid("interval", "end %s is before start %s", end.AsTime(), start.AsTime())
+		}
+	}
+
+	if tf := f.GetTagsFilter(); tf != nil {
+		switch tf.GetType() {
+		case datapb.TagsFilterType_TAGS_FILTER_TYPE_UNSPECIFIED:
+		case datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR:
+			if len(tf.GetTags()) == 0 {
+				invalid("tags_filter", "%s requires at least one tag", tf.GetType())
+			}
+		case datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED, datapb.TagsFilterType_TAGS_FILTER_TYPE_UNTAGGED:
+			if len(tf.GetTags()) > 0 {
+				invalid("tags_filter", "%s ignores tags %v", tf.GetType(), tf.GetTags())
+			}
+		default:
+			invalid("tags_filter", "unknown type %d", tf.GetType())
+		}
+		for _, tag := range tf.GetTags() {
+			if tag == "" {
+				invalid("tags_filter", "tags must not be empty")
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unsupported reports the fields set in f that are not in allowed, naming target in the error.
+func unsupported(f *datapb.Filter, target string, allowed map[string]bool) error {
+	var errs []error
+	f.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if name := string(fd.Name()); !allowed[name] {
+			errs = append(errs, &ValidationError{Field: name, Reason: "not supported by " + target})
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package localdata
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// AddBoundingBoxToImageByID adds a bounding box to an image and returns its id.
+func (s *Server) AddBoundingBoxToImageByID(
+	_ context.Context, req *datapb.AddBoundingBoxToImageByIDRequest,
+) (*datapb.AddBoundingBoxToImageByIDResponse, error) {
+	bbox := &datapb.BoundingBox{
+		Label:          req.GetLabel(),
+		XMinNormalized: req.GetXMinNormalized(),
+		YMinNormalized: req.GetYMinNormalized(),
+		XMaxNormalized: req.GetXMaxNormalized(),
+		YMaxNormalized: req.GetYMaxNormalized(),
+		Confidence:     req.Confidence,
+	}
+	if err := validateBoundingBox(bbox); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.image(req.GetBinaryDataId())
+	if err != nil {
+		return nil, err
+	}
+	s.seq++
+	bbox.Id = fmt.Sprintf("%024x", s.seq)
+	if e.md.Annotations == nil {
+		e.md.Annotations = &datapb.Annotations{}
+	}
+	e.md.Annotations.Bboxes = append(e.md.Annotations.Bboxes, bbox)
+	return &datapb.AddBoundingBoxToImageByIDResponse{BboxId: bbox.Id}, nil
+}
+
+// UpdateBoundingBox changes the fields of a bounding box that are set in the request.
+func (s *Server) UpdateBoundingBox(_ context.Context, req *datapb.UpdateBoundingBoxRequest) (*datapb.UpdateBoundingBoxResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.image(req.GetBinaryDataId())
+	if err != nil {
+		return nil, err
+	}
+	i, err := bboxIndex(e, req.GetBboxId())
+	if err != nil {
+		return nil, err
+	}
+
+	bbox := e.md.Annotations.Bboxes[i]
+	updated := &datapb.BoundingBox{
+		Id:             bbox.GetId(),
+		Label:          valueOr(req.Label, bbox.GetLabel()),
+		XMinNormalized: valueOr(req.XMinNormalized, bbox.GetXMinNormalized()),
+		YMinNormalized: valueOr(req.YMinNormalized, bbox.GetYMinNormalized()),
+		XMaxNormalized: valueOr(req.XMaxNormalized, bbox.GetXMaxNormalized()),
+		YMaxNormalized: valueOr(req.YMaxNormalized, bbox.GetYMaxNormalized()),
+		Confidence:     bbox.Confidence,
+	}
+	if req.Confidence != nil {
+		updated.Confidence = req.Confidence
+	}
+	if err := validateBoundingBox(updated); err != nil {
+		return nil, err
+	}
+	e.md.Annotations.Bboxes[i] = updated
+	return &datapb.UpdateBoundingBoxResponse{}, nil
+}
+
+// RemoveBoundingBoxFromImageByID removes a bounding box from an image.
+func (s *Server) RemoveBoundingBoxFromImageByID(
+	_ context.Context, req *datapb.RemoveBoundingBoxFromImageByIDRequest,
+) (*datapb.RemoveBoundingBoxFromImageByIDResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.image(req.GetBinaryDataId())
+	if err != nil {
+		return nil, err
+	}
+	i, err := bboxIndex(e, req.GetBboxId())
+	if err != nil {
+		return nil, err
+	}
+	e.md.Annotations.Bboxes = slices.Delete(e.md.Annotations.Bboxes, i, i+1)
+	return &datapb.RemoveBoundingBoxFromImageByIDResponse{}, nil
+}
+
+// BoundingBoxLabelsByFilter returns the distinct labels of the bounding boxes on the binary data matching
+// the filter, sorted.
+func (s *Server) BoundingBoxLabelsByFilter(
+	_ context.Context, req *datapb.BoundingBoxLabelsByFilterRequest,
+) (*datapb.BoundingBoxLabelsByFilterResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var labels []string
+	for _, e := range s.match(req.GetFilter()) {
+		for _, bbox := range e.md.GetAnnotations().GetBboxes() {
+			labels = append(labels, bbox.GetLabel())
+		}
+	}
+	slices.Sort(labels)
+	return &datapb.BoundingBoxLabelsByFilterResponse{Labels: slices.Compact(labels)}, nil
+}
+
+// image returns the entry with the given id, which must be an image.
+func (s *Server) image(id string) (*binaryEntry, error) {
+	e, ok := s.binary[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "binary data %q not found", id)
+	}
+	if mime := e.md.GetCaptureMetadata().GetMimeType(); mime != "" && !strings.HasPrefix(mime, "image/") {
+		return nil, status.Errorf(codes.InvalidArgument, "binary data %q is not an image", id)
+	}
+	return e, nil
+}
+
+func bboxIndex(e *binaryEntry, id string) (int, error) {
+	i := slices.IndexFunc(e.md.GetAnnotations().GetBboxes(), func(b *datapb.BoundingBox) bool { return b.GetId() == id })
+	if i < 0 {
+		return 0, status.Errorf(codes.NotFound, "bounding box %q not found", id)
+	}
+	return i, nil
+}
+
+// validateBoundingBox checks that the coordinates of bbox are normalized and ordered, that its confidence
+// is in [0, 1] and that it has a label.
+func validateBoundingBox(bbox *datapb.BoundingBox) error {
+	if bbox.GetLabel() == "" {
+		return status.Error(codes.InvalidArgument, "label is required")
+	}
+	for _, v := range []float64{bbox.GetXMinNormalized(), bbox.GetYMinNormalized(), bbox.GetXMaxNormalized(), bbox.GetYMaxNormalized()} {
+		if v < 0 || v > 1 {
+			return status.Errorf(codes.InvalidArgument, "bounding box coordinate %v is not in [0, 1]", v)
+		}
+	}
+	if bbox.GetXMinNormalized() > bbox.GetXMaxNormalized() || bbox.GetYMinNormalized() > bbox.GetYMaxNormalized() {
+		return status.Error(codes.InvalidArgument, "bounding box minimum exceeds its maximum")
+	}
+	if c := bbox.Confidence; c != nil && (*c < 0 || *c > 1) {
+		return status.Errorf(codes.InvalidArgument, "confidence %v is not in [0, 1]", *c)
+	}
+	return nil
+}
+
+func valueOr[T any](p *T, v T) T {
+	if p != nil {
+		return *p
+	}
+	return v
+}
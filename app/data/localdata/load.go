@@ -0,0 +1,205 @@
+package localdata
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	datapb "go.viam.com/api/app/data/v1"
+	"go.viam.com/api/app/datasync/capturefile"
+	"go.viam.com/api/app/datasync/localsync"
+	"go.viam.com/api/app/datasync/upload"
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+const sidecarExt = ".metadata.json"
+
+// AddCaptureFile indexes the records of a capture file as data of the given part, organization and
+// location.
+func (s *Server) AddCaptureFile(path, orgID, locationID, partID string) error {
+	r, err := capturefile.Open(path)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer r.Close()
+	records, err := r.ReadAll()
+	if err != nil {
+		return err
+	}
+	md := upload.UploadMetadata(r.Metadata(), partID)
+	for _, sd := range records {
+		if err := s.addSensorData(md, sd, orgID, locationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSyncDir indexes the data stored by a localsync server in dir as data of the given organization and
+// location. Binary data keeps the id it was given by the server, prefixed with the organization and
+// location ids.
+func (s *Server) AddSyncDir(dir, orgID, locationID string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch name := d.Name(); {
+		case name == localsync.TabularFile:
+			return s.addSyncTabular(dir, path, orgID, locationID)
+		case strings.HasSuffix(name, sidecarExt):
+			return s.addSyncBinary(path, orgID, locationID)
+		default:
+			return nil
+		}
+	})
+}
+
+func (s *Server) addSyncBinary(sidecar, orgID, locationID string) error {
+	data, err := os.ReadFile(sidecar)
+	if err != nil {
+		return err
+	}
+	var md datasyncpb.DataCaptureUploadMetadata
+	if err := protojson.Unmarshal(data, &md); err != nil {
+		return err
+	}
+	info, err := os.Stat(sidecar)
+	if err != nil {
+		return err
+	}
+
+	// The blob is the other file named after the id.
+	dir, id := filepath.Dir(sidecar), strings.TrimSuffix(filepath.Base(sidecar), sidecarExt)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(entries, func(e fs.DirEntry) bool {
+		name := e.Name()
+		return name != filepath.Base(sidecar) && strings.TrimSuffix(name, filepath.Ext(name)) == id
+	})
+	if i < 0 {
+		return &fs.PathError{Op: "open", Path: filepath.Join(dir, id), Err: fs.ErrNotExist}
+	}
+	blob, err := os.ReadFile(filepath.Join(dir, entries[i].Name()))
+	if err != nil {
+		return err
+	}
+
+	bm := binaryMetadata(md.GetUploadMetadata(), md.GetSensorMetadata(), orgID, locationID)
+	bm.BinaryDataId = orgID + "/" + locationID + "/" + id
+	if bm.TimeReceived == nil {
+		bm.TimeReceived = timestamppb.New(info.ModTime())
+	}
+	_, err = s.AddBinary(bm, blob)
+	return err
+}
+
+// addSyncTabular indexes the rows of a tabular file, whose metadata is given by its path under dir.
+func (s *Server) addSyncTabular(dir, path, orgID, locationID string) error {
+	rel, err := filepath.Rel(dir, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	if len(segments) != 4 {
+		return nil
+	}
+	for i, seg := range segments {
+		if seg == "_" {
+			segments[i] = ""
+		}
+	}
+	md := &datasyncpb.UploadMetadata{
+		PartId:        segments[0],
+		ComponentType: segments[1],
+		ComponentName: segments[2],
+		MethodName:    segments[3],
+		Type:          datasyncpb.DataType_DATA_TYPE_TABULAR_SENSOR,
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer f.Close()
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			var sd datasyncpb.SensorData
+			if uerr := protojson.Unmarshal(line, &sd); uerr != nil {
+				return uerr
+			}
+			if aerr := s.addSensorData(md, &sd, orgID, locationID); aerr != nil {
+				return aerr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// addSensorData indexes a captured record with upload metadata md.
+func (s *Server) addSensorData(md *datasyncpb.UploadMetadata, sd *datasyncpb.SensorData, orgID, locationID string) error {
+	switch data := sd.GetData().(type) {
+	case *datasyncpb.SensorData_Binary:
+		bm := binaryMetadata(md, sd.GetMetadata(), orgID, locationID)
+		_, err := s.AddBinary(bm, data.Binary)
+		return err
+	case *datasyncpb.SensorData_Struct:
+		s.AddTabular(captureMetadata(md, orgID, locationID), &datapb.TabularData{
+			Data:          data.Struct,
+			TimeRequested: sd.GetMetadata().GetTimeRequested(),
+			TimeReceived:  sd.GetMetadata().GetTimeReceived(),
+		})
+		return nil
+	default:
+		return nil
+	}
+}
+
+func binaryMetadata(md *datasyncpb.UploadMetadata, sm *datasyncpb.SensorMetadata, orgID, locationID string) *datapb.BinaryMetadata {
+	ext := md.GetFileExtension()
+	if ext == "" {
+		ext = filepath.Ext(md.GetFileName())
+	}
+	return &datapb.BinaryMetadata{
+		CaptureMetadata: captureMetadata(md, orgID, locationID),
+		TimeRequested:   sm.GetTimeRequested(),
+		TimeReceived:    sm.GetTimeReceived(),
+		FileName:        md.GetFileName(),
+		FileExt:         ext,
+		Annotations:     sm.GetAnnotations(),
+		DatasetIds:      slices.Clone(md.GetDatasetIds()),
+	}
+}
+
+func captureMetadata(md *datasyncpb.UploadMetadata, orgID, locationID string) *datapb.CaptureMetadata {
+	return &datapb.CaptureMetadata{
+		OrganizationId:   orgID,
+		LocationId:       locationID,
+		PartId:           md.GetPartId(),
+		ComponentType:    md.GetComponentType(),
+		ComponentName:    md.GetComponentName(),
+		MethodName:       md.GetMethodName(),
+		MethodParameters: maps.Clone(md.GetMethodParameters()),
+		Tags:             slices.Clone(md.GetTags()),
+		MimeType:         md.GetMimeType(),
+	}
+}
@@ -0,0 +1,472 @@
+package localdata
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	datapb "go.viam.com/api/app/data/v1"
+	"go.viam.com/api/app/datasync/capturefile"
+	"go.viam.com/api/app/datasync/localsync"
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+var epoch = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// image describes binary data received i seconds after epoch.
+func image(i int, cm *datapb.CaptureMetadata) *datapb.BinaryMetadata {
+	if cm == nil {
+		cm = &datapb.CaptureMetadata{}
+	}
+	cm.MimeType = "image/jpeg"
+	return &datapb.BinaryMetadata{CaptureMetadata: cm, TimeReceived: timestamppb.New(epoch.Add(time.Duration(i) * time.Second))}
+}
+
+func mustAdd(t *testing.T, s *Server, md *datapb.BinaryMetadata, data string) string {
+	t.Helper()
+	id, err := s.AddBinary(md, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func ids(data []*datapb.BinaryData) []string {
+	var out []string
+	for _, bd := range data {
+		out = append(out, bd.GetMetadata().GetBinaryDataId())
+	}
+	return out
+}
+
+func query(t *testing.T, s *Server, req *datapb.BinaryDataByFilterRequest) *datapb.BinaryDataByFilterResponse {
+	t.Helper()
+	resp, err := s.BinaryDataByFilter(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestAddBinary(t *testing.T) {
+	s := New()
+	md := image(0, &datapb.CaptureMetadata{OrganizationId: "org", LocationId: "loc"})
+	id := mustAdd(t, s, md, "abc")
+	if md.GetBinaryDataId() != "" {
+		t.Error("AddBinary modified its argument")
+	}
+	if id != "org/loc/000000000000000000000001" {
+		t.Errorf("id = %q", id)
+	}
+	md.BinaryDataId = id
+	if _, err := s.AddBinary(md, nil); err == nil {
+		t.Error("AddBinary accepted a duplicate id")
+	}
+
+	resp, err := s.BinaryDataByIDs(context.Background(), &datapb.BinaryDataByIDsRequest{BinaryDataIds: []string{id, "missing"}, IncludeBinary: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetCount() != 1 || string(resp.GetData()[0].GetBinary()) != "abc" || resp.GetData()[0].GetMetadata().GetFileSizeBytes() != 3 {
+		t.Errorf("BinaryDataByIDs = %v", resp)
+	}
+	// The response holds copies.
+	resp.GetData()[0].GetMetadata().GetCaptureMetadata().Tags = []string{"x"}
+	if tags, _ := s.TagsByFilter(context.Background(), &datapb.TagsByFilterRequest{}); len(tags.GetTags()) != 0 {
+		t.Error("modifying a response modified the server")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	s := New()
+	a := mustAdd(t, s, image(0, &datapb.CaptureMetadata{
+		OrganizationId: "org", LocationId: "loc1", ComponentName: "cam", ComponentType: "camera",
+		MethodName: "ReadImage", RobotId: "r", PartId: "p", Tags: []string{"a"},
+	}), "")
+	b := mustAdd(t, s, image(1, &datapb.CaptureMetadata{OrganizationId: "org", LocationId: "loc2", ComponentName: "cam2"}), "")
+	c := mustAdd(t, s, &datapb.BinaryMetadata{
+		CaptureMetadata: &datapb.CaptureMetadata{MimeType: "application/pcd", Tags: []string{"b"}},
+		TimeReceived:    timestamppb.New(epoch.Add(2 * time.Second)),
+		DatasetIds:      []string{"ds"},
+	}, "")
+	if _, err := s.AddBoundingBoxToImageByID(context.Background(), &datapb.AddBoundingBoxToImageByIDRequest{
+		BinaryDataId: a, Label: "dog", XMaxNormalized: 1, YMaxNormalized: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		f    *datapb.Filter
+		want []string
+	}{
+		{"all", nil, []string{a, b, c}},
+		{"component", &datapb.Filter{ComponentName: "cam"}, []string{a}},
+		{"capture fields", &datapb.Filter{ComponentType: "camera", Method: "ReadImage", RobotId: "r", PartId: "p"}, []string{a}},
+		{"no match", &datapb.Filter{ComponentName: "cam", PartName: "other"}, nil},
+		{"locations", &datapb.Filter{LocationIds: []string{"loc1", "loc2"}}, []string{a, b}},
+		{"organizations", &datapb.Filter{OrganizationIds: []string{"org"}}, []string{a, b}},
+		{"mime type", &datapb.Filter{MimeType: []string{"application/pcd"}}, []string{c}},
+		{"interval", &datapb.Filter{Interval: &datapb.CaptureInterval{
+			Start: timestamppb.New(epoch.Add(time.Second)), End: timestamppb.New(epoch.Add(2 * time.Second)),
+		}}, []string{b}},
+		{"tags", &datapb.Filter{TagsFilter: &datapb.TagsFilter{Tags: []string{"a", "b"}}}, []string{a, c}},
+		{"tagged", &datapb.Filter{TagsFilter: &datapb.TagsFilter{Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED}}, []string{a, c}},
+		{"untagged", &datapb.Filter{TagsFilter: &datapb.TagsFilter{Type: datapb.TagsFilterType_TAGS_FILTER_TYPE_UNTAGGED}}, []string{b}},
+		{"unknown tags type", &datapb.Filter{TagsFilter: &datapb.TagsFilter{Type: 99}}, nil},
+		{"bbox labels", &datapb.Filter{BboxLabels: []string{"cat", "dog"}}, []string{a}},
+		{"dataset", &datapb.Filter{DatasetId: "ds"}, []string{c}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := query(t, s, &datapb.BinaryDataByFilterRequest{DataRequest: &datapb.DataRequest{
+				Filter: tc.f, SortOrder: datapb.Order_ORDER_ASCENDING,
+			}})
+			if got := ids(resp.GetData()); !slices.Equal(got, tc.want) {
+				t.Errorf("matched %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPaging(t *testing.T) {
+	s := New()
+	var want []string
+	for i := range 5 {
+		want = append(want, mustAdd(t, s, image(i/2, nil), "xx"))
+	}
+	count := query(t, s, &datapb.BinaryDataByFilterRequest{CountOnly: true})
+	if count.GetCount() != 5 || count.GetTotalSizeBytes() != 10 || len(count.GetData()) != 0 {
+		t.Errorf("count = %v", count)
+	}
+
+	for _, order := range []datapb.Order{datapb.Order_ORDER_ASCENDING, datapb.Order_ORDER_DESCENDING} {
+		var got []string
+		last := ""
+		for range 5 {
+			resp := query(t, s, &datapb.BinaryDataByFilterRequest{DataRequest: &datapb.DataRequest{Limit: 2, Last: last, SortOrder: order}})
+			if len(resp.GetData()) == 0 {
+				break
+			}
+			got = append(got, ids(resp.GetData())...)
+			last = resp.GetLast()
+			// Data added while paging takes its place in the order rather than shifting the pages that
+			// follow: it is skipped when ascending and listed last when descending.
+			if len(got) == 2 {
+				mustAdd(t, s, image(-1, nil), "")
+			}
+		}
+		expected := want
+		if order == datapb.Order_ORDER_DESCENDING {
+			expected = slices.Clone(expected)
+			slices.Reverse(expected)
+			expected = append(expected, ids(query(t, s, &datapb.BinaryDataByFilterRequest{DataRequest: &datapb.DataRequest{
+				Filter: &datapb.Filter{Interval: &datapb.CaptureInterval{End: timestamppb.New(epoch)}}, SortOrder: order,
+			}}).GetData())...)
+		}
+		if !slices.Equal(got, expected) {
+			t.Errorf("%v: paged %q, want %q", order, got, expected)
+		}
+	}
+
+	if _, err := s.BinaryDataByFilter(context.Background(), &datapb.BinaryDataByFilterRequest{
+		DataRequest: &datapb.DataRequest{Last: "bad"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad last = %v", err)
+	}
+	for range DefaultLimit {
+		mustAdd(t, s, image(10, nil), "")
+	}
+	if resp := query(t, s, &datapb.BinaryDataByFilterRequest{}); len(resp.GetData()) != DefaultLimit || resp.GetData()[0].GetBinary() != nil {
+		t.Errorf("default page holds %d items", len(resp.GetData()))
+	}
+}
+
+func TestTags(t *testing.T) {
+	s := New()
+	a := mustAdd(t, s, image(0, &datapb.CaptureMetadata{ComponentName: "a"}), "")
+	b := mustAdd(t, s, image(1, &datapb.CaptureMetadata{ComponentName: "b"}), "")
+	ctx := context.Background()
+
+	if _, err := s.AddTagsToBinaryDataByIDs(ctx, &datapb.AddTagsToBinaryDataByIDsRequest{
+		BinaryDataIds: []string{a, "missing"}, Tags: []string{"x"},
+	}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown id = %v", err)
+	}
+	if _, err := s.AddTagsToBinaryDataByIDs(ctx, &datapb.AddTagsToBinaryDataByIDsRequest{
+		BinaryDataIds: []string{a, b}, Tags: []string{"x", "y"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddTagsToBinaryDataByFilter(ctx, &datapb.AddTagsToBinaryDataByFilterRequest{
+		Filter: &datapb.Filter{ComponentName: "b"}, Tags: []string{"x", "z"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := s.TagsByFilter(ctx, &datapb.TagsByFilterRequest{})
+	if err != nil || !slices.Equal(tags.GetTags(), []string{"x", "y", "z"}) {
+		t.Errorf("TagsByFilter = %v, %v", tags, err)
+	}
+
+	removed, err := s.RemoveTagsFromBinaryDataByIDs(ctx, &datapb.RemoveTagsFromBinaryDataByIDsRequest{
+		BinaryDataIds: []string{a, b}, Tags: []string{"y"},
+	})
+	if err != nil || removed.GetDeletedCount() != 2 {
+		t.Errorf("RemoveTagsFromBinaryDataByIDs = %v, %v", removed, err)
+	}
+	byFilter, err := s.RemoveTagsFromBinaryDataByFilter(ctx, &datapb.RemoveTagsFromBinaryDataByFilterRequest{
+		Filter: &datapb.Filter{ComponentName: "b"}, Tags: []string{"x", "z", "w"},
+	})
+	if err != nil || byFilter.GetDeletedCount() != 2 {
+		t.Errorf("RemoveTagsFromBinaryDataByFilter = %v, %v", byFilter, err)
+	}
+	tags, _ = s.TagsByFilter(ctx, &datapb.TagsByFilterRequest{})
+	if !slices.Equal(tags.GetTags(), []string{"x"}) {
+		t.Errorf("tags = %q", tags.GetTags())
+	}
+}
+
+func TestDatasets(t *testing.T) {
+	s := New()
+	a := mustAdd(t, s, image(0, nil), "")
+	ctx := context.Background()
+	for range 2 {
+		if _, err := s.AddBinaryDataToDatasetByIDs(ctx, &datapb.AddBinaryDataToDatasetByIDsRequest{
+			BinaryDataIds: []string{a}, DatasetId: "ds",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	inDataset := func() int {
+		return len(query(t, s, &datapb.BinaryDataByFilterRequest{DataRequest: &datapb.DataRequest{
+			Filter: &datapb.Filter{DatasetId: "ds"},
+		}}).GetData())
+	}
+	if n := inDataset(); n != 1 {
+		t.Errorf("%d items in the dataset", n)
+	}
+	if _, err := s.RemoveBinaryDataFromDatasetByIDs(ctx, &datapb.RemoveBinaryDataFromDatasetByIDsRequest{
+		BinaryDataIds: []string{a}, DatasetId: "ds",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n := inDataset(); n != 0 {
+		t.Errorf("%d items in the dataset after removal", n)
+	}
+	if _, err := s.AddBinaryDataToDatasetByIDs(ctx, &datapb.AddBinaryDataToDatasetByIDsRequest{
+		BinaryDataIds: []string{a},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("missing dataset id = %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := New()
+	a := mustAdd(t, s, image(0, &datapb.CaptureMetadata{ComponentName: "a"}), "")
+	mustAdd(t, s, image(1, &datapb.CaptureMetadata{ComponentName: "b"}), "")
+	mustAdd(t, s, image(2, &datapb.CaptureMetadata{ComponentName: "b"}), "")
+	ctx := context.Background()
+
+	byIDs, err := s.DeleteBinaryDataByIDs(ctx, &datapb.DeleteBinaryDataByIDsRequest{BinaryDataIds: []string{a, a, "missing"}})
+	if err != nil || byIDs.GetDeletedCount() != 1 {
+		t.Errorf("DeleteBinaryDataByIDs = %v, %v", byIDs, err)
+	}
+	byFilter, err := s.DeleteBinaryDataByFilter(ctx, &datapb.DeleteBinaryDataByFilterRequest{Filter: &datapb.Filter{ComponentName: "b"}})
+	if err != nil || byFilter.GetDeletedCount() != 2 {
+		t.Errorf("DeleteBinaryDataByFilter = %v, %v", byFilter, err)
+	}
+	if n := query(t, s, &datapb.BinaryDataByFilterRequest{CountOnly: true}).GetCount(); n != 0 {
+		t.Errorf("%d items left", n)
+	}
+}
+
+func TestBoundingBoxes(t *testing.T) {
+	s := New()
+	img := mustAdd(t, s, image(0, nil), "")
+	pcd := mustAdd(t, s, &datapb.BinaryMetadata{CaptureMetadata: &datapb.CaptureMetadata{MimeType: "pointcloud/pcd"}}, "")
+	ctx := context.Background()
+	half, tooMuch := 0.5, 2.0
+
+	resp, err := s.AddBoundingBoxToImageByID(ctx, &datapb.AddBoundingBoxToImageByIDRequest{
+		BinaryDataId: img, Label: "dog", XMaxNormalized: 0.5, YMaxNormalized: 0.5, Confidence: &half,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name string
+		req  *datapb.AddBoundingBoxToImageByIDRequest
+		code codes.Code
+	}{
+		{"no label", &datapb.AddBoundingBoxToImageByIDRequest{BinaryDataId: img}, codes.InvalidArgument},
+		{"out of range", &datapb.AddBoundingBoxToImageByIDRequest{BinaryDataId: img, Label: "x", XMaxNormalized: 1.5}, codes.InvalidArgument},
+		{"backwards", &datapb.AddBoundingBoxToImageByIDRequest{BinaryDataId: img, Label: "x", XMinNormalized: 0.5}, codes.InvalidArgument},
+		{"confidence", &datapb.AddBoundingBoxToImageByIDRequest{BinaryDataId: img, Label: "x", Confidence: &tooMuch}, codes.InvalidArgument},
+		{"not an image", &datapb.AddBoundingBoxToImageByIDRequest{BinaryDataId: pcd, Label: "x"}, codes.InvalidArgument},
+		{"missing", &datapb.AddBoundingBoxToImageByIDRequest{BinaryDataId: "missing", Label: "x"}, codes.NotFound},
+	} {
+		if _, err := s.AddBoundingBoxToImageByID(ctx, tc.req); status.Code(err) != tc.code {
+			t.Errorf("%s: error = %v, want %v", tc.name, err, tc.code)
+		}
+	}
+
+	cat := "cat"
+	if _, err := s.UpdateBoundingBox(ctx, &datapb.UpdateBoundingBoxRequest{BinaryDataId: img, BboxId: resp.GetBboxId(), Label: &cat}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateBoundingBox(ctx, &datapb.UpdateBoundingBoxRequest{
+		BinaryDataId: img, BboxId: resp.GetBboxId(), XMinNormalized: &tooMuch,
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid update = %v", err)
+	}
+	bboxes := query(t, s, &datapb.BinaryDataByFilterRequest{}).GetData()[1].GetMetadata().GetAnnotations().GetBboxes()
+	if len(bboxes) != 1 || bboxes[0].GetLabel() != "cat" || bboxes[0].GetConfidence() != 0.5 || bboxes[0].GetXMaxNormalized() != 0.5 {
+		t.Errorf("bboxes = %v", bboxes)
+	}
+	labels, err := s.BoundingBoxLabelsByFilter(ctx, &datapb.BoundingBoxLabelsByFilterRequest{})
+	if err != nil || !slices.Equal(labels.GetLabels(), []string{"cat"}) {
+		t.Errorf("labels = %v, %v", labels, err)
+	}
+
+	if _, err := s.RemoveBoundingBoxFromImageByID(ctx, &datapb.RemoveBoundingBoxFromImageByIDRequest{
+		BinaryDataId: img, BboxId: resp.GetBboxId(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RemoveBoundingBoxFromImageByID(ctx, &datapb.RemoveBoundingBoxFromImageByIDRequest{
+		BinaryDataId: img, BboxId: resp.GetBboxId(),
+	}); status.Code(err) != codes.NotFound {
+		t.Errorf("removing twice = %v", err)
+	}
+}
+
+type exportStream struct {
+	grpc.ServerStream
+	rows []*datapb.ExportTabularDataResponse
+}
+
+func (s *exportStream) Send(resp *datapb.ExportTabularDataResponse) error {
+	s.rows = append(s.rows, resp)
+	return nil
+}
+
+func row(i int, v float64) *datapb.TabularData {
+	return &datapb.TabularData{
+		Data:         &structpb.Struct{Fields: map[string]*structpb.Value{"v": structpb.NewNumberValue(v)}},
+		TimeReceived: timestamppb.New(epoch.Add(time.Duration(i) * time.Second)),
+	}
+}
+
+func TestTabular(t *testing.T) {
+	s := New()
+	md := &datapb.CaptureMetadata{PartId: "p", ComponentName: "sensor", ComponentType: "sensor", MethodName: "Readings", Tags: []string{"t"}}
+	s.AddTabular(md, row(2, 2), row(0, 0))
+	s.AddTabular(&datapb.CaptureMetadata{PartId: "p", ComponentName: "other"}, row(5, 5))
+
+	stream := &exportStream{}
+	if err := s.ExportTabularData(&datapb.ExportTabularDataRequest{
+		PartId: "p", ResourceName: "sensor", Interval: &datapb.CaptureInterval{End: timestamppb.New(epoch.Add(3 * time.Second))},
+	}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.rows) != 2 || stream.rows[0].GetPayload().GetFields()["v"].GetNumberValue() != 0 ||
+		stream.rows[1].GetResourceSubtype() != "sensor" || !slices.Equal(stream.rows[1].GetTags(), []string{"t"}) {
+		t.Errorf("exported %v", stream.rows)
+	}
+
+	latest, err := s.GetLatestTabularData(context.Background(), &datapb.GetLatestTabularDataRequest{PartId: "p"})
+	if err != nil || latest.GetPayload().GetFields()["v"].GetNumberValue() != 5 {
+		t.Errorf("GetLatestTabularData = %v, %v", latest, err)
+	}
+	if _, err := s.GetLatestTabularData(context.Background(), &datapb.GetLatestTabularDataRequest{PartId: "q"}); status.Code(err) != codes.NotFound {
+		t.Errorf("no rows = %v", err)
+	}
+}
+
+func TestAddCaptureFile(t *testing.T) {
+	dir := t.TempDir()
+	w, err := capturefile.Create(filepath.Join(dir, "cam"+capturefile.InProgressExt), &datasyncpb.DataCaptureMetadata{
+		ComponentName: "cam", Type: datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR, FileExtension: ".jpeg", Tags: []string{"t"},
+	}, capturefile.SyncPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []string{"one", "two"} {
+		if err := w.Write(&datasyncpb.SensorData{
+			Metadata: &datasyncpb.SensorMetadata{TimeReceived: timestamppb.New(epoch)},
+			Data:     &datasyncpb.SensorData_Binary{Binary: []byte(b)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path, err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := New()
+	if err := s.AddCaptureFile(path, "org", "loc", "part"); err != nil {
+		t.Fatal(err)
+	}
+	data := query(t, s, &datapb.BinaryDataByFilterRequest{IncludeBinary: true, DataRequest: &datapb.DataRequest{
+		Filter: &datapb.Filter{PartId: "part", LocationIds: []string{"loc"}, TagsFilter: &datapb.TagsFilter{Tags: []string{"t"}}},
+	}}).GetData()
+	if len(data) != 2 || data[0].GetMetadata().GetFileExt() != ".jpeg" || !data[0].GetMetadata().GetTimeReceived().AsTime().Equal(epoch) {
+		t.Errorf("indexed %v", data)
+	}
+	if err := s.AddCaptureFile(filepath.Join(dir, "missing"), "org", "loc", "part"); err == nil {
+		t.Error("AddCaptureFile succeeded for a missing file")
+	}
+}
+
+func TestAddSyncDir(t *testing.T) {
+	sync, err := localsync.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	resp, err := sync.DataCaptureUpload(ctx, &datasyncpb.DataCaptureUploadRequest{
+		Metadata: &datasyncpb.UploadMetadata{
+			PartId: "part", ComponentName: "cam", Type: datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR, FileExtension: "png",
+		},
+		SensorContents: []*datasyncpb.SensorData{{Data: &datasyncpb.SensorData_Binary{Binary: []byte("img")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sync.DataCaptureUpload(ctx, &datasyncpb.DataCaptureUploadRequest{
+		Metadata: &datasyncpb.UploadMetadata{
+			PartId: "part", ComponentType: "sensor", ComponentName: "sensor", MethodName: "Readings",
+			Type: datasyncpb.DataType_DATA_TYPE_TABULAR_SENSOR,
+		},
+		SensorContents: []*datasyncpb.SensorData{{Data: &datasyncpb.SensorData_Struct{Struct: row(0, 1).GetData()}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := New()
+	if err := s.AddSyncDir(sync.Dir(), "org", "loc"); err != nil {
+		t.Fatal(err)
+	}
+	id := "org/loc/" + resp.GetBinaryDataId()
+	got, err := s.BinaryDataByIDs(ctx, &datapb.BinaryDataByIDsRequest{BinaryDataIds: []string{id}, IncludeBinary: true})
+	if err != nil || len(got.GetData()) != 1 || string(got.GetData()[0].GetBinary()) != "img" {
+		t.Fatalf("BinaryDataByIDs(%q) = %v, %v", id, got, err)
+	}
+	if md := got.GetData()[0].GetMetadata(); md.GetCaptureMetadata().GetPartId() != "part" || md.GetFileExt() != "png" || md.GetTimeReceived() == nil {
+		t.Errorf("metadata = %v", md)
+	}
+	latest, err := s.GetLatestTabularData(ctx, &datapb.GetLatestTabularDataRequest{
+		PartId: "part", ResourceName: "sensor", ResourceSubtype: "sensor", MethodName: "Readings",
+	})
+	if err != nil || latest.GetPayload().GetFields()["v"].GetNumberValue() != 1 {
+		t.Errorf("GetLatestTabularData = %v, %v", latest, err)
+	}
+}
@@ -0,0 +1,153 @@
+package localdata
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// match returns the binary entries that f matches, in no particular order. A nil filter matches everything.
+func (s *Server) match(f *datapb.Filter) []*binaryEntry {
+	var entries []*binaryEntry
+	for _, e := range s.binary {
+		if matches(f, e.md) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// matches reports whether f matches binary data with metadata md. Every set field of f must match. The
+// repeated fields match if any of their values does, and the interval applies to time_received, starting
+// inclusive and ending exclusive, as in the cloud.
+func matches(f *datapb.Filter, md *datapb.BinaryMetadata) bool {
+	cm := md.GetCaptureMetadata()
+	if !matchCapture(f, cm) {
+		return false
+	}
+	if len(f.GetMimeType()) > 0 && !slices.Contains(f.GetMimeType(), cm.GetMimeType()) {
+		return false
+	}
+	if !inInterval(f.GetInterval(), timeReceived(md)) {
+		return false
+	}
+	if !matchTags(f.GetTagsFilter(), cm.GetTags()) {
+		return false
+	}
+	if labels := f.GetBboxLabels(); len(labels) > 0 {
+		if !slices.ContainsFunc(md.GetAnnotations().GetBboxes(), func(b *datapb.BoundingBox) bool {
+			return slices.Contains(labels, b.GetLabel())
+		}) {
+			return false
+		}
+	}
+	if f.GetDatasetId() != "" && !slices.Contains(md.GetDatasetIds(), f.GetDatasetId()) {
+		return false
+	}
+	return true
+}
+
+// matchCapture reports whether the capture metadata fields of f match cm.
+func matchCapture(f *datapb.Filter, cm *datapb.CaptureMetadata) bool {
+	for _, field := range []struct{ want, got string }{
+		{f.GetComponentName(), cm.GetComponentName()},
+		{f.GetComponentType(), cm.GetComponentType()},
+		{f.GetMethod(), cm.GetMethodName()},
+		{f.GetRobotName(), cm.GetRobotName()},
+		{f.GetRobotId(), cm.GetRobotId()},
+		{f.GetPartName(), cm.GetPartName()},
+		{f.GetPartId(), cm.GetPartId()},
+	} {
+		if field.want != "" && field.want != field.got {
+			return false
+		}
+	}
+	if len(f.GetLocationIds()) > 0 && !slices.Contains(f.GetLocationIds(), cm.GetLocationId()) {
+		return false
+	}
+	if len(f.GetOrganizationIds()) > 0 && !slices.Contains(f.GetOrganizationIds(), cm.GetOrganizationId()) {
+		return false
+	}
+	return true
+}
+
+func inInterval(iv *datapb.CaptureInterval, t time.Time) bool {
+	if iv.GetStart() != nil && t.Before(iv.GetStart().AsTime()) {
+		return false
+	}
+	if iv.GetEnd() != nil && !t.Before(iv.GetEnd().AsTime()) {
+		return false
+	}
+	return true
+}
+
+func matchTags(tf *datapb.TagsFilter, tags []string) bool {
+	switch tf.GetType() {
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_TAGGED:
+		return len(tags) > 0
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_UNTAGGED:
+		return len(tags) == 0
+	case datapb.TagsFilterType_TAGS_FILTER_TYPE_UNSPECIFIED, datapb.TagsFilterType_TAGS_FILTER_TYPE_MATCH_BY_OR:
+		if len(tf.GetTags()) == 0 {
+			return true
+		}
+		return slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(tf.GetTags(), tag) })
+	default:
+		return false
+	}
+}
+
+// compareEntries orders entries by time_received and then by id, so that the order is total.
+func compareEntries(a, b *binaryEntry) int {
+	return cmp.Or(
+		timeReceived(a.md).Compare(timeReceived(b.md)),
+		strings.Compare(a.md.GetBinaryDataId(), b.md.GetBinaryDataId()),
+	)
+}
+
+// sortEntries sorts entries in order, which is newest first unless it is ascending.
+func sortEntries(entries []*binaryEntry, order datapb.Order) {
+	slices.SortFunc(entries, compareEntries)
+	if order != datapb.Order_ORDER_ASCENDING {
+		slices.Reverse(entries)
+	}
+}
+
+// cursor returns the last token that continues a listing after e. It holds the position of e rather than
+// its index, so that the listing continues correctly if data is added or deleted between pages.
+func cursor(e *binaryEntry) string {
+	return strconv.FormatInt(timeReceived(e.md).UnixNano(), 10) + "," + e.md.GetBinaryDataId()
+}
+
+// after returns the entries, sorted in order, that follow the position in last.
+func after(entries []*binaryEntry, last string, order datapb.Order) ([]*binaryEntry, error) {
+	if last == "" {
+		return entries, nil
+	}
+	nanos, id, ok := strings.Cut(last, ",")
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if !ok || err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid last %q", last)
+	}
+	pos := &binaryEntry{md: &datapb.BinaryMetadata{BinaryDataId: id, TimeReceived: timestamppb.New(time.Unix(0, n))}}
+	i, _ := slices.BinarySearchFunc(entries, pos, func(e, pos *binaryEntry) int {
+		c := compareEntries(e, pos)
+		if order != datapb.Order_ORDER_ASCENDING {
+			c = -c
+		}
+		// Entries at the position itself come before it, so the search lands after them.
+		if c == 0 {
+			return -1
+		}
+		return c
+	})
+	return entries[i:], nil
+}
@@ -0,0 +1,331 @@
+// Package localdata is an in-memory stand-in for the cloud DataService, for testing tools that query,
+// tag, label and curate data without a network.
+//
+// A Server indexes binary and tabular data added directly, read from capture files, or read from the
+// directory of a localsync server. It implements the binary data queries with the same filter semantics as
+// the cloud, tags, bounding boxes, dataset membership and deletion, along with the tabular export and
+// latest-reading queries. Other methods return Unimplemented.
+package localdata
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// DefaultLimit is the page size of BinaryDataByFilter when the request does not set a limit.
+const DefaultLimit = 50
+
+type binaryEntry struct {
+	md   *datapb.BinaryMetadata
+	data []byte
+}
+
+type tabularEntry struct {
+	md  *datapb.CaptureMetadata
+	row *datapb.TabularData
+}
+
+// Server implements DataServiceServer in memory. It is safe for concurrent use.
+type Server struct {
+	datapb.UnimplementedDataServiceServer
+
+	mu      sync.Mutex
+	binary  map[string]*binaryEntry
+	tabular []tabularEntry
+	seq     uint64
+}
+
+// New returns an empty Server.
+func New() *Server {
+	return &Server{binary: map[string]*binaryEntry{}}
+}
+
+// AddBinary indexes data with metadata md and returns its binary data id. An id is assigned if md has none,
+// and time_received defaults to now. md is copied.
+func (s *Server) AddBinary(md *datapb.BinaryMetadata, data []byte) (string, error) {
+	md = proto.Clone(md).(*datapb.BinaryMetadata)
+	if md.CaptureMetadata == nil {
+		md.CaptureMetadata = &datapb.CaptureMetadata{}
+	}
+	if md.TimeReceived == nil {
+		md.TimeReceived = timestamppb.Now()
+	}
+	md.FileSizeBytes = uint64(len(data))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if md.BinaryDataId == "" {
+		s.seq++
+		cm := md.GetCaptureMetadata()
+		md.BinaryDataId = fmt.Sprintf("%s/%s/%024x", cm.GetOrganizationId(), cm.GetLocationId(), s.seq)
+	}
+	if _, ok := s.binary[md.BinaryDataId]; ok {
+		return "", fmt.Errorf("localdata: binary data id %q already exists", md.BinaryDataId)
+	}
+	s.binary[md.BinaryDataId] = &binaryEntry{md: md, data: slices.Clone(data)}
+	return md.BinaryDataId, nil
+}
+
+// AddTabular indexes tabular rows captured with metadata md. time_received defaults to now. md and rows are
+// copied.
+func (s *Server) AddTabular(md *datapb.CaptureMetadata, rows ...*datapb.TabularData) {
+	md = proto.Clone(md).(*datapb.CaptureMetadata)
+	now := timestamppb.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range rows {
+		row = proto.Clone(row).(*datapb.TabularData)
+		if row.TimeReceived == nil {
+			row.TimeReceived = now
+		}
+		s.tabular = append(s.tabular, tabularEntry{md: md, row: row})
+	}
+}
+
+// BinaryDataByFilter returns a page of the binary data matching the filter, in time_received order.
+func (s *Server) BinaryDataByFilter(
+	_ context.Context, req *datapb.BinaryDataByFilterRequest,
+) (*datapb.BinaryDataByFilterResponse, error) {
+	dr := req.GetDataRequest()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.match(dr.GetFilter())
+	sortEntries(entries, dr.GetSortOrder())
+
+	resp := &datapb.BinaryDataByFilterResponse{}
+	if req.GetCountOnly() {
+		resp.Count = uint64(len(entries))
+		for _, e := range entries {
+			resp.TotalSizeBytes += e.md.GetFileSizeBytes()
+		}
+		return resp, nil
+	}
+
+	entries, err := after(entries, dr.GetLast(), dr.GetSortOrder())
+	if err != nil {
+		return nil, err
+	}
+	limit := dr.GetLimit()
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if uint64(len(entries)) > limit {
+		entries = entries[:limit]
+	}
+	for _, e := range entries {
+		resp.Data = append(resp.Data, e.binaryData(req.GetIncludeBinary()))
+		resp.TotalSizeBytes += e.md.GetFileSizeBytes()
+	}
+	resp.Count = uint64(len(entries))
+	if len(entries) > 0 {
+		resp.Last = cursor(entries[len(entries)-1])
+	}
+	return resp, nil
+}
+
+// BinaryDataByIDs returns the binary data with the given ids. Unknown ids are skipped.
+func (s *Server) BinaryDataByIDs(_ context.Context, req *datapb.BinaryDataByIDsRequest) (*datapb.BinaryDataByIDsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &datapb.BinaryDataByIDsResponse{}
+	for _, id := range req.GetBinaryDataIds() {
+		if e, ok := s.binary[id]; ok {
+			resp.Data = append(resp.Data, e.binaryData(req.GetIncludeBinary()))
+		}
+	}
+	resp.Count = uint64(len(resp.Data))
+	return resp, nil
+}
+
+// DeleteBinaryDataByFilter deletes the binary data matching the filter.
+func (s *Server) DeleteBinaryDataByFilter(
+	_ context.Context, req *datapb.DeleteBinaryDataByFilterRequest,
+) (*datapb.DeleteBinaryDataByFilterResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.match(req.GetFilter())
+	for _, e := range entries {
+		delete(s.binary, e.md.GetBinaryDataId())
+	}
+	return &datapb.DeleteBinaryDataByFilterResponse{DeletedCount: uint64(len(entries))}, nil
+}
+
+// DeleteBinaryDataByIDs deletes the binary data with the given ids.
+func (s *Server) DeleteBinaryDataByIDs(
+	_ context.Context, req *datapb.DeleteBinaryDataByIDsRequest,
+) (*datapb.DeleteBinaryDataByIDsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n uint64
+	for _, id := range req.GetBinaryDataIds() {
+		if _, ok := s.binary[id]; ok {
+			delete(s.binary, id)
+			n++
+		}
+	}
+	return &datapb.DeleteBinaryDataByIDsResponse{DeletedCount: n}, nil
+}
+
+// AddTagsToBinaryDataByIDs adds tags to the binary data with the given ids.
+func (s *Server) AddTagsToBinaryDataByIDs(
+	_ context.Context, req *datapb.AddTagsToBinaryDataByIDsRequest,
+) (*datapb.AddTagsToBinaryDataByIDsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.lookup(req.GetBinaryDataIds())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		e.addTags(req.GetTags())
+	}
+	return &datapb.AddTagsToBinaryDataByIDsResponse{}, nil
+}
+
+// AddTagsToBinaryDataByFilter adds tags to the binary data matching the filter.
+func (s *Server) AddTagsToBinaryDataByFilter(
+	_ context.Context, req *datapb.AddTagsToBinaryDataByFilterRequest,
+) (*datapb.AddTagsToBinaryDataByFilterResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.match(req.GetFilter()) {
+		e.addTags(req.GetTags())
+	}
+	return &datapb.AddTagsToBinaryDataByFilterResponse{}, nil
+}
+
+// RemoveTagsFromBinaryDataByIDs removes tags from the binary data with the given ids. The count is the
+// number of tags removed.
+func (s *Server) RemoveTagsFromBinaryDataByIDs(
+	_ context.Context, req *datapb.RemoveTagsFromBinaryDataByIDsRequest,
+) (*datapb.RemoveTagsFromBinaryDataByIDsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.lookup(req.GetBinaryDataIds())
+	if err != nil {
+		return nil, err
+	}
+	var n uint64
+	for _, e := range entries {
+		n += e.removeTags(req.GetTags())
+	}
+	return &datapb.RemoveTagsFromBinaryDataByIDsResponse{DeletedCount: n}, nil
+}
+
+// RemoveTagsFromBinaryDataByFilter removes tags from the binary data matching the filter. The count is the
+// number of tags removed.
+func (s *Server) RemoveTagsFromBinaryDataByFilter(
+	_ context.Context, req *datapb.RemoveTagsFromBinaryDataByFilterRequest,
+) (*datapb.RemoveTagsFromBinaryDataByFilterResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n uint64
+	for _, e := range s.match(req.GetFilter()) {
+		n += e.removeTags(req.GetTags())
+	}
+	return &datapb.RemoveTagsFromBinaryDataByFilterResponse{DeletedCount: n}, nil
+}
+
+// TagsByFilter returns the distinct tags of the binary data matching the filter, sorted.
+func (s *Server) TagsByFilter(_ context.Context, req *datapb.TagsByFilterRequest) (*datapb.TagsByFilterResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var tags []string
+	for _, e := range s.match(req.GetFilter()) {
+		tags = append(tags, e.md.GetCaptureMetadata().GetTags()...)
+	}
+	slices.Sort(tags)
+	return &datapb.TagsByFilterResponse{Tags: slices.Compact(tags)}, nil
+}
+
+// AddBinaryDataToDatasetByIDs adds the binary data with the given ids to a dataset.
+func (s *Server) AddBinaryDataToDatasetByIDs(
+	_ context.Context, req *datapb.AddBinaryDataToDatasetByIDsRequest,
+) (*datapb.AddBinaryDataToDatasetByIDsResponse, error) {
+	if req.GetDatasetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "dataset_id is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.lookup(req.GetBinaryDataIds())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !slices.Contains(e.md.DatasetIds, req.GetDatasetId()) {
+			e.md.DatasetIds = append(e.md.DatasetIds, req.GetDatasetId())
+		}
+	}
+	return &datapb.AddBinaryDataToDatasetByIDsResponse{}, nil
+}
+
+// RemoveBinaryDataFromDatasetByIDs removes the binary data with the given ids from a dataset.
+func (s *Server) RemoveBinaryDataFromDatasetByIDs(
+	_ context.Context, req *datapb.RemoveBinaryDataFromDatasetByIDsRequest,
+) (*datapb.RemoveBinaryDataFromDatasetByIDsResponse, error) {
+	if req.GetDatasetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "dataset_id is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.lookup(req.GetBinaryDataIds())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		e.md.DatasetIds = slices.DeleteFunc(e.md.DatasetIds, func(id string) bool { return id == req.GetDatasetId() })
+	}
+	return &datapb.RemoveBinaryDataFromDatasetByIDsResponse{}, nil
+}
+
+// lookup returns the entries with the given ids, failing with NotFound if any is missing so that a request
+// is applied entirely or not at all.
+func (s *Server) lookup(ids []string) ([]*binaryEntry, error) {
+	entries := make([]*binaryEntry, 0, len(ids))
+	for _, id := range ids {
+		e, ok := s.binary[id]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "binary data %q not found", id)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (e *binaryEntry) binaryData(includeBinary bool) *datapb.BinaryData {
+	bd := &datapb.BinaryData{Metadata: proto.Clone(e.md).(*datapb.BinaryMetadata)}
+	if includeBinary {
+		bd.Binary = slices.Clone(e.data)
+	}
+	return bd
+}
+
+func (e *binaryEntry) addTags(tags []string) {
+	cm := e.md.GetCaptureMetadata()
+	for _, tag := range tags {
+		if !slices.Contains(cm.Tags, tag) {
+			cm.Tags = append(cm.Tags, tag)
+		}
+	}
+}
+
+func (e *binaryEntry) removeTags(tags []string) uint64 {
+	cm := e.md.GetCaptureMetadata()
+	n := len(cm.Tags)
+	cm.Tags = slices.DeleteFunc(cm.Tags, func(tag string) bool { return slices.Contains(tags, tag) })
+	return uint64(n - len(cm.Tags))
+}
+
+func timeReceived(md *datapb.BinaryMetadata) time.Time {
+	return md.GetTimeReceived().AsTime()
+}
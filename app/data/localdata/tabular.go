@@ -0,0 +1,80 @@
+package localdata
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// ExportTabularData streams the tabular rows of a resource method in the interval, in time_received order.
+// Method parameters are not exported.
+func (s *Server) ExportTabularData(req *datapb.ExportTabularDataRequest, stream datapb.DataService_ExportTabularDataServer) error {
+	s.mu.Lock()
+	rows := s.tabularRows(req.GetPartId(), req.GetResourceName(), req.GetResourceSubtype(), req.GetMethodName())
+	s.mu.Unlock()
+
+	for _, e := range rows {
+		if !inInterval(req.GetInterval(), e.row.GetTimeReceived().AsTime()) {
+			continue
+		}
+		if err := stream.Send(&datapb.ExportTabularDataResponse{
+			PartId:          e.md.GetPartId(),
+			ResourceName:    e.md.GetComponentName(),
+			ResourceSubtype: e.md.GetComponentType(),
+			MethodName:      e.md.GetMethodName(),
+			TimeCaptured:    e.row.GetTimeRequested(),
+			OrganizationId:  e.md.GetOrganizationId(),
+			LocationId:      e.md.GetLocationId(),
+			RobotName:       e.md.GetRobotName(),
+			RobotId:         e.md.GetRobotId(),
+			PartName:        e.md.GetPartName(),
+			Tags:            slices.Clone(e.md.GetTags()),
+			Payload:         proto.Clone(e.row.GetData()).(*structpb.Struct),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLatestTabularData returns the most recently received row of a resource method.
+func (s *Server) GetLatestTabularData(
+	_ context.Context, req *datapb.GetLatestTabularDataRequest,
+) (*datapb.GetLatestTabularDataResponse, error) {
+	s.mu.Lock()
+	rows := s.tabularRows(req.GetPartId(), req.GetResourceName(), req.GetResourceSubtype(), req.GetMethodName())
+	s.mu.Unlock()
+	if len(rows) == 0 {
+		return nil, status.Error(codes.NotFound, "no tabular data found")
+	}
+	latest := rows[len(rows)-1].row
+	return &datapb.GetLatestTabularDataResponse{
+		TimeCaptured: latest.GetTimeRequested(),
+		TimeSynced:   latest.GetTimeReceived(),
+		Payload:      proto.Clone(latest.GetData()).(*structpb.Struct),
+	}, nil
+}
+
+// tabularRows returns the rows of a resource method in time_received order. Empty arguments match
+// anything.
+func (s *Server) tabularRows(partID, name, subtype, method string) []tabularEntry {
+	var rows []tabularEntry
+	for _, e := range s.tabular {
+		if (partID == "" || e.md.GetPartId() == partID) &&
+			(name == "" || e.md.GetComponentName() == name) &&
+			(subtype == "" || e.md.GetComponentType() == subtype) &&
+			(method == "" || e.md.GetMethodName() == method) {
+			rows = append(rows, e)
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b tabularEntry) int {
+		return a.row.GetTimeReceived().AsTime().Compare(b.row.GetTimeReceived().AsTime())
+	})
+	return rows
+}
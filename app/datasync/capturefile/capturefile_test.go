@@ -0,0 +1,342 @@
+package capturefile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+func metadata(component string) *datasyncpb.DataCaptureMetadata {
+	return &datasyncpb.DataCaptureMetadata{ComponentName: component, MethodName: "ReadImage"}
+}
+
+func record(payload string) *datasyncpb.SensorData {
+	return &datasyncpb.SensorData{Data: &datasyncpb.SensorData_Binary{Binary: []byte(payload)}}
+}
+
+func payloads(records []*datasyncpb.SensorData) []string {
+	out := make([]string, len(records))
+	for i, sd := range records {
+		out[i] = string(sd.GetBinary())
+	}
+	return out
+}
+
+// writeFile writes a completed capture file named name in dir.
+func writeFile(t *testing.T, dir, name string, md *datasyncpb.DataCaptureMetadata, records ...string) string {
+	t.Helper()
+	w, err := Create(filepath.Join(dir, name+InProgressExt), md, SyncPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err := w.Write(record(r)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path, err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readFile(t *testing.T, path string) (*datasyncpb.DataCaptureMetadata, []string) {
+	t.Helper()
+	r, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer r.Close()
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.Metadata(), payloads(records)
+}
+
+func TestWriteRead(t *testing.T) {
+	dir := t.TempDir()
+	w, err := Create(filepath.Join(dir, "a"+InProgressExt), metadata("cam"), SyncPolicy{EveryRecords: 2, Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"one", "two", "three"} {
+		if err := w.Write(record(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w.Records() != 3 {
+		t.Errorf("Records = %d", w.Records())
+	}
+	size := w.Size()
+	path, err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "a"+Ext) {
+		t.Errorf("completed path = %s", path)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != size {
+		t.Errorf("file size = %v, %v, want %d", info, err, size)
+	}
+	if _, err := w.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("second Close = %v", err)
+	}
+	if err := w.Write(record("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write after Close = %v", err)
+	}
+
+	md, got := readFile(t, path)
+	if !proto.Equal(md, metadata("cam")) || !slices.Equal(got, []string{"one", "two", "three"}) {
+		t.Errorf("read %v, %q", md, got)
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Create(filepath.Join(dir, "a"+Ext), metadata("cam"), SyncPolicy{}); err == nil {
+		t.Error("Create accepted a completed file name")
+	}
+	writeFile(t, dir, "b", metadata("cam"))
+	if err := os.WriteFile(filepath.Join(dir, "c"+InProgressExt), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Create(filepath.Join(dir, "c"+InProgressExt), metadata("cam"), SyncPolicy{}); err == nil {
+		t.Error("Create overwrote an existing file")
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	for _, m := range []proto.Message{metadata("cam"), record("whole"), record("partial")} {
+		if _, err := protodelim.MarshalTo(&buf, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-3]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := r.ReadAll()
+	if err != nil || !slices.Equal(payloads(records), []string{"whole"}) || !r.Truncated() {
+		t.Errorf("ReadAll = %q, %v, truncated %v", payloads(records), err, r.Truncated())
+	}
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next after truncation = %v", err)
+	}
+
+	if _, err := NewReader(bytes.NewReader(nil)); err == nil || !strings.Contains(err.Error(), "missing header") {
+		t.Errorf("NewReader of an empty file = %v", err)
+	}
+	if _, err := NewReader(bytes.NewReader([]byte{2, 0xff, 0xff})); err == nil {
+		t.Error("NewReader accepted a corrupt header")
+	}
+}
+
+func TestRotatingWriter(t *testing.T) {
+	dir := t.TempDir()
+	rw := NewRotatingWriter(dir, metadata("cam"), RotateOptions{MaxSize: 40})
+	for _, p := range []string{"aaaaaaaaaa", "bbbbbbbbbb", "cccccccccc", "dddddddddd"} {
+		if err := rw.Write(record(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	paths, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var all []string
+	for _, p := range paths {
+		_, got := readFile(t, p)
+		all = append(all, got...)
+	}
+	if len(paths) < 2 || !slices.Equal(all, []string{"aaaaaaaaaa", "bbbbbbbbbb", "cccccccccc", "dddddddddd"}) {
+		t.Errorf("%d files holding %q", len(paths), all)
+	}
+	if path, err := rw.Close(); path != "" || err != nil {
+		t.Errorf("Close with no file = %q, %v", path, err)
+	}
+}
+
+func TestRotateByAge(t *testing.T) {
+	dir := t.TempDir()
+	rw := NewRotatingWriter(dir, metadata("cam"), RotateOptions{MaxAge: 10 * time.Millisecond})
+	if path, err := rw.Rotate(); path != "" || err != nil {
+		t.Errorf("Rotate with no file = %q, %v", path, err)
+	}
+	if err := rw.Write(record("a")); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := rw.Rotate(); path != "" || err != nil {
+		t.Errorf("Rotate of a new file = %q, %v", path, err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	path, err := rw.Rotate()
+	if err != nil || !strings.HasSuffix(path, Ext) {
+		t.Fatalf("Rotate of a stale file = %q, %v", path, err)
+	}
+	if err := rw.Write(record("b")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	// A stale file is completed before the next record is written.
+	if err := rw.Write(record("c")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if paths, _ := List(dir); len(paths) != 3 {
+		t.Errorf("List = %q", paths)
+	}
+}
+
+func TestNewNameSorts(t *testing.T) {
+	now := time.Now()
+	names := []string{NewName(now), NewName(now), NewName(now.Add(time.Nanosecond)), NewName(now.Add(time.Second))}
+	if !slices.IsSorted(names) {
+		t.Errorf("names out of order: %q", names)
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "b", metadata("cam"))
+	writeFile(t, dir, "a", metadata("cam"))
+	for _, name := range []string{"c" + InProgressExt, "d.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "e"+Ext), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	paths, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "a"+Ext), filepath.Join(dir, "b"+Ext)}; !slices.Equal(paths, want) {
+		t.Errorf("List = %q, want %q", paths, want)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "1", metadata("cam"), "a", "b")
+	writeFile(t, dir, "2", metadata("lidar"), "x")
+	writeFile(t, dir, "3", metadata("cam"), "c")
+	writeFile(t, dir, "4", metadata("cam"))
+	writeFile(t, dir, "5", metadata("cam"), "d")
+	// A later file of another component must still sort after the merged cam file.
+	writeFile(t, dir, "6", metadata("gps"), "g")
+
+	merged, err := Compact(dir, CompactOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "1_000001"+Ext)}; !slices.Equal(merged, want) {
+		t.Fatalf("merged = %q, want %q", merged, want)
+	}
+	md, got := readFile(t, merged[0])
+	if !proto.Equal(md, metadata("cam")) || !slices.Equal(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("merged file holds %v, %q", md, got)
+	}
+	paths, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "1_000001"+Ext), filepath.Join(dir, "2"+Ext), filepath.Join(dir, "6"+Ext)}
+	if !slices.Equal(paths, want) {
+		t.Errorf("List after Compact = %q, want %q", paths, want)
+	}
+}
+
+func TestCompactNames(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "1", metadata("cam"), "a")
+	writeFile(t, dir, "2", metadata("cam"), "b")
+	// The first name is taken by an unrelated file, and the second by one still being written.
+	writeFile(t, dir, "1_000001", metadata("lidar"), "x")
+	if err := os.WriteFile(filepath.Join(dir, "1_000002"+InProgressExt), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := Compact(dir, CompactOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "1_000003"+Ext)}; !slices.Equal(merged, want) {
+		t.Errorf("merged = %q, want %q", merged, want)
+	}
+	if _, got := readFile(t, filepath.Join(dir, "1_000001"+Ext)); !slices.Equal(got, []string{"x"}) {
+		t.Errorf("unrelated file holds %q", got)
+	}
+}
+
+func TestCompactLimits(t *testing.T) {
+	dir := t.TempDir()
+	big := strings.Repeat("z", 100)
+	writeFile(t, dir, "1", metadata("cam"), "a", "b")
+	writeFile(t, dir, "2", metadata("cam"), big)
+	writeFile(t, dir, "3", metadata("cam"), "c", "d")
+	writeFile(t, dir, "4", metadata("cam"), "e")
+
+	merged, err := Compact(dir, CompactOptions{SmallerThan: 100, MaxSize: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var all []string
+	for _, path := range merged {
+		_, got := readFile(t, path)
+		all = append(all, got...)
+	}
+	if len(merged) < 2 || !slices.Equal(all, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("%d merged files holding %q", len(merged), all)
+	}
+	if !slices.IsSorted(merged) {
+		t.Errorf("merged files out of order: %q", merged)
+	}
+	if _, got := readFile(t, filepath.Join(dir, "2"+Ext)); !slices.Equal(got, []string{big}) {
+		t.Error("the large file was compacted")
+	}
+	paths, _ := List(dir)
+	if len(paths) != len(merged)+1 {
+		t.Errorf("sources left behind: %q", paths)
+	}
+}
+
+func TestCompactDropsTruncatedTails(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "1", metadata("cam"), "a")
+	p := writeFile(t, dir, "2", metadata("cam"), "b", "partial")
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, data[:len(data)-2], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := Compact(dir, CompactOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, got := readFile(t, merged[0]); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("merged file holds %q", got)
+	}
+}
@@ -0,0 +1,199 @@
+package capturefile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// CompactOptions configures Compact.
+type CompactOptions struct {
+	// SmallerThan limits compaction to files smaller than this many bytes. Zero considers every file.
+	SmallerThan int64
+	// MaxSize starts a new merged file once the current one holds at least this many bytes. Zero means no
+	// limit.
+	MaxSize int64
+}
+
+// Compact merges the completed capture files in dir that have identical metadata into fewer, larger files,
+// keeping records in file name order, and returns the paths of the merged files. Each merged file is named
+// after the source its first record comes from, so that it sorts where that source did. Each source is
+// removed once the merged files holding its records are complete, so a crash during compaction can leave
+// records duplicated but never lost. Partially written tails of truncated sources are dropped.
+func Compact(dir string, opts CompactOptions) ([]string, error) {
+	paths, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type group struct {
+		md    *datasyncpb.DataCaptureMetadata
+		paths []string
+	}
+	var order []string
+	groups := map[string]*group{}
+	for _, path := range paths {
+		if opts.SmallerThan > 0 {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			if info.Size() >= opts.SmallerThan {
+				continue
+			}
+		}
+		r, err := Open(path)
+		if err != nil {
+			return nil, err
+		}
+		md := r.Metadata()
+		if err := r.Close(); err != nil {
+			return nil, err
+		}
+		key, err := proto.MarshalOptions{Deterministic: true}.Marshal(md)
+		if err != nil {
+			return nil, err
+		}
+		g, ok := groups[string(key)]
+		if !ok {
+			g = &group{md: md}
+			groups[string(key)] = g
+			order = append(order, string(key))
+		}
+		g.paths = append(g.paths, path)
+	}
+
+	var merged []string
+	for _, key := range order {
+		g := groups[key]
+		if len(g.paths) < 2 {
+			continue
+		}
+		out, err := merge(g.md, g.paths, opts.MaxSize)
+		merged = append(merged, out...)
+		if err != nil {
+			return merged, err
+		}
+	}
+	return merged, nil
+}
+
+// merge copies the records of paths into new files and removes paths once the copies are complete.
+func merge(md *datasyncpb.DataCaptureMetadata, paths []string, maxSize int64) ([]string, error) {
+	var merged []string
+	var w *Writer
+	// done holds the sources whose records are all in merged files that are not yet complete.
+	var done []string
+	finish := func() error {
+		path, err := w.Close()
+		w = nil
+		if err != nil {
+			return err
+		}
+		merged = append(merged, path)
+		var errs []error
+		for _, src := range done {
+			errs = append(errs, os.Remove(src))
+		}
+		done = nil
+		return errors.Join(errs...)
+	}
+	fail := func(err error) ([]string, error) {
+		if w != nil {
+			//nolint:errcheck
+			w.f.Close()
+			//nolint:errcheck
+			os.Remove(w.path)
+		}
+		return merged, err
+	}
+
+	for _, src := range paths {
+		r, err := Open(src)
+		if err != nil {
+			return fail(err)
+		}
+		for {
+			sd, err := r.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				//nolint:errcheck
+				r.Close()
+				return fail(err)
+			}
+			if w == nil {
+				name, err := mergedName(src)
+				if err == nil {
+					w, err = Create(name+InProgressExt, md, SyncPolicy{})
+				}
+				if err != nil {
+					//nolint:errcheck
+					r.Close()
+					return fail(err)
+				}
+			}
+			if err := w.Write(sd); err != nil {
+				//nolint:errcheck
+				r.Close()
+				return fail(err)
+			}
+			if maxSize > 0 && w.Size() >= maxSize {
+				// Sources read so far are only complete once this file is; the current source may continue
+				// into the next file, so it is not released yet.
+				if err := finish(); err != nil {
+					//nolint:errcheck
+					r.Close()
+					return merged, err
+				}
+			}
+		}
+		if err := r.Close(); err != nil {
+			return fail(err)
+		}
+		done = append(done, src)
+	}
+	if w != nil {
+		if err := finish(); err != nil {
+			return merged, err
+		}
+	} else {
+		// Every record ended exactly at a file boundary, or the sources were empty.
+		var errs []error
+		for _, src := range done {
+			errs = append(errs, os.Remove(src))
+		}
+		return merged, errors.Join(errs...)
+	}
+	return merged, nil
+}
+
+// mergedName returns the path, without extension, of a new merged file whose first record comes from src.
+// It is src's name with a numeric suffix, which sorts after src and before any file that sorted after it,
+// and that is not used by any file yet.
+func mergedName(src string) (string, error) {
+	base := strings.TrimSuffix(src, Ext)
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_%06d", base, i)
+		used := false
+		for _, ext := range []string{Ext, InProgressExt} {
+			_, err := os.Lstat(name + ext)
+			if err == nil {
+				used = true
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+		if !used {
+			return name, nil
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package capturefile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// MaxRecordSize is the largest record a Reader accepts, large enough for high resolution images.
+const MaxRecordSize = 256 << 20
+
+var unmarshalOptions = protodelim.UnmarshalOptions{MaxSize: MaxRecordSize}
+
+// Reader reads the records of a capture file.
+type Reader struct {
+	r         *bufio.Reader
+	closer    io.Closer
+	md        *datasyncpb.DataCaptureMetadata
+	truncated bool
+}
+
+// NewReader reads the header of a capture file from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	rd := &Reader{r: bufio.NewReader(r), md: &datasyncpb.DataCaptureMetadata{}}
+	if err := unmarshalOptions.UnmarshalFrom(rd.r, rd.md); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("capturefile: missing header: %w", err)
+		}
+		return nil, fmt.Errorf("capturefile: header: %w", err)
+	}
+	return rd, nil
+}
+
+// Open opens the capture file at path. The returned Reader must be closed.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewReader(f)
+	if err != nil {
+		//nolint:errcheck
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r.closer = f
+	return r, nil
+}
+
+// Metadata returns the header of the file.
+func (r *Reader) Metadata() *datasyncpb.DataCaptureMetadata {
+	return r.md
+}
+
+// Next returns the next record, or io.EOF after the last one. A partially written record at the end of the
+// file also ends the file with io.EOF, and is reported by Truncated.
+func (r *Reader) Next() (*datasyncpb.SensorData, error) {
+	if r.truncated {
+		return nil, io.EOF
+	}
+	sd := &datasyncpb.SensorData{}
+	err := unmarshalOptions.UnmarshalFrom(r.r, sd)
+	switch {
+	case err == nil:
+		return sd, nil
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		r.truncated = true
+		return nil, io.EOF
+	case errors.Is(err, io.EOF):
+		return nil, io.EOF
+	default:
+		return nil, fmt.Errorf("capturefile: %w", err)
+	}
+}
+
+// ReadAll returns the remaining records.
+func (r *Reader) ReadAll() ([]*datasyncpb.SensorData, error) {
+	var out []*datasyncpb.SensorData
+	for {
+		sd, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, sd)
+	}
+}
+
+// Truncated reports whether reading stopped at a partially written record.
+func (r *Reader) Truncated() bool {
+	return r.truncated
+}
+
+// Close closes the file opened by Open. It does nothing for Readers made with NewReader.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
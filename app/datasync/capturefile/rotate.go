@@ -0,0 +1,110 @@
+package capturefile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// RotateOptions configures a RotatingWriter.
+type RotateOptions struct {
+	// MaxSize closes the current file once it holds at least this many bytes. Zero means no limit.
+	MaxSize int64
+	// MaxAge closes the current file once it is this old. Age is checked on Write and by Rotate.
+	MaxAge time.Duration
+	// Sync is the SyncPolicy of each file.
+	Sync SyncPolicy
+}
+
+// RotatingWriter writes records with the same metadata to a sequence of capture files in a directory,
+// starting a new file when the current one grows too large or too old.
+type RotatingWriter struct {
+	dir  string
+	md   *datasyncpb.DataCaptureMetadata
+	opts RotateOptions
+	cur  *Writer
+}
+
+// NewRotatingWriter returns a RotatingWriter that creates files in dir. No file is created until the first
+// Write.
+func NewRotatingWriter(dir string, md *datasyncpb.DataCaptureMetadata, opts RotateOptions) *RotatingWriter {
+	return &RotatingWriter{dir: dir, md: md, opts: opts}
+}
+
+// Write appends a record, rotating first if the current file is too old and afterwards if it is too large.
+func (rw *RotatingWriter) Write(sd *datasyncpb.SensorData) error {
+	if rw.cur != nil && rw.stale() {
+		if _, err := rw.closeCurrent(); err != nil {
+			return err
+		}
+	}
+	if rw.cur == nil {
+		w, err := Create(filepath.Join(rw.dir, NewName(time.Now())+InProgressExt), rw.md, rw.opts.Sync)
+		if err != nil {
+			return err
+		}
+		rw.cur = w
+	}
+	if err := rw.cur.Write(sd); err != nil {
+		return err
+	}
+	if rw.opts.MaxSize > 0 && rw.cur.Size() >= rw.opts.MaxSize {
+		_, err := rw.closeCurrent()
+		return err
+	}
+	return nil
+}
+
+// Rotate closes the current file if it has reached MaxAge, returning its completed path. Call it
+// periodically so that files are completed even when no records arrive.
+func (rw *RotatingWriter) Rotate() (string, error) {
+	if rw.cur == nil || !rw.stale() {
+		return "", nil
+	}
+	return rw.closeCurrent()
+}
+
+// Close closes the current file, if any, returning its completed path.
+func (rw *RotatingWriter) Close() (string, error) {
+	if rw.cur == nil {
+		return "", nil
+	}
+	return rw.closeCurrent()
+}
+
+func (rw *RotatingWriter) stale() bool {
+	return rw.opts.MaxAge > 0 && time.Since(rw.cur.Created()) >= rw.opts.MaxAge
+}
+
+func (rw *RotatingWriter) closeCurrent() (string, error) {
+	w := rw.cur
+	rw.cur = nil
+	return w.Close()
+}
+
+var nameSeq atomic.Uint64
+
+// NewName returns a unique file name, without extension, that sorts in creation order.
+func NewName(t time.Time) string {
+	return fmt.Sprintf("%s_%d_%06d", t.UTC().Format("20060102T150405.000000000Z"), os.Getpid(), nameSeq.Add(1)%1000000)
+}
+
+// List returns the completed capture files in dir, in name order.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && strings.HasSuffix(e.Name(), Ext) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,148 @@
+// Package capturefile defines the on-disk format used to buffer data captures on a device until they can be
+// uploaded with DataCaptureUpload.
+//
+// A capture file is a sequence of varint length-delimited protobuf messages: one DataCaptureMetadata
+// header followed by any number of SensorData records. Files are written with the InProgressExt extension
+// and renamed to Ext once closed, so only files with Ext are complete and safe to upload, compact or
+// delete. A crash may leave a partially written record at the end of a file; readers stop cleanly before
+// it.
+package capturefile
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+const (
+	// Ext is the extension of completed capture files.
+	Ext = ".capture"
+	// InProgressExt is the extension of capture files that are still being written.
+	InProgressExt = ".prog"
+)
+
+// SyncPolicy controls when a Writer flushes its records to stable storage. The zero value only syncs on
+// Close.
+type SyncPolicy struct {
+	// EveryRecords syncs after this many records have been written since the last sync. 1 syncs after
+	// every record.
+	EveryRecords int
+	// Interval syncs after a write once this long has passed since the last sync.
+	Interval time.Duration
+}
+
+// Writer appends SensorData records to an in-progress capture file.
+type Writer struct {
+	f        *os.File
+	w        *bufio.Writer
+	path     string
+	policy   SyncPolicy
+	created  time.Time
+	size     int64
+	records  int
+	unsynced int
+	lastSync time.Time
+	closed   bool
+}
+
+// Create creates a capture file at path, which must end in InProgressExt, and writes md as its header.
+// Create fails if the file already exists.
+func Create(path string, md *datasyncpb.DataCaptureMetadata, policy SyncPolicy) (*Writer, error) {
+	if !strings.HasSuffix(path, InProgressExt) {
+		return nil, errors.New("capturefile: in-progress files must end in " + InProgressExt)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	w := &Writer{f: f, w: bufio.NewWriter(f), path: path, policy: policy, created: now, lastSync: now}
+	if err := w.append(md); err != nil {
+		//nolint:errcheck
+		f.Close()
+		//nolint:errcheck
+		os.Remove(path)
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) append(m proto.Message) error {
+	n, err := protodelim.MarshalTo(w.w, m)
+	w.size += int64(n)
+	return err
+}
+
+// Write appends a record and syncs it if the policy calls for it.
+func (w *Writer) Write(sd *datasyncpb.SensorData) error {
+	if w.closed {
+		return os.ErrClosed
+	}
+	if err := w.append(sd); err != nil {
+		return err
+	}
+	w.records++
+	w.unsynced++
+	if (w.policy.EveryRecords > 0 && w.unsynced >= w.policy.EveryRecords) ||
+		(w.policy.Interval > 0 && time.Since(w.lastSync) >= w.policy.Interval) {
+		return w.Sync()
+	}
+	return nil
+}
+
+// Sync flushes buffered records and fsyncs the file.
+func (w *Writer) Sync() error {
+	if w.closed {
+		return os.ErrClosed
+	}
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	if err := w.f.Sync(); err != nil {
+		return err
+	}
+	w.unsynced, w.lastSync = 0, time.Now()
+	return nil
+}
+
+// Size returns the number of bytes written so far, including the header.
+func (w *Writer) Size() int64 {
+	return w.size
+}
+
+// Records returns the number of records written so far.
+func (w *Writer) Records() int {
+	return w.records
+}
+
+// Created returns when the file was created.
+func (w *Writer) Created() time.Time {
+	return w.created
+}
+
+// Close syncs the file and renames it from InProgressExt to Ext, returning the completed path.
+func (w *Writer) Close() (string, error) {
+	if w.closed {
+		return "", os.ErrClosed
+	}
+	err := w.Sync()
+	w.closed = true
+	if cerr := w.f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	done := strings.TrimSuffix(w.path, InProgressExt) + Ext
+	if err := os.Rename(w.path, done); err != nil {
+		return "", err
+	}
+	return done, nil
+}
@@ -0,0 +1,104 @@
+package localsync
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A Fault makes matching calls misbehave. Latency is applied first; then, if Code is set, the call fails
+// with it. For the streaming methods, a Fault with DropAfter set instead fails the stream with Code, or
+// Unavailable if Code is not set, once that many messages have been received, so the client sees the
+// stream break partway through. Nothing received by a failed call is stored.
+type Fault struct {
+	// Method limits the fault to one method, given by its short name (e.g. "FileUpload") or its full
+	// name. Empty matches every method.
+	Method string
+	// Times is the number of calls the fault applies to. Zero applies it to every call until it is
+	// cleared.
+	Times int
+	// Latency delays the start of each matching call.
+	Latency time.Duration
+	// Code and Message make each matching call fail.
+	Code    codes.Code
+	Message string
+	// DropAfter fails a matching stream after this many messages, counting the metadata message.
+	DropAfter int
+}
+
+// Inject adds f to the server's faults. When several faults match a call, the first one added that has
+// not run out of Times applies.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// fault returns the fault to apply to a call of method, if any, and counts it against its Times.
+func (s *Server) fault(method string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != method && !strings.HasSuffix(f.Method, "/"+method) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// start applies the latency and immediate failure of f, if any.
+func (f *Fault) start(ctx context.Context) error {
+	if f == nil {
+		return nil
+	}
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return status.FromContextError(ctx.Err()).Err()
+		case <-t.C:
+		}
+	}
+	if f.Code != codes.OK && f.DropAfter == 0 {
+		return f.err()
+	}
+	return nil
+}
+
+// drop reports the error that ends a stream after it has received n messages, if any.
+func (f *Fault) drop(n int) error {
+	if f == nil || f.DropAfter == 0 || n < f.DropAfter {
+		return nil
+	}
+	return f.err()
+}
+
+func (f *Fault) err() error {
+	code := f.Code
+	if code == codes.OK {
+		code = codes.Unavailable
+	}
+	msg := f.Message
+	if msg == "" {
+		msg = "injected fault"
+	}
+	return status.Error(code, msg)
+}
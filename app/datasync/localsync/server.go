@@ -0,0 +1,397 @@
+// Package localsync is a stand-in for the cloud DataSyncService that stores uploads in a local directory,
+// for testing capture and sync pipelines without the cloud.
+//
+// Uploads are stored under dir/<part_id>/<component_type>/<component_name>/<method_name>/, with "_"
+// standing in for empty fields. Each binary upload is stored as <id><ext> next to <id>.metadata.json, which
+// holds its DataCaptureUploadMetadata in protojson form. Tabular rows are appended to tabular.jsonl, one
+// protojson SensorData per line. The id of a binary upload is derived from its metadata and contents, so retrying an
+// upload yields the same id and overwrites the same file.
+//
+// Faults can be injected to test how clients handle latency, broken streams and errors.
+package localsync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// TabularFile is the name of the file tabular rows are appended to.
+const TabularFile = "tabular.jsonl"
+
+// Upload describes data stored by the server.
+type Upload struct {
+	// ID is the binary data id of a binary upload, and empty for tabular rows.
+	ID       string
+	Metadata *datasyncpb.DataCaptureUploadMetadata
+	// Path is the file the data was stored in.
+	Path string
+	// Size is the size in bytes of a binary upload.
+	Size int64
+	// Rows is the number of tabular rows stored.
+	Rows int
+}
+
+// Server implements DataSyncServiceServer on top of a local directory. It is safe for concurrent use.
+type Server struct {
+	datasyncpb.UnimplementedDataSyncServiceServer
+
+	dir string
+
+	mu      sync.Mutex
+	faults  []*Fault
+	uploads []Upload
+	// stored maps the ids of binary uploads to their index in uploads.
+	stored map[string]int
+}
+
+// New returns a Server that stores uploads under dir, creating it if needed.
+func New(dir string) (*Server, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Server{dir: dir, stored: map[string]int{}}, nil
+}
+
+// Dir returns the directory uploads are stored in.
+func (s *Server) Dir() string {
+	return s.dir
+}
+
+// Uploads returns the data stored so far, in the order it was stored. A binary upload that was repeated is
+// listed once.
+func (s *Server) Uploads() []Upload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Upload(nil), s.uploads...)
+}
+
+// DataCaptureUpload stores tabular rows or binary captures.
+func (s *Server) DataCaptureUpload(
+	ctx context.Context, req *datasyncpb.DataCaptureUploadRequest,
+) (*datasyncpb.DataCaptureUploadResponse, error) {
+	f := s.fault("DataCaptureUpload")
+	if err := f.start(ctx); err != nil {
+		return nil, err
+	}
+	// A unary call receives a single message.
+	if err := f.drop(1); err != nil {
+		return nil, err
+	}
+	md := req.GetMetadata()
+	if md == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is required")
+	}
+
+	switch md.GetType() {
+	case datasyncpb.DataType_DATA_TYPE_TABULAR_SENSOR:
+		if err := s.appendRows(md, req.GetSensorContents()); err != nil {
+			return nil, err
+		}
+		return &datasyncpb.DataCaptureUploadResponse{}, nil
+	case datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR:
+		// Every capture is written before any is stored, so that a failed call stores nothing.
+		contents := req.GetSensorContents()
+		for _, sd := range contents {
+			if _, ok := sd.GetData().(*datasyncpb.SensorData_Binary); !ok {
+				return nil, status.Error(codes.InvalidArgument, "binary upload contains non-binary sensor data")
+			}
+		}
+		blobs := make([]*blob, 0, len(contents))
+		abort := func(blobs []*blob) {
+			for _, b := range blobs {
+				b.abort()
+			}
+		}
+		for _, sd := range contents {
+			b, err := s.newBlob(&datasyncpb.DataCaptureUploadMetadata{UploadMetadata: md, SensorMetadata: sd.GetMetadata()})
+			if err != nil {
+				abort(blobs)
+				return nil, err
+			}
+			blobs = append(blobs, b)
+			if err := b.write(sd.GetBinary()); err != nil {
+				abort(blobs)
+				return nil, err
+			}
+		}
+		ids := make([]string, len(blobs))
+		for i, b := range blobs {
+			id, err := b.commit()
+			if err != nil {
+				abort(blobs[i+1:])
+				return nil, err
+			}
+			ids[i] = id
+		}
+		return &datasyncpb.DataCaptureUploadResponse{BinaryDataId: strings.Join(ids, ",")}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported data type %v", md.GetType())
+	}
+}
+
+// FileUpload stores a file.
+func (s *Server) FileUpload(stream datasyncpb.DataSyncService_FileUploadServer) error {
+	f := s.fault("FileUpload")
+	if err := f.start(stream.Context()); err != nil {
+		return err
+	}
+	req, err := recvFirst(stream.Recv, f)
+	if err != nil {
+		return err
+	}
+	md := req.GetMetadata()
+	if md == nil {
+		return status.Error(codes.InvalidArgument, "first message must be metadata")
+	}
+	b, err := s.newBlob(&datasyncpb.DataCaptureUploadMetadata{UploadMetadata: md})
+	if err != nil {
+		return err
+	}
+	if err := recvData(b, stream.Recv, f, func(req *datasyncpb.FileUploadRequest) ([]byte, bool) {
+		contents, ok := req.GetUploadPacket().(*datasyncpb.FileUploadRequest_FileContents)
+		if !ok {
+			return nil, false
+		}
+		return contents.FileContents.GetData(), true
+	}); err != nil {
+		return err
+	}
+	id, err := b.commit()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&datasyncpb.FileUploadResponse{BinaryDataId: id})
+}
+
+// StreamingDataCaptureUpload stores a single binary capture.
+func (s *Server) StreamingDataCaptureUpload(stream datasyncpb.DataSyncService_StreamingDataCaptureUploadServer) error {
+	f := s.fault("StreamingDataCaptureUpload")
+	if err := f.start(stream.Context()); err != nil {
+		return err
+	}
+	req, err := recvFirst(stream.Recv, f)
+	if err != nil {
+		return err
+	}
+	md := req.GetMetadata()
+	if md.GetUploadMetadata() == nil {
+		return status.Error(codes.InvalidArgument, "first message must be metadata")
+	}
+	b, err := s.newBlob(md)
+	if err != nil {
+		return err
+	}
+	if err := recvData(b, stream.Recv, f, func(req *datasyncpb.StreamingDataCaptureUploadRequest) ([]byte, bool) {
+		data, ok := req.GetUploadPacket().(*datasyncpb.StreamingDataCaptureUploadRequest_Data)
+		if !ok {
+			return nil, false
+		}
+		return data.Data, true
+	}); err != nil {
+		return err
+	}
+	id, err := b.commit()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&datasyncpb.StreamingDataCaptureUploadResponse{BinaryDataId: id})
+}
+
+func recvFirst[Req any](recv func() (Req, error), f *Fault) (Req, error) {
+	req, err := recv()
+	if errors.Is(err, io.EOF) {
+		return req, status.Error(codes.InvalidArgument, "stream ended before metadata")
+	}
+	if err != nil {
+		return req, err
+	}
+	return req, f.drop(1)
+}
+
+// recvData writes the data messages of a stream to b, aborting b if the stream fails.
+func recvData[Req any](b *blob, recv func() (Req, error), f *Fault, data func(Req) ([]byte, bool)) error {
+	for n := 2; ; n++ {
+		req, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err == nil {
+			err = f.drop(n)
+		}
+		if err == nil {
+			chunk, ok := data(req)
+			if !ok {
+				err = status.Error(codes.InvalidArgument, "metadata may only be sent first")
+			} else {
+				err = b.write(chunk)
+			}
+		}
+		if err != nil {
+			b.abort()
+			return err
+		}
+	}
+}
+
+// pathFor returns the directory that uploads described by md are stored in.
+func (s *Server) pathFor(md *datasyncpb.UploadMetadata) string {
+	return filepath.Join(s.dir,
+		segment(md.GetPartId()), segment(md.GetComponentType()), segment(md.GetComponentName()), segment(md.GetMethodName()))
+}
+
+// segment makes s safe to use as a single path element.
+func segment(s string) string {
+	s = strings.NewReplacer("/", "_", `\`, "_").Replace(s)
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	return s
+}
+
+// extension returns the file extension to store a binary upload with.
+func extension(md *datasyncpb.UploadMetadata) string {
+	ext := md.GetFileExtension()
+	if ext == "" {
+		ext = filepath.Ext(md.GetFileName())
+	}
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	if strings.ContainsAny(ext, `/\`) {
+		return ""
+	}
+	return ext
+}
+
+func (s *Server) appendRows(md *datasyncpb.UploadMetadata, rows []*datasyncpb.SensorData) error {
+	var buf bytes.Buffer
+	for _, sd := range rows {
+		if _, ok := sd.GetData().(*datasyncpb.SensorData_Binary); ok {
+			return status.Error(codes.InvalidArgument, "tabular upload contains binary sensor data")
+		}
+		line, err := protojson.Marshal(sd)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	dir := s.pathFor(md)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, TabularFile)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		//nolint:errcheck
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	s.uploads = append(s.uploads, Upload{
+		Metadata: &datasyncpb.DataCaptureUploadMetadata{UploadMetadata: md},
+		Path:     path,
+		Rows:     len(rows),
+	})
+	return nil
+}
+
+// blob is a binary upload being written to a temporary file.
+type blob struct {
+	s    *Server
+	md   *datasyncpb.DataCaptureUploadMetadata
+	dir  string
+	f    *os.File
+	h    hash.Hash
+	size int64
+}
+
+func (s *Server) newBlob(md *datasyncpb.DataCaptureUploadMetadata) (*blob, error) {
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(md)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	dir := s.pathFor(md.GetUploadMetadata())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	b := &blob{s: s, md: md, dir: dir, f: f, h: sha256.New()}
+	b.h.Write(key)
+	return b, nil
+}
+
+func (b *blob) write(p []byte) error {
+	b.h.Write(p)
+	b.size += int64(len(p))
+	_, err := b.f.Write(p)
+	return err
+}
+
+func (b *blob) abort() {
+	//nolint:errcheck
+	b.f.Close()
+	//nolint:errcheck
+	os.Remove(b.f.Name())
+}
+
+// commit moves the blob into place and returns its id.
+func (b *blob) commit() (string, error) {
+	if err := b.f.Close(); err != nil {
+		//nolint:errcheck
+		os.Remove(b.f.Name())
+		return "", err
+	}
+	id := hex.EncodeToString(b.h.Sum(nil)[:16])
+	path := filepath.Join(b.dir, id+extension(b.md.GetUploadMetadata()))
+	sidecar, err := protojson.MarshalOptions{Multiline: true}.Marshal(b.md)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(b.dir, id+".metadata.json"), sidecar, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(b.f.Name(), path)
+	}
+	if err != nil {
+		//nolint:errcheck
+		os.Remove(b.f.Name())
+		return "", err
+	}
+
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+	u := Upload{ID: id, Metadata: b.md, Path: path, Size: b.size}
+	if i, ok := b.s.stored[id]; ok {
+		b.s.uploads[i] = u
+	} else {
+		b.s.stored[id] = len(b.s.uploads)
+		b.s.uploads = append(b.s.uploads, u)
+	}
+	return id, nil
+}
@@ -0,0 +1,318 @@
+package localsync
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// recvStream replays reqs to a streaming method and records its response.
+type recvStream[Req, Resp any] struct {
+	grpc.ServerStream
+	reqs []Req
+	resp Resp
+}
+
+func (s *recvStream[Req, Resp]) Context() context.Context { return context.Background() }
+
+func (s *recvStream[Req, Resp]) Recv() (Req, error) {
+	var zero Req
+	if len(s.reqs) == 0 {
+		return zero, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *recvStream[Req, Resp]) SendAndClose(resp Resp) error {
+	s.resp = resp
+	return nil
+}
+
+func newServer(t *testing.T) *Server {
+	t.Helper()
+	s, err := New(filepath.Join(t.TempDir(), "sync"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// files lists the files under the server's directory, relative to it.
+func files(t *testing.T, s *Server) []string {
+	t.Helper()
+	var out []string
+	err := filepath.WalkDir(s.Dir(), func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.Dir(), path)
+		out = append(out, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+var camera = &datasyncpb.UploadMetadata{
+	PartId: "part", ComponentType: "camera", ComponentName: "cam", MethodName: "ReadImage",
+	Type: datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR, FileExtension: "jpeg",
+}
+
+func binary(b string) *datasyncpb.SensorData {
+	return &datasyncpb.SensorData{Data: &datasyncpb.SensorData_Binary{Binary: []byte(b)}}
+}
+
+func fileUpload(name string, chunks ...string) *recvStream[*datasyncpb.FileUploadRequest, *datasyncpb.FileUploadResponse] {
+	reqs := []*datasyncpb.FileUploadRequest{{UploadPacket: &datasyncpb.FileUploadRequest_Metadata{
+		Metadata: &datasyncpb.UploadMetadata{PartId: "part", FileName: name, Type: datasyncpb.DataType_DATA_TYPE_FILE},
+	}}}
+	for _, c := range chunks {
+		reqs = append(reqs, &datasyncpb.FileUploadRequest{UploadPacket: &datasyncpb.FileUploadRequest_FileContents{
+			FileContents: &datasyncpb.FileData{Data: []byte(c)},
+		}})
+	}
+	return &recvStream[*datasyncpb.FileUploadRequest, *datasyncpb.FileUploadResponse]{reqs: reqs}
+}
+
+func TestBinaryCapture(t *testing.T) {
+	s := newServer(t)
+	req := &datasyncpb.DataCaptureUploadRequest{Metadata: camera, SensorContents: []*datasyncpb.SensorData{binary("one"), binary("two")}}
+	resp, err := s.DataCaptureUpload(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := strings.Split(resp.GetBinaryDataId(), ",")
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("ids = %q", ids)
+	}
+	for i, want := range []string{"one", "two"} {
+		data, err := os.ReadFile(filepath.Join(s.Dir(), "part/camera/cam/ReadImage", ids[i]+".jpeg"))
+		if err != nil || string(data) != want {
+			t.Errorf("capture %d = %q, %v", i, data, err)
+		}
+	}
+	if n := len(files(t, s)); n != 4 {
+		t.Errorf("stored %d files, want 2 captures and 2 sidecars", n)
+	}
+
+	// A retried upload gets the same ids and is listed once.
+	again, err := s.DataCaptureUpload(context.Background(), req)
+	if err != nil || again.GetBinaryDataId() != resp.GetBinaryDataId() {
+		t.Errorf("retry = %v, %v", again, err)
+	}
+	if uploads := s.Uploads(); len(uploads) != 2 || uploads[1].Size != 3 {
+		t.Errorf("uploads = %v", uploads)
+	}
+}
+
+func TestBinaryCaptureAllOrNothing(t *testing.T) {
+	s := newServer(t)
+	_, err := s.DataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadRequest{
+		Metadata:       camera,
+		SensorContents: []*datasyncpb.SensorData{binary("one"), {Data: &datasyncpb.SensorData_Struct{Struct: &structpb.Struct{}}}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error = %v", err)
+	}
+	if got := files(t, s); len(got) != 0 || len(s.Uploads()) != 0 {
+		t.Errorf("a failed upload stored %q", got)
+	}
+}
+
+func TestTabular(t *testing.T) {
+	s := newServer(t)
+	md := &datasyncpb.UploadMetadata{ComponentName: "sensor", Type: datasyncpb.DataType_DATA_TYPE_TABULAR_SENSOR}
+	row := &datasyncpb.SensorData{Data: &datasyncpb.SensorData_Struct{Struct: &structpb.Struct{}}}
+	for range 2 {
+		_, err := s.DataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadRequest{
+			Metadata: md, SensorContents: []*datasyncpb.SensorData{row, row},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(s.Dir(), "_/_/sensor/_", TabularFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "\n"); n != 4 {
+		t.Errorf("stored %d rows", n)
+	}
+
+	_, err = s.DataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadRequest{
+		Metadata: md, SensorContents: []*datasyncpb.SensorData{row, binary("x")},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("mixed upload = %v", err)
+	}
+	if uploads := s.Uploads(); len(uploads) != 2 || uploads[0].Rows != 2 {
+		t.Errorf("uploads = %v", uploads)
+	}
+}
+
+func TestDataCaptureUploadErrors(t *testing.T) {
+	s := newServer(t)
+	for _, req := range []*datasyncpb.DataCaptureUploadRequest{
+		{},
+		{Metadata: &datasyncpb.UploadMetadata{Type: datasyncpb.DataType_DATA_TYPE_FILE}},
+	} {
+		if _, err := s.DataCaptureUpload(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("DataCaptureUpload(%v) = %v", req, err)
+		}
+	}
+}
+
+func TestFileUpload(t *testing.T) {
+	s := newServer(t)
+	stream := fileUpload("notes.txt", "hello, ", "world")
+	if err := s.FileUpload(stream); err != nil {
+		t.Fatal(err)
+	}
+	u := s.Uploads()
+	if len(u) != 1 || u[0].ID != stream.resp.GetBinaryDataId() || u[0].Size != 12 || filepath.Ext(u[0].Path) != ".txt" {
+		t.Fatalf("uploads = %v", u)
+	}
+	if data, err := os.ReadFile(u[0].Path); err != nil || string(data) != "hello, world" {
+		t.Errorf("stored %q, %v", data, err)
+	}
+
+	for name, stream := range map[string]*recvStream[*datasyncpb.FileUploadRequest, *datasyncpb.FileUploadResponse]{
+		"empty":        {},
+		"no metadata":  {reqs: fileUpload("f", "data").reqs[1:]},
+		"two metadata": {reqs: append(fileUpload("f", "data").reqs, fileUpload("g").reqs...)},
+	} {
+		if err := s.FileUpload(stream); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: error = %v", name, err)
+		}
+	}
+	if got := files(t, s); len(got) != 2 {
+		t.Errorf("failed uploads left %q", got)
+	}
+}
+
+func TestStreamingDataCaptureUpload(t *testing.T) {
+	s := newServer(t)
+	stream := &recvStream[*datasyncpb.StreamingDataCaptureUploadRequest, *datasyncpb.StreamingDataCaptureUploadResponse]{
+		reqs: []*datasyncpb.StreamingDataCaptureUploadRequest{
+			{UploadPacket: &datasyncpb.StreamingDataCaptureUploadRequest_Metadata{
+				Metadata: &datasyncpb.DataCaptureUploadMetadata{UploadMetadata: camera},
+			}},
+			{UploadPacket: &datasyncpb.StreamingDataCaptureUploadRequest_Data{Data: []byte("ab")}},
+			{UploadPacket: &datasyncpb.StreamingDataCaptureUploadRequest_Data{Data: []byte("cd")}},
+		},
+	}
+	if err := s.StreamingDataCaptureUpload(stream); err != nil {
+		t.Fatal(err)
+	}
+	u := s.Uploads()
+	if len(u) != 1 || u[0].Size != 4 || u[0].ID != stream.resp.GetBinaryDataId() {
+		t.Errorf("uploads = %v", u)
+	}
+	// The stored capture holds the same id as one uploaded in a single request.
+	resp, err := s.DataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadRequest{
+		Metadata: camera, SensorContents: []*datasyncpb.SensorData{binary("abcd")},
+	})
+	if err != nil || resp.GetBinaryDataId() != u[0].ID {
+		t.Errorf("DataCaptureUpload = %v, %v; want id %s", resp, err, u[0].ID)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	s := newServer(t)
+	s.Inject(Fault{Method: "FileUpload", Times: 2, Code: codes.Unavailable})
+	s.Inject(Fault{Code: codes.Internal})
+	for i, want := range []codes.Code{codes.Unavailable, codes.Unavailable, codes.Internal} {
+		if err := s.FileUpload(fileUpload("f", "x")); status.Code(err) != want {
+			t.Errorf("call %d: error = %v, want %v", i, err, want)
+		}
+	}
+	if _, err := s.DataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("DataCaptureUpload = %v", err)
+	}
+	s.ClearFaults()
+	if err := s.FileUpload(fileUpload("f", "x")); err != nil {
+		t.Errorf("after ClearFaults: %v", err)
+	}
+
+	// A full method name matches too.
+	s.Inject(Fault{Method: "/viam.app.datasync.v1.DataSyncService/FileUpload", Times: 1, Code: codes.Aborted})
+	if err := s.FileUpload(fileUpload("f", "x")); status.Code(err) != codes.Aborted {
+		t.Errorf("full method name: %v", err)
+	}
+}
+
+func TestDropAfter(t *testing.T) {
+	s := newServer(t)
+	s.Inject(Fault{Method: "FileUpload", Times: 1, DropAfter: 3})
+	if err := s.FileUpload(fileUpload("f", "a", "b", "c")); status.Code(err) != codes.Unavailable {
+		t.Errorf("error = %v", err)
+	}
+	// Fewer messages than DropAfter get through.
+	s.Inject(Fault{Method: "FileUpload", Times: 1, DropAfter: 3, Code: codes.Aborted})
+	if err := s.FileUpload(fileUpload("f", "a")); err != nil {
+		t.Errorf("short stream: %v", err)
+	}
+	if got := files(t, s); len(got) != 2 || len(s.Uploads()) != 1 {
+		t.Errorf("stored %q", got)
+	}
+
+	s.Inject(Fault{Method: "DataCaptureUpload", Times: 1, DropAfter: 1})
+	req := &datasyncpb.DataCaptureUploadRequest{Metadata: camera, SensorContents: []*datasyncpb.SensorData{binary("x")}}
+	if _, err := s.DataCaptureUpload(context.Background(), req); status.Code(err) != codes.Unavailable {
+		t.Errorf("unary drop = %v", err)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	s := newServer(t)
+	s.Inject(Fault{Latency: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.DataCaptureUpload(ctx, &datasyncpb.DataCaptureUploadRequest{}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("error = %v", err)
+	}
+
+	s.ClearFaults()
+	s.Inject(Fault{Latency: 20 * time.Millisecond, Times: 1})
+	start := time.Now()
+	if err := s.FileUpload(fileUpload("f")); err != nil || time.Since(start) < 20*time.Millisecond {
+		t.Errorf("FileUpload = %v after %v", err, time.Since(start))
+	}
+}
+
+func TestPaths(t *testing.T) {
+	for in, want := range map[string]string{"": "_", ".": "_", "..": "_", "a/b": "a_b", `a\b`: "a_b", "cam": "cam"} {
+		if got := segment(in); got != want {
+			t.Errorf("segment(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, tc := range []struct {
+		md   *datasyncpb.UploadMetadata
+		want string
+	}{
+		{&datasyncpb.UploadMetadata{}, ""},
+		{&datasyncpb.UploadMetadata{FileExtension: "png"}, ".png"},
+		{&datasyncpb.UploadMetadata{FileExtension: ".png", FileName: "a.txt"}, ".png"},
+		{&datasyncpb.UploadMetadata{FileName: "dir/a.txt"}, ".txt"},
+		{&datasyncpb.UploadMetadata{FileExtension: "../x"}, ""},
+	} {
+		if got := extension(tc.md); got != tc.want {
+			t.Errorf("extension(%v) = %q, want %q", tc.md, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.viam.com/api/app/datasync/capturefile"
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// DirOptions configures UploadDir.
+type DirOptions struct {
+	// PartID is the machine part the data belongs to.
+	PartID string
+	// Workers is the number of files uploaded at once. Zero means 4.
+	Workers int
+	// Ledger, if set, records completed uploads and skips files it already holds. The ledger's own file is
+	// never uploaded, even if it is inside the directory.
+	Ledger *Ledger
+	// Tags are added to every upload.
+	Tags []string
+	// DatasetIDs adds every upload to the given datasets.
+	DatasetIDs []string
+	// DeleteUploaded removes each file once it has been uploaded and recorded.
+	DeleteUploaded bool
+}
+
+// UploadDir uploads the files directly inside dir. Completed capture files are uploaded as captured data
+// and other regular files with FileUpload; in-progress capture files and hidden files are skipped. A
+// failed file does not stop the others, and every failure is returned, joined with errors.Join.
+func (u *Uploader) UploadDir(ctx context.Context, dir string, opts DirOptions) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	paths := make(chan string)
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				if err := u.UploadFile(ctx, path, opts); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, capturefile.InProgressExt) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if opts.Ledger != nil && sameFile(path, opts.Ledger.path) {
+			continue
+		}
+		select {
+		case paths <- path:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(paths)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// UploadFile uploads a single file as UploadDir would.
+func (u *Uploader) UploadFile(ctx context.Context, path string, opts DirOptions) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	key := FileKey(path, info)
+	if opts.Ledger != nil && opts.Ledger.Done(key) {
+		return u.cleanup(path, opts)
+	}
+
+	var id string
+	if strings.HasSuffix(path, capturefile.Ext) {
+		id, err = u.uploadCapture(ctx, path, key, opts)
+	} else {
+		id, err = u.uploadPlain(ctx, path, info, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if opts.Ledger != nil {
+		if err := opts.Ledger.Mark(key, id); err != nil {
+			return err
+		}
+	}
+	return u.cleanup(path, opts)
+}
+
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	return err == nil && os.SameFile(ai, bi)
+}
+
+func (u *Uploader) cleanup(path string, opts DirOptions) error {
+	if !opts.DeleteUploaded {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+func (u *Uploader) uploadPlain(ctx context.Context, path string, info os.FileInfo, opts DirOptions) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	//nolint:errcheck
+	defer f.Close()
+	md := &datasyncpb.UploadMetadata{
+		PartId:         opts.PartID,
+		Type:           datasyncpb.DataType_DATA_TYPE_FILE,
+		FileName:       filepath.Base(path),
+		FileExtension:  filepath.Ext(path),
+		FileModifyTime: timestamppb.New(info.ModTime()),
+		Tags:           opts.Tags,
+		DatasetIds:     opts.DatasetIDs,
+	}
+	resp, err := u.FileUpload(ctx, md, f)
+	return resp.GetBinaryDataId(), err
+}
+
+// uploadCapture uploads a capture file. Tabular files are sent in one DataCaptureUpload. Binary files are
+// sent one record at a time, each recorded in the ledger under its index so that a retry after a crash
+// resumes after the last uploaded record; records larger than a chunk are streamed.
+func (u *Uploader) uploadCapture(ctx context.Context, path, key string, opts DirOptions) (string, error) {
+	r, err := capturefile.Open(path)
+	if err != nil {
+		return "", err
+	}
+	//nolint:errcheck
+	defer r.Close()
+	records, err := r.ReadAll()
+	if err != nil {
+		return "", err
+	}
+	md := UploadMetadata(r.Metadata(), opts.PartID)
+	md.Tags = append(md.Tags, opts.Tags...)
+	md.DatasetIds = append(md.DatasetIds, opts.DatasetIDs...)
+
+	if md.GetType() != datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR {
+		resp, err := u.DataCaptureUpload(ctx, &datasyncpb.DataCaptureUploadRequest{Metadata: md, SensorContents: records})
+		return resp.GetBinaryDataId(), err
+	}
+
+	var ids []string
+	for i, sd := range records {
+		recordKey := fmt.Sprintf("%s#%d", key, i)
+		if opts.Ledger != nil && opts.Ledger.Done(recordKey) {
+			continue
+		}
+		var id string
+		if len(sd.GetBinary()) > u.opts.ChunkSize {
+			resp, err := u.StreamingDataCaptureUpload(ctx,
+				&datasyncpb.DataCaptureUploadMetadata{UploadMetadata: md, SensorMetadata: sd.GetMetadata()},
+				bytes.NewReader(sd.GetBinary()))
+			if err != nil {
+				return "", fmt.Errorf("record %d: %w", i, err)
+			}
+			id = resp.GetBinaryDataId()
+		} else {
+			resp, err := u.DataCaptureUpload(ctx,
+				&datasyncpb.DataCaptureUploadRequest{Metadata: md, SensorContents: []*datasyncpb.SensorData{sd}})
+			if err != nil {
+				return "", fmt.Errorf("record %d: %w", i, err)
+			}
+			id = resp.GetBinaryDataId()
+		}
+		ids = append(ids, id)
+		if opts.Ledger != nil {
+			if err := opts.Ledger.Mark(recordKey, id); err != nil {
+				return "", err
+			}
+		}
+	}
+	return strings.Join(ids, ","), nil
+}
+
+// UploadMetadata returns the UploadMetadata for the records of a capture file with header md.
+func UploadMetadata(md *datasyncpb.DataCaptureMetadata, partID string) *datasyncpb.UploadMetadata {
+	return &datasyncpb.UploadMetadata{
+		PartId:           partID,
+		ComponentType:    md.GetComponentType(),
+		ComponentName:    md.GetComponentName(),
+		MethodName:       md.GetMethodName(),
+		Type:             md.GetType(),
+		MethodParameters: md.GetMethodParameters(),
+		FileExtension:    md.GetFileExtension(),
+		Tags:             slices.Clone(md.GetTags()),
+		MimeType:         md.GetMimeType(),
+	}
+}
@@ -0,0 +1,93 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Ledger is an append-only record of completed uploads, persisted to a file so that uploads interrupted by
+// a crash are not repeated once they have been recorded. It is safe for concurrent use.
+type Ledger struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	done map[string]bool
+}
+
+type ledgerEntry struct {
+	Key  string    `json:"key"`
+	ID   string    `json:"id,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+// OpenLedger opens the ledger file at path, creating it if needed. A partially written last entry, left by
+// a crash, is ignored.
+func OpenLedger(path string) (*Ledger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l := &Ledger{path: path, done: map[string]bool{}}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var e ledgerEntry
+		if len(line) == 0 || json.Unmarshal(line, &e) != nil {
+			continue
+		}
+		l.done[e.Key] = true
+	}
+
+	l.f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		// Terminate the torn entry so that the next one starts on its own line.
+		if _, err := l.f.Write([]byte("\n")); err != nil {
+			//nolint:errcheck
+			l.f.Close()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Done reports whether key has been recorded.
+func (l *Ledger) Done(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.done[key]
+}
+
+// Mark records key, along with the id the server assigned to the upload, and syncs the ledger to disk.
+func (l *Ledger) Mark(key, id string) error {
+	line, err := json.Marshal(ledgerEntry{Key: key, ID: id, Time: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := l.f.Sync(); err != nil {
+		return err
+	}
+	l.done[key] = true
+	return nil
+}
+
+// Close closes the ledger file.
+func (l *Ledger) Close() error {
+	return l.f.Close()
+}
+
+// FileKey returns the ledger key of a file: its path, size and modification time, so that a file replaced
+// by new content under the same name is uploaded again.
+func FileKey(path string, info fs.FileInfo) string {
+	return fmt.Sprintf("%s|%d|%d", path, info.Size(), info.ModTime().UnixNano())
+}
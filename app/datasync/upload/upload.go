@@ -0,0 +1,266 @@
+// Package upload sends files and captured data to DataSyncService.
+//
+// An Uploader streams an io.Reader through FileUpload or StreamingDataCaptureUpload in chunks, retrying
+// the whole stream with exponential backoff when the failure is transient, and limiting the bandwidth used
+// by all of its uploads together. UploadDir uploads a directory of files and capture files with a bounded
+// number of workers, recording each upload in a Ledger so that nothing is uploaded twice after a crash.
+package upload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+// DefaultChunkSize is the chunk size used when Options.ChunkSize is zero.
+const DefaultChunkSize = 64 << 10
+
+var errNotRewindable = errors.New("upload: cannot retry a stream whose reader is not an io.Seeker")
+
+// Backoff configures retries. Delays start at Initial and double up to Max, with random jitter.
+type Backoff struct {
+	Initial     time.Duration
+	Max         time.Duration
+	MaxAttempts int
+}
+
+// DefaultBackoff is used when Options.Backoff is the zero value.
+var DefaultBackoff = Backoff{Initial: 250 * time.Millisecond, Max: 30 * time.Second, MaxAttempts: 8}
+
+// delay returns the wait before the given retry, counting from 1.
+func (b Backoff) delay(retry int) time.Duration {
+	d := b.Initial
+	for i := 1; i < retry && d < b.Max; i++ {
+		d *= 2
+	}
+	d = min(d, b.Max)
+	// Wait between half and all of d so that clients that failed together do not retry together.
+	return d/2 + rand.N(d/2+1)
+}
+
+// Options configures an Uploader.
+type Options struct {
+	// ChunkSize is the size of each streamed chunk. Zero means DefaultChunkSize.
+	ChunkSize int
+	// BytesPerSecond limits the combined bandwidth of all uploads. Zero means no limit.
+	BytesPerSecond int64
+	// Backoff configures retries of transient failures. The zero value means DefaultBackoff.
+	Backoff Backoff
+	// Progress, if set, is called after each chunk with the bytes sent so far and the total, which is -1
+	// when the reader's size is unknown. A retried stream reports progress from zero again.
+	Progress func(sent, total int64)
+}
+
+// Uploader uploads data to DataSyncService. It is safe for concurrent use.
+type Uploader struct {
+	client  datasyncpb.DataSyncServiceClient
+	opts    Options
+	limiter *limiter
+}
+
+// New returns an Uploader that uses client.
+func New(client datasyncpb.DataSyncServiceClient, opts Options) *Uploader {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultChunkSize
+	}
+	if opts.Backoff == (Backoff{}) {
+		opts.Backoff = DefaultBackoff
+	}
+	return &Uploader{client: client, opts: opts, limiter: newLimiter(opts.BytesPerSecond)}
+}
+
+// clientStream is the client side of an upload stream.
+type clientStream[Req, Resp any] interface {
+	Send(Req) error
+	CloseAndRecv() (Resp, error)
+}
+
+// FileUpload uploads the contents of r as a file described by md. Failed streams are only retried if r is
+// an io.Seeker, in which case it is rewound to its starting offset.
+func (u *Uploader) FileUpload(ctx context.Context, md *datasyncpb.UploadMetadata, r io.Reader) (*datasyncpb.FileUploadResponse, error) {
+	return stream(ctx, u, r,
+		func(ctx context.Context) (clientStream[*datasyncpb.FileUploadRequest, *datasyncpb.FileUploadResponse], error) {
+			return u.client.FileUpload(ctx)
+		},
+		&datasyncpb.FileUploadRequest{UploadPacket: &datasyncpb.FileUploadRequest_Metadata{Metadata: md}},
+		func(chunk []byte) *datasyncpb.FileUploadRequest {
+			return &datasyncpb.FileUploadRequest{
+				UploadPacket: &datasyncpb.FileUploadRequest_FileContents{FileContents: &datasyncpb.FileData{Data: chunk}},
+			}
+		},
+	)
+}
+
+// StreamingDataCaptureUpload uploads the contents of r as a single binary capture described by md. It is
+// retried like FileUpload.
+func (u *Uploader) StreamingDataCaptureUpload(
+	ctx context.Context, md *datasyncpb.DataCaptureUploadMetadata, r io.Reader,
+) (*datasyncpb.StreamingDataCaptureUploadResponse, error) {
+	return stream(ctx, u, r,
+		func(ctx context.Context) (
+			clientStream[*datasyncpb.StreamingDataCaptureUploadRequest, *datasyncpb.StreamingDataCaptureUploadResponse], error,
+		) {
+			return u.client.StreamingDataCaptureUpload(ctx)
+		},
+		&datasyncpb.StreamingDataCaptureUploadRequest{UploadPacket: &datasyncpb.StreamingDataCaptureUploadRequest_Metadata{Metadata: md}},
+		func(chunk []byte) *datasyncpb.StreamingDataCaptureUploadRequest {
+			return &datasyncpb.StreamingDataCaptureUploadRequest{UploadPacket: &datasyncpb.StreamingDataCaptureUploadRequest_Data{Data: chunk}}
+		},
+	)
+}
+
+// DataCaptureUpload sends req, retrying transient failures.
+func (u *Uploader) DataCaptureUpload(
+	ctx context.Context, req *datasyncpb.DataCaptureUploadRequest,
+) (*datasyncpb.DataCaptureUploadResponse, error) {
+	var resp *datasyncpb.DataCaptureUploadResponse
+	err := u.retry(ctx, func() error { return nil }, func(ctx context.Context) error {
+		for _, sd := range req.GetSensorContents() {
+			if err := u.limiter.wait(ctx, len(sd.GetBinary())); err != nil {
+				return err
+			}
+		}
+		var err error
+		resp, err = u.client.DataCaptureUpload(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func stream[Req, Resp any](
+	ctx context.Context, u *Uploader, r io.Reader,
+	open func(context.Context) (clientStream[Req, Resp], error), first Req, chunk func([]byte) Req,
+) (Resp, error) {
+	var resp Resp
+	total := int64(-1)
+	// Without an io.Seeker, a stream can only be retried if it failed before reading from r.
+	consumed := false
+	rewind := func() error {
+		if consumed {
+			return errNotRewindable
+		}
+		return nil
+	}
+	if s, ok := r.(io.Seeker); ok {
+		start, err := s.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return resp, err
+		}
+		if end, err := s.Seek(0, io.SeekEnd); err == nil {
+			total = end - start
+		}
+		if _, err := s.Seek(start, io.SeekStart); err != nil {
+			return resp, err
+		}
+		rewind = func() error {
+			_, err := s.Seek(start, io.SeekStart)
+			return err
+		}
+	}
+
+	buf := make([]byte, u.opts.ChunkSize)
+	err := u.retry(ctx, rewind, func(ctx context.Context) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		s, err := open(ctx)
+		if err != nil {
+			return err
+		}
+		if err := s.Send(first); err != nil {
+			return sendError(s, err)
+		}
+		var sent int64
+		for {
+			n, rerr := io.ReadFull(r, buf)
+			consumed = true
+			if n > 0 {
+				if err := u.limiter.wait(ctx, n); err != nil {
+					return err
+				}
+				// Each chunk gets its own slice, since the stream may hold on to it after Send returns.
+				if err := s.Send(chunk(append([]byte(nil), buf[:n]...))); err != nil {
+					return sendError(s, err)
+				}
+				sent += int64(n)
+				if u.opts.Progress != nil {
+					u.opts.Progress(sent, total)
+				}
+			}
+			if errors.Is(rerr, io.EOF) || errors.Is(rerr, io.ErrUnexpectedEOF) {
+				break
+			}
+			if rerr != nil {
+				return &readError{err: rerr}
+			}
+		}
+		resp, err = s.CloseAndRecv()
+		return err
+	})
+	return resp, err
+}
+
+// sendError returns the real error of a stream whose Send failed. Send reports io.EOF when the server has
+// ended the stream, and the status is only available from CloseAndRecv.
+func sendError[Req, Resp any](s clientStream[Req, Resp], err error) error {
+	if errors.Is(err, io.EOF) {
+		_, err = s.CloseAndRecv()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+	}
+	return err
+}
+
+// readError marks a failure to read the source, which is never retried.
+type readError struct {
+	err error
+}
+
+func (e *readError) Error() string { return "upload: reading source: " + e.err.Error() }
+
+func (e *readError) Unwrap() error { return e.err }
+
+// retry runs attempt until it succeeds, fails permanently or runs out of attempts. rewind prepares the
+// source for another attempt, and fails if it cannot be resent.
+func (u *Uploader) retry(ctx context.Context, rewind func() error, attempt func(context.Context) error) error {
+	for i := 1; ; i++ {
+		err := attempt(ctx)
+		if err == nil || !retryable(ctx, err) || i >= u.opts.Backoff.MaxAttempts {
+			return err
+		}
+		t := time.NewTimer(u.opts.Backoff.delay(i))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-t.C:
+		}
+		if rerr := rewind(); rerr != nil {
+			return errors.Join(err, rerr)
+		}
+	}
+}
+
+// retryable reports whether err is a transient failure worth retrying.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var re *readError
+	if errors.As(err, &re) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
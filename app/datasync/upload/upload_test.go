@@ -0,0 +1,426 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/api/app/datasync/capturefile"
+	datasyncpb "go.viam.com/api/app/datasync/v1"
+)
+
+var fastBackoff = Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, MaxAttempts: 3}
+
+// fakeSync records what is uploaded. Each call fails with the next error in failures, if any.
+type fakeSync struct {
+	datasyncpb.DataSyncServiceClient
+
+	mu       sync.Mutex
+	failures []error
+	attempts int
+	files    map[string][]byte
+	chunks   int
+	captures []*datasyncpb.DataCaptureUploadRequest
+	streamed [][]byte
+}
+
+func (c *fakeSync) nextFailure() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts++
+	if len(c.failures) == 0 {
+		return nil
+	}
+	err := c.failures[0]
+	c.failures = c.failures[1:]
+	return err
+}
+
+type fileStream struct {
+	grpc.ClientStream
+	c    *fakeSync
+	name string
+	data []byte
+}
+
+func (s *fileStream) Send(req *datasyncpb.FileUploadRequest) error {
+	if md := req.GetMetadata(); md != nil {
+		s.name = md.GetFileName()
+		return nil
+	}
+	s.data = append(s.data, req.GetFileContents().GetData()...)
+	s.c.mu.Lock()
+	s.c.chunks++
+	s.c.mu.Unlock()
+	return nil
+}
+
+func (s *fileStream) CloseAndRecv() (*datasyncpb.FileUploadResponse, error) {
+	if err := s.c.nextFailure(); err != nil {
+		return nil, err
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	if s.c.files == nil {
+		s.c.files = map[string][]byte{}
+	}
+	s.c.files[s.name] = s.data
+	return &datasyncpb.FileUploadResponse{BinaryDataId: "file-" + s.name}, nil
+}
+
+func (c *fakeSync) FileUpload(context.Context, ...grpc.CallOption) (datasyncpb.DataSyncService_FileUploadClient, error) {
+	return &fileStream{c: c}, nil
+}
+
+type captureStream struct {
+	grpc.ClientStream
+	c    *fakeSync
+	data []byte
+}
+
+func (s *captureStream) Send(req *datasyncpb.StreamingDataCaptureUploadRequest) error {
+	s.data = append(s.data, req.GetData()...)
+	return nil
+}
+
+func (s *captureStream) CloseAndRecv() (*datasyncpb.StreamingDataCaptureUploadResponse, error) {
+	if err := s.c.nextFailure(); err != nil {
+		return nil, err
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	s.c.streamed = append(s.c.streamed, s.data)
+	return &datasyncpb.StreamingDataCaptureUploadResponse{BinaryDataId: "streamed"}, nil
+}
+
+func (c *fakeSync) StreamingDataCaptureUpload(
+	context.Context, ...grpc.CallOption,
+) (datasyncpb.DataSyncService_StreamingDataCaptureUploadClient, error) {
+	return &captureStream{c: c}, nil
+}
+
+func (c *fakeSync) DataCaptureUpload(
+	_ context.Context, in *datasyncpb.DataCaptureUploadRequest, _ ...grpc.CallOption,
+) (*datasyncpb.DataCaptureUploadResponse, error) {
+	if err := c.nextFailure(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.captures = append(c.captures, in)
+	return &datasyncpb.DataCaptureUploadResponse{BinaryDataId: "capture"}, nil
+}
+
+func TestFileUpload(t *testing.T) {
+	c := &fakeSync{}
+	var progress []int64
+	u := New(c, Options{ChunkSize: 4, Backoff: fastBackoff, Progress: func(sent, total int64) {
+		if total != 10 {
+			t.Errorf("total = %d", total)
+		}
+		progress = append(progress, sent)
+	}})
+	resp, err := u.FileUpload(context.Background(), &datasyncpb.UploadMetadata{FileName: "f"}, strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetBinaryDataId() != "file-f" || string(c.files["f"]) != "0123456789" || c.chunks != 3 {
+		t.Errorf("uploaded %q in %d chunks", c.files["f"], c.chunks)
+	}
+	if !slices.Equal(progress, []int64{4, 8, 10}) {
+		t.Errorf("progress = %v", progress)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	for _, tc := range []struct {
+		name     string
+		failures []error
+		reader   func() io.Reader
+		attempts int
+		want     error
+	}{
+		{"rewound", []error{unavailable, unavailable}, func() io.Reader { return strings.NewReader("data") }, 3, nil},
+		{"out of attempts", []error{unavailable, unavailable, unavailable}, func() io.Reader { return strings.NewReader("data") }, 3, unavailable},
+		{"permanent", []error{status.Error(codes.InvalidArgument, "bad")}, func() io.Reader { return strings.NewReader("data") }, 1, nil},
+		{"not rewindable", []error{unavailable}, func() io.Reader { return io.MultiReader(strings.NewReader("data")) }, 1, errNotRewindable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeSync{failures: slices.Clone(tc.failures)}
+			u := New(c, Options{Backoff: fastBackoff})
+			_, err := u.FileUpload(context.Background(), &datasyncpb.UploadMetadata{FileName: "f"}, tc.reader())
+			if c.attempts != tc.attempts {
+				t.Errorf("%d attempts, want %d", c.attempts, tc.attempts)
+			}
+			switch {
+			case tc.want != nil && !errors.Is(err, tc.want):
+				t.Errorf("error = %v, want %v", err, tc.want)
+			case tc.attempts == 3 && tc.want == nil:
+				if err != nil || string(c.files["f"]) != "data" {
+					t.Errorf("uploaded %q, %v", c.files["f"], err)
+				}
+			case tc.attempts == 1 && err == nil:
+				t.Error("upload succeeded")
+			}
+		})
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("disk error") }
+
+func TestReadErrorNotRetried(t *testing.T) {
+	c := &fakeSync{}
+	u := New(c, Options{Backoff: fastBackoff})
+	_, err := u.StreamingDataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadMetadata{}, failingReader{})
+	var re *readError
+	if !errors.As(err, &re) || c.attempts != 0 {
+		t.Errorf("error = %v after %d attempts", err, c.attempts)
+	}
+}
+
+func TestDataCaptureUploadRetry(t *testing.T) {
+	c := &fakeSync{failures: []error{status.Error(codes.ResourceExhausted, "slow down")}}
+	u := New(c, Options{Backoff: fastBackoff})
+	if _, err := u.DataCaptureUpload(context.Background(), &datasyncpb.DataCaptureUploadRequest{}); err != nil || c.attempts != 2 {
+		t.Errorf("DataCaptureUpload = %v after %d attempts", err, c.attempts)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second}
+	for retry, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for range 20 {
+			if d := b.delay(retry); d < max/2 || d > max {
+				t.Errorf("delay(%d) = %v, want between %v and %v", retry, d, max/2, max)
+			}
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	if err := (*limiter)(nil).wait(context.Background(), 1<<30); err != nil {
+		t.Errorf("nil limiter: %v", err)
+	}
+	if newLimiter(0) != nil {
+		t.Error("newLimiter(0) is not nil")
+	}
+	l := newLimiter(10000)
+	start := time.Now()
+	if err := l.wait(context.Background(), 10000); err != nil || time.Since(start) > 50*time.Millisecond {
+		t.Errorf("a full bucket waited %v, %v", time.Since(start), err)
+	}
+	if err := l.wait(context.Background(), 1000); err != nil || time.Since(start) < 80*time.Millisecond {
+		t.Errorf("an empty bucket waited %v, %v", time.Since(start), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(ctx, 100000); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled wait = %v", err)
+	}
+}
+
+func writeCapture(t *testing.T, dir, name string, typ datasyncpb.DataType, records ...*datasyncpb.SensorData) {
+	t.Helper()
+	w, err := capturefile.Create(filepath.Join(dir, name+capturefile.InProgressExt),
+		&datasyncpb.DataCaptureMetadata{ComponentName: name, Type: typ, Tags: []string{"captured"}}, capturefile.SyncPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sd := range records {
+		if err := w.Write(sd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func binary(b string) *datasyncpb.SensorData {
+	return &datasyncpb.SensorData{Data: &datasyncpb.SensorData_Binary{Binary: []byte(b)}}
+}
+
+func TestUploadDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{"notes.txt": "hello", ".hidden": "x", "partial" + capturefile.InProgressExt: "x"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCapture(t, dir, "readings", datasyncpb.DataType_DATA_TYPE_TABULAR_SENSOR, &datasyncpb.SensorData{}, &datasyncpb.SensorData{})
+	writeCapture(t, dir, "images", datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR, binary("ab"), binary("a large image"))
+
+	ledger, err := OpenLedger(filepath.Join(dir, "ledger.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &fakeSync{}
+	u := New(c, Options{ChunkSize: 4, Backoff: fastBackoff})
+	opts := DirOptions{PartID: "part", Ledger: ledger, Tags: []string{"extra"}, Workers: 2}
+	if err := u.UploadDir(context.Background(), dir, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.files) != 1 || string(c.files["notes.txt"]) != "hello" {
+		t.Errorf("files = %q", c.files)
+	}
+	if len(c.streamed) != 1 || string(c.streamed[0]) != "a large image" {
+		t.Errorf("streamed = %q", c.streamed)
+	}
+	if len(c.captures) != 2 {
+		t.Fatalf("got %d DataCaptureUploads", len(c.captures))
+	}
+	for _, req := range c.captures {
+		md := req.GetMetadata()
+		if md.GetPartId() != "part" || !slices.Equal(md.GetTags(), []string{"captured", "extra"}) {
+			t.Errorf("metadata = %v", md)
+		}
+		if md.GetComponentName() == "readings" && len(req.GetSensorContents()) != 2 {
+			t.Errorf("tabular upload holds %d records", len(req.GetSensorContents()))
+		}
+	}
+
+	// Everything is in the ledger, so nothing is uploaded again.
+	c2 := &fakeSync{}
+	opts.DeleteUploaded = true
+	if err := New(c2, Options{Backoff: fastBackoff}).UploadDir(context.Background(), dir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if c2.attempts != 0 {
+		t.Errorf("second run made %d uploads", c2.attempts)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var left []string
+	for _, e := range entries {
+		left = append(left, e.Name())
+	}
+	if want := []string{".hidden", "ledger.jsonl", "partial" + capturefile.InProgressExt}; !slices.Equal(left, want) {
+		t.Errorf("left %q, want %q", left, want)
+	}
+	if err := ledger.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUploadResumesBinaryCapture(t *testing.T) {
+	dir := t.TempDir()
+	writeCapture(t, dir, "images", datasyncpb.DataType_DATA_TYPE_BINARY_SENSOR, binary("a"), binary("b"), binary("c"))
+	path := filepath.Join(dir, "images"+capturefile.Ext)
+	ledger, err := OpenLedger(filepath.Join(t.TempDir(), "ledger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer ledger.Close()
+
+	// The first record was uploaded before a crash, and the second fails permanently.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ledger.Mark(FileKey(path, info)+"#0", "id0"); err != nil {
+		t.Fatal(err)
+	}
+	c := &fakeSync{failures: []error{status.Error(codes.InvalidArgument, "bad")}}
+	u := New(c, Options{Backoff: fastBackoff})
+	if err := u.UploadFile(context.Background(), path, DirOptions{Ledger: ledger}); err == nil || !strings.Contains(err.Error(), "record 1") {
+		t.Fatalf("UploadFile = %v", err)
+	}
+	if err := u.UploadFile(context.Background(), path, DirOptions{Ledger: ledger}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, req := range c.captures {
+		got = append(got, string(req.GetSensorContents()[0].GetBinary()))
+	}
+	if !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("uploaded %q", got)
+	}
+}
+
+func TestLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger")
+	l, err := OpenLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Mark("a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// A crash leaves a torn entry at the end.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"key":"b"`); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = OpenLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Done("a") || l.Done("b") {
+		t.Errorf("Done(a) = %v, Done(b) = %v", l.Done("a"), l.Done("b"))
+	}
+	if err := l.Mark("c", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	l, err = OpenLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer l.Close()
+	if !l.Done("c") {
+		t.Error("the entry after a torn one was lost")
+	}
+}
+
+func TestFileKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f")
+	key := func(data string) string {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return FileKey(path, info)
+	}
+	if a, b := key("one"), key("three"); a == b {
+		t.Errorf("replaced content has the same key %q", a)
+	}
+	if !bytes.HasPrefix([]byte(key("x")), []byte(path+"|1|")) {
+		t.Errorf("key = %q", key("x"))
+	}
+}
@@ -0,0 +1,538 @@
+package mql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BSON element types.
+const (
+	typeDouble     = 0x01
+	typeString     = 0x02
+	typeDocument   = 0x03
+	typeArray      = 0x04
+	typeBinary     = 0x05
+	typeUndefined  = 0x06
+	typeObjectID   = 0x07
+	typeBool       = 0x08
+	typeDateTime   = 0x09
+	typeNull       = 0x0A
+	typeRegex      = 0x0B
+	typeJavaScript = 0x0D
+	typeInt32      = 0x10
+	typeTimestamp  = 0x11
+	typeInt64      = 0x12
+	typeDecimal128 = 0x13
+	typeMinKey     = 0xFF
+	typeMaxKey     = 0x7F
+)
+
+// maxDepth bounds the nesting of decoded documents.
+const maxDepth = 100
+
+var errTruncated = errors.New("mql: truncated BSON")
+
+// E is a single element of a D.
+type E struct {
+	Key   string
+	Value any
+}
+
+// D is an ordered BSON document. Order matters for stages such as $sort and for index keys.
+type D []E
+
+// M is an unordered BSON document. Its keys are encoded in sorted order so that the output is stable.
+type M map[string]any
+
+// A is a BSON array.
+type A []any
+
+// ObjectID is a BSON ObjectId.
+type ObjectID [12]byte
+
+// String returns the hex form of id.
+func (id ObjectID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Regex is a BSON regular expression.
+type Regex struct {
+	Pattern string
+	Options string
+}
+
+// Binary is BSON binary data with a subtype other than the generic subtype 0, which decodes as []byte.
+type Binary struct {
+	Subtype byte
+	Data    []byte
+}
+
+// Timestamp is a BSON internal timestamp.
+type Timestamp struct {
+	T uint32
+	I uint32
+}
+
+// Decimal128 is a BSON decimal128 value, kept in its IEEE 754-2008 bit form.
+type Decimal128 struct {
+	High, Low uint64
+}
+
+// MinKey is the BSON MinKey value.
+type MinKey struct{}
+
+// MaxKey is the BSON MaxKey value.
+type MaxKey struct{}
+
+// Lookup returns the value of the first element of d with the given key.
+func (d D) Lookup(key string) (any, bool) {
+	for _, e := range d {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Map converts d, and any documents and arrays nested in it, to plain maps and slices.
+func (d D) Map() map[string]any {
+	m := make(map[string]any, len(d))
+	for _, e := range d {
+		m[e.Key] = plain(e.Value)
+	}
+	return m
+}
+
+func plain(v any) any {
+	switch v := v.(type) {
+	case D:
+		return v.Map()
+	case A:
+		out := make([]any, len(v))
+		for i, x := range v {
+			out[i] = plain(x)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Marshal encodes doc, which must be a D, an M or a map with string keys, as a BSON document.
+//
+// Go ints are encoded as int32 when they fit and int64 otherwise; uint64 values above math.MaxInt64 and
+// types with no BSON equivalent are rejected. time.Time is truncated to milliseconds.
+func Marshal(doc any) ([]byte, error) {
+	var e encoder
+	switch doc.(type) {
+	case D, M, map[string]any:
+	default:
+		if rv := reflect.ValueOf(doc); rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("mql: cannot encode %T as a document", doc)
+		}
+	}
+	if _, err := e.value(doc, 0); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+type encoder struct {
+	buf []byte
+}
+
+// document writes the elements produced by each as a document, back-patching its length.
+func (e *encoder) document(each func() error) error {
+	start := len(e.buf)
+	e.buf = append(e.buf, 0, 0, 0, 0)
+	if err := each(); err != nil {
+		return err
+	}
+	e.buf = append(e.buf, 0)
+	binary.LittleEndian.PutUint32(e.buf[start:], uint32(len(e.buf)-start))
+	return nil
+}
+
+func (e *encoder) cstring(s string) error {
+	if strings.IndexByte(s, 0) >= 0 {
+		return fmt.Errorf("mql: %q contains a NUL byte", s)
+	}
+	e.buf = append(append(e.buf, s...), 0)
+	return nil
+}
+
+func (e *encoder) element(key string, v any, depth int) error {
+	if depth > maxDepth {
+		return errors.New("mql: document nested too deeply")
+	}
+	typePos := len(e.buf)
+	e.buf = append(e.buf, 0)
+	if err := e.cstring(key); err != nil {
+		return err
+	}
+	t, err := e.value(v, depth)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	e.buf[typePos] = t
+	return nil
+}
+
+// value appends the encoding of v and returns its element type.
+func (e *encoder) value(v any, depth int) (byte, error) {
+	le := binary.LittleEndian
+	switch v := v.(type) {
+	case nil:
+		return typeNull, nil
+	case bool:
+		if v {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+		return typeBool, nil
+	case float64:
+		e.buf = le.AppendUint64(e.buf, math.Float64bits(v))
+		return typeDouble, nil
+	case float32:
+		e.buf = le.AppendUint64(e.buf, math.Float64bits(float64(v)))
+		return typeDouble, nil
+	case int:
+		return e.int(int64(v)), nil
+	case int8:
+		return e.int(int64(v)), nil
+	case int16:
+		return e.int(int64(v)), nil
+	case int32:
+		e.buf = le.AppendUint32(e.buf, uint32(v))
+		return typeInt32, nil
+	case int64:
+		e.buf = le.AppendUint64(e.buf, uint64(v))
+		return typeInt64, nil
+	case uint8:
+		return e.int(int64(v)), nil
+	case uint16:
+		return e.int(int64(v)), nil
+	case uint32:
+		return e.int(int64(v)), nil
+	case uint:
+		return e.uint(uint64(v))
+	case uint64:
+		return e.uint(v)
+	case string:
+		e.buf = le.AppendUint32(e.buf, uint32(len(v)+1))
+		e.buf = append(append(e.buf, v...), 0)
+		return typeString, nil
+	case []byte:
+		return e.binary(0, v), nil
+	case Binary:
+		return e.binary(v.Subtype, v.Data), nil
+	case ObjectID:
+		e.buf = append(e.buf, v[:]...)
+		return typeObjectID, nil
+	case time.Time:
+		e.buf = le.AppendUint64(e.buf, uint64(v.UnixMilli()))
+		return typeDateTime, nil
+	case Regex:
+		if err := e.cstring(v.Pattern); err != nil {
+			return 0, err
+		}
+		opts := []byte(v.Options)
+		slices.Sort(opts)
+		return typeRegex, e.cstring(string(opts))
+	case Timestamp:
+		e.buf = le.AppendUint32(le.AppendUint32(e.buf, v.I), v.T)
+		return typeTimestamp, nil
+	case Decimal128:
+		e.buf = le.AppendUint64(le.AppendUint64(e.buf, v.Low), v.High)
+		return typeDecimal128, nil
+	case MinKey:
+		return typeMinKey, nil
+	case MaxKey:
+		return typeMaxKey, nil
+	case D:
+		return typeDocument, e.document(func() error {
+			for _, el := range v {
+				if err := e.element(el.Key, el.Value, depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case M:
+		return e.value(map[string]any(v), depth)
+	case map[string]any:
+		return typeDocument, e.document(func() error {
+			for _, k := range sortedKeys(v) {
+				if err := e.element(k, v[k], depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case A:
+		return e.array(len(v), func(i int) any { return v[i] }, depth)
+	case []any:
+		return e.array(len(v), func(i int) any { return v[i] }, depth)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return e.array(rv.Len(), func(i int) any { return rv.Index(i).Interface() }, depth)
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			m := make(map[string]any, rv.Len())
+			iter := rv.MapRange()
+			for iter.Next() {
+				m[iter.Key().String()] = iter.Value().Interface()
+			}
+			return e.value(m, depth)
+		}
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return typeNull, nil
+		}
+		return e.value(rv.Elem().Interface(), depth)
+	}
+	return 0, fmt.Errorf("mql: cannot encode %T", v)
+}
+
+func (e *encoder) int(i int64) byte {
+	if i >= math.MinInt32 && i <= math.MaxInt32 {
+		e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(i))
+		return typeInt32
+	}
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, uint64(i))
+	return typeInt64
+}
+
+func (e *encoder) uint(u uint64) (byte, error) {
+	if u > math.MaxInt64 {
+		return 0, fmt.Errorf("mql: %d overflows int64", u)
+	}
+	return e.int(int64(u)), nil
+}
+
+func (e *encoder) binary(subtype byte, data []byte) byte {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, uint32(len(data)))
+	e.buf = append(append(e.buf, subtype), data...)
+	return typeBinary
+}
+
+func (e *encoder) array(n int, at func(int) any, depth int) (byte, error) {
+	return typeArray, e.document(func() error {
+		for i := 0; i < n; i++ {
+			if err := e.element(fmt.Sprint(i), at(i), depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Unmarshal decodes a single BSON document. Nested documents decode as D and arrays as A; the remaining
+// types decode as float64, string, []byte, Binary, ObjectID, bool, time.Time (in UTC), nil, Regex, int32,
+// Timestamp, int64, Decimal128, MinKey and MaxKey. Undefined decodes as nil and JavaScript code as string.
+func Unmarshal(data []byte) (D, error) {
+	d := decoder{buf: data}
+	doc, err := d.document(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(data) {
+		return nil, fmt.Errorf("mql: %d trailing bytes after document", len(data)-d.pos)
+	}
+	return doc, nil
+}
+
+type decoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *decoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.buf)-d.pos < n {
+		return nil, errTruncated
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) int32() (int32, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.LittleEndian.Uint32(b)), nil
+}
+
+func (d *decoder) uint64() (uint64, error) {
+	b, err := d.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *decoder) cstring() (string, error) {
+	i := bytes.IndexByte(d.buf[d.pos:], 0)
+	if i < 0 {
+		return "", errTruncated
+	}
+	s := string(d.buf[d.pos : d.pos+i])
+	d.pos += i + 1
+	return s, nil
+}
+
+func (d *decoder) document(depth int) (D, error) {
+	if depth > maxDepth {
+		return nil, errors.New("mql: document nested too deeply")
+	}
+	start := d.pos
+	n, err := d.int32()
+	if err != nil {
+		return nil, err
+	}
+	if n < 5 || int(n) > len(d.buf)-start {
+		return nil, fmt.Errorf("mql: invalid document length %d", n)
+	}
+	end := start + int(n)
+	doc := D{}
+	for {
+		if d.pos >= end {
+			return nil, errTruncated
+		}
+		t := d.buf[d.pos]
+		d.pos++
+		if t == 0 {
+			break
+		}
+		key, err := d.cstring()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.value(t, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		doc = append(doc, E{Key: key, Value: v})
+	}
+	if d.pos != end {
+		return nil, fmt.Errorf("mql: document length %d does not match its contents", n)
+	}
+	return doc, nil
+}
+
+func (d *decoder) value(t byte, depth int) (any, error) {
+	switch t {
+	case typeDouble:
+		u, err := d.uint64()
+		return math.Float64frombits(u), err
+	case typeString, typeJavaScript:
+		n, err := d.int32()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.next(int(n))
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 || b[n-1] != 0 {
+			return nil, errors.New("mql: string is not NUL terminated")
+		}
+		return string(b[:n-1]), nil
+	case typeDocument:
+		return d.document(depth + 1)
+	case typeArray:
+		doc, err := d.document(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr := make(A, len(doc))
+		for i, el := range doc {
+			arr[i] = el.Value
+		}
+		return arr, nil
+	case typeBinary:
+		n, err := d.int32()
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("mql: invalid binary length %d", n)
+		}
+		b, err := d.next(int(n) + 1)
+		if err != nil {
+			return nil, err
+		}
+		data := append([]byte(nil), b[1:]...)
+		if b[0] == 0 {
+			return data, nil
+		}
+		return Binary{Subtype: b[0], Data: data}, nil
+	case typeUndefined, typeNull:
+		return nil, nil
+	case typeObjectID:
+		b, err := d.next(12)
+		if err != nil {
+			return nil, err
+		}
+		return ObjectID(b), nil
+	case typeBool:
+		b, err := d.next(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case typeDateTime:
+		u, err := d.uint64()
+		return time.UnixMilli(int64(u)).UTC(), err
+	case typeRegex:
+		pattern, err := d.cstring()
+		if err != nil {
+			return nil, err
+		}
+		opts, err := d.cstring()
+		return Regex{Pattern: pattern, Options: opts}, err
+	case typeInt32:
+		return d.int32()
+	case typeTimestamp:
+		u, err := d.uint64()
+		return Timestamp{T: uint32(u >> 32), I: uint32(u)}, err
+	case typeInt64:
+		u, err := d.uint64()
+		return int64(u), err
+	case typeDecimal128:
+		low, err := d.uint64()
+		if err != nil {
+			return nil, err
+		}
+		high, err := d.uint64()
+		return Decimal128{High: high, Low: low}, err
+	case typeMinKey:
+		return MinKey{}, nil
+	case typeMaxKey:
+		return MaxKey{}, nil
+	default:
+		return nil, fmt.Errorf("mql: unsupported BSON type 0x%02x", t)
+	}
+}
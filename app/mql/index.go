@@ -0,0 +1,89 @@
+package mql
+
+import (
+	"errors"
+	"fmt"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// IndexSpec is a custom index specification. It is encoded as a single document holding keys and, when
+// set, options.
+type IndexSpec struct {
+	// Keys maps each indexed field to its index type, in order: 1 or -1 for ascending or descending, or a
+	// string such as "text" or "2dsphere". See Asc and Desc.
+	Keys D
+	// Options holds MongoDB index options such as unique, sparse, expireAfterSeconds or
+	// partialFilterExpression.
+	Options D
+}
+
+// NewIndexSpec returns an index over the given keys.
+func NewIndexSpec(keys ...E) IndexSpec {
+	return IndexSpec{Keys: keys}
+}
+
+// With returns a copy of s with an option added.
+func (s IndexSpec) With(option string, value any) IndexSpec {
+	s.Options = append(s.Options[:len(s.Options):len(s.Options)], E{Key: option, Value: value})
+	return s
+}
+
+// Binary encodes s in the form taken by CreateIndexRequest.index_spec.
+func (s IndexSpec) Binary() ([][]byte, error) {
+	if len(s.Keys) == 0 {
+		return nil, errors.New("mql: index has no keys")
+	}
+	doc := D{{Key: "keys", Value: s.Keys}}
+	if len(s.Options) > 0 {
+		doc = append(doc, E{Key: "options", Value: s.Options})
+	}
+	b, err := Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{b}, nil
+}
+
+// ParseIndexSpec decodes the index_spec of an Index. Keys and options spread over several documents are
+// merged in order.
+func ParseIndexSpec(spec [][]byte) (IndexSpec, error) {
+	var s IndexSpec
+	for i, b := range spec {
+		d, err := Unmarshal(b)
+		if err != nil {
+			return IndexSpec{}, fmt.Errorf("index spec %d: %w", i, err)
+		}
+		for _, e := range d {
+			sub, ok := e.Value.(D)
+			if !ok {
+				return IndexSpec{}, fmt.Errorf("index spec %d: %s is not a document", i, e.Key)
+			}
+			switch e.Key {
+			case "keys":
+				s.Keys = append(s.Keys, sub...)
+			case "options":
+				s.Options = append(s.Options, sub...)
+			default:
+				return IndexSpec{}, fmt.Errorf("index spec %d: unknown field %s", i, e.Key)
+			}
+		}
+	}
+	return s, nil
+}
+
+// CreateIndexRequest returns a request that builds s on a collection of an organization. pipelineName is
+// only used for pipeline sink collections and may be empty otherwise.
+func (s IndexSpec) CreateIndexRequest(
+	organizationID string, collection datapb.IndexableCollection, pipelineName string,
+) (*datapb.CreateIndexRequest, error) {
+	spec, err := s.Binary()
+	if err != nil {
+		return nil, err
+	}
+	req := &datapb.CreateIndexRequest{OrganizationId: organizationID, CollectionType: collection, IndexSpec: spec}
+	if pipelineName != "" {
+		req.PipelineName = &pipelineName
+	}
+	return req, nil
+}
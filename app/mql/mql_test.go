@@ -0,0 +1,306 @@
+package mql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+func TestMarshalKnownBytes(t *testing.T) {
+	// The examples of the BSON specification.
+	for _, tc := range []struct {
+		name string
+		doc  any
+		want []byte
+	}{
+		{"hello world", D{{Key: "hello", Value: "world"}}, []byte(
+			"\x16\x00\x00\x00\x02hello\x00\x06\x00\x00\x00world\x00\x00",
+		)},
+		{"array", M{"BSON": A{"awesome", 5.05, int32(1986)}}, []byte(
+			"\x31\x00\x00\x00\x04BSON\x00\x26\x00\x00\x00\x020\x00\x08\x00\x00\x00awesome\x00" +
+				"\x011\x00\x33\x33\x33\x33\x33\x33\x14\x40\x102\x00\xc2\x07\x00\x00\x00\x00",
+		)},
+		{"empty", D{}, []byte{5, 0, 0, 0, 0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Marshal(tc.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("Marshal = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	for _, tc := range []struct {
+		name string
+		in   any
+		want any
+	}{
+		{"double", 1.5, 1.5},
+		{"float32", float32(0.5), 0.5},
+		{"string", "a\x00b", "a\x00b"},
+		{"document", D{{Key: "b", Value: int32(1)}, {Key: "a", Value: nil}}, D{{Key: "b", Value: int32(1)}, {Key: "a", Value: nil}}},
+		{"map", map[string]int{"b": 2, "a": 1}, D{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(2)}}},
+		{"array", []string{"x", "y"}, A{"x", "y"}},
+		{"bytes", []byte{1, 2}, []byte{1, 2}},
+		{"binary", Binary{Subtype: 4, Data: []byte{9}}, Binary{Subtype: 4, Data: []byte{9}}},
+		{"object id", ObjectID{1, 2, 3}, ObjectID{1, 2, 3}},
+		{"bool", true, true},
+		{"time", when, when},
+		{"regex options sorted", Regex{Pattern: "^a", Options: "xi"}, Regex{Pattern: "^a", Options: "ix"}},
+		{"small int", 7, int32(7)},
+		{"large int", int64(1) << 40, int64(1) << 40},
+		{"int64 stays int64", int64(1), int64(1)},
+		{"uint", uint(1 << 35), int64(1 << 35)},
+		{"timestamp", Timestamp{T: 5, I: 6}, Timestamp{T: 5, I: 6}},
+		{"decimal", Decimal128{High: 1, Low: 2}, Decimal128{High: 1, Low: 2}},
+		{"min key", MinKey{}, MinKey{}},
+		{"max key", MaxKey{}, MaxKey{}},
+		{"nil pointer", (*int)(nil), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := Marshal(D{{Key: "v", Value: tc.in}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := Unmarshal(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := d.Lookup("v")
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("round trip = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	d := D{{Key: "a", Value: D{{Key: "b", Value: A{D{{Key: "c", Value: int32(1)}}}}}}}
+	want := map[string]any{"a": map[string]any{"b": []any{map[string]any{"c": int32(1)}}}}
+	if got := d.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Map = %#v", got)
+	}
+	if _, ok := d.Lookup("missing"); ok {
+		t.Error("Lookup found a missing key")
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	deep := D{}
+	for range maxDepth + 1 {
+		deep = D{{Key: "d", Value: deep}}
+	}
+	for _, tc := range []struct {
+		name string
+		doc  any
+	}{
+		{"not a document", A{1}},
+		{"int keys", map[int]int{1: 1}},
+		{"nul key", D{{Key: "a\x00", Value: 1}}},
+		{"nul regex", D{{Key: "r", Value: Regex{Pattern: "\x00"}}}},
+		{"uint64 overflow", D{{Key: "u", Value: uint64(math.MaxUint64)}}},
+		{"unsupported", D{{Key: "c", Value: make(chan int)}}},
+		{"too deep", deep},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Marshal(tc.doc); err == nil {
+				t.Error("Marshal succeeded")
+			}
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	valid, err := Marshal(D{{Key: "s", Value: "abc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deep := []byte{5, 0, 0, 0, 0}
+	for range maxDepth + 1 {
+		inner := deep
+		deep = binary.LittleEndian.AppendUint32(nil, uint32(len(inner)+8))
+		deep = append(append(append(deep, typeDocument, 'd', 0), inner...), 0)
+	}
+	if _, err := Unmarshal(deep); err == nil || !strings.Contains(err.Error(), "nested too deeply") {
+		t.Errorf("Unmarshal of a deep document = %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"short length", []byte{4, 0, 0, 0}},
+		{"length past end", []byte{10, 0, 0, 0, 0}},
+		{"trailing bytes", append(append([]byte(nil), valid...), 0)},
+		{"missing terminator", []byte{5, 0, 0, 0, 1}},
+		{"unterminated key", []byte{7, 0, 0, 0, typeNull, 'a', 'b'}},
+		{"string not terminated", []byte{14, 0, 0, 0, typeString, 's', 0, 2, 0, 0, 0, 'a', 'b', 0}},
+		{"negative string length", []byte{12, 0, 0, 0, typeString, 's', 0, 0xff, 0xff, 0xff, 0xff, 0}},
+		{"negative binary length", []byte{12, 0, 0, 0, typeBinary, 'a', 0, 0xff, 0xff, 0xff, 0xff, 0}},
+		{"binary past end", []byte{13, 0, 0, 0, typeBinary, 'a', 0, 9, 0, 0, 0, 0, 0}},
+		{"unknown type", []byte{8, 0, 0, 0, 0x20, 'a', 0, 0}},
+		{"length mismatch", []byte{9, 0, 0, 0, typeNull, 'a', 0, 0, 0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d, err := Unmarshal(tc.data); err == nil {
+				t.Errorf("Unmarshal = %v", d)
+			}
+		})
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := Pipeline{}.Match(D{{Key: "component_name", Value: "thermometer"}, {Key: "time_received", Value: Gte(since)}})
+	a := base.Sort(Desc("time_received")).Limit(10)
+	b := base.Group(Ref("location_id"), E{Key: "avg", Value: Avg(Ref("data.temp"))}).Skip(1)
+	if len(base) != 1 || len(a) != 3 || len(b) != 3 || a[1][0].Key != "$sort" || b[1][0].Key != "$group" {
+		t.Fatalf("pipelines share stages: %v, %v", a, b)
+	}
+
+	req, err := a.Project(Include("x")).AddFields(D{{Key: "y", Value: int32(1)}}).TabularDataByMQLRequest("org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetOrganizationId() != "org" || len(req.GetMqlBinary()) != 5 {
+		t.Errorf("request = %v", req)
+	}
+	p, err := ParsePipeline(req.GetMqlBinary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	match, _ := p[0].Lookup("$match")
+	if got, _ := match.(D)[1].Value.(D).Lookup("$gte"); got != since {
+		t.Errorf("$gte = %v", got)
+	}
+	if limit, _ := p[2].Lookup("$limit"); limit != int64(10) {
+		t.Errorf("$limit = %#v", limit)
+	}
+
+	if _, err := (Pipeline{}).Stage("$bad", make(chan int)).Binary(); err == nil {
+		t.Error("Binary encoded an unsupported stage")
+	}
+	if _, err := ParsePipeline([][]byte{{1}}); err == nil {
+		t.Error("ParsePipeline decoded a corrupt stage")
+	}
+}
+
+func TestOperators(t *testing.T) {
+	filter := D{
+		Or(D{{Key: "a", Value: In(1, 2)}}, D{{Key: "b", Value: Exists(false)}}),
+		{Key: "c", Value: Nin("x")},
+	}
+	b, err := Marshal(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := Unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	or, _ := d.Lookup("$or")
+	in, _ := or.(A)[0].(D)[0].Value.(D).Lookup("$in")
+	if !reflect.DeepEqual(in, A{int32(1), int32(2)}) {
+		t.Errorf("$in = %#v", in)
+	}
+	if e := Exclude("a", "b"); len(e) != 2 || e[1].Value != int32(0) {
+		t.Errorf("Exclude = %v", e)
+	}
+}
+
+func TestIndexSpec(t *testing.T) {
+	s := NewIndexSpec(Asc("a"), E{Key: "loc", Value: "2dsphere"}).With("unique", true)
+	t2 := s.With("sparse", true)
+	if len(s.Options) != 1 || len(t2.Options) != 2 {
+		t.Errorf("With modified its receiver: %v", s.Options)
+	}
+	req, err := t2.CreateIndexRequest("org", datapb.IndexableCollection_INDEXABLE_COLLECTION_PIPELINE_SINK, "p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetPipelineName() != "p" || len(req.GetIndexSpec()) != 1 {
+		t.Errorf("request = %v", req)
+	}
+	back, err := ParseIndexSpec(req.GetIndexSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, t2) {
+		t.Errorf("ParseIndexSpec = %v, want %v", back, t2)
+	}
+
+	if _, err := (IndexSpec{}).Binary(); err == nil {
+		t.Error("Binary encoded an index without keys")
+	}
+	for _, doc := range []D{
+		{{Key: "keys", Value: int32(1)}},
+		{{Key: "other", Value: D{}}},
+	} {
+		b, err := Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseIndexSpec([][]byte{b}); err == nil {
+			t.Errorf("ParseIndexSpec accepted %v", doc)
+		}
+	}
+}
+
+func TestDecodeRows(t *testing.T) {
+	row, err := Marshal(M{"x": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := DecodeRows(&datapb.TabularDataByMQLResponse{RawData: [][]byte{row, row}})
+	if err != nil || len(rows) != 2 {
+		t.Errorf("DecodeRows = %v, %v", rows, err)
+	}
+	if _, err := DecodeRows(&datapb.TabularDataByMQLResponse{RawData: [][]byte{row, nil}}); err == nil ||
+		!errors.Is(err, errTruncated) {
+		t.Errorf("DecodeRows of a corrupt row = %v", err)
+	}
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, doc := range []D{
+		{{Key: "hello", Value: "world"}},
+		{{Key: "a", Value: A{1.5, int64(2), true, nil, []byte{1}, Binary{Subtype: 5, Data: []byte{2}}}}},
+		{{Key: "d", Value: D{{Key: "t", Value: time.UnixMilli(1)}, {Key: "r", Value: Regex{Pattern: "x", Options: "i"}}}}},
+		{{Key: "o", Value: ObjectID{}}, {Key: "ts", Value: Timestamp{}}, {Key: "dec", Value: Decimal128{}}, {Key: "min", Value: MinKey{}}},
+	} {
+		b, err := Marshal(doc)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte{12, 0, 0, 0, typeBinary, 'a', 0, 0xff, 0xff, 0xff, 0xff, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := Unmarshal(data)
+		if err != nil {
+			return
+		}
+		// Whatever decodes must encode again, and the encoding must decode.
+		b, err := Marshal(d)
+		if err != nil {
+			// The encoder allows one level of nesting less than the decoder.
+			return
+		}
+		if _, err := Unmarshal(b); err != nil {
+			t.Fatalf("re-encoded document does not decode: %v", err)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+// Package mql builds the BSON payloads taken by the MQL APIs of app: the mql_binary stages of
+// TabularDataByMQL, saved queries and data pipelines, and the index_spec of custom indexes. It also decodes
+// the BSON rows returned in TabularDataByMQLResponse.raw_data.
+//
+// It carries its own BSON encoder and decoder so that callers do not need the MongoDB driver.
+//
+//	p := mql.Pipeline{}.
+//		Match(mql.D{{Key: "component_name", Value: "thermometer"}, {Key: "time_received", Value: mql.Gte(since)}}).
+//		Sort(mql.Desc("time_received")).
+//		Limit(10)
+//	stages, err := p.Binary()
+package mql
+
+import (
+	"fmt"
+
+	datapb "go.viam.com/api/app/data/v1"
+)
+
+// Pipeline is an aggregation pipeline, one document per stage. Its methods return a copy of the pipeline
+// with a stage appended, so a common prefix can be shared between queries.
+type Pipeline []D
+
+// Stage appends a stage with the given operator, e.g. "$unwind", for stages without a dedicated method.
+func (p Pipeline) Stage(op string, spec any) Pipeline {
+	return append(p[:len(p):len(p)], D{{Key: op, Value: spec}})
+}
+
+// Match appends a $match stage.
+func (p Pipeline) Match(filter D) Pipeline {
+	return p.Stage("$match", filter)
+}
+
+// Project appends a $project stage. See Include and Exclude for the common cases.
+func (p Pipeline) Project(spec D) Pipeline {
+	return p.Stage("$project", spec)
+}
+
+// Group appends a $group stage grouping by id, which is usually a field reference made with Ref or a
+// document of them, and computing each accumulator field, e.g. {Key: "avg", Value: Avg(Ref("x"))}.
+func (p Pipeline) Group(id any, accumulators ...E) Pipeline {
+	return p.Stage("$group", append(D{{Key: "_id", Value: id}}, accumulators...))
+}
+
+// Sort appends a $sort stage. Keys are applied in order; see Asc and Desc.
+func (p Pipeline) Sort(keys ...E) Pipeline {
+	return p.Stage("$sort", D(keys))
+}
+
+// Limit appends a $limit stage.
+func (p Pipeline) Limit(n int64) Pipeline {
+	return p.Stage("$limit", n)
+}
+
+// Skip appends a $skip stage.
+func (p Pipeline) Skip(n int64) Pipeline {
+	return p.Stage("$skip", n)
+}
+
+// AddFields appends an $addFields stage.
+func (p Pipeline) AddFields(fields D) Pipeline {
+	return p.Stage("$addFields", fields)
+}
+
+// Binary encodes each stage as a BSON document, in the form taken by the mql_binary request fields.
+func (p Pipeline) Binary() ([][]byte, error) {
+	out := make([][]byte, len(p))
+	for i, stage := range p {
+		b, err := Marshal(stage)
+		if err != nil {
+			return nil, fmt.Errorf("stage %d: %w", i, err)
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// ParsePipeline decodes mql_binary stages, such as those of a saved query, back into a Pipeline.
+func ParsePipeline(stages [][]byte) (Pipeline, error) {
+	p := make(Pipeline, len(stages))
+	for i, b := range stages {
+		d, err := Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("stage %d: %w", i, err)
+		}
+		p[i] = d
+	}
+	return p, nil
+}
+
+// TabularDataByMQLRequest returns a request that runs p against the tabular data of an organization.
+func (p Pipeline) TabularDataByMQLRequest(organizationID string) (*datapb.TabularDataByMQLRequest, error) {
+	stages, err := p.Binary()
+	if err != nil {
+		return nil, err
+	}
+	return &datapb.TabularDataByMQLRequest{OrganizationId: organizationID, MqlBinary: stages}, nil
+}
+
+// DecodeRows decodes the raw_data rows of a TabularDataByMQL response.
+func DecodeRows(resp *datapb.TabularDataByMQLResponse) ([]D, error) {
+	rows := make([]D, len(resp.GetRawData()))
+	for i, b := range resp.GetRawData() {
+		d, err := Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		rows[i] = d
+	}
+	return rows, nil
+}
+
+// Ref returns the aggregation expression referring to a field path, e.g. Ref("data.readings.temp").
+func Ref(path string) string {
+	return "$" + path
+}
+
+// Asc sorts by field in ascending order.
+func Asc(field string) E {
+	return E{Key: field, Value: int32(1)}
+}
+
+// Desc sorts by field in descending order.
+func Desc(field string) E {
+	return E{Key: field, Value: int32(-1)}
+}
+
+// Include returns a $project spec that keeps only the given fields, and _id.
+func Include(fields ...string) D {
+	return projection(fields, 1)
+}
+
+// Exclude returns a $project spec that drops the given fields.
+func Exclude(fields ...string) D {
+	return projection(fields, 0)
+}
+
+func projection(fields []string, v int32) D {
+	d := make(D, len(fields))
+	for i, f := range fields {
+		d[i] = E{Key: f, Value: v}
+	}
+	return d
+}
+
+func op(name string, v any) D {
+	return D{{Key: name, Value: v}}
+}
+
+// Query and expression operators, for use as field values in Match or as accumulator expressions.
+
+// Eq matches values equal to v.
+func Eq(v any) D { return op("$eq", v) }
+
+// Ne matches values not equal to v.
+func Ne(v any) D { return op("$ne", v) }
+
+// Gt matches values greater than v.
+func Gt(v any) D { return op("$gt", v) }
+
+// Gte matches values greater than or equal to v.
+func Gte(v any) D { return op("$gte", v) }
+
+// Lt matches values less than v.
+func Lt(v any) D { return op("$lt", v) }
+
+// Lte matches values less than or equal to v.
+func Lte(v any) D { return op("$lte", v) }
+
+// In matches any of vs.
+func In(vs ...any) D { return op("$in", A(vs)) }
+
+// Nin matches none of vs.
+func Nin(vs ...any) D { return op("$nin", A(vs)) }
+
+// Exists matches documents that have, or do not have, the field.
+func Exists(b bool) D { return op("$exists", b) }
+
+// And matches documents that match every filter.
+func And(filters ...D) E { return E{Key: "$and", Value: docs(filters)} }
+
+// Or matches documents that match any filter.
+func Or(filters ...D) E { return E{Key: "$or", Value: docs(filters)} }
+
+func docs(ds []D) A {
+	a := make(A, len(ds))
+	for i, d := range ds {
+		a[i] = d
+	}
+	return a
+}
+
+// Sum is the $sum accumulator. Sum(int32(1)) counts documents.
+func Sum(expr any) D { return op("$sum", expr) }
+
+// Avg is the $avg accumulator.
+func Avg(expr any) D { return op("$avg", expr) }
+
+// Min is the $min accumulator.
+func Min(expr any) D { return op("$min", expr) }
+
+// Max is the $max accumulator.
+func Max(expr any) D { return op("$max", expr) }
+
+// First is the $first accumulator.
+func First(expr any) D { return op("$first", expr) }
+
+// Last is the $last accumulator.
+func Last(expr any) D { return op("$last", expr) }
+
+// Push is the $push accumulator.
+func Push(expr any) D { return op("$push", expr) }
@@ -0,0 +1,94 @@
+package paginate
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/protobuf/proto"
+
+	datapb "go.viam.com/api/app/data/v1"
+	datapipelinespb "go.viam.com/api/app/datapipelines/v1"
+	mltrainingpb "go.viam.com/api/app/mltraining/v1"
+	apppb "go.viam.com/api/app/v1"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// RobotPartLogs iterates over the logs returned by GetRobotPartLogs, starting at req.page_token. req is not
+// modified.
+func RobotPartLogs(
+	ctx context.Context, client apppb.AppServiceClient, req *apppb.GetRobotPartLogsRequest, opts ...Option,
+) iter.Seq2[*commonpb.LogEntry, error] {
+	return All(ctx, req.GetPageToken(), func(ctx context.Context, token string) ([]*commonpb.LogEntry, string, error) {
+		r := proto.Clone(req).(*apppb.GetRobotPartLogsRequest)
+		r.PageToken = optional(token)
+		resp, err := client.GetRobotPartLogs(ctx, r)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.GetLogs(), resp.GetNextPageToken(), nil
+	}, opts...)
+}
+
+// DataPipelineRuns iterates over the runs returned by ListDataPipelineRuns, starting at req.page_token.
+// req.page_size is passed through unchanged. req is not modified.
+func DataPipelineRuns(
+	ctx context.Context, client datapipelinespb.DataPipelinesServiceClient, req *datapipelinespb.ListDataPipelineRunsRequest,
+	opts ...Option,
+) iter.Seq2[*datapipelinespb.DataPipelineRun, error] {
+	return All(ctx, req.GetPageToken(), func(ctx context.Context, token string) ([]*datapipelinespb.DataPipelineRun, string, error) {
+		r := proto.Clone(req).(*datapipelinespb.ListDataPipelineRunsRequest)
+		r.PageToken = token
+		resp, err := client.ListDataPipelineRuns(ctx, r)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.GetRuns(), resp.GetNextPageToken(), nil
+	}, opts...)
+}
+
+// TrainingJobLogs iterates over the logs returned by GetTrainingJobLogs, starting at req.page_token. req is
+// not modified.
+func TrainingJobLogs(
+	ctx context.Context, client mltrainingpb.MLTrainingServiceClient, req *mltrainingpb.GetTrainingJobLogsRequest, opts ...Option,
+) iter.Seq2[*mltrainingpb.TrainingJobLogEntry, error] {
+	return All(ctx, req.GetPageToken(), func(ctx context.Context, token string) ([]*mltrainingpb.TrainingJobLogEntry, string, error) {
+		r := proto.Clone(req).(*mltrainingpb.GetTrainingJobLogsRequest)
+		r.PageToken = optional(token)
+		resp, err := client.GetTrainingJobLogs(ctx, r)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.GetLogs(), resp.GetNextPageToken(), nil
+	}, opts...)
+}
+
+// BinaryDataByFilter iterates over the data returned by BinaryDataByFilter, starting after
+// req.data_request.last. Pages hold up to req.data_request.limit items. BinaryDataByFilter has no
+// end-of-results token, so an empty page ends the iteration. req is not modified.
+func BinaryDataByFilter(
+	ctx context.Context, client datapb.DataServiceClient, req *datapb.BinaryDataByFilterRequest, opts ...Option,
+) iter.Seq2[*datapb.BinaryData, error] {
+	return All(ctx, req.GetDataRequest().GetLast(), func(ctx context.Context, last string) ([]*datapb.BinaryData, string, error) {
+		r := proto.Clone(req).(*datapb.BinaryDataByFilterRequest)
+		if r.DataRequest == nil {
+			r.DataRequest = &datapb.DataRequest{}
+		}
+		r.DataRequest.Last = last
+		resp, err := client.BinaryDataByFilter(ctx, r)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(resp.GetData()) == 0 {
+			return nil, "", nil
+		}
+		return resp.GetData(), resp.GetLast(), nil
+	}, opts...)
+}
+
+// optional returns nil for the empty token, so that the first page is requested without a page_token.
+func optional(token string) *string {
+	if token == "" {
+		return nil
+	}
+	return &token
+}
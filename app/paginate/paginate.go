@@ -0,0 +1,160 @@
+// Package paginate turns the paged list APIs of app into iter.Seq2 iterators, so that every page of results
+// can be consumed with a single range loop:
+//
+//	for entry, err := range paginate.RobotPartLogs(ctx, client, req) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// An iterator yields at most one error, after which it stops. The next page is fetched in the background
+// while the current one is consumed, and breaking out of the loop stops any fetch in flight.
+package paginate
+
+import (
+	"context"
+	"iter"
+)
+
+// Fetch fetches the page identified by token, where the empty token identifies the first page. It returns
+// the items of the page and the token of the next page, which is empty after the final page.
+type Fetch[T any] func(ctx context.Context, token string) (items []T, next string, err error)
+
+// Option configures an iterator.
+type Option func(*options)
+
+type options struct {
+	maxItems int
+	prefetch int
+}
+
+// WithMaxItems stops the iterator after n items, and stops fetching pages once n items have been fetched.
+// Zero, the default, means no limit.
+func WithMaxItems(n int) Option {
+	return func(o *options) {
+		o.maxItems = n
+	}
+}
+
+// WithPrefetch sets how many pages may be fetched ahead of the page being consumed. The default is 1; 0
+// fetches each page only once the previous one has been consumed.
+func WithPrefetch(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.prefetch = n
+		}
+	}
+}
+
+type page[T any] struct {
+	items []T
+	err   error
+}
+
+// All returns an iterator over the items of every page, starting at the page identified by first.
+// Iteration stops after the page whose next token is empty, or is the same as the token it was fetched
+// with, so that a server that echoes the final token back does not cause an endless loop.
+func All[T any](ctx context.Context, first string, fetch Fetch[T], opts ...Option) iter.Seq2[T, error] {
+	o := options{prefetch: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		fetchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		next := syncPages(fetchCtx, first, fetch, o.maxItems)
+		if o.prefetch > 0 {
+			pages := make(chan page[T], o.prefetch-1)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				produce(fetchCtx, first, fetch, o.maxItems, pages)
+			}()
+			defer func() {
+				cancel()
+				<-done
+			}()
+			next = func() (page[T], bool) {
+				p, ok := <-pages
+				return p, ok
+			}
+		}
+
+		count := 0
+		for {
+			p, ok := next()
+			if !ok {
+				// The producer stops silently when ctx is cancelled while it waits to hand over a page.
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+				}
+				return
+			}
+			if p.err != nil {
+				yield(zero, p.err)
+				return
+			}
+			for _, item := range p.items {
+				if o.maxItems > 0 && count >= o.maxItems {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+		}
+	}
+}
+
+// produce fetches pages into out until the final page, an error, or maxItems items, then closes out.
+func produce[T any](ctx context.Context, token string, fetch Fetch[T], maxItems int, out chan<- page[T]) {
+	defer close(out)
+	pull := syncPages(ctx, token, fetch, maxItems)
+	for {
+		p, ok := pull()
+		if !ok {
+			return
+		}
+		select {
+		case out <- p:
+		case <-ctx.Done():
+			return
+		}
+		if p.err != nil {
+			return
+		}
+	}
+}
+
+// syncPages returns a function that fetches one page per call, reporting false once there are no more.
+func syncPages[T any](ctx context.Context, token string, fetch Fetch[T], maxItems int) func() (page[T], bool) {
+	fetched, finished := 0, false
+	return func() (page[T], bool) {
+		if finished {
+			return page[T]{}, false
+		}
+		if err := ctx.Err(); err != nil {
+			finished = true
+			return page[T]{err: err}, true
+		}
+		items, next, err := fetch(ctx, token)
+		if err != nil {
+			finished = true
+			return page[T]{err: err}, true
+		}
+		fetched += len(items)
+		if next == "" || next == token || (maxItems > 0 && fetched >= maxItems) {
+			finished = true
+		}
+		token = next
+		return page[T]{items: items}, true
+	}
+}
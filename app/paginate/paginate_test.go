@@ -0,0 +1,212 @@
+package paginate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	datapb "go.viam.com/api/app/data/v1"
+	apppb "go.viam.com/api/app/v1"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// pager serves pages of three numbers; page i has token "i" and the final page has no next token.
+type pager struct {
+	mu      sync.Mutex
+	pages   int
+	tokens  []string
+	failAt  string
+	echoEnd bool
+}
+
+func (p *pager) fetch(_ context.Context, token string) ([]int, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokens = append(p.tokens, token)
+	if token == p.failAt && p.failAt != "" {
+		return nil, "", errors.New("boom")
+	}
+	i, _ := strconv.Atoi(token)
+	items := []int{3 * i, 3*i + 1, 3*i + 2}
+	switch {
+	case i < p.pages-1:
+		return items, fmt.Sprint(i + 1), nil
+	case p.echoEnd:
+		return items, token, nil
+	default:
+		return items, "", nil
+	}
+}
+
+func (p *pager) fetched() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.tokens)
+}
+
+func collect[T any](seq func(func(T, error) bool)) ([]T, error) {
+	var out []T
+	for v, err := range seq {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func TestAll(t *testing.T) {
+	for _, prefetch := range []int{0, 1, 3} {
+		t.Run(fmt.Sprint("prefetch ", prefetch), func(t *testing.T) {
+			p := &pager{pages: 3}
+			got, err := collect(All(context.Background(), "", p.fetch, WithPrefetch(prefetch)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8}; !slices.Equal(got, want) {
+				t.Errorf("items = %v, want %v", got, want)
+			}
+			if tokens := p.fetched(); !slices.Equal(tokens, []string{"", "1", "2"}) {
+				t.Errorf("fetched %q", tokens)
+			}
+		})
+	}
+}
+
+func TestAllStops(t *testing.T) {
+	t.Run("first token", func(t *testing.T) {
+		p := &pager{pages: 3}
+		got, _ := collect(All(context.Background(), "2", p.fetch))
+		if !slices.Equal(got, []int{6, 7, 8}) {
+			t.Errorf("items = %v", got)
+		}
+	})
+	t.Run("echoed token", func(t *testing.T) {
+		p := &pager{pages: 2, echoEnd: true}
+		got, _ := collect(All(context.Background(), "", p.fetch, WithPrefetch(0)))
+		if len(got) != 6 || len(p.fetched()) != 2 {
+			t.Errorf("items = %v after fetching %q", got, p.fetched())
+		}
+	})
+	t.Run("max items", func(t *testing.T) {
+		p := &pager{pages: 10}
+		got, _ := collect(All(context.Background(), "", p.fetch, WithMaxItems(4), WithPrefetch(3)))
+		if !slices.Equal(got, []int{0, 1, 2, 3}) {
+			t.Errorf("items = %v", got)
+		}
+		if n := len(p.fetched()); n != 2 {
+			t.Errorf("fetched %d pages for 4 items", n)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		p := &pager{pages: 3, failAt: "1"}
+		n, errs := 0, 0
+		for _, err := range All(context.Background(), "", p.fetch) {
+			if err != nil {
+				errs++
+				continue
+			}
+			n++
+		}
+		if n != 3 || errs != 1 {
+			t.Errorf("got %d items and %d errors", n, errs)
+		}
+	})
+	t.Run("break", func(t *testing.T) {
+		p := &pager{pages: 100}
+		for v := range All(context.Background(), "", p.fetch, WithPrefetch(2)) {
+			if v == 4 {
+				break
+			}
+		}
+		// After the loop ends the producer has stopped, so no more pages are fetched.
+		n := len(p.fetched())
+		if n > 5 {
+			t.Errorf("fetched %d pages", n)
+		}
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		p := &pager{pages: 100}
+		var err error
+		for v, e := range All(ctx, "", p.fetch) {
+			if e != nil {
+				err = e
+				break
+			}
+			if v == 1 {
+				cancel()
+			}
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+type fakeApp struct {
+	apppb.AppServiceClient
+	reqs []*apppb.GetRobotPartLogsRequest
+}
+
+func (f *fakeApp) GetRobotPartLogs(
+	_ context.Context, in *apppb.GetRobotPartLogsRequest, _ ...grpc.CallOption,
+) (*apppb.GetRobotPartLogsResponse, error) {
+	f.reqs = append(f.reqs, in)
+	if in.PageToken == nil {
+		return &apppb.GetRobotPartLogsResponse{Logs: []*commonpb.LogEntry{{Message: "a"}}, NextPageToken: "p2"}, nil
+	}
+	return &apppb.GetRobotPartLogsResponse{Logs: []*commonpb.LogEntry{{Message: "b"}}}, nil
+}
+
+func TestRobotPartLogs(t *testing.T) {
+	client := &fakeApp{}
+	req := &apppb.GetRobotPartLogsRequest{Id: "part"}
+	got, err := collect(RobotPartLogs(context.Background(), client, req, WithPrefetch(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].GetMessage() != "a" || got[1].GetMessage() != "b" {
+		t.Errorf("logs = %v", got)
+	}
+	if len(client.reqs) != 2 || client.reqs[1].GetPageToken() != "p2" || client.reqs[1].GetId() != "part" {
+		t.Errorf("requests = %v", client.reqs)
+	}
+	if req.PageToken != nil {
+		t.Error("the request was modified")
+	}
+}
+
+type fakeData struct {
+	datapb.DataServiceClient
+	lasts []string
+}
+
+func (f *fakeData) BinaryDataByFilter(
+	_ context.Context, in *datapb.BinaryDataByFilterRequest, _ ...grpc.CallOption,
+) (*datapb.BinaryDataByFilterResponse, error) {
+	f.lasts = append(f.lasts, in.GetDataRequest().GetLast())
+	if len(f.lasts) > 2 {
+		return &datapb.BinaryDataByFilterResponse{Last: "end"}, nil
+	}
+	last := fmt.Sprint("l", len(f.lasts))
+	return &datapb.BinaryDataByFilterResponse{Data: []*datapb.BinaryData{{Binary: []byte(last)}}, Last: last}, nil
+}
+
+func TestBinaryDataByFilter(t *testing.T) {
+	client := &fakeData{}
+	got, err := collect(BinaryDataByFilter(context.Background(), client, &datapb.BinaryDataByFilterRequest{}, WithPrefetch(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !slices.Equal(client.lasts, []string{"", "l1", "l2"}) {
+		t.Errorf("got %d items after requesting %q", len(got), client.lasts)
+	}
+}
@@ -0,0 +1,97 @@
+// Package audio describes the audio formats of common.v1.AudioInfo, as used by the audioin and audioout
+// components, and reads and writes them as WAV files.
+//
+// Raw audio is interleaved little-endian PCM: "pcm16" has signed 16-bit samples and "pcm32float" has
+// 32-bit IEEE float samples. Other codecs, such as "mp3", are carried as opaque bytes.
+//
+// See http://soundfile.sapp.org/doc/WaveFormat/ for the WAV format itself.
+package audio
+
+import (
+	"fmt"
+	"time"
+
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// The codecs named in AudioInfo.codec.
+const (
+	CodecPCM16      = "pcm16"
+	CodecPCM32Float = "pcm32float"
+	CodecMP3        = "mp3"
+)
+
+// Format is the format of an audio stream.
+type Format struct {
+	Codec      string
+	SampleRate int
+	Channels   int
+}
+
+// FormatOf returns the format described by info.
+func FormatOf(info *commonpb.AudioInfo) Format {
+	return Format{Codec: info.GetCodec(), SampleRate: int(info.GetSampleRateHz()), Channels: int(info.GetNumChannels())}
+}
+
+// Info returns the AudioInfo describing f.
+func (f Format) Info() *commonpb.AudioInfo {
+	return &commonpb.AudioInfo{Codec: f.Codec, SampleRateHz: int32(f.SampleRate), NumChannels: int32(f.Channels)}
+}
+
+func (f Format) String() string {
+	return fmt.Sprintf("%s %d Hz x%d", f.Codec, f.SampleRate, f.Channels)
+}
+
+// IsPCM reports whether f is a raw PCM format.
+func (f Format) IsPCM() bool {
+	return f.SampleSize() > 0
+}
+
+// SampleSize is the size in bytes of one sample of one channel, or 0 if f is not a PCM format.
+func (f Format) SampleSize() int {
+	switch f.Codec {
+	case CodecPCM16:
+		return 2
+	case CodecPCM32Float:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// FrameSize is the size in bytes of one sample of every channel, or 0 if f is not a PCM format.
+func (f Format) FrameSize() int {
+	return f.SampleSize() * f.Channels
+}
+
+// Validate checks that f is a PCM format that can be played or written.
+func (f Format) Validate() error {
+	switch {
+	case !f.IsPCM():
+		return fmt.Errorf("audio: unsupported codec %q", f.Codec)
+	case f.SampleRate <= 0:
+		return fmt.Errorf("audio: invalid sample rate %d", f.SampleRate)
+	case f.Channels <= 0:
+		return fmt.Errorf("audio: invalid channel count %d", f.Channels)
+	}
+	return nil
+}
+
+// Duration returns how long n bytes of f last. It is 0 if f is not a PCM format.
+func (f Format) Duration(n int) time.Duration {
+	if f.FrameSize() == 0 || f.SampleRate <= 0 {
+		return 0
+	}
+	frames := int64(n / f.FrameSize())
+	return time.Duration(frames * int64(time.Second) / int64(f.SampleRate))
+}
+
+// Size returns the number of bytes of f lasting d, rounded to the nearest whole frame. It is 0 if f is not
+// a PCM format.
+func (f Format) Size(d time.Duration) int {
+	if f.FrameSize() == 0 || d <= 0 {
+		return 0
+	}
+	frames := (int64(d)*int64(f.SampleRate) + int64(time.Second)/2) / int64(time.Second)
+	return int(frames) * f.FrameSize()
+}
@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	for _, tc := range []struct {
+		f         Format
+		frameSize int
+		valid     bool
+	}{
+		{Format{CodecPCM16, 48000, 2}, 4, true},
+		{Format{CodecPCM32Float, 16000, 1}, 4, true},
+		{Format{CodecPCM32Float, 44100, 2}, 8, true},
+		{Format{CodecMP3, 44100, 2}, 0, false},
+		{Format{CodecPCM16, 0, 1}, 2, false},
+		{Format{CodecPCM16, 8000, 0}, 0, false},
+	} {
+		if got := tc.f.FrameSize(); got != tc.frameSize {
+			t.Errorf("%v: FrameSize() = %d, want %d", tc.f, got, tc.frameSize)
+		}
+		if err := tc.f.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%v: Validate() = %v, want valid %v", tc.f, err, tc.valid)
+		}
+		if got := FormatOf(tc.f.Info()); got != tc.f {
+			t.Errorf("FormatOf(%v.Info()) = %v", tc.f, got)
+		}
+	}
+}
+
+func TestDurationAndSize(t *testing.T) {
+	stereo := Format{CodecPCM16, 48000, 2}
+	for _, tc := range []struct {
+		f    Format
+		n    int
+		d    time.Duration
+		size int
+	}{
+		{stereo, 192000, time.Second, 192000},
+		{stereo, 4, time.Second / 48000, 4},
+		// A partial frame lasts nothing.
+		{stereo, 3, 0, 0},
+		{Format{CodecPCM32Float, 16000, 1}, 64, time.Millisecond, 64},
+		{Format{CodecMP3, 44100, 2}, 1000, 0, 0},
+	} {
+		if got := tc.f.Duration(tc.n); got != tc.d {
+			t.Errorf("%v: Duration(%d) = %v, want %v", tc.f, tc.n, got, tc.d)
+		}
+		if got := tc.f.Size(tc.d); got != tc.size {
+			t.Errorf("%v: Size(%v) = %d, want %d", tc.f, tc.d, got, tc.size)
+		}
+	}
+
+	// Sizes round to the nearest whole frame.
+	for _, tc := range []struct {
+		d    time.Duration
+		size int
+	}{
+		{10 * time.Microsecond, 0},
+		{11 * time.Microsecond, 4},
+		{30 * time.Microsecond, 4},
+		{-time.Second, 0},
+	} {
+		if got := stereo.Size(tc.d); got != tc.size {
+			t.Errorf("Size(%v) = %d, want %d", tc.d, got, tc.size)
+		}
+	}
+}
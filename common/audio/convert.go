@@ -0,0 +1,132 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Converter converts a stream of PCM audio from one format to another: it changes the codec, mixes the
+// channels up or down, and resamples by linear interpolation. It keeps the state of the stream between
+// calls, so audio can be passed in pieces of any size.
+type Converter struct {
+	from, to Format
+	// partial holds the bytes of an incomplete input frame.
+	partial []byte
+	// frames holds the input frames still needed for interpolation, already mixed to the output channels,
+	// and pos is the position of the next output frame, in input frames from the first of them.
+	frames [][]float32
+	pos    float64
+	step   float64
+}
+
+// NewConverter returns a Converter from PCM format from to PCM format to.
+func NewConverter(from, to Format) (*Converter, error) {
+	if err := from.Validate(); err != nil {
+		return nil, err
+	}
+	if err := to.Validate(); err != nil {
+		return nil, err
+	}
+	return &Converter{from: from, to: to, step: float64(from.SampleRate) / float64(to.SampleRate)}, nil
+}
+
+// Convert converts p, and returns the audio that is complete so far. An incomplete frame at the end of p,
+// and when resampling the frames needed to interpolate the next output frame, are kept for the next call.
+func (c *Converter) Convert(p []byte) []byte {
+	if c.from == c.to {
+		return c.passthrough(p)
+	}
+	in := c.from.FrameSize()
+	data := append(c.partial, p...)
+	n := len(data) / in * in
+	for off := 0; off < n; off += in {
+		c.frames = append(c.frames, c.mix(c.decode(data[off:off+in])))
+	}
+	c.partial = append(c.partial[:0:0], data[n:]...)
+	return c.resample(false)
+}
+
+// Flush returns the audio still held, padding the interpolation with the last frame.
+func (c *Converter) Flush() []byte {
+	c.partial = nil
+	if c.from == c.to {
+		return nil
+	}
+	return c.resample(true)
+}
+
+func (c *Converter) passthrough(p []byte) []byte {
+	data := append(c.partial, p...)
+	n := len(data) / c.from.FrameSize() * c.from.FrameSize()
+	c.partial = append(c.partial[:0:0], data[n:]...)
+	return data[:n]
+}
+
+// resample interpolates the output frames that the input frames held allow, and drops the input frames
+// no longer needed.
+func (c *Converter) resample(final bool) []byte {
+	var out []byte
+	for {
+		i := int(c.pos)
+		frac := float32(c.pos - float64(i))
+		if i >= len(c.frames) || (i+1 >= len(c.frames) && !final) {
+			break
+		}
+		a, b := c.frames[i], c.frames[min(i+1, len(c.frames)-1)]
+		for ch := range a {
+			out = c.encode(out, a[ch]+(b[ch]-a[ch])*frac)
+		}
+		c.pos += c.step
+	}
+	drop := min(int(c.pos), len(c.frames))
+	c.frames = c.frames[drop:]
+	c.pos -= float64(drop)
+	if final {
+		c.frames, c.pos = nil, 0
+	}
+	return out
+}
+
+// decode returns the samples of a frame as floats in [-1, 1].
+func (c *Converter) decode(frame []byte) []float32 {
+	samples := make([]float32, c.from.Channels)
+	for ch := range samples {
+		switch c.from.Codec {
+		case CodecPCM16:
+			samples[ch] = float32(int16(binary.LittleEndian.Uint16(frame[2*ch:]))) / 32768
+		case CodecPCM32Float:
+			samples[ch] = math.Float32frombits(binary.LittleEndian.Uint32(frame[4*ch:]))
+		}
+	}
+	return samples
+}
+
+// mix maps the channels of a frame to the output channels: a single channel is copied to all of them,
+// all channels are averaged into a single one, and otherwise extra channels are dropped and missing ones
+// repeat the first channels.
+func (c *Converter) mix(in []float32) []float32 {
+	if len(in) == c.to.Channels {
+		return in
+	}
+	out := make([]float32, c.to.Channels)
+	if c.to.Channels == 1 {
+		var sum float32
+		for _, s := range in {
+			sum += s
+		}
+		out[0] = sum / float32(len(in))
+		return out
+	}
+	for ch := range out {
+		out[ch] = in[ch%len(in)]
+	}
+	return out
+}
+
+func (c *Converter) encode(out []byte, s float32) []byte {
+	if c.to.Codec == CodecPCM16 {
+		v := math.Round(float64(s) * 32768)
+		return binary.LittleEndian.AppendUint16(out, uint16(int16(max(-32768, min(32767, v)))))
+	}
+	return binary.LittleEndian.AppendUint32(out, math.Float32bits(s))
+}
@@ -0,0 +1,156 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func pcm16(samples ...int16) []byte {
+	var b []byte
+	for _, s := range samples {
+		b = binary.LittleEndian.AppendUint16(b, uint16(s))
+	}
+	return b
+}
+
+func float32s(samples ...float32) []byte {
+	var b []byte
+	for _, s := range samples {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(s))
+	}
+	return b
+}
+
+func TestConverter(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to Format
+		in, out  []byte
+	}{
+		{
+			name: "same format",
+			from: Format{CodecPCM16, 8000, 1}, to: Format{CodecPCM16, 8000, 1},
+			in: pcm16(1, 2, 3), out: pcm16(1, 2, 3),
+		},
+		{
+			name: "to float",
+			from: Format{CodecPCM16, 8000, 1}, to: Format{CodecPCM32Float, 8000, 1},
+			in: pcm16(0, 16384, -32768), out: float32s(0, 0.5, -1),
+		},
+		{
+			name: "to pcm16 clipped",
+			from: Format{CodecPCM32Float, 8000, 1}, to: Format{CodecPCM16, 8000, 1},
+			in: float32s(0, -0.5, 1, -1, 2), out: pcm16(0, -16384, 32767, -32768, 32767),
+		},
+		{
+			name: "stereo to mono",
+			from: Format{CodecPCM32Float, 8000, 2}, to: Format{CodecPCM32Float, 8000, 1},
+			in: float32s(0.5, 0.25, -1, 1), out: float32s(0.375, 0),
+		},
+		{
+			name: "mono to stereo",
+			from: Format{CodecPCM32Float, 8000, 1}, to: Format{CodecPCM32Float, 8000, 2},
+			in: float32s(0.5, 0.25), out: float32s(0.5, 0.5, 0.25, 0.25),
+		},
+		{
+			name: "extra channels dropped",
+			from: Format{CodecPCM32Float, 8000, 3}, to: Format{CodecPCM32Float, 8000, 2},
+			in: float32s(0.1, 0.2, 0.3), out: float32s(0.1, 0.2),
+		},
+		{
+			name: "upsampled",
+			from: Format{CodecPCM32Float, 1000, 1}, to: Format{CodecPCM32Float, 2000, 1},
+			in: float32s(0, 0.25, 0.5), out: float32s(0, 0.125, 0.25, 0.375, 0.5, 0.5),
+		},
+		{
+			name: "downsampled",
+			from: Format{CodecPCM32Float, 2000, 1}, to: Format{CodecPCM32Float, 1000, 1},
+			in: float32s(0, 0.1, 0.2, 0.3, 0.4, 0.5), out: float32s(0, 0.2, 0.4),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewConverter(tc.from, tc.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := append(c.Convert(tc.in), c.Flush()...); !bytes.Equal(got, tc.out) {
+				t.Errorf("converted %v, want %v", got, tc.out)
+			}
+		})
+	}
+}
+
+func TestConverterPieces(t *testing.T) {
+	from, to := Format{CodecPCM16, 44100, 2}, Format{CodecPCM32Float, 48000, 1}
+	in := make([]byte, 4*1000)
+	for i := range in {
+		in[i] = byte(rand.N(256))
+	}
+	whole, err := NewConverter(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(whole.Convert(in), whole.Flush()...)
+	if n := len(want) / to.FrameSize(); n < 1087 || n > 1089 {
+		t.Errorf("converted 1000 frames to %d, want about 1088", n)
+	}
+
+	// Audio passed in pieces of any size, splitting frames, converts the same.
+	c, err := NewConverter(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []byte
+	for rest := in; len(rest) > 0; {
+		n := min(len(rest), rand.N(50))
+		got = append(got, c.Convert(rest[:n])...)
+		rest = rest[n:]
+	}
+	got = append(got, c.Flush()...)
+	if !bytes.Equal(got, want) {
+		t.Error("converting in pieces differs from converting at once")
+	}
+}
+
+func TestConverterPartialFrames(t *testing.T) {
+	f := Format{CodecPCM16, 8000, 2}
+	c, err := NewConverter(f, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := pcm16(1, 2, 3, 4)
+	var got []byte
+	for _, p := range [][]byte{in[:3], in[3:5], in[5:]} {
+		got = append(got, c.Convert(p)...)
+	}
+	if !slices.Equal(got, in) {
+		t.Errorf("converted %v, want %v", got, in)
+	}
+	// An incomplete frame left at the end is dropped.
+	if got := c.Convert([]byte{9}); len(got) != 0 {
+		t.Errorf("converted %v", got)
+	}
+	if got := c.Flush(); len(got) != 0 {
+		t.Errorf("flushed %v", got)
+	}
+	if got := c.Convert(pcm16(5, 6)); !slices.Equal(got, pcm16(5, 6)) {
+		t.Errorf("converted %v after Flush", got)
+	}
+}
+
+func TestNewConverterErrors(t *testing.T) {
+	ok := Format{CodecPCM16, 8000, 1}
+	for _, tc := range [][2]Format{
+		{{CodecMP3, 44100, 2}, ok},
+		{ok, {CodecMP3, 44100, 2}},
+		{ok, {CodecPCM16, 0, 1}},
+	} {
+		if _, err := NewConverter(tc[0], tc[1]); err == nil {
+			t.Errorf("NewConverter(%v, %v) succeeded", tc[0], tc[1])
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// The WAV format tags of the supported codecs.
+const (
+	wavFormatPCM   = 1
+	wavFormatFloat = 3
+	// wavFormatExtensible is the tag of files whose actual format tag is in the extension of the fmt chunk.
+	wavFormatExtensible = 0xFFFE
+)
+
+const wavHeaderSize = 44
+
+// ErrTooLarge is returned when a WAV file would exceed the 4 GiB the format allows.
+var ErrTooLarge = errors.New("audio: WAV data exceeds 4 GiB")
+
+// WAVWriter writes PCM audio as a WAV file. The sizes in the header are only known once all the audio is
+// written, so they are filled in by Close, which seeks back to the start of the file.
+type WAVWriter struct {
+	w    io.WriteSeeker
+	f    Format
+	size int64
+	err  error
+}
+
+// NewWAVWriter writes the header of a WAV file of format f to w, and returns a WAVWriter for its data.
+func NewWAVWriter(w io.WriteSeeker, f Format) (*WAVWriter, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	ww := &WAVWriter{w: w, f: f}
+	if _, err := w.Write(wavHeader(f, 0)); err != nil {
+		return nil, err
+	}
+	return ww, nil
+}
+
+// Write writes PCM audio in the format of the file.
+func (w *WAVWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.size+int64(len(p)) > math.MaxUint32-wavHeaderSize {
+		w.err = ErrTooLarge
+		return 0, w.err
+	}
+	n, err := w.w.Write(p)
+	w.size += int64(n)
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// Close fills in the sizes in the header. It does not close the underlying writer.
+func (w *WAVWriter) Close() error {
+	if w.err != nil && !errors.Is(w.err, ErrTooLarge) {
+		return w.err
+	}
+	if w.size%2 == 1 {
+		// Chunks are padded to an even size.
+		if _, err := w.w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+	if _, err := w.w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(wavHeader(w.f, w.size)); err != nil {
+		return err
+	}
+	_, err := w.w.Seek(0, io.SeekEnd)
+	return err
+}
+
+// wavHeader returns the header of a WAV file of format f holding size bytes of audio.
+func wavHeader(f Format, size int64) []byte {
+	tag := uint16(wavFormatPCM)
+	if f.Codec == CodecPCM32Float {
+		tag = wavFormatFloat
+	}
+	padded := size + size%2
+	h := make([]byte, 0, wavHeaderSize)
+	h = append(h, "RIFF"...)
+	h = binary.LittleEndian.AppendUint32(h, uint32(wavHeaderSize-8+padded))
+	h = append(h, "WAVEfmt "...)
+	h = binary.LittleEndian.AppendUint32(h, 16)
+	h = binary.LittleEndian.AppendUint16(h, tag)
+	h = binary.LittleEndian.AppendUint16(h, uint16(f.Channels))
+	h = binary.LittleEndian.AppendUint32(h, uint32(f.SampleRate))
+	h = binary.LittleEndian.AppendUint32(h, uint32(f.SampleRate*f.FrameSize()))
+	h = binary.LittleEndian.AppendUint16(h, uint16(f.FrameSize()))
+	h = binary.LittleEndian.AppendUint16(h, uint16(8*f.SampleSize()))
+	h = append(h, "data"...)
+	h = binary.LittleEndian.AppendUint32(h, uint32(size))
+	return h
+}
+
+// WAVReader reads the PCM audio of a WAV file.
+type WAVReader struct {
+	r      io.Reader
+	f      Format
+	remain int64
+}
+
+// NewWAVReader reads the header of a WAV file from r, up to the start of its audio data. The file must
+// hold 16-bit integer or 32-bit float PCM.
+func NewWAVReader(r io.Reader) (*WAVReader, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, fmt.Errorf("audio: reading WAV header: %w", err)
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, errors.New("audio: not a WAV file")
+	}
+	var f Format
+	for {
+		var hdr [8]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return nil, fmt.Errorf("audio: reading WAV chunk: %w", err)
+		}
+		id, size := string(hdr[0:4]), int64(binary.LittleEndian.Uint32(hdr[4:8]))
+		switch id {
+		case "fmt ":
+			if size < 16 || size > 1<<10 {
+				return nil, fmt.Errorf("audio: invalid WAV fmt chunk size %d", size)
+			}
+			buf := make([]byte, size+size%2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return nil, fmt.Errorf("audio: reading WAV fmt chunk: %w", err)
+			}
+			var err error
+			if f, err = parseWAVFormat(buf[:size]); err != nil {
+				return nil, err
+			}
+		case "data":
+			if f.Codec == "" {
+				return nil, errors.New("audio: WAV data chunk before fmt chunk")
+			}
+			return &WAVReader{r: r, f: f, remain: size}, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+				return nil, fmt.Errorf("audio: skipping WAV %q chunk: %w", id, err)
+			}
+		}
+	}
+}
+
+func parseWAVFormat(b []byte) (Format, error) {
+	tag := binary.LittleEndian.Uint16(b[0:2])
+	channels := int(binary.LittleEndian.Uint16(b[2:4]))
+	rate := int(binary.LittleEndian.Uint32(b[4:8]))
+	bits := binary.LittleEndian.Uint16(b[14:16])
+	if tag == wavFormatExtensible && len(b) >= 26 {
+		// The sub-format GUID starts with the actual format tag.
+		tag = binary.LittleEndian.Uint16(b[24:26])
+	}
+	var codec string
+	switch {
+	case tag == wavFormatPCM && bits == 16:
+		codec = CodecPCM16
+	case tag == wavFormatFloat && bits == 32:
+		codec = CodecPCM32Float
+	default:
+		return Format{}, fmt.Errorf("audio: unsupported WAV format %d with %d-bit samples", tag, bits)
+	}
+	f := Format{Codec: codec, SampleRate: rate, Channels: channels}
+	return f, f.Validate()
+}
+
+// Format returns the format of the audio.
+func (r *WAVReader) Format() Format {
+	return r.f
+}
+
+// Read reads PCM audio. It returns io.EOF at the end of the data chunk, and io.ErrUnexpectedEOF if the
+// file ends before it.
+func (r *WAVReader) Read(p []byte) (int, error) {
+	if r.remain <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.remain {
+		p = p[:r.remain]
+	}
+	n, err := r.r.Read(p)
+	r.remain -= int64(n)
+	if errors.Is(err, io.EOF) && r.remain > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
@@ -0,0 +1,296 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// memFile is an in-memory io.WriteSeeker.
+type memFile struct {
+	b   []byte
+	off int64
+	err error
+}
+
+func (m *memFile) Write(p []byte) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	if end := m.off + int64(len(p)); end > int64(len(m.b)) {
+		m.b = append(m.b, make([]byte, end-int64(len(m.b)))...)
+	}
+	n := copy(m.b[m.off:], p)
+	m.off += int64(n)
+	return n, nil
+}
+
+func (m *memFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		m.off = offset
+	case io.SeekCurrent:
+		m.off += offset
+	case io.SeekEnd:
+		m.off = int64(len(m.b)) + offset
+	}
+	return m.off, nil
+}
+
+func TestWAVWriter(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		f    Format
+		data []byte
+		tag  uint16
+	}{
+		{"pcm16", Format{CodecPCM16, 48000, 2}, []byte{1, 2, 3, 4, 5, 6, 7, 8}, wavFormatPCM},
+		{"float", Format{CodecPCM32Float, 16000, 1}, []byte{0, 0, 0x80, 0x3f}, wavFormatFloat},
+		{"odd size", Format{CodecPCM16, 8000, 1}, []byte{1, 2, 3}, wavFormatPCM},
+		{"empty", Format{CodecPCM16, 8000, 1}, nil, wavFormatPCM},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var m memFile
+			w, err := NewWAVWriter(&m, tc.f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Write in two parts to check the sizes add up.
+			half := len(tc.data) / 2
+			for _, p := range [][]byte{tc.data[:half], tc.data[half:]} {
+				if _, err := w.Write(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			padded := len(tc.data) + len(tc.data)%2
+			if len(m.b) != wavHeaderSize+padded || m.off != int64(len(m.b)) {
+				t.Fatalf("file is %d bytes at offset %d, want %d at the end", len(m.b), m.off, wavHeaderSize+padded)
+			}
+			h := m.b[:wavHeaderSize]
+			le := binary.LittleEndian
+			for _, c := range []struct {
+				name      string
+				got, want uint32
+			}{
+				{"RIFF size", le.Uint32(h[4:]), uint32(36 + padded)},
+				{"fmt size", le.Uint32(h[16:]), 16},
+				{"format tag", uint32(le.Uint16(h[20:])), uint32(tc.tag)},
+				{"channels", uint32(le.Uint16(h[22:])), uint32(tc.f.Channels)},
+				{"sample rate", le.Uint32(h[24:]), uint32(tc.f.SampleRate)},
+				{"byte rate", le.Uint32(h[28:]), uint32(tc.f.SampleRate * tc.f.FrameSize())},
+				{"block align", uint32(le.Uint16(h[32:])), uint32(tc.f.FrameSize())},
+				{"bits per sample", uint32(le.Uint16(h[34:])), uint32(8 * tc.f.SampleSize())},
+				{"data size", le.Uint32(h[40:]), uint32(len(tc.data))},
+			} {
+				if c.got != c.want {
+					t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+				}
+			}
+			if string(h[0:4]) != "RIFF" || string(h[8:16]) != "WAVEfmt " || string(h[36:40]) != "data" {
+				t.Errorf("header = %q", h)
+			}
+			if got := m.b[wavHeaderSize : wavHeaderSize+len(tc.data)]; !bytes.Equal(got, tc.data) {
+				t.Errorf("data = %v, want %v", got, tc.data)
+			}
+		})
+	}
+}
+
+func TestWAVWriterErrors(t *testing.T) {
+	if _, err := NewWAVWriter(&memFile{}, Format{CodecMP3, 44100, 2}); err == nil {
+		t.Error("NewWAVWriter accepted mp3")
+	}
+
+	m := &memFile{}
+	w, err := NewWAVWriter(m, Format{CodecPCM16, 8000, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Past 4 GiB nothing more is written, but the file is still closed with the audio written so far.
+	w.size = 1<<32 - wavHeaderSize - 2
+	if _, err := w.Write(make([]byte, 4)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Write = %v, want ErrTooLarge", err)
+	}
+	if _, err := w.Write(nil); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Write after ErrTooLarge = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close = %v", err)
+	}
+
+	errWrite := errors.New("disk full")
+	m = &memFile{}
+	if w, err = NewWAVWriter(m, Format{CodecPCM16, 8000, 1}); err != nil {
+		t.Fatal(err)
+	}
+	m.err = errWrite
+	if _, err := w.Write([]byte{1, 2}); !errors.Is(err, errWrite) {
+		t.Errorf("Write = %v", err)
+	}
+	if err := w.Close(); !errors.Is(err, errWrite) {
+		t.Errorf("Close = %v", err)
+	}
+}
+
+// riffChunk returns a chunk of a RIFF file, padded to an even size.
+func riffChunk(id string, data []byte) []byte {
+	b := binary.LittleEndian.AppendUint32([]byte(id), uint32(len(data)))
+	b = append(b, data...)
+	if len(data)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// fmtChunk returns a fmt chunk of mono 8 kHz audio.
+func fmtChunk(tag, bits uint16, extension ...byte) []byte {
+	le := binary.LittleEndian
+	b := le.AppendUint16(nil, tag)
+	b = le.AppendUint16(b, 1)
+	b = le.AppendUint32(b, 8000)
+	b = le.AppendUint32(b, 8000*uint32(bits/8))
+	b = le.AppendUint16(b, bits/8)
+	b = le.AppendUint16(b, bits)
+	return riffChunk("fmt ", append(b, extension...))
+}
+
+func wavFile(chunks ...[]byte) []byte {
+	body := bytes.Join(append([][]byte{[]byte("WAVE")}, chunks...), nil)
+	return riffChunk("RIFF", body)
+}
+
+func TestWAVRoundTrip(t *testing.T) {
+	for _, f := range []Format{{CodecPCM16, 48000, 2}, {CodecPCM32Float, 16000, 1}} {
+		var m memFile
+		w, err := NewWAVWriter(&m, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := bytes.Repeat([]byte{1, 2, 3, 4, 5, 6, 7, 8}, 100)
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		r, err := NewWAVReader(bytes.NewReader(m.b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Format() != f {
+			t.Errorf("format = %v, want %v", r.Format(), f)
+		}
+		if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, data) {
+			t.Errorf("%v: read %d bytes, %v", f, len(got), err)
+		}
+	}
+}
+
+func TestWAVReader(t *testing.T) {
+	// The sub-format GUID of an extensible fmt chunk, after the size of the extension, the valid bits and
+	// the channel mask.
+	extensible := func(tag byte) []byte {
+		return []byte{22, 0, 16, 0, 4, 0, 0, 0, tag, 0, 0, 0, 0, 0, 0x10, 0, 0x80, 0, 0, 0xaa, 0, 0x38, 0x9b, 0x71}
+	}
+	pcm := fmtChunk(wavFormatPCM, 16)
+	for _, tc := range []struct {
+		name string
+		file []byte
+		f    Format
+		data []byte
+		err  bool
+	}{
+		{"pcm16", wavFile(pcm, riffChunk("data", []byte{1, 2, 3, 4})), Format{CodecPCM16, 8000, 1}, []byte{1, 2, 3, 4}, false},
+		{"float", wavFile(fmtChunk(wavFormatFloat, 32), riffChunk("data", []byte{1, 2, 3, 4})), Format{CodecPCM32Float, 8000, 1}, []byte{1, 2, 3, 4}, false},
+		{
+			"unknown chunks skipped",
+			wavFile(riffChunk("LIST", []byte{1, 2, 3}), pcm, riffChunk("fact", []byte{1, 2, 3, 4}), riffChunk("data", []byte{5, 6})),
+			Format{CodecPCM16, 8000, 1}, []byte{5, 6}, false,
+		},
+		{
+			"extensible",
+			wavFile(fmtChunk(wavFormatExtensible, 16, extensible(wavFormatPCM)...), riffChunk("data", []byte{1, 2})),
+			Format{CodecPCM16, 8000, 1}, []byte{1, 2}, false,
+		},
+		{
+			"extensible float",
+			wavFile(fmtChunk(wavFormatExtensible, 32, extensible(wavFormatFloat)...), riffChunk("data", []byte{1, 2, 3, 4})),
+			Format{CodecPCM32Float, 8000, 1}, []byte{1, 2, 3, 4}, false,
+		},
+		{"not RIFF", append([]byte("RIFX"), wavFile(pcm)[4:]...), Format{}, nil, true},
+		{"not WAVE", riffChunk("RIFF", append([]byte("AVI "), pcm...)), Format{}, nil, true},
+		{"short", []byte("RIFF"), Format{}, nil, true},
+		{"no data", wavFile(pcm), Format{}, nil, true},
+		{"data before fmt", wavFile(riffChunk("data", []byte{1, 2}), pcm), Format{}, nil, true},
+		{"fmt too small", wavFile(riffChunk("fmt ", make([]byte, 14)), riffChunk("data", nil)), Format{}, nil, true},
+		{"fmt too large", wavFile(riffChunk("fmt ", make([]byte, 2000)), riffChunk("data", nil)), Format{}, nil, true},
+		{"8-bit", wavFile(fmtChunk(wavFormatPCM, 8), riffChunk("data", []byte{1})), Format{}, nil, true},
+		{"24-bit", wavFile(fmtChunk(wavFormatPCM, 24), riffChunk("data", []byte{1, 2, 3})), Format{}, nil, true},
+		{"no channels", wavFile(append(pcm[:10:10], append([]byte{0, 0}, pcm[12:]...)...), riffChunk("data", nil)), Format{}, nil, true},
+		{"truncated chunk", wavFile(pcm, riffChunk("LIST", []byte{1, 2, 3, 4}))[:46], Format{}, nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewWAVReader(bytes.NewReader(tc.file))
+			if tc.err {
+				if err == nil {
+					t.Errorf("NewWAVReader succeeded with format %v", r.Format())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Format() != tc.f {
+				t.Errorf("format = %v, want %v", r.Format(), tc.f)
+			}
+			if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, tc.data) {
+				t.Errorf("read %v, %v; want %v", got, err, tc.data)
+			}
+		})
+	}
+}
+
+func TestWAVReaderTruncated(t *testing.T) {
+	file := wavFile(fmtChunk(wavFormatPCM, 16), riffChunk("data", make([]byte, 100)))
+	r, err := NewWAVReader(bytes.NewReader(file[:len(file)-10]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); !errors.Is(err, io.ErrUnexpectedEOF) || len(got) != 90 {
+		t.Errorf("read %d bytes, %v; want 90 and io.ErrUnexpectedEOF", len(got), err)
+	}
+
+	// Whatever follows the data chunk is not read.
+	file = append(wavFile(fmtChunk(wavFormatPCM, 16), riffChunk("data", []byte{1, 2})), riffChunk("LIST", []byte{3, 4})...)
+	if r, err = NewWAVReader(bytes.NewReader(file)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, []byte{1, 2}) {
+		t.Errorf("read %v, %v", got, err)
+	}
+}
+
+func FuzzWAVReader(f *testing.F) {
+	f.Add(wavFile(fmtChunk(wavFormatPCM, 16), riffChunk("data", []byte{1, 2, 3, 4})))
+	f.Add(wavFile(riffChunk("LIST", []byte{1}), fmtChunk(wavFormatFloat, 32), riffChunk("data", []byte{1, 2, 3, 4})))
+	f.Add(wavFile(riffChunk("LIST", make([]byte, 10)))[:30])
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r, err := NewWAVReader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if err := r.Format().Validate(); err != nil {
+			t.Fatalf("read an invalid format: %v", err)
+		}
+		got, err := io.ReadAll(r)
+		if err == nil && len(got) > len(data) {
+			t.Fatalf("read %d bytes of audio from a %d byte file", len(got), len(data))
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package collision
+
+import (
+	"math"
+
+	"go.viam.com/api/common/spatialmath"
+)
+
+// AABB is an axis-aligned bounding box in millimeters.
+type AABB struct {
+	Min, Max spatialmath.Vector
+}
+
+// Overlaps reports whether a and b share any point.
+func (a AABB) Overlaps(b AABB) bool {
+	return a.Min.X <= b.Max.X && b.Min.X <= a.Max.X &&
+		a.Min.Y <= b.Max.Y && b.Min.Y <= a.Max.Y &&
+		a.Min.Z <= b.Max.Z && b.Min.Z <= a.Max.Z
+}
+
+// Distance returns the distance between the closest points of a and b, or zero if they overlap.
+func (a AABB) Distance(b AABB) float64 {
+	gap := func(aMin, aMax, bMin, bMax float64) float64 {
+		return math.Max(0, math.Max(aMin-bMax, bMin-aMax))
+	}
+	return spatialmath.Vector{
+		X: gap(a.Min.X, a.Max.X, b.Min.X, b.Max.X),
+		Y: gap(a.Min.Y, a.Max.Y, b.Min.Y, b.Max.Y),
+		Z: gap(a.Min.Z, a.Max.Z, b.Min.Z, b.Max.Z),
+	}.Norm()
+}
+
+// Union returns the smallest box containing both a and b.
+func (a AABB) Union(b AABB) AABB {
+	return AABB{
+		Min: spatialmath.Vector{X: math.Min(a.Min.X, b.Min.X), Y: math.Min(a.Min.Y, b.Min.Y), Z: math.Min(a.Min.Z, b.Min.Z)},
+		Max: spatialmath.Vector{X: math.Max(a.Max.X, b.Max.X), Y: math.Max(a.Max.Y, b.Max.Y), Z: math.Max(a.Max.Z, b.Max.Z)},
+	}
+}
+
+// Center returns the midpoint of a.
+func (a AABB) Center() spatialmath.Vector {
+	return a.Min.Add(a.Max).Mul(0.5)
+}
+
+func (a AABB) expand(r float64) AABB {
+	d := spatialmath.Vector{X: r, Y: r, Z: r}
+	return AABB{Min: a.Min.Sub(d), Max: a.Max.Add(d)}
+}
+
+func aabbOf(pts []spatialmath.Vector) AABB {
+	box := AABB{Min: pts[0], Max: pts[0]}
+	for _, p := range pts[1:] {
+		box = box.Union(AABB{Min: p, Max: p})
+	}
+	return box
+}
@@ -0,0 +1,132 @@
+package collision
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// bvhLeafSize is the largest number of primitives stored in a single leaf.
+const bvhLeafSize = 8
+
+type bvhNode struct {
+	box         AABB
+	left, right int // child node indices, or -1 for a leaf
+	start, end  int // range of primitives covered by a leaf
+}
+
+// bvh is a bounding volume hierarchy over the primitives of a geometry. The primitives are reordered so
+// that every node covers a contiguous range.
+type bvh struct {
+	prims []primitive
+	boxes []AABB
+	nodes []bvhNode
+}
+
+func newBVH(prims []primitive) *bvh {
+	t := &bvh{prims: prims, boxes: make([]AABB, len(prims))}
+	for i := range prims {
+		t.boxes[i] = prims[i].bounds()
+	}
+	if len(prims) > 0 {
+		t.build(0, len(prims))
+	}
+	return t
+}
+
+func (t *bvh) build(start, end int) int {
+	box := t.boxes[start]
+	for _, b := range t.boxes[start+1 : end] {
+		box = box.Union(b)
+	}
+	idx := len(t.nodes)
+	t.nodes = append(t.nodes, bvhNode{box: box, left: -1, right: -1, start: start, end: end})
+	if end-start <= bvhLeafSize {
+		return idx
+	}
+
+	// Split at the median along the longest axis of the node.
+	ext := box.Max.Sub(box.Min)
+	axis := func(b AABB) float64 { return b.Center().X }
+	if ext.Y >= ext.X && ext.Y >= ext.Z {
+		axis = func(b AABB) float64 { return b.Center().Y }
+	} else if ext.Z >= ext.X {
+		axis = func(b AABB) float64 { return b.Center().Z }
+	}
+	order := make([]int, end-start)
+	for i := range order {
+		order[i] = start + i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Compare(axis(t.boxes[a]), axis(t.boxes[b]))
+	})
+	prims := make([]primitive, len(order))
+	boxes := make([]AABB, len(order))
+	for i, j := range order {
+		prims[i], boxes[i] = t.prims[j], t.boxes[j]
+	}
+	copy(t.prims[start:end], prims)
+	copy(t.boxes[start:end], boxes)
+
+	mid := start + (end-start)/2
+	left := t.build(start, mid)
+	right := t.build(mid, end)
+	t.nodes[idx].left, t.nodes[idx].right = left, right
+	return idx
+}
+
+func (t *bvh) bounds() AABB {
+	return t.nodes[0].box
+}
+
+// minDistance returns the smallest distance between a primitive of a and a primitive of b. The search
+// stops early once a distance of at most stop is found.
+func minDistance(a, b *bvh, stop float64) float64 {
+	if len(a.nodes) == 0 || len(b.nodes) == 0 {
+		return math.Inf(1)
+	}
+	best := math.Inf(1)
+	var visit func(i, j int)
+	visit = func(i, j int) {
+		if best <= stop {
+			return
+		}
+		na, nb := &a.nodes[i], &b.nodes[j]
+		if na.box.Distance(nb.box) >= best {
+			return
+		}
+		aLeaf, bLeaf := na.left < 0, nb.left < 0
+		switch {
+		case aLeaf && bLeaf:
+			for pi := na.start; pi < na.end; pi++ {
+				for pj := nb.start; pj < nb.end; pj++ {
+					if a.boxes[pi].Distance(b.boxes[pj]) >= best {
+						continue
+					}
+					if d := distance(&a.prims[pi], &b.prims[pj]); d < best {
+						best = d
+						if best <= stop {
+							return
+						}
+					}
+				}
+			}
+		case bLeaf || (!aLeaf && na.end-na.start >= nb.end-nb.start):
+			first, second := na.left, na.right
+			if a.nodes[second].box.Distance(nb.box) < a.nodes[first].box.Distance(nb.box) {
+				first, second = second, first
+			}
+			visit(first, j)
+			visit(second, j)
+		default:
+			first, second := nb.left, nb.right
+			if b.nodes[second].box.Distance(na.box) < b.nodes[first].box.Distance(na.box) {
+				first, second = second, first
+			}
+			visit(i, first)
+			visit(i, second)
+		}
+	}
+	visit(0, 0)
+	return best
+}
@@ -0,0 +1,239 @@
+package collision
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"go.viam.com/api/common/spatialmath"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/api/robot/framesystem"
+)
+
+func at(x, y, z float64) *commonpb.Pose {
+	return &commonpb.Pose{X: x, Y: y, Z: z, OZ: 1}
+}
+
+func sphere(label string, r float64, center *commonpb.Pose) *commonpb.Geometry {
+	return &commonpb.Geometry{
+		Label:        label,
+		Center:       center,
+		GeometryType: &commonpb.Geometry_Sphere{Sphere: &commonpb.Sphere{RadiusMm: r}},
+	}
+}
+
+func box(label string, x, y, z float64, center *commonpb.Pose) *commonpb.Geometry {
+	return &commonpb.Geometry{
+		Label:        label,
+		Center:       center,
+		GeometryType: &commonpb.Geometry_Box{Box: &commonpb.RectangularPrism{DimsMm: &commonpb.Vector3{X: x, Y: y, Z: z}}},
+	}
+}
+
+func capsule(label string, r, l float64, center *commonpb.Pose) *commonpb.Geometry {
+	return &commonpb.Geometry{
+		Label:        label,
+		Center:       center,
+		GeometryType: &commonpb.Geometry_Capsule{Capsule: &commonpb.Capsule{RadiusMm: r, LengthMm: l}},
+	}
+}
+
+// square is a PLY mesh of two triangles covering [0, 10]² at z = 0.
+const square = `ply
+format ascii 1.0
+element vertex 4
+property float x
+property float y
+property float z
+element face 1
+property list uchar int vertex_indices
+end_header
+0 0 0
+10 0 0
+10 10 0
+0 10 0
+4 0 1 2 3
+`
+
+func mesh(label, ply string, center *commonpb.Pose) *commonpb.Geometry {
+	return &commonpb.Geometry{
+		Label:        label,
+		Center:       center,
+		GeometryType: &commonpb.Geometry_Mesh{Mesh: &commonpb.Mesh{ContentType: "ply", Mesh: []byte(ply)}},
+	}
+}
+
+// pointCloud returns a binary PCD point cloud of pts.
+func pointCloud(label string, pts ...[3]float32) *commonpb.Geometry {
+	data := fmt.Appendf(nil, "VERSION .7\nFIELDS x y z\nSIZE 4 4 4\nTYPE F F F\nCOUNT 1 1 1\n"+
+		"WIDTH %d\nHEIGHT 1\nPOINTS %d\nDATA binary\n", len(pts), len(pts))
+	for _, p := range pts {
+		for _, v := range p {
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(v))
+		}
+	}
+	return &commonpb.Geometry{
+		Label:        label,
+		GeometryType: &commonpb.Geometry_Pointcloud{Pointcloud: &commonpb.PointCloud{PointCloud: data}},
+	}
+}
+
+func mustGeometry(t *testing.T, g *commonpb.Geometry) *Geometry {
+	t.Helper()
+	cg, err := NewGeometry(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cg
+}
+
+func TestDistance(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a, b *commonpb.Geometry
+		want float64
+	}{
+		{"spheres apart", sphere("a", 1, nil), sphere("b", 2, at(10, 0, 0)), 7},
+		{"spheres overlapping", sphere("a", 5, nil), sphere("b", 5, at(1, 0, 0)), 0},
+		{"sphere and box", sphere("a", 1, at(0, 0, 10)), box("b", 4, 4, 4, nil), 7},
+		{"sphere and box corner", sphere("a", 1, at(5, 5, 2)), box("b", 4, 4, 4, nil), math.Sqrt(18) - 1},
+		{"rotated box", sphere("a", 1, at(10, 0, 0)), box("b", 2, 10, 2, &commonpb.Pose{OZ: 1, Theta: 90}), 4},
+		{"boxes", box("a", 2, 2, 2, nil), box("b", 2, 2, 2, at(0, 5, 0)), 3},
+		{"box inside box", box("a", 1, 1, 1, nil), box("b", 10, 10, 10, nil), 0},
+		{"capsule along z", capsule("a", 1, 10, nil), sphere("b", 1, at(0, 0, 10)), 4},
+		{"capsule side", capsule("a", 1, 10, nil), sphere("b", 1, at(5, 0, 2)), 3},
+		{"capsules crossed", capsule("a", 1, 10, nil), capsule("b", 1, 10, &commonpb.Pose{Y: 4, OX: 1}), 2},
+		{"mesh above", mesh("a", square, nil), sphere("b", 1, at(5, 5, 3)), 2},
+		{"mesh beside", mesh("a", square, nil), sphere("b", 1, at(13, 5, 0)), 2},
+		{"mesh moved", mesh("a", square, at(0, 0, 10)), sphere("b", 1, at(5, 5, 0)), 9},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := mustGeometry(t, tc.a), mustGeometry(t, tc.b)
+			if got := Distance(a, b); math.Abs(got-tc.want) > 1e-6 {
+				t.Errorf("Distance = %v, want %v", got, tc.want)
+			}
+			if got := Distance(b, a); math.Abs(got-tc.want) > 1e-6 {
+				t.Errorf("reversed Distance = %v, want %v", got, tc.want)
+			}
+			if got := Collides(a, b, 0); got != (tc.want == 0) {
+				t.Errorf("Collides(0) = %v", got)
+			}
+			if !Collides(a, b, tc.want+1e-3) {
+				t.Errorf("Collides(%v) = false", tc.want+1e-3)
+			}
+		})
+	}
+}
+
+func TestPointCloud(t *testing.T) {
+	// The points are in meters and the geometries in millimeters.
+	pc := mustGeometry(t, pointCloud("cloud",
+		[3]float32{0, 0, 0},
+		[3]float32{0.1, 0, 0},
+		[3]float32{float32(math.NaN()), 0, 0},
+	))
+	if got := Distance(pc, mustGeometry(t, sphere("s", 10, at(150, 0, 0)))); math.Abs(got-40) > 1e-4 {
+		t.Errorf("Distance = %v, want 40", got)
+	}
+	if bb := pc.AABB(); math.Abs(bb.Max.X-100) > 1e-4 || bb.Min.X != 0 {
+		t.Errorf("AABB = %+v", bb)
+	}
+}
+
+func TestInvalidGeometries(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		g    *commonpb.Geometry
+		want string
+	}{
+		{"no shape", &commonpb.Geometry{Label: "x"}, "no shape"},
+		{"sphere radius", sphere("x", 0, nil), "positive radius"},
+		{"box dims", box("x", 1, 0, 1, nil), "positive dimensions"},
+		{"short capsule", capsule("x", 2, 3, nil), "twice"},
+		{"not ply", mesh("x", "solid stl\n", nil), "not a PLY file"},
+		{"bad face", mesh("x", strings.Replace(square, "4 0 1 2 3", "3 0 1 9", 1), nil), "vertex 9"},
+		{"empty mesh", mesh("x", strings.NewReplacer("element face 1", "element face 0", "4 0 1 2 3\n", "").Replace(square), nil), "empty"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewGeometry(tc.g)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("NewGeometry() error = %v, want it to mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAABB(t *testing.T) {
+	bb, err := AABBOf(box("b", 2, 4, 6, at(10, 0, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AABB{Min: spatialmath.Vector{X: 9, Y: -2, Z: -3}, Max: spatialmath.Vector{X: 11, Y: 2, Z: 3}}
+	if bb.Min.Sub(want.Min).Norm() > 1e-9 || bb.Max.Sub(want.Max).Norm() > 1e-9 {
+		t.Errorf("AABBOf = %+v, want %+v", bb, want)
+	}
+	other := AABB{Min: spatialmath.Vector{X: 14, Y: -2, Z: -3}, Max: spatialmath.Vector{X: 15, Y: 2, Z: 3}}
+	if bb.Overlaps(other) || bb.Distance(other) != 3 {
+		t.Errorf("Overlaps = %v, Distance = %v", bb.Overlaps(other), bb.Distance(other))
+	}
+	if u := bb.Union(other); u.Min.X != 9 || u.Max.X != 15 {
+		t.Errorf("Union = %+v", u)
+	}
+}
+
+func TestWorldState(t *testing.T) {
+	fs, err := framesystem.New(nil, &commonpb.Transform{
+		ReferenceFrame:      "table",
+		PoseInObserverFrame: &commonpb.PoseInFrame{ReferenceFrame: framesystem.World, Pose: at(0, 0, 100)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := &commonpb.WorldState{
+		Obstacles: []*commonpb.GeometriesInFrame{
+			{ReferenceFrame: "table", Geometries: []*commonpb.Geometry{box("top", 100, 100, 10, nil)}},
+			{ReferenceFrame: "shelf", Geometries: []*commonpb.Geometry{sphere("jar", 5, nil)}},
+		},
+		Transforms: []*commonpb.Transform{{
+			ReferenceFrame:      "shelf",
+			PoseInObserverFrame: &commonpb.PoseInFrame{ReferenceFrame: "table", Pose: at(0, 0, 50)},
+		}},
+	}
+	obstacles, err := Obstacles(ws, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(obstacles) != 2 {
+		t.Fatalf("got %d obstacles", len(obstacles))
+	}
+	if c := obstacles[1].AABB().Center(); c.Sub(spatialmath.Vector{Z: 150}).Norm() > 1e-9 {
+		t.Errorf("jar center = %v", c)
+	}
+
+	robot, err := NewGeometries([]*commonpb.Geometry{
+		sphere("gripper", 10, at(0, 0, 115)),
+		sphere("base", 10, at(0, 0, -50)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := Collisions(robot, obstacles, 0)
+	if len(cs) != 1 || cs[0].A.Label() != "gripper" || cs[0].B.Label() != "top" {
+		t.Errorf("Collisions = %+v", cs)
+	}
+	if cs := Collisions(robot, obstacles, 100); len(cs) != 2 {
+		t.Errorf("Collisions with a buffer of 100 = %d, want 2", len(cs))
+	}
+	if cs := SelfCollisions(robot, 0); len(cs) != 0 {
+		t.Errorf("SelfCollisions = %+v", cs)
+	}
+	if cs := SelfCollisions(robot, 150); len(cs) != 1 || math.Abs(cs[0].Distance-145) > 1e-9 {
+		t.Errorf("SelfCollisions with a buffer = %+v", cs)
+	}
+
+	if _, err := InFrame(ws.Obstacles[1], nil, framesystem.World); err == nil {
+		t.Error("InFrame placed geometries in a frame the frame system does not know")
+	}
+}
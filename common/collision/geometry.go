@@ -0,0 +1,143 @@
+// Package collision answers intersection and distance queries between common.v1.Geometry values.
+//
+// Every variant of the geometry oneof is supported. Spheres, capsules and boxes are treated as solids.
+// Meshes are treated as their triangle surfaces and point clouds as their individual points, so a small
+// geometry entirely inside a closed mesh does not collide with it. Distances are in millimeters.
+package collision
+
+import (
+	"fmt"
+	"math"
+
+	"go.viam.com/api/common/spatialmath"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// Geometry is a common.v1.Geometry prepared for collision queries, with its center pose applied.
+type Geometry struct {
+	label string
+	tree  *bvh
+}
+
+// NewGeometry converts g. The primitives of g are placed at g.Center, which is relative to the frame g is
+// observed in.
+func NewGeometry(g *commonpb.Geometry) (*Geometry, error) {
+	var prims []primitive
+	switch t := g.GetGeometryType().(type) {
+	case *commonpb.Geometry_Sphere:
+		r := t.Sphere.GetRadiusMm()
+		if r <= 0 {
+			return nil, fmt.Errorf("sphere %q must have a positive radius, got %v", g.GetLabel(), r)
+		}
+		prims = []primitive{{kind: kindPoint, radius: r}}
+	case *commonpb.Geometry_Box:
+		dims := t.Box.GetDimsMm()
+		if dims.GetX() <= 0 || dims.GetY() <= 0 || dims.GetZ() <= 0 {
+			return nil, fmt.Errorf("box %q must have positive dimensions, got %v", g.GetLabel(), dims)
+		}
+		prims = []primitive{{
+			kind: kindBox,
+			axes: [3]spatialmath.Vector{{X: 1}, {Y: 1}, {Z: 1}},
+			half: [3]float64{dims.GetX() / 2, dims.GetY() / 2, dims.GetZ() / 2},
+		}}
+	case *commonpb.Geometry_Capsule:
+		r, l := t.Capsule.GetRadiusMm(), t.Capsule.GetLengthMm()
+		if r <= 0 || l < 2*r {
+			return nil, fmt.Errorf("capsule %q must have a positive radius and a length of at least twice it, got %v and %v",
+				g.GetLabel(), r, l)
+		}
+		// The length of a capsule includes its hemispherical caps and runs along the local Z axis.
+		h := l/2 - r
+		prims = []primitive{{kind: kindSegment, pts: [3]spatialmath.Vector{{Z: -h}, {Z: h}}, radius: r}}
+	case *commonpb.Geometry_Mesh:
+		tris, err := readMesh(t.Mesh)
+		if err != nil {
+			return nil, fmt.Errorf("mesh %q: %w", g.GetLabel(), err)
+		}
+		for _, tri := range tris {
+			prims = append(prims, primitive{kind: kindTriangle, pts: tri})
+		}
+	case *commonpb.Geometry_Pointcloud:
+		pts, err := readPointCloud(t.Pointcloud.GetPointCloud())
+		if err != nil {
+			return nil, fmt.Errorf("point cloud %q: %w", g.GetLabel(), err)
+		}
+		for _, p := range pts {
+			prims = append(prims, primitive{kind: kindPoint, pts: [3]spatialmath.Vector{p}})
+		}
+	case nil:
+		return nil, fmt.Errorf("geometry %q has no shape", g.GetLabel())
+	default:
+		return nil, fmt.Errorf("geometry %q has unsupported shape %T", g.GetLabel(), t)
+	}
+	if len(prims) == 0 {
+		return nil, fmt.Errorf("geometry %q is empty", g.GetLabel())
+	}
+
+	center := spatialmath.PoseFromProto(g.GetCenter())
+	for i := range prims {
+		prims[i] = prims[i].transform(center)
+	}
+	return &Geometry{label: g.GetLabel(), tree: newBVH(prims)}, nil
+}
+
+// NewGeometries converts every geometry in gs.
+func NewGeometries(gs []*commonpb.Geometry) ([]*Geometry, error) {
+	out := make([]*Geometry, 0, len(gs))
+	for _, g := range gs {
+		cg, err := NewGeometry(g)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cg)
+	}
+	return out, nil
+}
+
+// Label returns the label of the geometry.
+func (g *Geometry) Label() string {
+	return g.label
+}
+
+// AABB returns the axis-aligned bounding box of the geometry.
+func (g *Geometry) AABB() AABB {
+	return g.tree.bounds()
+}
+
+// Transform returns a copy of g moved by pose, for example to re-express it in the parent of the frame it
+// was observed in.
+func (g *Geometry) Transform(pose spatialmath.Pose) *Geometry {
+	prims := make([]primitive, len(g.tree.prims))
+	for i := range prims {
+		prims[i] = g.tree.prims[i].transform(pose)
+	}
+	return &Geometry{label: g.label, tree: newBVH(prims)}
+}
+
+// AABBOf returns the axis-aligned bounding box of a common.v1.Geometry in the frame it is observed in.
+func AABBOf(g *commonpb.Geometry) (AABB, error) {
+	cg, err := NewGeometry(g)
+	if err != nil {
+		return AABB{}, err
+	}
+	return cg.AABB(), nil
+}
+
+// Distance returns the separation between a and b, or zero if they touch or overlap.
+func Distance(a, b *Geometry) float64 {
+	return minDistance(a.tree, b.tree, 0)
+}
+
+// Collides reports whether a and b come within buffer millimeters of each other. A zero buffer checks for
+// contact or overlap.
+func Collides(a, b *Geometry, buffer float64) bool {
+	if a.AABB().Distance(b.AABB()) > buffer {
+		return false
+	}
+	return minDistance(a.tree, b.tree, buffer) <= buffer
+}
+
+// finite reports whether every component of v is a finite number.
+func finite(v spatialmath.Vector) bool {
+	return !math.IsNaN(v.X+v.Y+v.Z) && !math.IsInf(v.X+v.Y+v.Z, 0)
+}
@@ -0,0 +1,155 @@
+package collision
+
+import (
+	"math"
+
+	"go.viam.com/api/common/spatialmath"
+)
+
+const (
+	// gjkMaxIterations bounds the GJK loop. Polytopes with a handful of vertices converge in far fewer.
+	gjkMaxIterations = 64
+	// gjkRelTolerance is the relative improvement below which GJK is considered converged.
+	gjkRelTolerance = 1e-12
+	// gjkContactTolerance is the squared distance, relative to the size of the simplex, below which the
+	// origin is considered to touch the Minkowski difference.
+	gjkContactTolerance = 1e-14
+)
+
+// coreDistance returns the distance between the cores of a and b, ignoring their radii, using the
+// Gilbert-Johnson-Keerthi algorithm on the Minkowski difference a-b.
+func coreDistance(a, b *primitive) float64 {
+	support := func(d spatialmath.Vector) spatialmath.Vector {
+		return a.support(d).Sub(b.support(d.Mul(-1)))
+	}
+
+	v := a.anyPoint().Sub(b.anyPoint())
+	simplex := make([]spatialmath.Vector, 0, 4)
+	for range gjkMaxIterations {
+		vv := v.Dot(v)
+		if vv == 0 {
+			return 0
+		}
+		w := support(v.Mul(-1))
+		if vv-v.Dot(w) <= gjkRelTolerance*vv {
+			return math.Sqrt(vv)
+		}
+		for _, s := range simplex {
+			if s == w {
+				return math.Sqrt(vv)
+			}
+		}
+		simplex = append(simplex, w)
+		v, simplex = closestOnSimplex(simplex)
+
+		maxNorm := 0.0
+		for _, s := range simplex {
+			maxNorm = math.Max(maxNorm, s.Dot(s))
+		}
+		if len(simplex) == 4 || v.Dot(v) <= gjkContactTolerance*maxNorm {
+			return 0
+		}
+	}
+	return v.Norm()
+}
+
+// closestOnSimplex returns the point of the convex hull of simplex closest to the origin, along with the
+// smallest subset of simplex whose hull contains that point.
+//
+// Simplices have at most four vertices, so every face is tried: the closest point of the hull is the
+// closest among the affine minimizers of each face that lie strictly inside that face.
+func closestOnSimplex(simplex []spatialmath.Vector) (spatialmath.Vector, []spatialmath.Vector) {
+	bestNorm := math.Inf(1)
+	var best spatialmath.Vector
+	var bestMask int
+	for mask := 1; mask < 1<<len(simplex); mask++ {
+		var face [4]spatialmath.Vector
+		n := 0
+		for i := range simplex {
+			if mask&(1<<i) != 0 {
+				face[n] = simplex[i]
+				n++
+			}
+		}
+		p, ok := affineMinimizer(face[:n])
+		if !ok {
+			continue
+		}
+		if d := p.Dot(p); d < bestNorm {
+			bestNorm, best, bestMask = d, p, mask
+		}
+	}
+
+	reduced := simplex[:0:0]
+	for i := range simplex {
+		if bestMask&(1<<i) != 0 {
+			reduced = append(reduced, simplex[i])
+		}
+	}
+	return best, reduced
+}
+
+// affineMinimizer returns the point of the affine hull of face closest to the origin. ok is false if the
+// face is degenerate or the point lies outside the face.
+func affineMinimizer(face []spatialmath.Vector) (spatialmath.Vector, bool) {
+	p0 := face[0]
+	k := len(face) - 1
+	if k == 0 {
+		return p0, true
+	}
+
+	// Minimize |p0 + sum(l_i * d_i)|^2 over l by solving the normal equations G l = r.
+	var d [3]spatialmath.Vector
+	var g [3][4]float64
+	for i := range k {
+		d[i] = face[i+1].Sub(p0)
+	}
+	scale := 0.0
+	for i := range k {
+		for j := range k {
+			g[i][j] = d[i].Dot(d[j])
+		}
+		g[i][k] = -d[i].Dot(p0)
+		scale = math.Max(scale, g[i][i])
+	}
+	if scale == 0 {
+		return spatialmath.Vector{}, false
+	}
+
+	for col := range k {
+		pivot := col
+		for row := col + 1; row < k; row++ {
+			if math.Abs(g[row][col]) > math.Abs(g[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(g[pivot][col]) <= 1e-12*scale {
+			return spatialmath.Vector{}, false
+		}
+		g[col], g[pivot] = g[pivot], g[col]
+		for row := range k {
+			if row == col {
+				continue
+			}
+			f := g[row][col] / g[col][col]
+			for c := col; c <= k; c++ {
+				g[row][c] -= f * g[col][c]
+			}
+		}
+	}
+
+	p := p0
+	sum := 0.0
+	for i := range k {
+		l := g[i][k] / g[i][i]
+		if l <= 0 {
+			return spatialmath.Vector{}, false
+		}
+		sum += l
+		p = p.Add(d[i].Mul(l))
+	}
+	if sum >= 1 {
+		return spatialmath.Vector{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,218 @@
+package collision
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.viam.com/api/common/spatialmath"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// readMesh returns the triangles of a mesh. Only PLY meshes are supported; polygons with more than three
+// vertices are split into triangle fans. Vertex coordinates are taken to be millimeters.
+func readMesh(m *commonpb.Mesh) ([][3]spatialmath.Vector, error) {
+	switch ct := strings.ToLower(m.GetContentType()); ct {
+	case "ply", "model/ply", "application/ply", "":
+	default:
+		return nil, fmt.Errorf("unsupported mesh content type %q", ct)
+	}
+	return readPLY(m.GetMesh())
+}
+
+// maxPLYListLength bounds list properties so that a corrupt count cannot exhaust memory.
+const maxPLYListLength = 1 << 16
+
+type plyProperty struct {
+	name      string
+	typ       string
+	countType string // non-empty for list properties
+}
+
+type plyElement struct {
+	name  string
+	count int
+	props []plyProperty
+}
+
+func readPLY(data []byte) ([][3]spatialmath.Vector, error) {
+	r := bufio.NewReader(bytes.NewReader(data))
+	format, elements, err := readPLYHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var next func(typ string) (float64, error)
+	switch format {
+	case "ascii":
+		sc := bufio.NewScanner(r)
+		sc.Split(bufio.ScanWords)
+		next = func(string) (float64, error) {
+			if !sc.Scan() {
+				if sc.Err() != nil {
+					return 0, sc.Err()
+				}
+				return 0, io.ErrUnexpectedEOF
+			}
+			return strconv.ParseFloat(sc.Text(), 64)
+		}
+	case "binary_little_endian":
+		next = func(typ string) (float64, error) { return readPLYBinary(r, binary.LittleEndian, typ) }
+	case "binary_big_endian":
+		next = func(typ string) (float64, error) { return readPLYBinary(r, binary.BigEndian, typ) }
+	default:
+		return nil, fmt.Errorf("unsupported PLY format %q", format)
+	}
+
+	var vertices []spatialmath.Vector
+	var tris [][3]spatialmath.Vector
+	for _, el := range elements {
+		for range el.count {
+			var v spatialmath.Vector
+			for _, p := range el.props {
+				if p.countType == "" {
+					val, err := next(p.typ)
+					if err != nil {
+						return nil, fmt.Errorf("reading %s.%s: %w", el.name, p.name, err)
+					}
+					switch p.name {
+					case "x":
+						v.X = val
+					case "y":
+						v.Y = val
+					case "z":
+						v.Z = val
+					}
+					continue
+				}
+
+				n, err := next(p.countType)
+				if err != nil {
+					return nil, fmt.Errorf("reading %s.%s: %w", el.name, p.name, err)
+				}
+				if n < 0 || n > maxPLYListLength {
+					return nil, fmt.Errorf("invalid %s.%s list length %v", el.name, p.name, n)
+				}
+				idx := make([]int, int(n))
+				for i := range idx {
+					val, err := next(p.typ)
+					if err != nil {
+						return nil, fmt.Errorf("reading %s.%s: %w", el.name, p.name, err)
+					}
+					idx[i] = int(val)
+				}
+				if el.name != "face" || (p.name != "vertex_indices" && p.name != "vertex_index") {
+					continue
+				}
+				for i := 2; i < len(idx); i++ {
+					var tri [3]spatialmath.Vector
+					for k, j := range [3]int{idx[0], idx[i-1], idx[i]} {
+						if j < 0 || j >= len(vertices) {
+							return nil, fmt.Errorf("face references vertex %d of %d", j, len(vertices))
+						}
+						tri[k] = vertices[j]
+					}
+					tris = append(tris, tri)
+				}
+			}
+			if el.name == "vertex" {
+				vertices = append(vertices, v)
+			}
+		}
+	}
+	return tris, nil
+}
+
+func readPLYHeader(r *bufio.Reader) (string, []plyElement, error) {
+	var format string
+	var elements []plyElement
+	for first := true; ; first = false {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", nil, fmt.Errorf("reading PLY header: %w", err)
+		}
+		fields := strings.Fields(line)
+		if first {
+			if len(fields) != 1 || fields[0] != "ply" {
+				return "", nil, errors.New("not a PLY file")
+			}
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "format":
+			if len(fields) < 2 {
+				return "", nil, errors.New("malformed PLY format line")
+			}
+			format = fields[1]
+		case "element":
+			if len(fields) != 3 {
+				return "", nil, fmt.Errorf("malformed PLY element line %q", strings.TrimSpace(line))
+			}
+			n, err := strconv.Atoi(fields[2])
+			if err != nil || n < 0 {
+				return "", nil, fmt.Errorf("invalid PLY element count %q", fields[2])
+			}
+			elements = append(elements, plyElement{name: fields[1], count: n})
+		case "property":
+			if len(elements) == 0 {
+				return "", nil, errors.New("PLY property declared before any element")
+			}
+			el := &elements[len(elements)-1]
+			switch {
+			case len(fields) == 5 && fields[1] == "list":
+				el.props = append(el.props, plyProperty{name: fields[4], typ: fields[3], countType: fields[2]})
+			case len(fields) == 3:
+				el.props = append(el.props, plyProperty{name: fields[2], typ: fields[1]})
+			default:
+				return "", nil, fmt.Errorf("malformed PLY property line %q", strings.TrimSpace(line))
+			}
+		case "end_header":
+			return format, elements, nil
+		}
+	}
+}
+
+var plyTypeSizes = map[string]int{
+	"char": 1, "int8": 1, "uchar": 1, "uint8": 1,
+	"short": 2, "int16": 2, "ushort": 2, "uint16": 2,
+	"int": 4, "int32": 4, "uint": 4, "uint32": 4, "float": 4, "float32": 4,
+	"double": 8, "float64": 8,
+}
+
+func readPLYBinary(r io.Reader, order binary.ByteOrder, typ string) (float64, error) {
+	var buf [8]byte
+	size := plyTypeSizes[typ]
+	if size == 0 {
+		return 0, fmt.Errorf("unsupported PLY type %q", typ)
+	}
+	if _, err := io.ReadFull(r, buf[:size]); err != nil {
+		return 0, err
+	}
+	switch typ {
+	case "char", "int8":
+		return float64(int8(buf[0])), nil
+	case "uchar", "uint8":
+		return float64(buf[0]), nil
+	case "short", "int16":
+		return float64(int16(order.Uint16(buf[:]))), nil
+	case "ushort", "uint16":
+		return float64(order.Uint16(buf[:])), nil
+	case "int", "int32":
+		return float64(int32(order.Uint32(buf[:]))), nil
+	case "uint", "uint32":
+		return float64(order.Uint32(buf[:])), nil
+	case "float", "float32":
+		return float64(math.Float32frombits(order.Uint32(buf[:]))), nil
+	default:
+		return math.Float64frombits(order.Uint64(buf[:])), nil
+	}
+}
@@ -0,0 +1,35 @@
+package collision
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"go.viam.com/api/common/pcd"
+	"go.viam.com/api/common/spatialmath"
+)
+
+// readPointCloud returns the points of a PCD file, converted from meters to millimeters. Points with
+// non-finite coordinates are dropped.
+func readPointCloud(data []byte) ([]spatialmath.Vector, error) {
+	r, err := pcd.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if h := r.Header(); !h.Has("x") || !h.Has("y") || !h.Has("z") {
+		return nil, errors.New("PCD has no x, y and z fields")
+	}
+	var out []spatialmath.Vector
+	for {
+		p, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v := (spatialmath.Vector{X: p.X, Y: p.Y, Z: p.Z}).Mul(1000); finite(v) {
+			out = append(out, v)
+		}
+	}
+}
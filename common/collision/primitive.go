@@ -0,0 +1,98 @@
+package collision
+
+import (
+	"math"
+
+	"go.viam.com/api/common/spatialmath"
+)
+
+type primitiveKind int
+
+const (
+	kindPoint primitiveKind = iota
+	kindSegment
+	kindTriangle
+	kindBox
+)
+
+// primitive is a convex core (a point, segment, triangle or box) inflated by a radius. Every geometry is
+// represented as one or more primitives: a sphere is an inflated point and a capsule an inflated segment.
+type primitive struct {
+	kind   primitiveKind
+	pts    [3]spatialmath.Vector
+	axes   [3]spatialmath.Vector
+	half   [3]float64
+	radius float64
+}
+
+// support returns the point of the core of p furthest in direction d.
+func (p *primitive) support(d spatialmath.Vector) spatialmath.Vector {
+	switch p.kind {
+	case kindSegment, kindTriangle:
+		n := 2
+		if p.kind == kindTriangle {
+			n = 3
+		}
+		best := p.pts[0]
+		bestDot := best.Dot(d)
+		for _, v := range p.pts[1:n] {
+			if dot := v.Dot(d); dot > bestDot {
+				best, bestDot = v, dot
+			}
+		}
+		return best
+	case kindBox:
+		out := p.pts[0]
+		for i, axis := range p.axes {
+			if axis.Dot(d) >= 0 {
+				out = out.Add(axis.Mul(p.half[i]))
+			} else {
+				out = out.Sub(axis.Mul(p.half[i]))
+			}
+		}
+		return out
+	default:
+		return p.pts[0]
+	}
+}
+
+// anyPoint returns an arbitrary point of the core of p.
+func (p *primitive) anyPoint() spatialmath.Vector {
+	return p.pts[0]
+}
+
+// transform returns p moved by pose.
+func (p primitive) transform(pose spatialmath.Pose) primitive {
+	for i := range p.pts {
+		p.pts[i] = pose.Transform(p.pts[i])
+	}
+	for i := range p.axes {
+		p.axes[i] = pose.Orientation.Rotate(p.axes[i])
+	}
+	return p
+}
+
+// bounds returns the axis-aligned bounding box of p including its radius.
+func (p *primitive) bounds() AABB {
+	var box AABB
+	switch p.kind {
+	case kindSegment:
+		box = aabbOf(p.pts[:2])
+	case kindTriangle:
+		box = aabbOf(p.pts[:3])
+	case kindBox:
+		var ext spatialmath.Vector
+		for i, axis := range p.axes {
+			ext = ext.Add(spatialmath.Vector{X: math.Abs(axis.X), Y: math.Abs(axis.Y), Z: math.Abs(axis.Z)}.Mul(p.half[i]))
+		}
+		box = AABB{Min: p.pts[0].Sub(ext), Max: p.pts[0].Add(ext)}
+	default:
+		box = AABB{Min: p.pts[0], Max: p.pts[0]}
+	}
+	return box.expand(p.radius)
+}
+
+// distance returns the separation between a and b, or zero if they touch or overlap.
+func distance(a, b *primitive) float64 {
+	return math.Max(0, coreDistance(a, b)-a.radius-b.radius)
+}
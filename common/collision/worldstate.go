@@ -0,0 +1,97 @@
+package collision
+
+import (
+	"fmt"
+
+	"go.viam.com/api/common/spatialmath"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/api/robot/framesystem"
+)
+
+// Collision is a pair of geometries found within the requested buffer of each other.
+type Collision struct {
+	A, B     *Geometry
+	Distance float64
+}
+
+// InFrame converts the geometries of gif and re-expresses them in destination using fs. A nil frame system
+// only knows the world frame.
+func InFrame(gif *commonpb.GeometriesInFrame, fs *framesystem.FrameSystem, destination string) ([]*Geometry, error) {
+	gs, err := NewGeometries(gif.GetGeometries())
+	if err != nil {
+		return nil, err
+	}
+	if gif.GetReferenceFrame() == destination {
+		return gs, nil
+	}
+	if fs == nil {
+		if fs, err = framesystem.New(nil); err != nil {
+			return nil, err
+		}
+	}
+	pose, err := fs.Transform(spatialmath.NewZeroPose(), gif.GetReferenceFrame(), destination)
+	if err != nil {
+		return nil, fmt.Errorf("placing geometries observed in %q: %w", gif.GetReferenceFrame(), err)
+	}
+	for i, g := range gs {
+		gs[i] = g.Transform(pose)
+	}
+	return gs, nil
+}
+
+// Obstacles returns every obstacle of ws expressed in the world frame. The transforms of ws are added to fs
+// as supplemental transforms, so obstacles may be observed in frames that only ws defines. fs may be nil.
+func Obstacles(ws *commonpb.WorldState, fs *framesystem.FrameSystem) ([]*Geometry, error) {
+	var err error
+	if fs == nil {
+		fs, err = framesystem.New(nil, ws.GetTransforms()...)
+	} else {
+		fs, err = fs.With(ws.GetTransforms()...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []*Geometry
+	for _, gif := range ws.GetObstacles() {
+		gs, err := InFrame(gif, fs, framesystem.World)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, gs...)
+	}
+	return out, nil
+}
+
+// Collisions returns every pair of one geometry from a and one from b that come within buffer of each other.
+func Collisions(a, b []*Geometry, buffer float64) []Collision {
+	var out []Collision
+	for _, ga := range a {
+		for _, gb := range b {
+			if c, ok := check(ga, gb, buffer); ok {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// SelfCollisions returns every pair of distinct geometries in gs that come within buffer of each other.
+func SelfCollisions(gs []*Geometry, buffer float64) []Collision {
+	var out []Collision
+	for i, ga := range gs {
+		for _, gb := range gs[i+1:] {
+			if c, ok := check(ga, gb, buffer); ok {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+func check(a, b *Geometry, buffer float64) (Collision, bool) {
+	if a.AABB().Distance(b.AABB()) > buffer {
+		return Collision{}, false
+	}
+	d := minDistance(a.tree, b.tree, 0)
+	return Collision{A: a, B: b, Distance: d}, d <= buffer
+}
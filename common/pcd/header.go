@@ -0,0 +1,278 @@
+// Package pcd reads and writes point clouds in the PCD format used for point cloud bytes throughout the API,
+// such as camera GetPointCloudResponse.point_cloud, common.v1.PointCloudObject.point_cloud, slam
+// GetPointCloudMapResponse chunks and robot TransformPCDRequest.point_cloud_pcd.
+//
+// The ascii, binary and binary_compressed data encodings are supported. Points are exposed through the x,
+// y, z, rgb (or rgba) and intensity fields; any other fields are skipped when reading. Coordinates are
+// returned exactly as stored, which for Viam point clouds is meters.
+//
+// See https://pointclouds.org/documentation/tutorials/pcd_file_format.html for the format itself.
+package pcd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format is the encoding of the DATA section of a PCD file.
+type Format int
+
+// The supported data encodings.
+const (
+	FormatASCII Format = iota
+	FormatBinary
+	FormatBinaryCompressed
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatASCII:
+		return "ascii"
+	case FormatBinary:
+		return "binary"
+	case FormatBinaryCompressed:
+		return "binary_compressed"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+func parseFormat(s string) (Format, error) {
+	switch s {
+	case "ascii":
+		return FormatASCII, nil
+	case "binary":
+		return FormatBinary, nil
+	case "binary_compressed":
+		return FormatBinaryCompressed, nil
+	default:
+		return 0, fmt.Errorf("unsupported PCD data encoding %q", s)
+	}
+}
+
+// Field describes one column of a PCD file.
+type Field struct {
+	Name string
+	// Size is the size in bytes of a single element: 1, 2, 4 or 8.
+	Size int
+	// Type is 'F' for floating point, 'I' for signed and 'U' for unsigned integers.
+	Type byte
+	// Count is the number of elements in the field.
+	Count int
+}
+
+// Well known fields, with the types this package writes them as.
+var (
+	FieldX         = Field{Name: "x", Size: 4, Type: 'F', Count: 1}
+	FieldY         = Field{Name: "y", Size: 4, Type: 'F', Count: 1}
+	FieldZ         = Field{Name: "z", Size: 4, Type: 'F', Count: 1}
+	FieldRGB       = Field{Name: "rgb", Size: 4, Type: 'I', Count: 1}
+	FieldIntensity = Field{Name: "intensity", Size: 4, Type: 'F', Count: 1}
+)
+
+// Header is the header of a PCD file.
+type Header struct {
+	Version string
+	Fields  []Field
+	Width   int
+	Height  int
+	// Viewpoint is the acquisition viewpoint as tx ty tz qw qx qy qz.
+	Viewpoint [7]float64
+	Points    int
+	Format    Format
+}
+
+// NewHeader returns a header for an unorganized cloud of n points with the given fields.
+func NewHeader(format Format, n int, fields ...Field) Header {
+	return Header{
+		Version:   "0.7",
+		Fields:    fields,
+		Width:     n,
+		Height:    1,
+		Viewpoint: [7]float64{0, 0, 0, 1, 0, 0, 0},
+		Points:    n,
+		Format:    format,
+	}
+}
+
+// Has reports whether the header declares a field with the given name.
+func (h Header) Has(name string) bool {
+	return h.fieldIndex(name) >= 0
+}
+
+func (h Header) fieldIndex(name string) int {
+	for i, f := range h.Fields {
+		if f.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// pointSize returns the number of bytes a single point occupies in the binary encodings.
+func (h Header) pointSize() int {
+	n := 0
+	for _, f := range h.Fields {
+		n += f.Size * f.Count
+	}
+	return n
+}
+
+func (h Header) validate() error {
+	if len(h.Fields) == 0 {
+		return errors.New("PCD header declares no fields")
+	}
+	for _, f := range h.Fields {
+		if f.Count < 1 {
+			return fmt.Errorf("PCD field %q has count %d", f.Name, f.Count)
+		}
+		switch {
+		case f.Type == 'F' && (f.Size == 4 || f.Size == 8):
+		case (f.Type == 'I' || f.Type == 'U') && (f.Size == 1 || f.Size == 2 || f.Size == 4 || f.Size == 8):
+		default:
+			return fmt.Errorf("PCD field %q has unsupported type %c%d", f.Name, f.Type, f.Size)
+		}
+	}
+	if h.Points < 0 {
+		return fmt.Errorf("PCD header declares %d points", h.Points)
+	}
+	return nil
+}
+
+func (h Header) write(w io.Writer) error {
+	var b strings.Builder
+	line := func(key string, vals func(Field) string) {
+		b.WriteString(key)
+		for _, f := range h.Fields {
+			b.WriteByte(' ')
+			b.WriteString(vals(f))
+		}
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "VERSION %s\n", h.Version)
+	line("FIELDS", func(f Field) string { return f.Name })
+	line("SIZE", func(f Field) string { return strconv.Itoa(f.Size) })
+	line("TYPE", func(f Field) string { return string(f.Type) })
+	line("COUNT", func(f Field) string { return strconv.Itoa(f.Count) })
+	fmt.Fprintf(&b, "WIDTH %d\nHEIGHT %d\nVIEWPOINT", h.Width, h.Height)
+	for _, v := range h.Viewpoint {
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	fmt.Fprintf(&b, "\nPOINTS %d\nDATA %s\n", h.Points, h.Format)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func readHeader(r *bufio.Reader) (Header, error) {
+	h := Header{Version: "0.7", Height: 1, Viewpoint: [7]float64{0, 0, 0, 1, 0, 0, 0}, Points: -1}
+	var sizes, counts []int
+	var types []byte
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return Header{}, fmt.Errorf("reading PCD header: %w", err)
+		}
+		f := strings.Fields(line)
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+		key, vals := f[0], f[1:]
+		switch key {
+		case "VERSION":
+			if len(vals) > 0 {
+				h.Version = vals[0]
+			}
+		case "FIELDS":
+			h.Fields = make([]Field, len(vals))
+			for i, v := range vals {
+				h.Fields[i] = Field{Name: v, Count: 1}
+			}
+		case "SIZE":
+			if sizes, err = atois(key, vals); err != nil {
+				return Header{}, err
+			}
+		case "TYPE":
+			types = make([]byte, len(vals))
+			for i, v := range vals {
+				if len(v) != 1 {
+					return Header{}, fmt.Errorf("invalid PCD TYPE %q", v)
+				}
+				types[i] = v[0]
+			}
+		case "COUNT":
+			if counts, err = atois(key, vals); err != nil {
+				return Header{}, err
+			}
+		case "WIDTH", "HEIGHT", "POINTS":
+			n, err := atois(key, vals)
+			if err != nil {
+				return Header{}, err
+			}
+			if len(n) != 1 {
+				return Header{}, fmt.Errorf("PCD %s expects one value", key)
+			}
+			switch key {
+			case "WIDTH":
+				h.Width = n[0]
+			case "HEIGHT":
+				h.Height = n[0]
+			default:
+				h.Points = n[0]
+			}
+		case "VIEWPOINT":
+			if len(vals) != 7 {
+				return Header{}, errors.New("PCD VIEWPOINT expects seven values")
+			}
+			for i, v := range vals {
+				if h.Viewpoint[i], err = strconv.ParseFloat(v, 64); err != nil {
+					return Header{}, fmt.Errorf("invalid PCD VIEWPOINT: %w", err)
+				}
+			}
+		case "DATA":
+			if len(vals) != 1 {
+				return Header{}, errors.New("PCD DATA expects one value")
+			}
+			if h.Format, err = parseFormat(vals[0]); err != nil {
+				return Header{}, err
+			}
+			if len(sizes) != len(h.Fields) || len(types) != len(h.Fields) {
+				return Header{}, errors.New("PCD FIELDS, SIZE and TYPE have different lengths")
+			}
+			if counts != nil && len(counts) != len(h.Fields) {
+				return Header{}, errors.New("PCD FIELDS and COUNT have different lengths")
+			}
+			for i := range h.Fields {
+				h.Fields[i].Size, h.Fields[i].Type = sizes[i], types[i]
+				if counts != nil {
+					h.Fields[i].Count = counts[i]
+				}
+			}
+			if h.Points < 0 {
+				h.Points = h.Width * h.Height
+			}
+			return h, h.validate()
+		default:
+			return Header{}, fmt.Errorf("unknown PCD header entry %q", key)
+		}
+	}
+}
+
+func atois(key string, vals []string) ([]int, error) {
+	out := make([]int, len(vals))
+	for i, v := range vals {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid PCD %s value %q", key, v)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package pcd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// binary_compressed PCD data is compressed with LZF. Each chunk starts with a control byte: values below 32
+// introduce a run of ctrl+1 literal bytes, anything else is a back reference whose length is in the top
+// three bits (extended by a following byte when they are all set) and whose offset is in the low five bits
+// and the byte after the length.
+
+const (
+	lzfHashBits   = 14
+	lzfMaxLiteral = 32
+	lzfMaxOffset  = 1 << 13
+	lzfMaxRef     = (1 << 8) + (1 << 3) // longest back reference: 7 + 255 + 2 bytes
+)
+
+func lzfCompress(in []byte) []byte {
+	out := make([]byte, 0, len(in)/2+16)
+	var table [1 << lzfHashBits]int32 // position+1 of the last occurrence of each hash
+	litStart := 0
+	flush := func(end int) {
+		for litStart < end {
+			n := min(lzfMaxLiteral, end-litStart)
+			out = append(out, byte(n-1))
+			out = append(out, in[litStart:litStart+n]...)
+			litStart += n
+		}
+	}
+
+	for ip := 0; ip+2 < len(in); {
+		v := uint32(in[ip])<<16 | uint32(in[ip+1])<<8 | uint32(in[ip+2])
+		h := (v * 2654435761) >> (32 - lzfHashBits)
+		ref := int(table[h]) - 1
+		table[h] = int32(ip + 1)
+		off := ip - ref - 1
+		if ref < 0 || off >= lzfMaxOffset || in[ref] != in[ip] || in[ref+1] != in[ip+1] || in[ref+2] != in[ip+2] {
+			ip++
+			continue
+		}
+
+		maxLen := min(len(in)-ip, lzfMaxRef)
+		n := 3
+		for n < maxLen && in[ref+n] == in[ip+n] {
+			n++
+		}
+		flush(ip)
+		if l := n - 2; l < 7 {
+			out = append(out, byte(off>>8)|byte(l<<5))
+		} else {
+			out = append(out, byte(off>>8)|7<<5, byte(l-7))
+		}
+		out = append(out, byte(off))
+		ip += n
+		litStart = ip
+	}
+	flush(len(in))
+	return out
+}
+
+func lzfDecompress(in []byte, size int) ([]byte, error) {
+	out := make([]byte, 0, min(size, 1<<24))
+	for ip := 0; ip < len(in); {
+		ctrl := int(in[ip])
+		ip++
+		if ctrl < lzfMaxLiteral {
+			n := ctrl + 1
+			if ip+n > len(in) || len(out)+n > size {
+				return nil, errors.New("corrupt LZF literal run")
+			}
+			out = append(out, in[ip:ip+n]...)
+			ip += n
+			continue
+		}
+
+		n := ctrl >> 5
+		if n == 7 {
+			if ip >= len(in) {
+				return nil, errors.New("corrupt LZF back reference")
+			}
+			n += int(in[ip])
+			ip++
+		}
+		n += 2
+		if ip >= len(in) {
+			return nil, errors.New("corrupt LZF back reference")
+		}
+		ref := len(out) - (ctrl&0x1f)<<8 - int(in[ip]) - 1
+		ip++
+		if ref < 0 || len(out)+n > size {
+			return nil, errors.New("corrupt LZF back reference")
+		}
+		for i := range n {
+			out = append(out, out[ref+i])
+		}
+	}
+	if len(out) != size {
+		return nil, fmt.Errorf("LZF data decompressed to %d bytes, expected %d", len(out), size)
+	}
+	return out, nil
+}
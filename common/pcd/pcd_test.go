@@ -0,0 +1,290 @@
+package pcd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+var testPoints = []Point{
+	{X: 1, Y: 2, Z: 3, RGB: NewRGB(255, 0, 0), Intensity: 0.5},
+	{X: -1.5, Y: 0, Z: 1e-3, RGB: NewRGB(0, 128, 255), Intensity: 1},
+	{X: 100, Y: -200, Z: 300, RGB: 0, Intensity: 0},
+}
+
+func TestRoundTrip(t *testing.T) {
+	fields := []Field{FieldX, FieldY, FieldZ, FieldRGB, FieldIntensity}
+	for _, format := range []Format{FormatASCII, FormatBinary, FormatBinaryCompressed} {
+		t.Run(format.String(), func(t *testing.T) {
+			data, err := Encode(format, testPoints, fields...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h, pts, err := Decode(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h.Format != format || h.Points != len(testPoints) || len(h.Fields) != len(fields) {
+				t.Errorf("header = %+v", h)
+			}
+			if len(pts) != len(testPoints) {
+				t.Fatalf("decoded %d points, want %d", len(pts), len(testPoints))
+			}
+			for i, p := range pts {
+				want := testPoints[i]
+				if p.RGB != want.RGB || math.Abs(p.X-want.X) > 1e-4 || math.Abs(p.Y-want.Y) > 1e-4 ||
+					math.Abs(p.Z-want.Z) > 1e-4 || math.Abs(p.Intensity-want.Intensity) > 1e-4 {
+					t.Errorf("point %d = %+v, want %+v", i, p, want)
+				}
+			}
+		})
+	}
+}
+
+func TestColor(t *testing.T) {
+	r, g, b := Point{RGB: NewRGB(1, 2, 3)}.Color()
+	if r != 1 || g != 2 || b != 3 {
+		t.Errorf("Color() = %d, %d, %d", r, g, b)
+	}
+}
+
+func TestDecodeForeignFields(t *testing.T) {
+	// Fields this package does not know, with counts above one, are skipped.
+	const data = `# .PCD v0.7
+VERSION 0.7
+FIELDS x y z normal rgb
+SIZE 4 4 4 4 4
+TYPE F F F F U
+COUNT 1 1 1 3 1
+WIDTH 2
+HEIGHT 1
+VIEWPOINT 0 0 0 1 0 0 0
+POINTS 2
+DATA ascii
+1 2 3 0 0 1 16711680
+4 5 6 0 1 0 255
+`
+	h, pts, err := Decode([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.Has("normal") || h.Has("intensity") {
+		t.Errorf("Has() is wrong for %+v", h.Fields)
+	}
+	want := []Point{{X: 1, Y: 2, Z: 3, RGB: 0xff0000}, {X: 4, Y: 5, Z: 6, RGB: 0xff}}
+	for i := range want {
+		if pts[i] != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, pts[i], want[i])
+		}
+	}
+}
+
+func TestDecodePCLFloatColors(t *testing.T) {
+	// PCL writes float colors as the integer of their bits; older writers print the float.
+	const data = `# .PCD v0.7
+VERSION 0.7
+FIELDS x y z rgb
+SIZE 4 4 4 4
+TYPE F F F F
+COUNT 1 1 1 1
+WIDTH 3
+HEIGHT 1
+VIEWPOINT 0 0 0 1 0 0 0
+POINTS 3
+DATA ascii
+1 2 3 4294901760
+4 5 6 4278255360
+7 8 9 4.2108e+06
+`
+	_, pts, err := Decode([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []uint32{0xffff0000, 0xff00ff00, math.Float32bits(4.2108e+06)} {
+		if pts[i].RGB != want {
+			t.Errorf("point %d RGB = %#x, want %#x", i, pts[i].RGB, want)
+		}
+	}
+}
+
+func TestOpaqueFloatColorRoundTrip(t *testing.T) {
+	points := []Point{{RGB: 0xffff0000}, {RGB: 0xff0080ff}, {RGB: 0x00123456}}
+	for _, f := range []Field{{Name: "rgb", Size: 4, Type: 'F', Count: 1}, {Name: "rgba", Size: 4, Type: 'F', Count: 1}} {
+		for _, format := range []Format{FormatASCII, FormatBinary, FormatBinaryCompressed} {
+			t.Run(f.Name+"/"+format.String(), func(t *testing.T) {
+				data, err := Encode(format, points, FieldX, FieldY, FieldZ, f)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if format == FormatASCII && bytes.Contains(data, []byte("NaN")) {
+					t.Errorf("encoded a color as NaN:\n%s", data)
+				}
+				_, pts, err := Decode(data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range points {
+					if pts[i].RGB != points[i].RGB {
+						t.Errorf("point %d RGB = %#x, want %#x", i, pts[i].RGB, points[i].RGB)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	valid, err := Encode(FormatBinary, testPoints, FieldX, FieldY, FieldZ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"no data line", []byte("VERSION 0.7\nFIELDS x\nSIZE 4\nTYPE F\nCOUNT 1\nPOINTS 1\n")},
+		{"unknown encoding", bytes.Replace(valid, []byte("DATA binary"), []byte("DATA zip"), 1)},
+		{"truncated", valid[:len(valid)-1]},
+		{"more points than data", bytes.Replace(valid, []byte("POINTS 3"), []byte("POINTS 4"), 1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := Decode(tc.data); err == nil {
+				t.Error("Decode() succeeded")
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, NewHeader(FormatBinary, 1, FieldX, FieldY, FieldZ))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(Point{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(Point{}); err == nil {
+		t.Error("Write() beyond the declared points succeeded")
+	}
+
+	w, err = NewWriter(io.Discard, NewHeader(FormatASCII, 2, FieldX))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(Point{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err == nil {
+		t.Error("Close() with fewer points than declared succeeded")
+	}
+
+	if _, err := NewWriter(io.Discard, NewHeader(FormatASCII, 0, Field{Name: "normal_x", Size: 4, Type: 'F', Count: 1})); err == nil {
+		t.Error("NewWriter() accepted a field it cannot write")
+	}
+	if _, err := NewWriter(io.Discard, NewHeader(FormatASCII, 0)); err == nil {
+		t.Error("NewWriter() accepted a header without fields")
+	}
+}
+
+func TestLZF(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	random := make([]byte, 10000)
+	for i := range random {
+		random[i] = byte(rnd.IntN(256))
+	}
+	for _, tc := range []struct {
+		name string
+		in   []byte
+	}{
+		{"empty", nil},
+		{"one byte", []byte{7}},
+		{"short", []byte("abc")},
+		{"repeated", bytes.Repeat([]byte("abcd"), 1000)},
+		{"long run", make([]byte, 5000)},
+		{"text", []byte(strings.Repeat("the quick brown fox jumps over the lazy dog ", 50))},
+		{"random", random},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := lzfCompress(tc.in)
+			out, err := lzfDecompress(c, len(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(out, tc.in) {
+				t.Error("decompressed data differs")
+			}
+		})
+	}
+}
+
+func TestLZFCorrupt(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   []byte
+		size int
+	}{
+		{"literal past input", []byte{5, 'a'}, 6},
+		{"literal past size", []byte{1, 'a', 'b'}, 1},
+		{"reference before start", []byte{0, 'a', 0x20, 5}, 4},
+		{"reference without offset", []byte{0, 'a', 0x20}, 4},
+		{"long reference without length", []byte{0, 'a', 0xe0}, 20},
+		{"reference past size", []byte{0, 'a', 0x20, 0}, 2},
+		{"short output", []byte{0, 'a'}, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := lzfDecompress(tc.in, tc.size); err == nil {
+				t.Error("lzfDecompress() succeeded")
+			}
+		})
+	}
+}
+
+func FuzzLZF(f *testing.F) {
+	f.Add([]byte("abcabcabcabc"), 12)
+	f.Add(lzfCompress(bytes.Repeat([]byte{1, 2, 3}, 100)), 300)
+	f.Add([]byte{0xe0, 0xff, 0xff}, 1000)
+	f.Fuzz(func(t *testing.T, in []byte, size int) {
+		if size < 0 || size > 1<<20 {
+			return
+		}
+		out, err := lzfDecompress(in, size)
+		if err == nil && len(out) != size {
+			t.Fatalf("decompressed %d bytes, want %d", len(out), size)
+		}
+		back, err := lzfDecompress(lzfCompress(in), len(in))
+		if err != nil || !bytes.Equal(back, in) {
+			t.Fatalf("round trip failed: %v", err)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, format := range []Format{FormatASCII, FormatBinary, FormatBinaryCompressed} {
+		data, err := Encode(format, testPoints, FieldX, FieldY, FieldZ, FieldRGB)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r, err := NewReader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		// Corrupt headers may declare any number of points, so only a bounded number is read.
+		for range 1000 {
+			if _, err := r.Read(); err != nil {
+				if errors.Is(err, io.EOF) && r.read != r.header.Points {
+					t.Fatalf("io.EOF after %d of %d points", r.read, r.header.Points)
+				}
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,287 @@
+package pcd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxCompressedSize bounds the size of a binary_compressed payload so that a corrupt header cannot exhaust
+// memory.
+const maxCompressedSize = 1 << 31
+
+// Point is a single point of a cloud. Fields that the cloud does not declare are left at zero.
+type Point struct {
+	X, Y, Z float64
+	// RGB is the color packed as 0x00RRGGBB, or 0xAARRGGBB for rgba clouds.
+	RGB       uint32
+	Intensity float64
+}
+
+// NewRGB packs a color into the layout used by Point.RGB.
+func NewRGB(r, g, b uint8) uint32 {
+	return uint32(r)<<16 | uint32(g)<<8 | uint32(b)
+}
+
+// Color unpacks p.RGB.
+func (p Point) Color() (r, g, b uint8) {
+	return uint8(p.RGB >> 16), uint8(p.RGB >> 8), uint8(p.RGB)
+}
+
+// Reader decodes points from a PCD stream. The ascii and binary encodings are decoded incrementally;
+// binary_compressed data is stored column by column and so is read in full on the first call to Read.
+type Reader struct {
+	r      *bufio.Reader
+	header Header
+	read   int
+
+	offsets            []int // byte offset of each field within a binary point
+	xi, yi, zi, ci, ii int   // field indices, -1 if absent
+	columns            []int // token index of each field within an ascii line
+	buf                []byte
+	decompressed       []byte
+}
+
+// NewReader reads the PCD header from r and returns a Reader positioned at the first point.
+func NewReader(r io.Reader) (*Reader, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	h, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	pr := &Reader{
+		r:      br,
+		header: h,
+		xi:     h.fieldIndex("x"),
+		yi:     h.fieldIndex("y"),
+		zi:     h.fieldIndex("z"),
+		ci:     h.fieldIndex("rgb"),
+		ii:     h.fieldIndex("intensity"),
+	}
+	if pr.ci < 0 {
+		pr.ci = h.fieldIndex("rgba")
+	}
+	pr.offsets = make([]int, len(h.Fields))
+	pr.columns = make([]int, len(h.Fields))
+	off, col := 0, 0
+	for i, f := range h.Fields {
+		pr.offsets[i], pr.columns[i] = off, col
+		off += f.Size * f.Count
+		col += f.Count
+	}
+	pr.buf = make([]byte, off)
+	return pr, nil
+}
+
+// Header returns the header of the stream.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Read returns the next point, or io.EOF once every point declared by the header has been read.
+func (r *Reader) Read() (Point, error) {
+	if r.read >= r.header.Points {
+		return Point{}, io.EOF
+	}
+	var p Point
+	var err error
+	switch r.header.Format {
+	case FormatASCII:
+		p, err = r.readASCII()
+	case FormatBinary:
+		if _, err = io.ReadFull(r.r, r.buf); err == nil {
+			p = r.decode(func(i int) []byte { return r.buf[r.offsets[i]:] })
+		}
+	default:
+		p, err = r.readCompressed()
+	}
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return Point{}, fmt.Errorf("PCD point %d: %w", r.read, err)
+	}
+	r.read++
+	return p, nil
+}
+
+// ReadAll returns every remaining point.
+func (r *Reader) ReadAll() ([]Point, error) {
+	out := make([]Point, 0, min(r.header.Points-r.read, 1<<20))
+	for {
+		p, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+}
+
+// Decode reads a complete PCD file from data.
+func Decode(data []byte) (Header, []Point, error) {
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return Header{}, nil, err
+	}
+	pts, err := r.ReadAll()
+	return r.header, pts, err
+}
+
+func (r *Reader) readASCII() (Point, error) {
+	line, err := r.r.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return Point{}, err
+	}
+	toks := strings.Fields(line)
+	if want := r.columns[len(r.columns)-1] + r.header.Fields[len(r.header.Fields)-1].Count; len(toks) < want {
+		return Point{}, fmt.Errorf("expected %d values, got %d", want, len(toks))
+	}
+
+	var p Point
+	parse := func(i int, dst *float64) error {
+		if i < 0 {
+			return nil
+		}
+		bits := 64
+		if f := r.header.Fields[i]; f.Type == 'F' && f.Size == 4 {
+			bits = 32
+		}
+		v, err := strconv.ParseFloat(toks[r.columns[i]], bits)
+		*dst = v
+		return err
+	}
+	for _, f := range []struct {
+		i   int
+		dst *float64
+	}{{r.xi, &p.X}, {r.yi, &p.Y}, {r.zi, &p.Z}, {r.ii, &p.Intensity}} {
+		if err := parse(f.i, f.dst); err != nil {
+			return Point{}, err
+		}
+	}
+	if r.ci >= 0 {
+		tok := toks[r.columns[r.ci]]
+		if r.header.Fields[r.ci].Type == 'F' {
+			// Colors stored as floats hold the packed bits of the color, not its numeric value.
+			// PCL writes those bits as an integer, since opaque colors are NaN as floats;
+			// older writers print the float itself.
+			if v, err := strconv.ParseUint(tok, 10, 32); err == nil {
+				p.RGB = uint32(v)
+			} else {
+				v, err := strconv.ParseFloat(tok, 32)
+				if err != nil {
+					return Point{}, err
+				}
+				p.RGB = math.Float32bits(float32(v))
+			}
+		} else {
+			v, err := strconv.ParseInt(tok, 10, 64)
+			if err != nil {
+				return Point{}, err
+			}
+			p.RGB = uint32(v)
+		}
+	}
+	return p, nil
+}
+
+func (r *Reader) readCompressed() (Point, error) {
+	if r.decompressed == nil {
+		var sizes [8]byte
+		if _, err := io.ReadFull(r.r, sizes[:]); err != nil {
+			return Point{}, err
+		}
+		compressed := binary.LittleEndian.Uint32(sizes[:4])
+		raw := binary.LittleEndian.Uint32(sizes[4:])
+		if want := uint64(r.header.pointSize()) * uint64(r.header.Points); uint64(raw) != want {
+			return Point{}, fmt.Errorf("binary_compressed data holds %d bytes, expected %d", raw, want)
+		}
+		if compressed > maxCompressedSize {
+			return Point{}, fmt.Errorf("binary_compressed data of %d bytes is too large", compressed)
+		}
+		// The buffer grows with the data read rather than with the size declared, which may be corrupt.
+		in, err := io.ReadAll(io.LimitReader(r.r, int64(compressed)))
+		if err != nil {
+			return Point{}, err
+		}
+		if len(in) < int(compressed) {
+			return Point{}, io.ErrUnexpectedEOF
+		}
+		out, err := lzfDecompress(in, int(raw))
+		if err != nil {
+			return Point{}, err
+		}
+		r.decompressed = out
+	}
+	// Fields are stored column by column: every point's value of the first field, then the second, and so on.
+	return r.decode(func(i int) []byte {
+		f := r.header.Fields[i]
+		width := f.Size * f.Count
+		return r.decompressed[r.offsets[i]*r.header.Points+r.read*width:]
+	}), nil
+}
+
+// decode builds a point from binary field data. field returns the bytes of the given field.
+func (r *Reader) decode(field func(i int) []byte) Point {
+	var p Point
+	num := func(i int) float64 {
+		if i < 0 {
+			return 0
+		}
+		return decodeNumber(field(i), r.header.Fields[i])
+	}
+	p.X, p.Y, p.Z, p.Intensity = num(r.xi), num(r.yi), num(r.zi), num(r.ii)
+	if r.ci >= 0 {
+		b := field(r.ci)
+		switch r.header.Fields[r.ci].Size {
+		case 4, 8:
+			p.RGB = binary.LittleEndian.Uint32(b)
+		default:
+			p.RGB = uint32(decodeNumber(b, r.header.Fields[r.ci]))
+		}
+	}
+	return p
+}
+
+func decodeNumber(b []byte, f Field) float64 {
+	switch f.Type {
+	case 'F':
+		if f.Size == 4 {
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	case 'I':
+		switch f.Size {
+		case 1:
+			return float64(int8(b[0]))
+		case 2:
+			return float64(int16(binary.LittleEndian.Uint16(b)))
+		case 4:
+			return float64(int32(binary.LittleEndian.Uint32(b)))
+		default:
+			return float64(int64(binary.LittleEndian.Uint64(b)))
+		}
+	default:
+		switch f.Size {
+		case 1:
+			return float64(b[0])
+		case 2:
+			return float64(binary.LittleEndian.Uint16(b))
+		case 4:
+			return float64(binary.LittleEndian.Uint32(b))
+		default:
+			return float64(binary.LittleEndian.Uint64(b))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package pcd
+
+import (
+	"context"
+	"io"
+
+	slampb "go.viam.com/api/service/slam/v1"
+)
+
+// MapStreamReader returns an io.Reader over the concatenated chunks of a slam GetPointCloudMap stream, so
+// that the map can be decoded with NewReader as it arrives.
+func MapStreamReader(stream slampb.SLAMService_GetPointCloudMapClient) io.Reader {
+	return &mapStreamReader{stream: stream}
+}
+
+type mapStreamReader struct {
+	stream slampb.SLAMService_GetPointCloudMapClient
+	chunk  []byte
+	err    error
+}
+
+func (r *mapStreamReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		resp, err := r.stream.Recv()
+		if err != nil {
+			r.err = err
+			continue
+		}
+		r.chunk = resp.GetPointCloudPcdChunk()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// ReadMap requests the point cloud map of a slam service and returns the reassembled PCD bytes.
+func ReadMap(ctx context.Context, client slampb.SLAMServiceClient, req *slampb.GetPointCloudMapRequest) ([]byte, error) {
+	stream, err := client.GetPointCloudMap(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(MapStreamReader(stream))
+}
+
+// DecodeMap requests the point cloud map of a slam service and decodes it.
+func DecodeMap(ctx context.Context, client slampb.SLAMServiceClient, req *slampb.GetPointCloudMapRequest) (Header, []Point, error) {
+	stream, err := client.GetPointCloudMap(ctx, req)
+	if err != nil {
+		return Header{}, nil, err
+	}
+	r, err := NewReader(MapStreamReader(stream))
+	if err != nil {
+		return Header{}, nil, err
+	}
+	pts, err := r.ReadAll()
+	return r.Header(), pts, err
+}
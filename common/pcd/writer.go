@@ -0,0 +1,195 @@
+package pcd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Writer encodes points to a PCD stream. The header, including the number of points, is fixed up front;
+// Close fails if a different number of points was written. ascii and binary points are written as they
+// arrive, while binary_compressed points are buffered until Close.
+type Writer struct {
+	w      *bufio.Writer
+	header Header
+	n      int
+	buf    []byte
+	cols   [][]byte // per-field column data for binary_compressed
+	closed bool
+}
+
+// NewWriter writes the header h to w and returns a Writer for its points. Only the x, y, z, rgb, rgba and
+// intensity fields may be declared.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	if err := h.validate(); err != nil {
+		return nil, err
+	}
+	for _, f := range h.Fields {
+		switch f.Name {
+		case "x", "y", "z", "rgb", "rgba", "intensity":
+		default:
+			return nil, fmt.Errorf("cannot write PCD field %q", f.Name)
+		}
+		if f.Count != 1 {
+			return nil, fmt.Errorf("cannot write PCD field %q with count %d", f.Name, f.Count)
+		}
+	}
+	pw := &Writer{w: bufio.NewWriter(w), header: h, buf: make([]byte, 0, h.pointSize())}
+	if h.Format == FormatBinaryCompressed {
+		pw.cols = make([][]byte, len(h.Fields))
+	}
+	if err := h.write(pw.w); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// Write appends p.
+func (w *Writer) Write(p Point) error {
+	if w.closed {
+		return errors.New("write to closed PCD writer")
+	}
+	if w.n >= w.header.Points {
+		return fmt.Errorf("PCD header declares %d points", w.header.Points)
+	}
+	w.n++
+	switch w.header.Format {
+	case FormatASCII:
+		w.buf = w.buf[:0]
+		for i, f := range w.header.Fields {
+			if i > 0 {
+				w.buf = append(w.buf, ' ')
+			}
+			if f.Name == "rgb" || f.Name == "rgba" {
+				w.buf = appendColorText(w.buf, f, p.RGB)
+				continue
+			}
+			w.buf = strconv.AppendFloat(w.buf, value(p, f.Name), 'g', -1, f.Size*8)
+		}
+		w.buf = append(w.buf, '\n')
+		_, err := w.w.Write(w.buf)
+		return err
+	case FormatBinary:
+		w.buf = w.buf[:0]
+		for _, f := range w.header.Fields {
+			w.buf = appendField(w.buf, f, p)
+		}
+		_, err := w.w.Write(w.buf)
+		return err
+	default:
+		for i, f := range w.header.Fields {
+			w.cols[i] = appendField(w.cols[i], f, p)
+		}
+		return nil
+	}
+}
+
+// Close writes any buffered data and flushes the underlying writer. It does not close the writer passed
+// to NewWriter.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.n != w.header.Points {
+		return fmt.Errorf("PCD header declares %d points but %d were written", w.header.Points, w.n)
+	}
+	if w.header.Format == FormatBinaryCompressed {
+		raw := bytes.Join(w.cols, nil)
+		compressed := lzfCompress(raw)
+		var sizes [8]byte
+		binary.LittleEndian.PutUint32(sizes[:4], uint32(len(compressed)))
+		binary.LittleEndian.PutUint32(sizes[4:], uint32(len(raw)))
+		if _, err := w.w.Write(sizes[:]); err != nil {
+			return err
+		}
+		if _, err := w.w.Write(compressed); err != nil {
+			return err
+		}
+	}
+	return w.w.Flush()
+}
+
+// Encode returns points encoded as a PCD file with the given format and fields.
+func Encode(format Format, points []Point, fields ...Field) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, NewHeader(format, len(points), fields...))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range points {
+		if err := w.Write(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func value(p Point, name string) float64 {
+	switch name {
+	case "x":
+		return p.X
+	case "y":
+		return p.Y
+	case "z":
+		return p.Z
+	default:
+		return p.Intensity
+	}
+}
+
+func appendColorText(b []byte, f Field, rgb uint32) []byte {
+	// Like PCL, float colors are written as the integer of their bits, since opaque colors are NaN as floats.
+	if f.Type == 'I' && f.Size == 4 {
+		return strconv.AppendInt(b, int64(int32(rgb)), 10)
+	}
+	return strconv.AppendUint(b, uint64(rgb), 10)
+}
+
+func appendField(b []byte, f Field, p Point) []byte {
+	if f.Name == "rgb" || f.Name == "rgba" {
+		switch f.Size {
+		case 1:
+			return append(b, byte(p.RGB))
+		case 2:
+			return binary.LittleEndian.AppendUint16(b, uint16(p.RGB))
+		case 4:
+			return binary.LittleEndian.AppendUint32(b, p.RGB)
+		default:
+			return binary.LittleEndian.AppendUint64(b, uint64(p.RGB))
+		}
+	}
+	v := value(p, f.Name)
+	switch f.Type {
+	case 'F':
+		if f.Size == 4 {
+			return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v)))
+		}
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	case 'I':
+		return appendInt(b, f.Size, uint64(int64(v)))
+	default:
+		return appendInt(b, f.Size, uint64(v))
+	}
+}
+
+func appendInt(b []byte, size int, v uint64) []byte {
+	switch size {
+	case 1:
+		return append(b, byte(v))
+	case 2:
+		return binary.LittleEndian.AppendUint16(b, uint16(v))
+	case 4:
+		return binary.LittleEndian.AppendUint32(b, uint32(v))
+	default:
+		return binary.LittleEndian.AppendUint64(b, v)
+	}
+}
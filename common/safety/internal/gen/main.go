@@ -0,0 +1,91 @@
+// Command gen writes the table of methods marked with the safety_heartbeat_monitored option. It links in
+// every component, service and robot package so that their descriptors are registered, then walks the
+// global registry.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	commonpb "go.viam.com/api/common/v1"
+	_ "go.viam.com/api/component/arm/v1"
+	_ "go.viam.com/api/component/audioin/v1"
+	_ "go.viam.com/api/component/audioinput/v1"
+	_ "go.viam.com/api/component/audioout/v1"
+	_ "go.viam.com/api/component/base/v1"
+	_ "go.viam.com/api/component/board/v1"
+	_ "go.viam.com/api/component/button/v1"
+	_ "go.viam.com/api/component/camera/v1"
+	_ "go.viam.com/api/component/encoder/v1"
+	_ "go.viam.com/api/component/gantry/v1"
+	_ "go.viam.com/api/component/generic/v1"
+	_ "go.viam.com/api/component/gripper/v1"
+	_ "go.viam.com/api/component/inputcontroller/v1"
+	_ "go.viam.com/api/component/motor/v1"
+	_ "go.viam.com/api/component/movementsensor/v1"
+	_ "go.viam.com/api/component/posetracker/v1"
+	_ "go.viam.com/api/component/powersensor/v1"
+	_ "go.viam.com/api/component/sensor/v1"
+	_ "go.viam.com/api/component/servo/v1"
+	_ "go.viam.com/api/component/switch/v1"
+	_ "go.viam.com/api/component/testecho/v1"
+	_ "go.viam.com/api/robot/v1"
+	_ "go.viam.com/api/service/datamanager/v1"
+	_ "go.viam.com/api/service/discovery/v1"
+	_ "go.viam.com/api/service/generic/v1"
+	_ "go.viam.com/api/service/mlmodel/v1"
+	_ "go.viam.com/api/service/motion/v1"
+	_ "go.viam.com/api/service/navigation/v1"
+	_ "go.viam.com/api/service/sensors/v1"
+	_ "go.viam.com/api/service/shell/v1"
+	_ "go.viam.com/api/service/slam/v1"
+	_ "go.viam.com/api/service/video/v1"
+	_ "go.viam.com/api/service/vision/v1"
+	_ "go.viam.com/api/service/worldstatestore/v1"
+)
+
+func main() {
+	out := flag.String("o", "monitored.go", "output file")
+	flag.Parse()
+
+	var methods []string
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				if proto.GetExtension(md.Options(), commonpb.E_SafetyHeartbeatMonitored).(bool) {
+					methods = append(methods, fmt.Sprintf("/%s/%s", sd.FullName(), md.Name()))
+				}
+			}
+		}
+		return true
+	})
+	slices.Sort(methods)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go run ./internal/gen. DO NOT EDIT.\n\npackage safety\n\n")
+	buf.WriteString("// MonitoredMethods is the set of full method names marked with the safety_heartbeat_monitored option.\n")
+	buf.WriteString("var MonitoredMethods = map[string]bool{\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%q: true,\n", m)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,26 @@
+// Code generated by go run ./internal/gen. DO NOT EDIT.
+
+package safety
+
+// MonitoredMethods is the set of full method names marked with the safety_heartbeat_monitored option.
+var MonitoredMethods = map[string]bool{
+	"/viam.component.arm.v1.ArmService/MoveThroughJointPositions": true,
+	"/viam.component.arm.v1.ArmService/MoveToJointPositions":      true,
+	"/viam.component.arm.v1.ArmService/MoveToPosition":            true,
+	"/viam.component.base.v1.BaseService/MoveStraight":            true,
+	"/viam.component.base.v1.BaseService/SetPower":                true,
+	"/viam.component.base.v1.BaseService/SetVelocity":             true,
+	"/viam.component.base.v1.BaseService/Spin":                    true,
+	"/viam.component.button.v1.ButtonService/Push":                true,
+	"/viam.component.gantry.v1.GantryService/MoveToPosition":      true,
+	"/viam.component.gripper.v1.GripperService/GoToInputs":        true,
+	"/viam.component.gripper.v1.GripperService/Grab":              true,
+	"/viam.component.gripper.v1.GripperService/Open":              true,
+	"/viam.component.motor.v1.MotorService/GoFor":                 true,
+	"/viam.component.motor.v1.MotorService/GoTo":                  true,
+	"/viam.component.motor.v1.MotorService/SetPower":              true,
+	"/viam.component.motor.v1.MotorService/SetRPM":                true,
+	"/viam.component.servo.v1.ServoService/Move":                  true,
+	"/viam.component.switch.v1.SwitchService/SetPosition":         true,
+	"/viam.component.testecho.v1.TestEchoService/EchoMultiple":    true,
+}
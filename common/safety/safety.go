@@ -0,0 +1,191 @@
+// Package safety enforces the common.v1 safety_heartbeat_monitored method option.
+//
+// Calls to a monitored method are tied to the session that made them, so that the resource can be stopped
+// when the session's heartbeats are lost. The server interceptors here reject monitored calls that do not
+// carry an active session, and the client interceptors warn about, or refuse, monitored calls made without
+// one.
+package safety
+
+//go:generate go run ./internal/gen -o monitored.go
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/api/robot/session"
+)
+
+var lookups sync.Map
+
+// Monitored reports whether the full method name, of the form /package.Service/Method, is marked with the
+// safety_heartbeat_monitored option. Methods whose descriptors are registered are looked up through
+// protoregistry; any others fall back to MonitoredMethods.
+func Monitored(fullMethod string) bool {
+	if v, ok := lookups.Load(fullMethod); ok {
+		return v.(bool)
+	}
+	monitored, ok := lookup(fullMethod)
+	if !ok {
+		monitored = MonitoredMethods[fullMethod]
+	}
+	lookups.Store(fullMethod, monitored)
+	return monitored
+}
+
+func lookup(fullMethod string) (bool, bool) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return false, false
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return false, false
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return false, false
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return false, false
+	}
+	return proto.GetExtension(md.Options(), commonpb.E_SafetyHeartbeatMonitored).(bool), true
+}
+
+// SessionActive reports whether the session with the given id is active on the server.
+type SessionActive func(ctx context.Context, id string) bool
+
+// UnaryServerInterceptor rejects calls to monitored methods that do not carry an active session. Calls
+// with no session fail with FailedPrecondition; calls with an unknown or expired session fail with
+// InvalidArgument and a SESSION_EXPIRED message, matching SendSessionHeartbeat.
+func UnaryServerInterceptor(active SessionActive) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkIncoming(ctx, info.FullMethod, active); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(active SessionActive) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkIncoming(ss.Context(), info.FullMethod, active); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkIncoming(ctx context.Context, method string, active SessionActive) error {
+	if !Monitored(method) {
+		return nil
+	}
+	id := sessionID(metadata.FromIncomingContext(ctx))
+	if id == "" {
+		return status.Errorf(codes.FailedPrecondition, "%s is safety heartbeat monitored and requires a session", method)
+	}
+	if !active(ctx, id) {
+		return status.Error(codes.InvalidArgument, "SESSION_EXPIRED")
+	}
+	return nil
+}
+
+// ClientOption configures the client interceptors.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	failFast bool
+	warn     func(method string)
+}
+
+// FailFast makes monitored calls without a session fail with FailedPrecondition instead of being sent.
+func FailFast() ClientOption {
+	return func(o *clientOptions) {
+		o.failFast = true
+	}
+}
+
+// WithWarning replaces the default warning, which is logged once per method with the standard logger.
+func WithWarning(warn func(method string)) ClientOption {
+	return func(o *clientOptions) {
+		o.warn = warn
+	}
+}
+
+// UnaryClientInterceptor checks that calls to monitored methods carry a session id. By default a warning is
+// logged and the call proceeds. It must run after any interceptor that attaches the session, such as
+// session.Manager.UnaryClientInterceptor, so it should be placed later in the chain.
+func UnaryClientInterceptor(opts ...ClientOption) grpc.UnaryClientInterceptor {
+	check := newClientCheck(opts)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption,
+	) error {
+		if err := check(ctx, method); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, callOpts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of UnaryClientInterceptor.
+func StreamClientInterceptor(opts ...ClientOption) grpc.StreamClientInterceptor {
+	check := newClientCheck(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, callOpts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if err := check(ctx, method); err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, callOpts...)
+	}
+}
+
+func newClientCheck(opts []ClientOption) func(ctx context.Context, method string) error {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.warn == nil {
+		var warned sync.Map
+		o.warn = func(method string) {
+			if _, loaded := warned.LoadOrStore(method, true); !loaded {
+				log.Printf("%s is safety heartbeat monitored but was called without a session", method)
+			}
+		}
+	}
+	return func(ctx context.Context, method string) error {
+		if !Monitored(method) {
+			return nil
+		}
+		if md, ok := metadata.FromOutgoingContext(ctx); ok && sessionID(md, true) != "" {
+			return nil
+		}
+		if o.failFast {
+			return status.Errorf(codes.FailedPrecondition, "%s is safety heartbeat monitored and requires a session", method)
+		}
+		o.warn(method)
+		return nil
+	}
+}
+
+func sessionID(md metadata.MD, ok bool) string {
+	if !ok {
+		return ""
+	}
+	if ids := md.Get(session.MetadataKey); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
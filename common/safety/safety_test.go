@@ -0,0 +1,135 @@
+package safety
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	_ "go.viam.com/api/component/arm/v1"
+	_ "go.viam.com/api/component/base/v1"
+	"go.viam.com/api/robot/session"
+)
+
+const (
+	monitored   = "/viam.component.arm.v1.ArmService/MoveToPosition"
+	unmonitored = "/viam.component.arm.v1.ArmService/GetEndPosition"
+)
+
+func TestMonitored(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		want   bool
+	}{
+		{monitored, true},
+		{"/viam.component.base.v1.BaseService/Spin", true},
+		{unmonitored, false},
+		{"/viam.component.arm.v1.ArmService/NoSuchMethod", false},
+		// Not registered in this binary, so the generated table answers.
+		{"/viam.component.servo.v1.ServoService/Move", true},
+		{"/viam.component.servo.v1.ServoService/GetPosition", false},
+		{"malformed", false},
+	} {
+		if got := Monitored(tc.method); got != tc.want {
+			t.Errorf("Monitored(%q) = %v, want %v", tc.method, got, tc.want)
+		}
+	}
+}
+
+func TestMonitoredMethodsMatchRegistry(t *testing.T) {
+	for method := range MonitoredMethods {
+		if monitored, ok := lookup(method); ok && !monitored {
+			t.Errorf("%s is in MonitoredMethods but its descriptor is not marked", method)
+		}
+	}
+}
+
+func incoming(id string) context.Context {
+	if id == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(session.MetadataKey, id))
+}
+
+func TestServerInterceptors(t *testing.T) {
+	active := func(_ context.Context, id string) bool { return id == "live" }
+	unary := UnaryServerInterceptor(active)
+	stream := StreamServerInterceptor(active)
+	for _, tc := range []struct {
+		name   string
+		method string
+		id     string
+		want   codes.Code
+	}{
+		{"unmonitored without session", unmonitored, "", codes.OK},
+		{"monitored with session", monitored, "live", codes.OK},
+		{"monitored without session", monitored, "", codes.FailedPrecondition},
+		{"monitored with expired session", monitored, "dead", codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := incoming(tc.id)
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method},
+				func(context.Context, any) (any, error) { return nil, nil })
+			if status.Code(err) != tc.want {
+				t.Errorf("unary: %v, want %v", err, tc.want)
+			}
+			if tc.want == codes.InvalidArgument && status.Convert(err).Message() != "SESSION_EXPIRED" {
+				t.Errorf("unary message = %q", status.Convert(err).Message())
+			}
+			err = stream(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tc.method},
+				func(any, grpc.ServerStream) error { return nil })
+			if status.Code(err) != tc.want {
+				t.Errorf("stream: %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+func TestClientInterceptors(t *testing.T) {
+	withSession := metadata.AppendToOutgoingContext(context.Background(), session.MetadataKey, "s")
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+
+	var warned []string
+	warn := UnaryClientInterceptor(WithWarning(func(method string) { warned = append(warned, method) }))
+	for _, call := range []struct {
+		ctx    context.Context
+		method string
+	}{
+		{context.Background(), unmonitored},
+		{withSession, monitored},
+		{context.Background(), monitored},
+		{context.Background(), monitored},
+	} {
+		if err := warn(call.ctx, call.method, nil, nil, nil, invoker); err != nil {
+			t.Errorf("%s: %v", call.method, err)
+		}
+	}
+	if len(warned) != 2 || warned[0] != monitored {
+		t.Errorf("warned about %q", warned)
+	}
+
+	strict := UnaryClientInterceptor(FailFast())
+	if err := strict(context.Background(), monitored, nil, nil, nil, invoker); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("fail fast without session: %v", err)
+	}
+	if err := strict(withSession, monitored, nil, nil, nil, invoker); err != nil {
+		t.Errorf("fail fast with session: %v", err)
+	}
+
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, nil
+	}
+	if _, err := StreamClientInterceptor(FailFast())(context.Background(), nil, nil, monitored, streamer); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("streaming fail fast without session: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package spatialmath
+
+import "math"
+
+// Orientation is any representation of a rotation in R3.
+type Orientation interface {
+	Quaternion() Quaternion
+}
+
+// NoOrientation is the absence of a rotation. It exists so that app.v1 no_orientation values survive a
+// round trip through this package.
+type NoOrientation struct{}
+
+// Quaternion returns the identity quaternion.
+func (NoOrientation) Quaternion() Quaternion {
+	return Identity()
+}
+
+// OrientationVector is an orientation vector with theta in radians. (OX, OY, OZ) is the point on the unit
+// sphere the local Z axis points at and Theta is the rotation about that axis. A zero vector is treated as
+// pointing along +Z, which is what an unset common.v1.Orientation means.
+type OrientationVector struct {
+	OX, OY, OZ, Theta float64
+}
+
+// OrientationVectorDegrees is an OrientationVector with theta in degrees, as used by common.v1.Pose.
+type OrientationVectorDegrees struct {
+	OX, OY, OZ, Theta float64
+}
+
+// EulerAngles are Tait-Bryan angles in radians applied in the intrinsic Z-Y-X (yaw, pitch, roll) sequence.
+type EulerAngles struct {
+	Roll, Pitch, Yaw float64
+}
+
+// AxisAngles is a rotation of Theta radians about the axis (RX, RY, RZ).
+type AxisAngles struct {
+	Theta, RX, RY, RZ float64
+}
+
+// Quaternion returns the rotation described by ov.
+func (ov OrientationVector) Quaternion() Quaternion {
+	o := Vector{ov.OX, ov.OY, ov.OZ}.Normalize()
+	if o == (Vector{}) {
+		o = Vector{Z: 1}
+	}
+	lon := 0.0
+	if math.Hypot(o.X, o.Y) > poleEpsilon {
+		lon = math.Atan2(o.Y, o.X)
+	}
+	lat := math.Acos(clamp(o.Z))
+	return axisRotation(lon, Vector{Z: 1}).
+		Mul(axisRotation(lat, Vector{Y: 1})).
+		Mul(axisRotation(ov.Theta, Vector{Z: 1})).
+		Normalize()
+}
+
+// Degrees returns ov with theta expressed in degrees.
+func (ov OrientationVector) Degrees() OrientationVectorDegrees {
+	return OrientationVectorDegrees{ov.OX, ov.OY, ov.OZ, ov.Theta * 180 / math.Pi}
+}
+
+// Quaternion returns the rotation described by ovd.
+func (ovd OrientationVectorDegrees) Quaternion() Quaternion {
+	return ovd.Radians().Quaternion()
+}
+
+// Radians returns ovd with theta expressed in radians.
+func (ovd OrientationVectorDegrees) Radians() OrientationVector {
+	return OrientationVector{ovd.OX, ovd.OY, ovd.OZ, ovd.Theta * math.Pi / 180}
+}
+
+// Quaternion returns the rotation described by ea.
+func (ea EulerAngles) Quaternion() Quaternion {
+	return axisRotation(ea.Yaw, Vector{Z: 1}).
+		Mul(axisRotation(ea.Pitch, Vector{Y: 1})).
+		Mul(axisRotation(ea.Roll, Vector{X: 1})).
+		Normalize()
+}
+
+// Quaternion returns the rotation described by aa. A zero axis is treated as no rotation.
+func (aa AxisAngles) Quaternion() Quaternion {
+	axis := Vector{aa.RX, aa.RY, aa.RZ}.Normalize()
+	if axis == (Vector{}) {
+		return Identity()
+	}
+	return axisRotation(aa.Theta, axis).Normalize()
+}
+
+// OrientationVector returns q as an orientation vector.
+//
+// When the local Z axis points straight up or down, longitude and theta describe the same rotation. In that
+// case the longitude is taken to be zero and the whole rotation about Z is reported as theta, matching the
+// behaviour described on common.v1.Pose.
+func (q Quaternion) OrientationVector() OrientationVector {
+	m := q.Normalize().matrix()
+	ov := OrientationVector{OX: m[0][2], OY: m[1][2], OZ: m[2][2]}
+	switch {
+	case math.Hypot(m[2][0], m[2][1]) > poleEpsilon:
+		ov.Theta = math.Atan2(m[2][1], -m[2][0])
+	case m[2][2] > 0:
+		// North pole: R = Rz(theta).
+		ov.Theta = math.Atan2(m[1][0], m[0][0])
+		ov.OX, ov.OY, ov.OZ = 0, 0, 1
+	default:
+		// South pole: R = Ry(pi) * Rz(theta).
+		ov.Theta = math.Atan2(m[1][0], m[1][1])
+		ov.OX, ov.OY, ov.OZ = 0, 0, -1
+	}
+	return ov
+}
+
+// OrientationVectorDegrees returns q as an orientation vector with theta in degrees.
+func (q Quaternion) OrientationVectorDegrees() OrientationVectorDegrees {
+	return q.OrientationVector().Degrees()
+}
+
+// EulerAngles returns q as intrinsic Z-Y-X Euler angles.
+//
+// At a pitch of +/-90 degrees roll and yaw rotate about the same axis (gimbal lock). In that case roll is
+// taken to be zero and the combined rotation is reported as yaw.
+func (q Quaternion) EulerAngles() EulerAngles {
+	m := q.Normalize().matrix()
+	ea := EulerAngles{Pitch: math.Asin(clamp(-m[2][0]))}
+	if math.Hypot(m[2][1], m[2][2]) > poleEpsilon {
+		ea.Roll = math.Atan2(m[2][1], m[2][2])
+		ea.Yaw = math.Atan2(m[1][0], m[0][0])
+	} else {
+		ea.Yaw = math.Atan2(-m[0][1], m[1][1])
+	}
+	return ea
+}
+
+// AxisAngles returns q as a rotation about a unit axis with theta in [0, pi]. The identity rotation is
+// reported about +Z.
+func (q Quaternion) AxisAngles() AxisAngles {
+	q = q.Normalize()
+	v := Vector{q.X, q.Y, q.Z}
+	n := v.Norm()
+	if n == 0 {
+		return AxisAngles{RZ: 1}
+	}
+	v = v.Mul(1 / n)
+	return AxisAngles{Theta: 2 * math.Atan2(n, q.W), RX: v.X, RY: v.Y, RZ: v.Z}
+}
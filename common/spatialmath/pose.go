@@ -0,0 +1,53 @@
+package spatialmath
+
+// Pose is a rigid transform: a rotation followed by a translation in millimeters.
+type Pose struct {
+	Point       Vector
+	Orientation Quaternion
+}
+
+// NewPose returns the pose at point with orientation o. A nil orientation is the identity.
+func NewPose(point Vector, o Orientation) Pose {
+	if o == nil {
+		o = Identity()
+	}
+	return Pose{Point: point, Orientation: o.Quaternion().Normalize()}
+}
+
+// NewZeroPose returns the identity pose.
+func NewZeroPose() Pose {
+	return Pose{Orientation: Identity()}
+}
+
+// Compose returns the pose obtained by applying b in the frame of a, i.e. a*b.
+func Compose(a, b Pose) Pose {
+	return Pose{
+		Point:       a.Point.Add(a.Orientation.Rotate(b.Point)),
+		Orientation: a.Orientation.Mul(b.Orientation).Normalize(),
+	}
+}
+
+// PoseBetween returns the pose of b expressed in the frame of a, so that Compose(a, PoseBetween(a, b)) == b.
+func PoseBetween(a, b Pose) Pose {
+	return Compose(a.Invert(), b)
+}
+
+// Invert returns the pose that undoes p.
+func (p Pose) Invert() Pose {
+	inv := p.Orientation.Conj()
+	return Pose{
+		Point:       inv.Rotate(p.Point).Mul(-1),
+		Orientation: inv.Normalize(),
+	}
+}
+
+// Transform maps v from the frame described by p into the parent frame of p.
+func (p Pose) Transform(v Vector) Vector {
+	return p.Point.Add(p.Orientation.Rotate(v))
+}
+
+// AlmostEqual reports whether p and q agree to within pointTol millimeters and rotTol, where rotTol is
+// compared against 1-|<p, q>| of the two orientation quaternions.
+func (p Pose) AlmostEqual(q Pose, pointTol, rotTol float64) bool {
+	return p.Point.Sub(q.Point).Norm() <= pointTol && p.Orientation.AlmostEqual(q.Orientation, rotTol)
+}
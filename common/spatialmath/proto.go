@@ -0,0 +1,107 @@
+package spatialmath
+
+import (
+	"fmt"
+
+	apppb "go.viam.com/api/app/v1"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// PoseFromProto converts a common.v1.Pose. A nil pose is the identity.
+func PoseFromProto(p *commonpb.Pose) Pose {
+	if p == nil {
+		return NewZeroPose()
+	}
+	ov := OrientationVectorDegrees{OX: p.GetOX(), OY: p.GetOY(), OZ: p.GetOZ(), Theta: p.GetTheta()}
+	return NewPose(Vector{p.GetX(), p.GetY(), p.GetZ()}, ov)
+}
+
+// PoseToProto converts p to a common.v1.Pose.
+func PoseToProto(p Pose) *commonpb.Pose {
+	ov := p.Orientation.OrientationVectorDegrees()
+	return &commonpb.Pose{
+		X:     p.Point.X,
+		Y:     p.Point.Y,
+		Z:     p.Point.Z,
+		OX:    ov.OX,
+		OY:    ov.OY,
+		OZ:    ov.OZ,
+		Theta: ov.Theta,
+	}
+}
+
+// OrientationFromProto converts a common.v1.Orientation. A nil orientation points along +Z.
+func OrientationFromProto(o *commonpb.Orientation) OrientationVectorDegrees {
+	if o == nil {
+		return OrientationVectorDegrees{OZ: 1}
+	}
+	return OrientationVectorDegrees{OX: o.GetOX(), OY: o.GetOY(), OZ: o.GetOZ(), Theta: o.GetTheta()}
+}
+
+// OrientationToProto converts o to a common.v1.Orientation.
+func OrientationToProto(o Orientation) *commonpb.Orientation {
+	ov, ok := o.(OrientationVectorDegrees)
+	if !ok {
+		ov = o.Quaternion().OrientationVectorDegrees()
+	}
+	return &commonpb.Orientation{OX: ov.OX, OY: ov.OY, OZ: ov.OZ, Theta: ov.Theta}
+}
+
+// OrientationFromAppProto converts an app.v1.Orientation to the matching concrete type in this package, so
+// that OrientationToAppProto returns the same oneof variant. A nil orientation or an unset oneof is treated
+// as NoOrientation.
+func OrientationFromAppProto(o *apppb.Orientation) (Orientation, error) {
+	switch t := o.GetType().(type) {
+	case nil, *apppb.Orientation_NoOrientation_:
+		return NoOrientation{}, nil
+	case *apppb.Orientation_VectorRadians:
+		v := t.VectorRadians
+		return OrientationVector{OX: v.GetX(), OY: v.GetY(), OZ: v.GetZ(), Theta: v.GetTheta()}, nil
+	case *apppb.Orientation_VectorDegrees:
+		v := t.VectorDegrees
+		return OrientationVectorDegrees{OX: v.GetX(), OY: v.GetY(), OZ: v.GetZ(), Theta: v.GetTheta()}, nil
+	case *apppb.Orientation_EulerAngles_:
+		v := t.EulerAngles
+		return EulerAngles{Roll: v.GetRoll(), Pitch: v.GetPitch(), Yaw: v.GetYaw()}, nil
+	case *apppb.Orientation_AxisAngles_:
+		v := t.AxisAngles
+		return AxisAngles{Theta: v.GetTheta(), RX: v.GetX(), RY: v.GetY(), RZ: v.GetZ()}, nil
+	case *apppb.Orientation_Quaternion_:
+		v := t.Quaternion
+		return Quaternion{W: v.GetW(), X: v.GetX(), Y: v.GetY(), Z: v.GetZ()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported orientation type %T", t)
+	}
+}
+
+// OrientationToAppProto converts o to an app.v1.Orientation using the oneof variant matching its concrete
+// type. Orientation implementations from outside this package are sent as quaternions.
+func OrientationToAppProto(o Orientation) *apppb.Orientation {
+	switch v := o.(type) {
+	case nil, NoOrientation:
+		return &apppb.Orientation{Type: &apppb.Orientation_NoOrientation_{
+			NoOrientation: &apppb.Orientation_NoOrientation{},
+		}}
+	case OrientationVector:
+		return &apppb.Orientation{Type: &apppb.Orientation_VectorRadians{
+			VectorRadians: &apppb.Orientation_OrientationVectorRadians{Theta: v.Theta, X: v.OX, Y: v.OY, Z: v.OZ},
+		}}
+	case OrientationVectorDegrees:
+		return &apppb.Orientation{Type: &apppb.Orientation_VectorDegrees{
+			VectorDegrees: &apppb.Orientation_OrientationVectorDegrees{Theta: v.Theta, X: v.OX, Y: v.OY, Z: v.OZ},
+		}}
+	case EulerAngles:
+		return &apppb.Orientation{Type: &apppb.Orientation_EulerAngles_{
+			EulerAngles: &apppb.Orientation_EulerAngles{Roll: v.Roll, Pitch: v.Pitch, Yaw: v.Yaw},
+		}}
+	case AxisAngles:
+		return &apppb.Orientation{Type: &apppb.Orientation_AxisAngles_{
+			AxisAngles: &apppb.Orientation_AxisAngles{Theta: v.Theta, X: v.RX, Y: v.RY, Z: v.RZ},
+		}}
+	default:
+		q := o.Quaternion()
+		return &apppb.Orientation{Type: &apppb.Orientation_Quaternion_{
+			Quaternion: &apppb.Orientation_Quaternion{W: q.W, X: q.X, Y: q.Y, Z: q.Z},
+		}}
+	}
+}
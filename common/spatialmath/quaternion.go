@@ -0,0 +1,145 @@
+// Package spatialmath converts between the orientation representations used by the API and composes poses.
+//
+// Every orientation is reduced to a unit Quaternion, which is the representation used for all composition
+// and inversion. The orientation vector described on common.v1.Pose is treated as a ZYZ rotation: the
+// pointing direction (o_x, o_y, o_z) is reached by rotating about Z by the longitude and about Y by the
+// latitude of that point, after which theta rotates about the new local Z axis.
+package spatialmath
+
+import "math"
+
+// poleEpsilon is how close sin(latitude) may get to zero before a pointing direction is considered to lie on
+// the north or south pole of the unit sphere, where longitude and theta can no longer be told apart.
+const poleEpsilon = 1e-9
+
+// Vector is a point or direction in R3. For poses the units are millimeters.
+type Vector struct {
+	X, Y, Z float64
+}
+
+// Add returns v+w.
+func (v Vector) Add(w Vector) Vector {
+	return Vector{v.X + w.X, v.Y + w.Y, v.Z + w.Z}
+}
+
+// Sub returns v-w.
+func (v Vector) Sub(w Vector) Vector {
+	return Vector{v.X - w.X, v.Y - w.Y, v.Z - w.Z}
+}
+
+// Mul returns v scaled by s.
+func (v Vector) Mul(s float64) Vector {
+	return Vector{v.X * s, v.Y * s, v.Z * s}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vector) Dot(w Vector) float64 {
+	return v.X*w.X + v.Y*w.Y + v.Z*w.Z
+}
+
+// Cross returns the cross product of v and w.
+func (v Vector) Cross(w Vector) Vector {
+	return Vector{
+		v.Y*w.Z - v.Z*w.Y,
+		v.Z*w.X - v.X*w.Z,
+		v.X*w.Y - v.Y*w.X,
+	}
+}
+
+// Norm returns the length of v.
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Normalize returns v scaled to unit length. The zero vector is returned unchanged.
+func (v Vector) Normalize() Vector {
+	n := v.Norm()
+	if n == 0 {
+		return v
+	}
+	return v.Mul(1 / n)
+}
+
+// Quaternion is a rotation expressed as w + xi + yj + zk.
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// Identity returns the quaternion representing no rotation.
+func Identity() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// Quaternion returns q, allowing a Quaternion to be used as an Orientation.
+func (q Quaternion) Quaternion() Quaternion {
+	return q
+}
+
+// Mul returns the Hamilton product q*r, which applies r first and then q.
+func (q Quaternion) Mul(r Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*r.W - q.X*r.X - q.Y*r.Y - q.Z*r.Z,
+		X: q.W*r.X + q.X*r.W + q.Y*r.Z - q.Z*r.Y,
+		Y: q.W*r.Y - q.X*r.Z + q.Y*r.W + q.Z*r.X,
+		Z: q.W*r.Z + q.X*r.Y - q.Y*r.X + q.Z*r.W,
+	}
+}
+
+// Conj returns the conjugate of q. For a unit quaternion this is its inverse.
+func (q Quaternion) Conj() Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+// Norm returns the length of q.
+func (q Quaternion) Norm() float64 {
+	return math.Sqrt(q.W*q.W + q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+}
+
+// Normalize returns q scaled to unit length with a non-negative real part, so that the two quaternions
+// describing the same rotation normalize to the same value. The zero quaternion normalizes to the identity.
+func (q Quaternion) Normalize() Quaternion {
+	n := q.Norm()
+	if n == 0 {
+		return Identity()
+	}
+	if q.W < 0 {
+		n = -n
+	}
+	return Quaternion{q.W / n, q.X / n, q.Y / n, q.Z / n}
+}
+
+// Rotate applies the rotation q to v.
+func (q Quaternion) Rotate(v Vector) Vector {
+	// v' = v + 2w(u x v) + 2(u x (u x v)), where u is the vector part of q.
+	u := Vector{q.X, q.Y, q.Z}
+	t := u.Cross(v).Mul(2)
+	return v.Add(t.Mul(q.W)).Add(u.Cross(t))
+}
+
+// AlmostEqual reports whether q and r describe the same rotation to within tol, treating q and -q as equal.
+func (q Quaternion) AlmostEqual(r Quaternion, tol float64) bool {
+	q, r = q.Normalize(), r.Normalize()
+	dot := q.W*r.W + q.X*r.X + q.Y*r.Y + q.Z*r.Z
+	return 1-math.Abs(dot) <= tol
+}
+
+// matrix returns the row-major rotation matrix of the unit quaternion q.
+func (q Quaternion) matrix() [3][3]float64 {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// axisRotation returns the quaternion rotating by theta radians about the given unit axis.
+func axisRotation(theta float64, axis Vector) Quaternion {
+	s, c := math.Sincos(theta / 2)
+	return Quaternion{c, axis.X * s, axis.Y * s, axis.Z * s}
+}
+
+// clamp limits v to [-1, 1] so that floating point error does not push it outside the domain of asin or acos.
+func clamp(v float64) float64 {
+	return math.Max(-1, math.Min(1, v))
+}
@@ -0,0 +1,184 @@
+package spatialmath
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	apppb "go.viam.com/api/app/v1"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+const tol = 1e-9
+
+func vectorsClose(a, b Vector) bool {
+	return a.Sub(b).Norm() <= 1e-9
+}
+
+func TestOrientationVectorPointsLocalZ(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ov   OrientationVectorDegrees
+		want Vector
+	}{
+		{"default", OrientationVectorDegrees{OZ: 1}, Vector{Z: 1}},
+		{"zero vector", OrientationVectorDegrees{}, Vector{Z: 1}},
+		{"x", OrientationVectorDegrees{OX: 1}, Vector{X: 1}},
+		{"unnormalized y", OrientationVectorDegrees{OY: 3, Theta: 30}, Vector{Y: 1}},
+		{"down", OrientationVectorDegrees{OZ: -1, Theta: 45}, Vector{Z: -1}},
+		{"diagonal", OrientationVectorDegrees{OX: 1, OY: 1, OZ: 1, Theta: -60}, Vector{1, 1, 1}.Normalize()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ov.Quaternion().Rotate(Vector{Z: 1}); !vectorsClose(got, tc.want) {
+				t.Errorf("local Z = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestThetaRotatesAboutLocalZ(t *testing.T) {
+	q := OrientationVectorDegrees{OZ: 1, Theta: 90}.Quaternion()
+	if got := q.Rotate(Vector{X: 1}); !vectorsClose(got, Vector{Y: 1}) {
+		t.Errorf("rotating +X by theta 90 gives %v, want +Y", got)
+	}
+}
+
+func TestOrientationRoundTrips(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		o    Orientation
+	}{
+		{"identity", Identity()},
+		{"ov", OrientationVector{OX: 0.2, OY: -0.5, OZ: 0.8, Theta: 1.1}},
+		{"ov north pole", OrientationVector{OZ: 1, Theta: -2}},
+		{"ov south pole", OrientationVector{OZ: -1, Theta: 0.7}},
+		{"euler", EulerAngles{Roll: 0.3, Pitch: -0.4, Yaw: 2.5}},
+		{"euler gimbal lock", EulerAngles{Pitch: math.Pi / 2, Yaw: 0.6}},
+		{"axis angles", AxisAngles{Theta: 2.2, RX: 1, RY: 2, RZ: -1}},
+		{"quaternion", Quaternion{W: -0.5, X: 0.5, Y: 0.5, Z: -0.5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.o.Quaternion()
+			for _, back := range []Orientation{
+				q.OrientationVector(),
+				q.OrientationVectorDegrees(),
+				q.EulerAngles(),
+				q.AxisAngles(),
+			} {
+				if got := back.Quaternion(); !got.AlmostEqual(q, tol) {
+					t.Errorf("%T round trip = %v, want %v", back, got, q)
+				}
+			}
+		})
+	}
+}
+
+func TestAmbiguousConversions(t *testing.T) {
+	// At the poles, the whole rotation about Z is reported as theta.
+	if got := (OrientationVector{OZ: 1, Theta: 0.5}).Quaternion().OrientationVector(); got.OX != 0 || got.OY != 0 ||
+		got.OZ != 1 || math.Abs(got.Theta-0.5) > tol {
+		t.Errorf("north pole = %+v", got)
+	}
+	if got := (OrientationVector{OZ: -1, Theta: 0.5}).Quaternion().OrientationVector(); got.OX != 0 || got.OY != 0 ||
+		got.OZ != -1 || math.Abs(got.Theta-0.5) > tol {
+		t.Errorf("south pole = %+v", got)
+	}
+	ea := EulerAngles{Roll: 0.2, Pitch: math.Pi / 2, Yaw: 0.5}.Quaternion().EulerAngles()
+	if ea.Roll != 0 {
+		t.Errorf("gimbal lock roll = %v, want 0", ea.Roll)
+	}
+	if aa := Identity().AxisAngles(); aa != (AxisAngles{RZ: 1}) {
+		t.Errorf("identity axis angles = %+v", aa)
+	}
+	if q := (AxisAngles{Theta: 1}).Quaternion(); q != Identity() {
+		t.Errorf("zero axis = %v, want identity", q)
+	}
+	if q := (Quaternion{}).Normalize(); q != Identity() {
+		t.Errorf("zero quaternion normalizes to %v", q)
+	}
+	if q := (Quaternion{W: -2}).Normalize(); q != Identity() {
+		t.Errorf("negative quaternion normalizes to %v", q)
+	}
+}
+
+func TestPoses(t *testing.T) {
+	a := NewPose(Vector{1, 2, 3}, OrientationVectorDegrees{OZ: 1, Theta: 90})
+	b := NewPose(Vector{10, 0, 0}, EulerAngles{Roll: 0.3})
+
+	ab := Compose(a, b)
+	if want := (Vector{1, 12, 3}); !vectorsClose(ab.Point, want) {
+		t.Errorf("Compose point = %v, want %v", ab.Point, want)
+	}
+	if got := Compose(a, PoseBetween(a, ab)); !got.AlmostEqual(ab, tol, tol) {
+		t.Errorf("Compose(a, PoseBetween(a, ab)) = %v, want %v", got, ab)
+	}
+	if got := Compose(a, a.Invert()); !got.AlmostEqual(NewZeroPose(), tol, tol) {
+		t.Errorf("a * a^-1 = %v, want identity", got)
+	}
+	if got := a.Transform(Vector{X: 1}); !vectorsClose(got, Vector{1, 3, 3}) {
+		t.Errorf("Transform = %v", got)
+	}
+	if p := NewPose(Vector{}, nil); p != NewZeroPose() {
+		t.Errorf("NewPose with no orientation = %v", p)
+	}
+}
+
+func TestPoseProto(t *testing.T) {
+	if p := PoseFromProto(nil); p != NewZeroPose() {
+		t.Errorf("PoseFromProto(nil) = %v", p)
+	}
+	in := &commonpb.Pose{X: 1, Y: -2, Z: 3, OX: 0, OY: 1, OZ: 0, Theta: 30}
+	out := PoseToProto(PoseFromProto(in))
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"x", out.X, in.X}, {"y", out.Y, in.Y}, {"z", out.Z, in.Z},
+		{"ox", out.OX, in.OX}, {"oy", out.OY, in.OY}, {"oz", out.OZ, in.OZ}, {"theta", out.Theta, in.Theta},
+	} {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	if o := OrientationFromProto(nil); o != (OrientationVectorDegrees{OZ: 1}) {
+		t.Errorf("OrientationFromProto(nil) = %v", o)
+	}
+	ovd := OrientationVectorDegrees{OX: 1, Theta: 10}
+	if got := OrientationToProto(ovd); got.GetOX() != 1 || got.GetTheta() != 10 {
+		t.Errorf("OrientationToProto kept %v as %v", ovd, got)
+	}
+	if got := OrientationToProto(Identity()); got.GetOZ() != 1 {
+		t.Errorf("OrientationToProto(identity) = %v", got)
+	}
+}
+
+func TestAppOrientationRoundTrip(t *testing.T) {
+	for _, o := range []Orientation{
+		NoOrientation{},
+		OrientationVector{OX: 1, Theta: 0.1},
+		OrientationVectorDegrees{OY: 1, Theta: 10},
+		EulerAngles{Roll: 1, Pitch: 2, Yaw: 3},
+		AxisAngles{Theta: 1, RZ: 1},
+		Quaternion{W: 1},
+	} {
+		msg := OrientationToAppProto(o)
+		back, err := OrientationFromAppProto(msg)
+		if err != nil {
+			t.Fatalf("%T: %v", o, err)
+		}
+		if back != o {
+			t.Errorf("%T round trip = %#v, want %#v", o, back, o)
+		}
+		if again := OrientationToAppProto(back); !proto.Equal(again, msg) {
+			t.Errorf("%T: %v, want %v", o, again, msg)
+		}
+	}
+	if o, err := OrientationFromAppProto(nil); err != nil || o != (NoOrientation{}) {
+		t.Errorf("OrientationFromAppProto(nil) = %v, %v", o, err)
+	}
+	if o, err := OrientationFromAppProto(&apppb.Orientation{}); err != nil || o != (NoOrientation{}) {
+		t.Errorf("OrientationFromAppProto(unset) = %v, %v", o, err)
+	}
+}
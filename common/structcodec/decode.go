@@ -0,0 +1,312 @@
+package structcodec
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var errNotPointer = errors.New("structcodec: Unmarshal target must be a non-nil pointer")
+
+// Unmarshal decodes s into the value pointed to by v. Keys of s with no matching struct field are ignored.
+func Unmarshal(s *structpb.Struct, v any) error {
+	return UnmarshalValue(structpb.NewStructValue(s), v)
+}
+
+// UnmarshalValue decodes val into the value pointed to by v.
+func UnmarshalValue(val *structpb.Value, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errNotPointer
+	}
+	return decode(val, rv.Elem(), "")
+}
+
+func decode(val *structpb.Value, v reflect.Value, path string) error {
+	fail := func(format string, args ...any) error {
+		return &DecodeError{Path: path, Type: v.Type(), Reason: fmt.Sprintf(format, args...)}
+	}
+	mismatch := func() error {
+		return fail("got %s", kindName(val))
+	}
+
+	switch v.Type() {
+	case structType:
+		if s := val.GetStructValue(); s != nil || isNull(val) {
+			v.Set(reflect.ValueOf(s))
+			return nil
+		}
+		return mismatch()
+	case valueType:
+		v.Set(reflect.ValueOf(val))
+		return nil
+	case listType:
+		if l := val.GetListValue(); l != nil || isNull(val) {
+			v.Set(reflect.ValueOf(l))
+			return nil
+		}
+		return mismatch()
+	}
+
+	if isNull(val) {
+		v.SetZero()
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		str, ok := val.GetKind().(*structpb.Value_StringValue)
+		if !ok {
+			return mismatch()
+		}
+		t, err := time.Parse(time.RFC3339Nano, str.StringValue)
+		if err != nil {
+			return fail("%v", err)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		switch k := val.GetKind().(type) {
+		case *structpb.Value_StringValue:
+			d, err := time.ParseDuration(k.StringValue)
+			if err != nil {
+				return fail("%v", err)
+			}
+			v.SetInt(int64(d))
+			return nil
+		case *structpb.Value_NumberValue:
+			ns := k.NumberValue * float64(time.Second)
+			if math.IsNaN(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+				return fail("%v seconds is out of range", k.NumberValue)
+			}
+			v.SetInt(int64(ns))
+			return nil
+		default:
+			return mismatch()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := val.GetKind().(*structpb.Value_BoolValue)
+		if !ok {
+			return mismatch()
+		}
+		v.SetBool(b.BoolValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := decodeInt(val)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if v.OverflowInt(i) {
+			return fail("%d is out of range", i)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := decodeUint(val)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if v.OverflowUint(u) {
+			return fail("%d is out of range", u)
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := decodeFloat(val)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if !math.IsInf(f, 0) && v.OverflowFloat(f) {
+			return fail("%v is out of range", f)
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		str, ok := val.GetKind().(*structpb.Value_StringValue)
+		if !ok {
+			return mismatch()
+		}
+		v.SetString(str.StringValue)
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decode(val, v.Elem(), path)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fail("non-empty interface")
+		}
+		v.Set(reflect.ValueOf(val.AsInterface()))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			str, ok := val.GetKind().(*structpb.Value_StringValue)
+			if !ok {
+				return mismatch()
+			}
+			b, err := base64.StdEncoding.DecodeString(str.StringValue)
+			if err != nil {
+				return fail("%v", err)
+			}
+			v.Set(reflect.ValueOf(b).Convert(v.Type()))
+			return nil
+		}
+		l := val.GetListValue()
+		if l == nil {
+			return mismatch()
+		}
+		s := reflect.MakeSlice(v.Type(), len(l.GetValues()), len(l.GetValues()))
+		for i, ev := range l.GetValues() {
+			if err := decode(ev, s.Index(i), elem(path, i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Array:
+		l := val.GetListValue()
+		if l == nil {
+			return mismatch()
+		}
+		if len(l.GetValues()) != v.Len() {
+			return fail("got a list of %d values", len(l.GetValues()))
+		}
+		for i, ev := range l.GetValues() {
+			if err := decode(ev, v.Index(i), elem(path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fail("map keys must be strings")
+		}
+		s := val.GetStructValue()
+		if s == nil {
+			return mismatch()
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(s.GetFields()))
+		for key, fv := range s.GetFields() {
+			ev := reflect.New(v.Type().Elem()).Elem()
+			if err := decode(fv, ev, child(path, key)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), ev)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		s := val.GetStructValue()
+		if s == nil {
+			return mismatch()
+		}
+		for _, f := range fieldsOf(v.Type()) {
+			fv, ok := s.GetFields()[f.name]
+			if !ok {
+				continue
+			}
+			if err := decode(fv, allocFieldByIndex(v, f.index), child(path, f.name)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fail("unsupported type")
+	}
+	return nil
+}
+
+// allocFieldByIndex is like reflect.Value.FieldByIndex but allocates nil embedded pointers on the way.
+func allocFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func decodeInt(val *structpb.Value) (int64, error) {
+	switch k := val.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		f := k.NumberValue
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("%v is not an integer", f)
+		}
+		if f >= math.MaxInt64 || f < math.MinInt64 {
+			return 0, fmt.Errorf("%v is out of range", f)
+		}
+		return int64(f), nil
+	case *structpb.Value_StringValue:
+		return strconv.ParseInt(k.StringValue, 10, 64)
+	default:
+		return 0, fmt.Errorf("got %s", kindName(val))
+	}
+}
+
+func decodeUint(val *structpb.Value) (uint64, error) {
+	switch k := val.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		f := k.NumberValue
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("%v is not an integer", f)
+		}
+		if f >= math.MaxUint64 || f < 0 {
+			return 0, fmt.Errorf("%v is out of range", f)
+		}
+		return uint64(f), nil
+	case *structpb.Value_StringValue:
+		return strconv.ParseUint(k.StringValue, 10, 64)
+	default:
+		return 0, fmt.Errorf("got %s", kindName(val))
+	}
+}
+
+func decodeFloat(val *structpb.Value) (float64, error) {
+	switch k := val.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		return k.NumberValue, nil
+	case *structpb.Value_StringValue:
+		switch k.StringValue {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+		return strconv.ParseFloat(k.StringValue, 64)
+	default:
+		return 0, fmt.Errorf("got %s", kindName(val))
+	}
+}
+
+func isNull(val *structpb.Value) bool {
+	switch val.GetKind().(type) {
+	case nil, *structpb.Value_NullValue:
+		return true
+	default:
+		return false
+	}
+}
+
+func kindName(val *structpb.Value) string {
+	switch val.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		return "bool"
+	case *structpb.Value_NumberValue:
+		return "number"
+	case *structpb.Value_StringValue:
+		return "string"
+	case *structpb.Value_ListValue:
+		return "list"
+	case *structpb.Value_StructValue:
+		return "struct"
+	default:
+		return "null"
+	}
+}
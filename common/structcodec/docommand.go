@@ -0,0 +1,31 @@
+package structcodec
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// DoCommander is implemented by every generated resource client.
+type DoCommander interface {
+	DoCommand(ctx context.Context, in *commonpb.DoCommandRequest, opts ...grpc.CallOption) (*commonpb.DoCommandResponse, error)
+}
+
+// Do sends req as the command of a DoCommand call to the named resource and decodes the result into a Resp.
+func Do[Req, Resp any](ctx context.Context, client DoCommander, name string, req Req, opts ...grpc.CallOption) (Resp, error) {
+	var resp Resp
+	cmd, err := Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+	out, err := client.DoCommand(ctx, &commonpb.DoCommandRequest{Name: name, Command: cmd}, opts...)
+	if err != nil {
+		return resp, err
+	}
+	if err := Unmarshal(out.GetResult(), &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,220 @@
+// Package structcodec converts between Go values and google.protobuf.Struct, the type used for the extra
+// field of most requests and for DoCommand and GetStatus payloads.
+//
+// Struct fields are named by a structpb tag, falling back to a json tag and then the Go field name, and
+// accept the omitempty option. Values are represented as follows:
+//
+//   - time.Time is an RFC 3339 string with nanoseconds.
+//   - time.Duration is a string in time.Duration.String form; numbers are also accepted as seconds.
+//   - []byte is a standard base64 string.
+//   - Integers that a float64 cannot hold exactly, beyond 2^53 in magnitude, are decimal strings. Decoding
+//     an integer rejects fractions and out of range values instead of truncating them.
+//   - float32 values are widened through their shortest decimal form, so 0.1 encodes as 0.1.
+//   - NaN and infinite floats are the strings "NaN", "Infinity" and "-Infinity", as in protojson.
+//   - Maps must have string keys and become nested Structs; slices and arrays become lists.
+//   - *structpb.Struct, *structpb.Value and *structpb.ListValue are passed through unchanged.
+package structcodec
+
+import (
+	"encoding/base64"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxExactInt is the largest magnitude up to which every integer is exactly representable as a float64.
+const maxExactInt = 1 << 53
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	structType    = reflect.TypeOf((*structpb.Struct)(nil))
+	valueType     = reflect.TypeOf((*structpb.Value)(nil))
+	listType      = reflect.TypeOf((*structpb.ListValue)(nil))
+	byteSliceType = reflect.TypeOf([]byte(nil))
+)
+
+// Marshal encodes v, which must be a struct, a map with string keys, or a non-nil pointer to either, as a
+// Struct.
+func Marshal(v any) (*structpb.Struct, error) {
+	val, err := encode(reflect.ValueOf(v), "")
+	if err != nil {
+		return nil, err
+	}
+	s := val.GetStructValue()
+	if s == nil {
+		return nil, &UnsupportedTypeError{Type: reflect.TypeOf(v)}
+	}
+	return s, nil
+}
+
+// MarshalValue encodes any supported Go value as a Value.
+func MarshalValue(v any) (*structpb.Value, error) {
+	return encode(reflect.ValueOf(v), "")
+}
+
+func encode(v reflect.Value, path string) (*structpb.Value, error) {
+	if !v.IsValid() {
+		return structpb.NewNullValue(), nil
+	}
+	switch v.Type() {
+	case timeType:
+		return structpb.NewStringValue(v.Interface().(time.Time).Format(time.RFC3339Nano)), nil
+	case durationType:
+		return structpb.NewStringValue(time.Duration(v.Int()).String()), nil
+	case structType:
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		return structpb.NewStructValue(v.Interface().(*structpb.Struct)), nil
+	case valueType:
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		return v.Interface().(*structpb.Value), nil
+	case listType:
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		return structpb.NewListValue(v.Interface().(*structpb.ListValue)), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return structpb.NewBoolValue(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.Int()
+		if i > maxExactInt || i < -maxExactInt {
+			return structpb.NewStringValue(strconv.FormatInt(i, 10)), nil
+		}
+		return structpb.NewNumberValue(float64(i)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if u > maxExactInt {
+			return structpb.NewStringValue(strconv.FormatUint(u, 10)), nil
+		}
+		return structpb.NewNumberValue(float64(u)), nil
+	case reflect.Float32:
+		// Widen through the shortest decimal form so that 0.1 stays 0.1 rather than 0.10000000149011612.
+		f, err := strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+		if err != nil {
+			f = v.Float()
+		}
+		return encodeFloat(f), nil
+	case reflect.Float64:
+		return encodeFloat(v.Float()), nil
+	case reflect.String:
+		return structpb.NewStringValue(v.String()), nil
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		return encode(v.Elem(), path)
+	case reflect.Slice:
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return structpb.NewStringValue(base64.StdEncoding.EncodeToString(v.Convert(byteSliceType).Bytes())), nil
+		}
+		return encodeList(v, path)
+	case reflect.Array:
+		return encodeList(v, path)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, &UnsupportedTypeError{Path: path, Type: v.Type()}
+		}
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		s := &structpb.Struct{Fields: make(map[string]*structpb.Value, v.Len())}
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			fv, err := encode(iter.Value(), child(path, key))
+			if err != nil {
+				return nil, err
+			}
+			s.Fields[key] = fv
+		}
+		return structpb.NewStructValue(s), nil
+	case reflect.Struct:
+		return encodeStruct(v, path)
+	default:
+		return nil, &UnsupportedTypeError{Path: path, Type: v.Type()}
+	}
+}
+
+func encodeFloat(f float64) *structpb.Value {
+	switch {
+	case math.IsNaN(f):
+		return structpb.NewStringValue("NaN")
+	case math.IsInf(f, 1):
+		return structpb.NewStringValue("Infinity")
+	case math.IsInf(f, -1):
+		return structpb.NewStringValue("-Infinity")
+	default:
+		return structpb.NewNumberValue(f)
+	}
+}
+
+func encodeList(v reflect.Value, path string) (*structpb.Value, error) {
+	l := &structpb.ListValue{Values: make([]*structpb.Value, v.Len())}
+	for i := range l.Values {
+		ev, err := encode(v.Index(i), elem(path, i))
+		if err != nil {
+			return nil, err
+		}
+		l.Values[i] = ev
+	}
+	return structpb.NewListValue(l), nil
+}
+
+func encodeStruct(v reflect.Value, path string) (*structpb.Value, error) {
+	fields := fieldsOf(v.Type())
+	s := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(fields))}
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmpty(fv)) {
+			continue
+		}
+		ev, err := encode(fv, child(path, f.name))
+		if err != nil {
+			return nil, err
+		}
+		s.Fields[f.name] = ev
+	}
+	return structpb.NewStructValue(s), nil
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead of panicking when it passes
+// through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface().(time.Time).IsZero()
+		}
+		return false
+	default:
+		return v.IsZero()
+	}
+}
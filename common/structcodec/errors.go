@@ -0,0 +1,46 @@
+package structcodec
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnsupportedTypeError is returned when a Go value has no structpb representation.
+type UnsupportedTypeError struct {
+	Path string
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("structcodec: %scannot encode %s", at(e.Path), e.Type)
+}
+
+// DecodeError is returned when a structpb value cannot be stored in a Go value.
+type DecodeError struct {
+	Path string
+	Type reflect.Type
+	// Reason describes the mismatch, e.g. "got string" or "1.5 is not an integer".
+	Reason string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("structcodec: %scannot decode into %s: %s", at(e.Path), e.Type, e.Reason)
+}
+
+func at(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + ": "
+}
+
+func child(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func elem(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
@@ -0,0 +1,94 @@
+package structcodec
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// field describes one encoded field of a Go struct.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var fieldCache sync.Map
+
+// fieldsOf returns the encoded fields of struct type t. Fields are named by their structpb tag, then their
+// json tag, then their Go name. Embedded structs without a tag name are flattened into their parent, and
+// shallower fields win over deeper ones with the same name, as in encoding/json. Fields of unexported
+// embedded pointers to structs are skipped.
+func fieldsOf(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field)
+	}
+	var fields []field
+	seen := map[string]bool{}
+	collect(t, nil, seen, &fields)
+	f, _ := fieldCache.LoadOrStore(t, fields)
+	return f.([]field)
+}
+
+func collect(t reflect.Type, index []int, seen map[string]bool, fields *[]field) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("structpb")
+		if !ok {
+			tag = sf.Tag.Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				if !sf.IsExported() {
+					// An unexported embedded pointer cannot be allocated when decoding, so its fields are
+					// skipped, as in encoding/json.
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, sf)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		*fields = append(*fields, field{
+			name:      name,
+			index:     append(append([]int(nil), index...), i),
+			omitEmpty: hasOption(opts, "omitempty"),
+		})
+	}
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		collect(ft, append(append([]int(nil), index...), sf.Index...), seen, fields)
+	}
+}
+
+func hasOption(opts, want string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == want {
+			return true
+		}
+	}
+	return false
+}
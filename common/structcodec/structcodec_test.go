@@ -0,0 +1,257 @@
+package structcodec
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	commonpb "go.viam.com/api/common/v1"
+)
+
+type Inner struct {
+	Depth int `json:"depth"`
+	Label string
+}
+
+type hidden struct {
+	Secret string
+}
+
+type config struct {
+	*Inner
+	*hidden
+	Name      string           `json:"name"`
+	Override  string           `structpb:"override" json:"ignored"`
+	Skipped   string           `json:"-"`
+	Optional  string           `json:"optional,omitempty"`
+	Count     uint8            `json:"count"`
+	Big       int64            `json:"big"`
+	Ratio     float32          `json:"ratio"`
+	Score     float64          `json:"score"`
+	Enabled   bool             `json:"enabled"`
+	Timeout   time.Duration    `json:"timeout"`
+	At        time.Time        `json:"at"`
+	Blob      []byte           `json:"blob"`
+	Tags      []string         `json:"tags"`
+	Point     [2]int           `json:"point"`
+	Labels    map[string]int   `json:"labels"`
+	Ptr       *int             `json:"ptr"`
+	Any       any              `json:"any"`
+	Raw       *structpb.Struct `json:"raw"`
+	RawValue  *structpb.Value  `json:"raw_value"`
+	Nested    *config          `json:"nested"`
+	unexposed string
+}
+
+func TestRoundTrip(t *testing.T) {
+	seven := 7
+	in := config{
+		Inner:    &Inner{Depth: 3, Label: "in"},
+		Name:     "arm",
+		Override: "o",
+		Skipped:  "dropped",
+		Count:    255,
+		Big:      1<<60 + 1,
+		Ratio:    0.1,
+		Score:    math.Inf(-1),
+		Enabled:  true,
+		Timeout:  1500 * time.Millisecond,
+		At:       time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC),
+		Blob:     []byte{0, 1, 2, 255},
+		Tags:     []string{"a", "b"},
+		Point:    [2]int{-1, 1},
+		Labels:   map[string]int{"x": 1},
+		Ptr:      &seven,
+		Any:      map[string]any{"k": []any{true, 1.5, nil}},
+		Raw:      &structpb.Struct{Fields: map[string]*structpb.Value{"r": structpb.NewBoolValue(true)}},
+		RawValue: structpb.NewStringValue("v"),
+		Nested:   &config{Name: "child"},
+	}
+	s, err := Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]*structpb.Value{
+		"depth":    structpb.NewNumberValue(3),
+		"Label":    structpb.NewStringValue("in"),
+		"override": structpb.NewStringValue("o"),
+		"big":      structpb.NewStringValue("1152921504606846977"),
+		"ratio":    structpb.NewNumberValue(0.1),
+		"score":    structpb.NewStringValue("-Infinity"),
+		"timeout":  structpb.NewStringValue("1.5s"),
+		"at":       structpb.NewStringValue("2024-05-06T07:08:09.00000001Z"),
+		"blob":     structpb.NewStringValue("AAEC/w=="),
+	} {
+		if got := s.GetFields()[key]; !proto.Equal(got, want) {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	for _, key := range []string{"ignored", "Skipped", "optional", "unexposed", "Secret", "Inner"} {
+		if _, ok := s.GetFields()[key]; ok {
+			t.Errorf("encoded %s", key)
+		}
+	}
+
+	var out config
+	if err := Unmarshal(s, &out); err != nil {
+		t.Fatal(err)
+	}
+	in.Skipped = ""
+	in.Nested = &config{Name: "child"}
+	if !reflect.DeepEqual(out.Inner, in.Inner) || out.Name != in.Name || out.Big != in.Big || out.Ratio != in.Ratio ||
+		out.Score != in.Score || out.Timeout != in.Timeout || !out.At.Equal(in.At) || string(out.Blob) != string(in.Blob) ||
+		!reflect.DeepEqual(out.Tags, in.Tags) || out.Point != in.Point || !reflect.DeepEqual(out.Labels, in.Labels) ||
+		*out.Ptr != 7 || !reflect.DeepEqual(out.Any, in.Any) || !proto.Equal(out.Raw, in.Raw) ||
+		!proto.Equal(out.RawValue, in.RawValue) || out.Nested.Name != "child" || out.Skipped != "" || out.Count != 255 {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestUnexportedEmbeddedPointer(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]any{"Secret": "x", "name": "n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out config
+	if err := Unmarshal(s, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.hidden != nil || out.Name != "n" {
+		t.Errorf("Unmarshal = %+v", out)
+	}
+}
+
+func TestDecodeAccepts(t *testing.T) {
+	var out struct {
+		Timeout time.Duration
+		Count   int
+		Ratio   float64
+		Ptr     *int
+	}
+	s, err := structpb.NewStruct(map[string]any{"Timeout": 2.5, "Count": "-12", "Ratio": "NaN", "Ptr": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Unmarshal(s, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Timeout != 2500*time.Millisecond || out.Count != -12 || !math.IsNaN(out.Ratio) || out.Ptr != nil {
+		t.Errorf("Unmarshal = %+v", out)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		val  *structpb.Value
+		into any
+		path string
+	}{
+		{"fraction", structpb.NewNumberValue(1.5), new(int), ""},
+		{"overflow", structpb.NewNumberValue(256), new(uint8), ""},
+		{"negative unsigned", structpb.NewNumberValue(-1), new(uint), ""},
+		{"huge", structpb.NewNumberValue(1e20), new(int64), ""},
+		{"float32 overflow", structpb.NewNumberValue(1e300), new(float32), ""},
+		{"bad int string", structpb.NewStringValue("x"), new(int), ""},
+		{"string for bool", structpb.NewStringValue("true"), new(bool), ""},
+		{"bad time", structpb.NewStringValue("yesterday"), new(time.Time), ""},
+		{"bad duration", structpb.NewStringValue("soon"), new(time.Duration), ""},
+		{"duration out of range", structpb.NewNumberValue(1e12), new(time.Duration), ""},
+		{"bad base64", structpb.NewStringValue("!"), new([]byte), ""},
+		{"array length", structpb.NewListValue(&structpb.ListValue{}), new([2]int), ""},
+		{"int map keys", structpb.NewStructValue(&structpb.Struct{}), new(map[int]int), ""},
+		{"non-empty interface", structpb.NewStringValue("x"), new(error), ""},
+		{"unsupported", structpb.NewNumberValue(1), new(chan int), ""},
+		{"struct for list", structpb.NewStructValue(&structpb.Struct{}), new(*structpb.ListValue), ""},
+		{
+			"nested path",
+			structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+				"tags": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+					structpb.NewStringValue("a"), structpb.NewBoolValue(true),
+				}}),
+			}}),
+			new(config),
+			"tags[1]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := UnmarshalValue(tc.val, tc.into)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("UnmarshalValue = %v, want a DecodeError", err)
+			}
+			if de.Path != tc.path {
+				t.Errorf("path = %q, want %q", de.Path, tc.path)
+			}
+		})
+	}
+	if err := Unmarshal(&structpb.Struct{}, config{}); !errors.Is(err, errNotPointer) {
+		t.Errorf("Unmarshal into a non-pointer = %v", err)
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	var ute *UnsupportedTypeError
+	if _, err := Marshal(struct{ C chan int }{}); !errors.As(err, &ute) || ute.Path != "C" {
+		t.Errorf("Marshal channel = %v", err)
+	}
+	if _, err := Marshal(map[int]int{}); !errors.As(err, &ute) {
+		t.Errorf("Marshal int keys = %v", err)
+	}
+	if _, err := Marshal([]int{1}); !errors.As(err, &ute) {
+		t.Errorf("Marshal of a list = %v", err)
+	}
+	if v, err := MarshalValue(nil); err != nil || v.GetNullValue() != structpb.NullValue_NULL_VALUE {
+		t.Errorf("MarshalValue(nil) = %v, %v", v, err)
+	}
+}
+
+type fakeDoCommander struct {
+	got *commonpb.DoCommandRequest
+}
+
+func (f *fakeDoCommander) DoCommand(
+	_ context.Context, in *commonpb.DoCommandRequest, _ ...grpc.CallOption,
+) (*commonpb.DoCommandResponse, error) {
+	f.got = in
+	return &commonpb.DoCommandResponse{Result: in.GetCommand()}, nil
+}
+
+func TestDo(t *testing.T) {
+	type echo struct {
+		Message string `json:"message"`
+	}
+	client := &fakeDoCommander{}
+	resp, err := Do[echo, echo](context.Background(), client, "echo", echo{Message: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Message != "hi" || client.got.GetName() != "echo" {
+		t.Errorf("Do = %+v, sent %v", resp, client.got)
+	}
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	f.Add([]byte(`{"name": "a", "depth": 1, "tags": ["x"], "point": [1, 2], "labels": {"a": 1}}`))
+	f.Add([]byte(`{"big": "123", "timeout": 1.5, "at": "2024-01-01T00:00:00Z", "blob": "AA==", "Secret": "s"}`))
+	f.Add([]byte(`{"nested": {"nested": {"ptr": null, "any": [{}]}}, "ratio": "Infinity", "count": 300}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var s structpb.Struct
+		if err := s.UnmarshalJSON(data); err != nil {
+			return
+		}
+		var out config
+		err := Unmarshal(&s, &out)
+		var de *DecodeError
+		if err != nil && !errors.As(err, &de) {
+			t.Fatalf("Unmarshal returned %T: %v", err, err)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+// Package audiostream reads the audio streamed by the GetAudio method of AudioInService as one continuous
+// stream.
+//
+// A Reader puts chunks back in sequence order, holding a few back in case an earlier one arrives late, and
+// checks that each chunk starts where the previous one ended. A chunk starting later leaves a gap, which
+// can be filled with silence; a chunk starting earlier overlaps audio already read, which is cut. Every
+// such discontinuity is counted and reported, so that audio is never lost silently. When the stream fails
+// with a transient error, the Reader reconnects, asking the machine to resume after the last timestamp it
+// read with previous_timestamp_nanoseconds.
+package audiostream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/api/common/audio"
+	audioinpb "go.viam.com/api/component/audioin/v1"
+)
+
+const (
+	// DefaultReorderWindow is how many chunks are held back waiting for a missing one by default.
+	DefaultReorderWindow = 8
+	// DefaultTolerance is how far apart the end of a chunk and the start of the next may be by default
+	// before they count as a discontinuity.
+	DefaultTolerance = time.Millisecond
+	// DefaultMaxReconnects is how many times in a row a Reader reconnects by default before giving up.
+	DefaultMaxReconnects = 5
+)
+
+// Reconnection delays start at this and double up to the maximum, with random jitter.
+const (
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// ErrFormatChanged is returned by Read when a chunk is in a different format from the first one.
+var ErrFormatChanged = errors.New("audiostream: audio format changed")
+
+// Client is the subset of audioinpb.AudioInServiceClient used by a Reader.
+type Client interface {
+	GetAudio(ctx context.Context, in *audioinpb.GetAudioRequest, opts ...grpc.CallOption) (audioinpb.AudioInService_GetAudioClient, error)
+}
+
+// Kind is the kind of a Discontinuity.
+type Kind int
+
+// The kinds of discontinuities.
+const (
+	// Gap is audio missing between two chunks.
+	Gap Kind = iota
+	// Overlap is audio repeated by a chunk.
+	Overlap
+	// Late is a chunk dropped because it arrived after later chunks were read.
+	Late
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Gap:
+		return "gap"
+	case Overlap:
+		return "overlap"
+	case Late:
+		return "late"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Discontinuity is a break in the timeline of a stream.
+type Discontinuity struct {
+	Kind Kind
+	// Sequence is the sequence number of the chunk where the discontinuity was found.
+	Sequence int32
+	// Start and End are the timestamps, in nanoseconds, of the audio missing, repeated or dropped.
+	Start int64
+	End   int64
+}
+
+// Duration is how long the audio missing, repeated or dropped lasts.
+func (d Discontinuity) Duration() time.Duration {
+	return time.Duration(d.End - d.Start)
+}
+
+// Stats counts what happened to a stream so far.
+type Stats struct {
+	Chunks     int
+	Reordered  int
+	Gaps       int
+	GapTime    time.Duration
+	Overlaps   int
+	Late       int
+	Reconnects int
+}
+
+type options struct {
+	codec           string
+	duration        time.Duration
+	window          int
+	tolerance       time.Duration
+	fillGaps        bool
+	maxReconnects   int
+	onDiscontinuity func(Discontinuity)
+}
+
+// Option configures a Reader.
+type Option func(*options)
+
+// WithCodec sets the codec requested from the machine. The default is pcm16. Gaps can only be filled and
+// overlaps cut in PCM codecs; in others they are only reported.
+func WithCodec(codec string) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// WithDuration limits the stream to d of audio, counted by timestamps across reconnections. The default
+// is an endless stream.
+func WithDuration(d time.Duration) Option {
+	return func(o *options) {
+		o.duration = d
+	}
+}
+
+// WithReorderWindow sets how many chunks are held back waiting for a missing one.
+func WithReorderWindow(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.window = n
+		}
+	}
+}
+
+// WithTolerance sets how far apart the end of a chunk and the start of the next may be before they count
+// as a discontinuity.
+func WithTolerance(d time.Duration) Option {
+	return func(o *options) {
+		if d >= 0 {
+			o.tolerance = d
+		}
+	}
+}
+
+// WithFillGaps fills gaps with silence, so that the audio read keeps the timing of the stream.
+func WithFillGaps() Option {
+	return func(o *options) {
+		o.fillGaps = true
+	}
+}
+
+// WithMaxReconnects sets how many times in a row the Reader reconnects after transient errors before it
+// gives up. Zero disables reconnecting.
+func WithMaxReconnects(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.maxReconnects = n
+		}
+	}
+}
+
+// WithOnDiscontinuity registers a function called, from Read, with every discontinuity found.
+func WithOnDiscontinuity(f func(Discontinuity)) Option {
+	return func(o *options) {
+		o.onDiscontinuity = f
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		codec:           audio.CodecPCM16,
+		window:          DefaultReorderWindow,
+		tolerance:       DefaultTolerance,
+		maxReconnects:   DefaultMaxReconnects,
+		onDiscontinuity: func(Discontinuity) {},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// backoff returns the wait before the given reconnection, counting from 1.
+func backoff(retry int) time.Duration {
+	d := initialBackoff
+	for i := 1; i < retry && d < maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, maxBackoff)
+	// Wait between half and all of d so that clients that failed together do not retry together.
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryable reports whether err is a transient failure worth reconnecting after.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,391 @@
+package audiostream
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/api/common/audio"
+	audioinpb "go.viam.com/api/component/audioin/v1"
+)
+
+// format is 1 kHz mono pcm16, so that every millisecond of audio is 2 bytes.
+var format = audio.Format{Codec: audio.CodecPCM16, SampleRate: 1000, Channels: 1}
+
+// pcm returns ms milliseconds of the audio of the chunk with sequence number seq.
+func pcm(seq int32, ms int64) []byte {
+	return bytes.Repeat([]byte{byte(seq + 1)}, int(2*ms))
+}
+
+// chunk returns the chunk seq, lasting from start to end milliseconds.
+func chunk(seq int32, start, end int64) *audioinpb.AudioChunk {
+	return &audioinpb.AudioChunk{
+		AudioData:                 pcm(seq, end-start),
+		AudioInfo:                 format.Info(),
+		StartTimestampNanoseconds: start * int64(time.Millisecond),
+		EndTimestampNanoseconds:   end * int64(time.Millisecond),
+		Sequence:                  seq,
+	}
+}
+
+// script is what one GetAudio stream sends: chunks then err, which is io.EOF if nil.
+type script struct {
+	chunks  []*audioinpb.AudioChunk
+	err     error
+	openErr error
+	// requestID overrides the request id echoed in responses.
+	requestID string
+}
+
+type fakeClient struct {
+	scripts []script
+	reqs    []*audioinpb.GetAudioRequest
+}
+
+func (c *fakeClient) GetAudio(ctx context.Context, in *audioinpb.GetAudioRequest, _ ...grpc.CallOption) (audioinpb.AudioInService_GetAudioClient, error) {
+	c.reqs = append(c.reqs, in)
+	if len(c.scripts) == 0 {
+		return &fakeStream{}, nil
+	}
+	s := c.scripts[0]
+	c.scripts = c.scripts[1:]
+	if s.openErr != nil {
+		return nil, s.openErr
+	}
+	id := in.GetRequestId()
+	if s.requestID != "" {
+		id = s.requestID
+	}
+	st := &fakeStream{err: s.err}
+	for _, ch := range s.chunks {
+		st.resps = append(st.resps, &audioinpb.GetAudioResponse{Audio: ch, RequestId: id})
+	}
+	return st, nil
+}
+
+type fakeStream struct {
+	grpc.ClientStream
+	resps []*audioinpb.GetAudioResponse
+	err   error
+}
+
+func (s *fakeStream) Recv() (*audioinpb.GetAudioResponse, error) {
+	if len(s.resps) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	resp := s.resps[0]
+	s.resps = s.resps[1:]
+	return resp, nil
+}
+
+func TestReader(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		opts   []Option
+		chunks []*audioinpb.AudioChunk
+		want   [][]byte
+		discs  []Discontinuity
+		stats  Stats
+	}{
+		{
+			name:   "in order",
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 10, 20), chunk(2, 20, 30)},
+			want:   [][]byte{pcm(0, 10), pcm(1, 10), pcm(2, 10)},
+			stats:  Stats{Chunks: 3},
+		},
+		{
+			name:   "reordered",
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(2, 20, 30), chunk(1, 10, 20)},
+			want:   [][]byte{pcm(0, 10), pcm(1, 10), pcm(2, 10)},
+			stats:  Stats{Chunks: 3, Reordered: 1},
+		},
+		{
+			name:   "late",
+			opts:   []Option{WithReorderWindow(1)},
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(2, 20, 30), chunk(3, 30, 40), chunk(1, 10, 20)},
+			want:   [][]byte{pcm(0, 10), pcm(2, 10), pcm(3, 10)},
+			discs: []Discontinuity{
+				{Kind: Gap, Sequence: 2, Start: 10e6, End: 20e6},
+				{Kind: Late, Sequence: 1, Start: 10e6, End: 20e6},
+			},
+			stats: Stats{Chunks: 3, Reordered: 2, Gaps: 1, GapTime: 10 * time.Millisecond, Late: 1},
+		},
+		{
+			name:   "missing at the end",
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(2, 20, 30)},
+			want:   [][]byte{pcm(0, 10), pcm(2, 10)},
+			discs:  []Discontinuity{{Kind: Gap, Sequence: 2, Start: 10e6, End: 20e6}},
+			stats:  Stats{Chunks: 2, Reordered: 1, Gaps: 1, GapTime: 10 * time.Millisecond},
+		},
+		{
+			name:   "gap filled",
+			opts:   []Option{WithFillGaps()},
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 15, 20)},
+			want:   [][]byte{pcm(0, 10), make([]byte, 10), pcm(1, 5)},
+			discs:  []Discontinuity{{Kind: Gap, Sequence: 1, Start: 10e6, End: 15e6}},
+			stats:  Stats{Chunks: 2, Gaps: 1, GapTime: 5 * time.Millisecond},
+		},
+		{
+			name:   "within tolerance",
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 11, 21)},
+			want:   [][]byte{pcm(0, 10), pcm(1, 10)},
+			stats:  Stats{Chunks: 2},
+		},
+		{
+			name:   "outside tolerance",
+			opts:   []Option{WithTolerance(0)},
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 11, 21)},
+			want:   [][]byte{pcm(0, 10), pcm(1, 10)},
+			discs:  []Discontinuity{{Kind: Gap, Sequence: 1, Start: 10e6, End: 11e6}},
+			stats:  Stats{Chunks: 2, Gaps: 1, GapTime: time.Millisecond},
+		},
+		{
+			name:   "overlap cut",
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 5, 15)},
+			want:   [][]byte{pcm(0, 10), pcm(1, 5)},
+			discs:  []Discontinuity{{Kind: Overlap, Sequence: 1, Start: 5e6, End: 10e6}},
+			stats:  Stats{Chunks: 2, Overlaps: 1},
+		},
+		{
+			name:   "overlap dropped",
+			chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 2, 8), chunk(2, 10, 20)},
+			want:   [][]byte{pcm(0, 10), pcm(2, 10)},
+			discs:  []Discontinuity{{Kind: Overlap, Sequence: 1, Start: 2e6, End: 8e6}},
+			stats:  Stats{Chunks: 3, Overlaps: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var discs []Discontinuity
+			opts := append(tc.opts, WithOnDiscontinuity(func(d Discontinuity) { discs = append(discs, d) }))
+			client := &fakeClient{scripts: []script{{chunks: tc.chunks}}}
+			r := NewReader(context.Background(), client, "mic", opts...)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := slices.Concat(tc.want...); !bytes.Equal(got, want) {
+				t.Errorf("read %v, want %v", got, want)
+			}
+			if !slices.Equal(discs, tc.discs) {
+				t.Errorf("discontinuities = %+v, want %+v", discs, tc.discs)
+			}
+			if r.Stats() != tc.stats {
+				t.Errorf("stats = %+v, want %+v", r.Stats(), tc.stats)
+			}
+			if r.Format() != format {
+				t.Errorf("format = %v", r.Format())
+			}
+			if req := client.reqs[0]; req.GetName() != "mic" || req.GetCodec() != audio.CodecPCM16 || req.GetRequestId() == "" {
+				t.Errorf("request = %v", req)
+			}
+		})
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	client := &fakeClient{scripts: []script{
+		{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 10, 20)}, err: status.Error(codes.Unavailable, "lost")},
+		{openErr: status.Error(codes.Unavailable, "still lost")},
+		// A resumed stream numbers its chunks afresh.
+		{chunks: []*audioinpb.AudioChunk{chunk(0, 20, 30)}},
+	}}
+	r := NewReader(context.Background(), client, "mic", WithDuration(time.Second))
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := slices.Concat(pcm(0, 10), pcm(1, 10), pcm(0, 10)); !bytes.Equal(got, want) {
+		t.Errorf("read %v, want %v", got, want)
+	}
+	if st := r.Stats(); st.Reconnects != 1 || st.Chunks != 3 {
+		t.Errorf("stats = %+v", st)
+	}
+	if len(client.reqs) != 3 {
+		t.Fatalf("%d requests, want 3", len(client.reqs))
+	}
+	first, last := client.reqs[0], client.reqs[2]
+	if first.GetPreviousTimestampNanoseconds() != 0 || first.GetDurationSeconds() != 1 {
+		t.Errorf("first request = %v", first)
+	}
+	if last.GetPreviousTimestampNanoseconds() != 20e6 || last.GetDurationSeconds() != 0.98 || last.GetRequestId() != first.GetRequestId() {
+		t.Errorf("resumed request = %v", last)
+	}
+}
+
+func TestDurationReached(t *testing.T) {
+	client := &fakeClient{scripts: []script{
+		{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 10, 20)}, err: status.Error(codes.Unavailable, "lost")},
+	}}
+	r := NewReader(context.Background(), client, "mic", WithDuration(20*time.Millisecond))
+	if got, err := io.ReadAll(r); err != nil || len(got) != 40 {
+		t.Errorf("ReadAll = %d bytes, %v", len(got), err)
+	}
+	if len(client.reqs) != 1 {
+		t.Errorf("%d requests, want no reconnection", len(client.reqs))
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	stereo := chunk(1, 10, 20)
+	stereo.AudioInfo.NumChannels = 2
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		scripts []script
+		code    codes.Code
+		is      error
+		reqs    int
+	}{
+		{
+			name:    "not transient",
+			scripts: []script{{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10)}, err: status.Error(codes.InvalidArgument, "bad")}},
+			code:    codes.InvalidArgument,
+			reqs:    1,
+		},
+		{
+			name:    "open failed",
+			scripts: []script{{openErr: status.Error(codes.NotFound, "no mic")}},
+			code:    codes.NotFound,
+			reqs:    1,
+		},
+		{
+			name: "too many reconnects",
+			opts: []Option{WithMaxReconnects(1)},
+			scripts: []script{
+				{err: status.Error(codes.Unavailable, "lost")},
+				{err: status.Error(codes.Unavailable, "lost")},
+				{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10)}},
+			},
+			code: codes.Unavailable,
+			reqs: 2,
+		},
+		{
+			name:    "reconnecting disabled",
+			opts:    []Option{WithMaxReconnects(0)},
+			scripts: []script{{openErr: status.Error(codes.Unavailable, "lost")}},
+			code:    codes.Unavailable,
+			reqs:    1,
+		},
+		{
+			name:    "format changed",
+			scripts: []script{{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), stereo}}},
+			is:      ErrFormatChanged,
+			reqs:    1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeClient{scripts: tc.scripts}
+			r := NewReader(context.Background(), client, "mic", tc.opts...)
+			_, err := io.ReadAll(r)
+			if tc.is != nil && !errors.Is(err, tc.is) || tc.is == nil && status.Code(err) != tc.code {
+				t.Errorf("ReadAll = %v", err)
+			}
+			if len(client.reqs) != tc.reqs {
+				t.Errorf("%d requests, want %d", len(client.reqs), tc.reqs)
+			}
+			// The error sticks.
+			if _, err2 := r.Read(make([]byte, 1)); err2 != err {
+				t.Errorf("second Read = %v, want %v", err2, err)
+			}
+		})
+	}
+}
+
+func TestOtherRequestIgnored(t *testing.T) {
+	client := &fakeClient{scripts: []script{{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10)}, requestID: "someone else"}}}
+	r := NewReader(context.Background(), client, "mic")
+	if got, err := io.ReadAll(r); err != nil || len(got) != 0 {
+		t.Errorf("ReadAll = %v, %v", got, err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	client := &fakeClient{scripts: []script{{err: status.Error(codes.Unavailable, "lost")}}}
+	r := NewReader(context.Background(), client, "mic")
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read(make([]byte, 1)); status.Code(err) != codes.Unavailable {
+		t.Errorf("Read after Close = %v, want the error without reconnecting", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for retry, want := range []time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 7: maxBackoff, 20: maxBackoff} {
+		if want == 0 {
+			continue
+		}
+		for range 20 {
+			if d := backoff(retry); d < want/2 || d > want {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", retry, d, want/2, want)
+			}
+		}
+	}
+}
+
+func TestKindString(t *testing.T) {
+	for k, want := range map[Kind]string{Gap: "gap", Overlap: "overlap", Late: "late", Kind(7): "Kind(7)"} {
+		if got := k.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		scripts []script
+		data    []byte
+		err     bool
+	}{
+		{"complete", []script{{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10), chunk(1, 10, 20)}}}, slices.Concat(pcm(0, 10), pcm(1, 10)), false},
+		{"failed", []script{{chunks: []*audioinpb.AudioChunk{chunk(0, 0, 10)}, err: status.Error(codes.Internal, "broken")}}, pcm(0, 10), true},
+		{"no audio", nil, nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rec.wav")
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stats, err := Record(context.Background(), &fakeClient{scripts: tc.scripts}, "mic", f)
+			if (err != nil) != tc.err {
+				t.Errorf("Record = %v", err)
+			}
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.data == nil {
+				if len(b) != 0 {
+					t.Errorf("wrote %d bytes without audio", len(b))
+				}
+				return
+			}
+			// The recording is a valid WAV file of all the audio read, even if the stream failed.
+			if len(b) < 44 || binary.LittleEndian.Uint32(b[40:]) != uint32(len(tc.data)) || !bytes.Equal(b[44:], tc.data) {
+				t.Errorf("file = %v", b)
+			}
+			if stats.Chunks != len(tc.data)/20 {
+				t.Errorf("stats = %+v", stats)
+			}
+		})
+	}
+}
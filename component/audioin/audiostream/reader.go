@@ -0,0 +1,313 @@
+package audiostream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"go.viam.com/api/common/audio"
+	audioinpb "go.viam.com/api/component/audioin/v1"
+)
+
+// maxGapFill is the longest gap filled with silence. Longer gaps are only reported, since they more
+// likely come from a jump of the machine's clock than from lost audio.
+const maxGapFill = time.Minute
+
+// Reader reads the audio of a GetAudio stream in order. It is not safe for concurrent use.
+type Reader struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	client    Client
+	name      string
+	o         *options
+	requestID string
+
+	stream audioinpb.AudioInService_GetAudioClient
+	opened bool
+	// failures counts the reconnections since a chunk was last received.
+	failures int
+
+	// started is whether the current stream has delivered a chunk, and next is the sequence number of
+	// the chunk expected after the ones read. held are the chunks received ahead of it.
+	started bool
+	next    int32
+	held    map[int32]*audioinpb.AudioChunk
+
+	format     audio.Format
+	hasFormat  bool
+	hasEnd     bool
+	firstStart int64
+	lastEnd    int64
+
+	out   []byte
+	err   error
+	stats Stats
+}
+
+// NewReader returns a Reader of the audio of the audio input with the given name. The stream is opened by
+// the first Read, and lasts until the Reader is closed or ctx is done.
+func NewReader(ctx context.Context, client Client, name string, opts ...Option) *Reader {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Reader{
+		ctx:       ctx,
+		cancel:    cancel,
+		client:    client,
+		name:      name,
+		o:         newOptions(opts),
+		requestID: strconv.FormatUint(rand.Uint64(), 16),
+		held:      make(map[int32]*audioinpb.AudioChunk),
+	}
+}
+
+// Read reads audio data, in the format returned by Format. It returns io.EOF once the machine ends the
+// stream or the duration set with WithDuration has been read.
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.fill()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// Format returns the format of the stream, which is known once data has been read.
+func (r *Reader) Format() audio.Format {
+	return r.format
+}
+
+// Stats returns what happened to the stream so far.
+func (r *Reader) Stats() Stats {
+	return r.stats
+}
+
+// Close ends the stream.
+func (r *Reader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// fill receives the next response, reconnecting if the stream failed. It returns an error only if the
+// stream cannot go on.
+func (r *Reader) fill() error {
+	if r.stream == nil {
+		if err := r.connect(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return err
+			}
+			return r.retry(err)
+		}
+	}
+	resp, err := r.stream.Recv()
+	if err == nil {
+		if id := resp.GetRequestId(); id != "" && id != r.requestID {
+			return nil
+		}
+		r.failures = 0
+		return r.receive(resp.GetAudio())
+	}
+	r.stream = nil
+	// The chunks held back are all there is before the stream resumes.
+	if err := r.flush(); err != nil {
+		return err
+	}
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	return r.retry(err)
+}
+
+func (r *Reader) connect() error {
+	req := &audioinpb.GetAudioRequest{Name: r.name, Codec: r.o.codec, RequestId: r.requestID}
+	if r.hasEnd {
+		req.PreviousTimestampNanoseconds = r.lastEnd
+	}
+	if r.o.duration > 0 {
+		remaining := r.o.duration
+		if r.hasEnd {
+			remaining -= time.Duration(r.lastEnd - r.firstStart)
+		}
+		if remaining <= 0 {
+			return io.EOF
+		}
+		req.DurationSeconds = float32(remaining.Seconds())
+	}
+	stream, err := r.client.GetAudio(r.ctx, req)
+	if err != nil {
+		return err
+	}
+	if r.opened {
+		r.stats.Reconnects++
+	}
+	r.opened = true
+	r.stream = stream
+	r.started = false
+	return nil
+}
+
+// retry waits before the next reconnection, or returns err if the failure is not transient or there have
+// been too many reconnections in a row.
+func (r *Reader) retry(err error) error {
+	if !retryable(r.ctx, err) || r.failures >= r.o.maxReconnects {
+		return err
+	}
+	r.failures++
+	t := time.NewTimer(backoff(r.failures))
+	select {
+	case <-r.ctx.Done():
+		t.Stop()
+		return errors.Join(err, r.ctx.Err())
+	case <-t.C:
+	}
+	return nil
+}
+
+// receive reads the chunks that are due once c has arrived.
+func (r *Reader) receive(c *audioinpb.AudioChunk) error {
+	seq := c.GetSequence()
+	if !r.started {
+		r.started = true
+		r.next = seq
+	}
+	if seq < r.next {
+		r.stats.Late++
+		r.report(Discontinuity{Kind: Late, Sequence: seq, Start: c.GetStartTimestampNanoseconds(), End: c.GetEndTimestampNanoseconds()})
+		return nil
+	}
+	if seq != r.next {
+		r.stats.Reordered++
+	}
+	r.held[seq] = c
+	for {
+		if err := r.drain(); err != nil {
+			return err
+		}
+		if len(r.held) <= r.o.window {
+			return nil
+		}
+		// Too many chunks are waiting: give up on the missing one, whose audio shows up as a gap.
+		r.next = r.firstHeld()
+	}
+}
+
+// flush reads all the chunks held back.
+func (r *Reader) flush() error {
+	for len(r.held) > 0 {
+		r.next = r.firstHeld()
+		if err := r.drain(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drain reads the held chunks that follow on from the ones read.
+func (r *Reader) drain() error {
+	for {
+		c, ok := r.held[r.next]
+		if !ok {
+			return nil
+		}
+		delete(r.held, r.next)
+		r.next++
+		if err := r.read(c); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Reader) firstHeld() int32 {
+	first, ok := int32(0), false
+	for seq := range r.held {
+		if !ok || seq < first {
+			first, ok = seq, true
+		}
+	}
+	return first
+}
+
+// read adds the audio of c to the output, after checking it against the end of the audio read so far.
+func (r *Reader) read(c *audioinpb.AudioChunk) error {
+	f := audio.FormatOf(c.GetAudioInfo())
+	if !r.hasFormat {
+		r.format, r.hasFormat = f, true
+	} else if f != r.format {
+		return fmt.Errorf("%w: from %v to %v", ErrFormatChanged, r.format, f)
+	}
+	r.stats.Chunks++
+	data := c.GetAudioData()
+	start, end := c.GetStartTimestampNanoseconds(), c.GetEndTimestampNanoseconds()
+	if !r.hasEnd {
+		r.firstStart, r.lastEnd, r.hasEnd = start, end, true
+		r.out = append(r.out, data...)
+		return nil
+	}
+
+	tolerance := int64(r.o.tolerance)
+	switch d := start - r.lastEnd; {
+	case d > tolerance:
+		r.stats.Gaps++
+		r.stats.GapTime += time.Duration(d)
+		r.report(Discontinuity{Kind: Gap, Sequence: c.GetSequence(), Start: r.lastEnd, End: start})
+		if r.o.fillGaps && time.Duration(d) <= maxGapFill {
+			r.out = append(r.out, make([]byte, r.format.Size(time.Duration(d)))...)
+		}
+	case d < -tolerance:
+		r.stats.Overlaps++
+		r.report(Discontinuity{Kind: Overlap, Sequence: c.GetSequence(), Start: start, End: min(end, r.lastEnd)})
+		if end <= r.lastEnd {
+			return nil
+		}
+		data = data[min(len(data), r.format.Size(time.Duration(-d))):]
+	}
+	r.lastEnd = max(r.lastEnd, end)
+	r.out = append(r.out, data...)
+	return nil
+}
+
+func (r *Reader) report(d Discontinuity) {
+	r.o.onDiscontinuity(d)
+}
+
+// Record writes the audio of the audio input with the given name to w as a WAV file, until the stream
+// ends. If the stream fails, the audio recorded so far is still left as a valid WAV file. The codec must
+// be a PCM one.
+func Record(ctx context.Context, client Client, name string, w io.WriteSeeker, opts ...Option) (Stats, error) {
+	r := NewReader(ctx, client, name, opts...)
+	//nolint:errcheck
+	defer r.Close()
+	var ww *audio.WAVWriter
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if ww == nil {
+				var werr error
+				if ww, werr = audio.NewWAVWriter(w, r.Format()); werr != nil {
+					return r.Stats(), werr
+				}
+			}
+			if _, werr := ww.Write(buf[:n]); werr != nil {
+				return r.Stats(), errors.Join(werr, ww.Close())
+			}
+		}
+		if err != nil {
+			if ww == nil {
+				if errors.Is(err, io.EOF) {
+					err = errors.New("audiostream: stream ended without audio")
+				}
+				return r.Stats(), err
+			}
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			return r.Stats(), errors.Join(err, ww.Close())
+		}
+	}
+}
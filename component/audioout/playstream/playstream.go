@@ -0,0 +1,234 @@
+// Package playstream plays audio on an audio output through the PlayStream method of AudioOutService.
+//
+// Open asks the audio output for its properties and negotiates the format the audio is sent in: the
+// source codec if the output supports it, or else a PCM codec it supports, at the output's sample rate
+// and with no more channels than it has. PCM audio is converted to that format as it is written, so any
+// PCM source can be played; other codecs, such as mp3, are sent as they are and must be supported. The
+// returned Writer sends the PlayStreamInit message, then the audio in PlayStreamChunk messages.
+package playstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"go.viam.com/api/common/audio"
+	commonpb "go.viam.com/api/common/v1"
+	audiooutpb "go.viam.com/api/component/audioout/v1"
+)
+
+const (
+	// DefaultChunkDuration is how much audio each chunk carries by default.
+	DefaultChunkDuration = 100 * time.Millisecond
+	// compressedChunkSize is the size of the chunks of codecs whose duration cannot be computed.
+	compressedChunkSize = 32 << 10
+)
+
+// pcmCodecs are the PCM codecs audio can be converted to, in order of preference.
+var pcmCodecs = []string{audio.CodecPCM16, audio.CodecPCM32Float}
+
+// Client is the subset of audiooutpb.AudioOutServiceClient used to play audio.
+type Client interface {
+	PlayStream(ctx context.Context, opts ...grpc.CallOption) (audiooutpb.AudioOutService_PlayStreamClient, error)
+	GetProperties(ctx context.Context, in *commonpb.GetPropertiesRequest, opts ...grpc.CallOption) (*commonpb.GetPropertiesResponse, error)
+}
+
+type options struct {
+	chunkDuration time.Duration
+}
+
+// Option configures playback.
+type Option func(*options)
+
+// WithChunkDuration sets how much audio each chunk carries.
+func WithChunkDuration(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.chunkDuration = d
+		}
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{chunkDuration: DefaultChunkDuration}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Negotiate returns the format audio of format src is sent in to an audio output with the given
+// properties. Properties left unset do not constrain the format.
+func Negotiate(src audio.Format, props *commonpb.GetPropertiesResponse) (audio.Format, error) {
+	if src.Codec == "" {
+		return audio.Format{}, errors.New("playstream: no codec given")
+	}
+	if src.IsPCM() {
+		if err := src.Validate(); err != nil {
+			return audio.Format{}, err
+		}
+	}
+	to := src
+	codecs := props.GetSupportedCodecs()
+	if len(codecs) > 0 && !slices.Contains(codecs, src.Codec) {
+		if !src.IsPCM() {
+			return audio.Format{}, fmt.Errorf("playstream: codec %q is not supported by the audio output, which supports %v", src.Codec, codecs)
+		}
+		i := slices.IndexFunc(pcmCodecs, func(c string) bool { return slices.Contains(codecs, c) })
+		if i < 0 {
+			return audio.Format{}, fmt.Errorf("playstream: the audio output supports no PCM codec, only %v", codecs)
+		}
+		to.Codec = pcmCodecs[i]
+	}
+	if !src.IsPCM() {
+		return to, nil
+	}
+	if rate := int(props.GetSampleRateHz()); rate > 0 {
+		to.SampleRate = rate
+	}
+	if n := int(props.GetNumChannels()); n > 0 && to.Channels > n {
+		to.Channels = n
+	}
+	return to, nil
+}
+
+// Writer plays the audio written to it. Write blocks while the stream's flow control window is full, so
+// a writer faster than playback is held back as far as the audio output stops reading. A Writer is not
+// safe for concurrent use.
+type Writer struct {
+	stream    audiooutpb.AudioOutService_PlayStreamClient
+	format    audio.Format
+	conv      *audio.Converter
+	chunkSize int
+	buf       []byte
+	err       error
+	closed    bool
+}
+
+// Open starts playing audio of format src on the audio output with the given name. The stream lasts until
+// the Writer is closed or ctx is done.
+func Open(ctx context.Context, client Client, name string, src audio.Format, opts ...Option) (*Writer, error) {
+	o := newOptions(opts)
+	props, err := client.GetProperties(ctx, &commonpb.GetPropertiesRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	to, err := Negotiate(src, props)
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{format: to, chunkSize: max(to.Size(o.chunkDuration), to.FrameSize())}
+	if !to.IsPCM() {
+		w.chunkSize = compressedChunkSize
+	}
+	if to != src {
+		if w.conv, err = audio.NewConverter(src, to); err != nil {
+			return nil, err
+		}
+	}
+	if w.stream, err = client.PlayStream(ctx); err != nil {
+		return nil, err
+	}
+	init := &audiooutpb.PlayStreamInit{Name: name, AudioInfo: to.Info()}
+	if err := w.send(&audiooutpb.PlayStreamRequest{Payload: &audiooutpb.PlayStreamRequest_Init{Init: init}}); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Format returns the format the audio is sent in.
+func (w *Writer) Format() audio.Format {
+	return w.format
+}
+
+// Write plays p, which is audio in the source format.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("playstream: write after close")
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.conv != nil {
+		w.buf = append(w.buf, w.conv.Convert(p)...)
+	} else {
+		w.buf = append(w.buf, p...)
+	}
+	for len(w.buf) >= w.chunkSize {
+		if err := w.sendChunk(w.chunkSize); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close sends the rest of the audio and ends the stream. It returns once the audio output has accepted
+// the stream, which may be before the audio has finished playing.
+func (w *Writer) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+	if w.conv != nil {
+		w.buf = append(w.buf, w.conv.Flush()...)
+	}
+	if len(w.buf) > 0 {
+		if err := w.sendChunk(len(w.buf)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.stream.CloseAndRecv(); err != nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// sendChunk sends the first n bytes buffered. They are not modified afterwards, as gRPC requires of sent
+// messages.
+func (w *Writer) sendChunk(n int) error {
+	chunk := w.buf[:n:n]
+	w.buf = w.buf[n:]
+	return w.send(&audiooutpb.PlayStreamRequest{
+		Payload: &audiooutpb.PlayStreamRequest_AudioChunk{AudioChunk: &audiooutpb.PlayStreamChunk{AudioData: chunk}},
+	})
+}
+
+func (w *Writer) send(req *audiooutpb.PlayStreamRequest) error {
+	err := w.stream.Send(req)
+	if errors.Is(err, io.EOF) {
+		// The audio output ended the stream, and says why in its response.
+		_, err = w.stream.CloseAndRecv()
+		if err == nil {
+			err = errors.New("playstream: audio output ended the stream early")
+		}
+	}
+	w.err = err
+	return err
+}
+
+// PlayWAV plays a WAV file on the audio output with the given name, and returns once the whole file is
+// sent.
+func PlayWAV(ctx context.Context, client Client, name string, r io.Reader, opts ...Option) error {
+	wr, err := audio.NewWAVReader(r)
+	if err != nil {
+		return err
+	}
+	w, err := Open(ctx, client, name, wr.Format(), opts...)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, wr); err != nil {
+		//nolint:errcheck
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
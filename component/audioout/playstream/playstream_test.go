@@ -0,0 +1,319 @@
+package playstream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/api/common/audio"
+	commonpb "go.viam.com/api/common/v1"
+	audiooutpb "go.viam.com/api/component/audioout/v1"
+)
+
+func TestNegotiate(t *testing.T) {
+	pcm16 := audio.Format{Codec: audio.CodecPCM16, SampleRate: 44100, Channels: 2}
+	mp3 := audio.Format{Codec: audio.CodecMP3, SampleRate: 44100, Channels: 2}
+	for _, tc := range []struct {
+		name  string
+		src   audio.Format
+		props *commonpb.GetPropertiesResponse
+		want  audio.Format
+		err   bool
+	}{
+		{"unconstrained", pcm16, &commonpb.GetPropertiesResponse{}, pcm16, false},
+		{"no properties", pcm16, nil, pcm16, false},
+		{"supported", pcm16, &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecPCM16}, SampleRateHz: 44100, NumChannels: 2}, pcm16, false},
+		{
+			"converted",
+			pcm16,
+			&commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecMP3, audio.CodecPCM32Float}, SampleRateHz: 48000, NumChannels: 1},
+			audio.Format{Codec: audio.CodecPCM32Float, SampleRate: 48000, Channels: 1},
+			false,
+		},
+		{"unknown codec", audio.Format{Codec: "pcm8", SampleRate: 8000, Channels: 1}, &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecPCM16}}, audio.Format{}, true},
+		{"channels not added", audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}, &commonpb.GetPropertiesResponse{NumChannels: 2}, audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}, false},
+		{"no PCM codec", pcm16, &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecMP3}}, audio.Format{}, true},
+		{"compressed", mp3, &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecMP3}, SampleRateHz: 48000, NumChannels: 1}, mp3, false},
+		{"compressed unsupported", mp3, &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecPCM16}}, audio.Format{}, true},
+		{"no codec", audio.Format{SampleRate: 8000, Channels: 1}, nil, audio.Format{}, true},
+		{"invalid PCM", audio.Format{Codec: audio.CodecPCM16, Channels: 1}, nil, audio.Format{}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Negotiate(tc.src, tc.props)
+			if (err != nil) != tc.err || got != tc.want {
+				t.Errorf("Negotiate = %v, %v; want %v, error %v", got, err, tc.want, tc.err)
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	props    *commonpb.GetPropertiesResponse
+	propsErr error
+	openErr  error
+	stream   *fakeStream
+}
+
+func (c *fakeClient) GetProperties(ctx context.Context, in *commonpb.GetPropertiesRequest, _ ...grpc.CallOption) (*commonpb.GetPropertiesResponse, error) {
+	return c.props, c.propsErr
+}
+
+func (c *fakeClient) PlayStream(ctx context.Context, _ ...grpc.CallOption) (audiooutpb.AudioOutService_PlayStreamClient, error) {
+	if c.openErr != nil {
+		return nil, c.openErr
+	}
+	return c.stream, nil
+}
+
+type fakeStream struct {
+	grpc.ClientStream
+	reqs []*audiooutpb.PlayStreamRequest
+	// sendErr is returned by Send once errAfter messages are sent, and closeErr by CloseAndRecv.
+	sendErr  error
+	errAfter int
+	closeErr error
+	closed   int
+}
+
+func (s *fakeStream) Send(req *audiooutpb.PlayStreamRequest) error {
+	if s.sendErr != nil && len(s.reqs) >= s.errAfter {
+		return s.sendErr
+	}
+	s.reqs = append(s.reqs, req)
+	return nil
+}
+
+func (s *fakeStream) CloseAndRecv() (*audiooutpb.PlayStreamResponse, error) {
+	s.closed++
+	if s.closeErr != nil {
+		return nil, s.closeErr
+	}
+	return &audiooutpb.PlayStreamResponse{}, nil
+}
+
+// audioSent returns the audio of each chunk sent, after checking that the stream starts with init.
+func (s *fakeStream) audioSent(t *testing.T, name string, f audio.Format) [][]byte {
+	t.Helper()
+	if len(s.reqs) == 0 || s.reqs[0].GetInit().GetName() != name || audio.FormatOf(s.reqs[0].GetInit().GetAudioInfo()) != f {
+		t.Fatalf("stream does not start with the init of %q in %v: %v", name, f, s.reqs)
+	}
+	var chunks [][]byte
+	for _, req := range s.reqs[1:] {
+		if req.GetAudioChunk() == nil {
+			t.Fatalf("sent %v after init", req)
+		}
+		chunks = append(chunks, req.GetAudioChunk().GetAudioData())
+	}
+	return chunks
+}
+
+func TestWriter(t *testing.T) {
+	f := audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}
+	stream := &fakeStream{}
+	client := &fakeClient{props: &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecPCM16}}, stream: stream}
+	w, err := Open(context.Background(), client, "speaker", f, WithChunkDuration(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Format() != f {
+		t.Errorf("format = %v", w.Format())
+	}
+	data := make([]byte, 400)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	for _, p := range [][]byte{data[:100], data[100:170], data[170:]} {
+		if n, err := w.Write(p); err != nil || n != len(p) {
+			t.Fatalf("Write = %d, %v", n, err)
+		}
+	}
+	// Writing the next chunks reuses none of the memory of the chunks already sent.
+	sent := slices.Clone(stream.audioSent(t, "speaker", f)[0])
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	chunks := stream.audioSent(t, "speaker", f)
+	if !bytes.Equal(chunks[0], sent) {
+		t.Error("a sent chunk was modified")
+	}
+	var lens []int
+	for _, c := range chunks {
+		lens = append(lens, len(c))
+	}
+	if !slices.Equal(lens, []int{160, 160, 80}) {
+		t.Errorf("sent chunks of %v bytes, want 160, 160 and 80", lens)
+	}
+	if !bytes.Equal(slices.Concat(chunks...), data) {
+		t.Error("sent audio differs from the audio written")
+	}
+	if stream.closed != 1 {
+		t.Errorf("CloseAndRecv called %d times", stream.closed)
+	}
+
+	// Closing again is harmless, and writing fails.
+	if err := w.Close(); err != nil || stream.closed != 1 {
+		t.Errorf("second Close = %v", err)
+	}
+	if _, err := w.Write(data); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+}
+
+func TestWriterConverts(t *testing.T) {
+	src := audio.Format{Codec: audio.CodecPCM32Float, SampleRate: 16000, Channels: 2}
+	stream := &fakeStream{}
+	client := &fakeClient{
+		props:  &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecPCM16}, SampleRateHz: 8000, NumChannels: 1},
+		stream: stream,
+	}
+	w, err := Open(context.Background(), client, "speaker", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}
+	if w.Format() != to {
+		t.Errorf("format = %v, want %v", w.Format(), to)
+	}
+	// A second of audio is sent in 100 ms chunks of a second of the output format.
+	if _, err := w.Write(make([]byte, src.Size(time.Second))); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	chunks := stream.audioSent(t, "speaker", to)
+	if len(chunks) != 10 || len(chunks[0]) != to.Size(DefaultChunkDuration) || len(slices.Concat(chunks...)) != to.Size(time.Second) {
+		t.Errorf("sent %d chunks, the first of %d bytes", len(chunks), len(chunks[0]))
+	}
+}
+
+func TestWriterCompressed(t *testing.T) {
+	mp3 := audio.Format{Codec: audio.CodecMP3, SampleRate: 44100, Channels: 2}
+	stream := &fakeStream{}
+	w, err := Open(context.Background(), &fakeClient{stream: stream}, "speaker", mp3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(make([]byte, compressedChunkSize+5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	chunks := stream.audioSent(t, "speaker", mp3)
+	if len(chunks) != 2 || len(chunks[0]) != compressedChunkSize || len(chunks[1]) != 5 {
+		t.Errorf("sent %d chunks", len(chunks))
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	f := audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}
+	errRejected := status.Error(codes.InvalidArgument, "unsupported sample rate")
+	for _, tc := range []struct {
+		name   string
+		stream *fakeStream
+		msg    string
+		code   codes.Code
+	}{
+		{"rejected", &fakeStream{sendErr: io.EOF, errAfter: 1, closeErr: errRejected}, "", codes.InvalidArgument},
+		{"ended early", &fakeStream{sendErr: io.EOF, errAfter: 1}, "ended the stream early", codes.Unknown},
+		{"failed on close", &fakeStream{closeErr: errRejected}, "", codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := Open(context.Background(), &fakeClient{stream: tc.stream}, "speaker", f, WithChunkDuration(10*time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+			check := func(what string, err error) {
+				t.Helper()
+				if status.Code(err) != tc.code || !strings.Contains(err.Error(), tc.msg) {
+					t.Errorf("%s = %v", what, err)
+				}
+			}
+			if _, err := w.Write(make([]byte, 160)); tc.stream.sendErr != nil {
+				check("Write", err)
+				// The error sticks.
+				_, err = w.Write(make([]byte, 160))
+				check("second Write", err)
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			check("Close", w.Close())
+			check("second Close", w.Close())
+		})
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	f := audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}
+	errUnavailable := status.Error(codes.Unavailable, "down")
+	for _, tc := range []struct {
+		name   string
+		client *fakeClient
+		src    audio.Format
+	}{
+		{"properties", &fakeClient{propsErr: errUnavailable}, f},
+		{"negotiation", &fakeClient{props: &commonpb.GetPropertiesResponse{SupportedCodecs: []string{audio.CodecMP3}}}, f},
+		{"stream", &fakeClient{openErr: errUnavailable}, f},
+		{"init rejected", &fakeClient{stream: &fakeStream{sendErr: io.EOF, closeErr: errUnavailable}}, f},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Open(context.Background(), tc.client, "speaker", tc.src); err == nil {
+				t.Error("Open succeeded")
+			}
+		})
+	}
+}
+
+func TestPlayWAV(t *testing.T) {
+	f := audio.Format{Codec: audio.CodecPCM16, SampleRate: 8000, Channels: 1}
+	path := filepath.Join(t.TempDir(), "a.wav")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ww, err := audio.NewWAVWriter(file, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte{1, 2}, 1000)
+	if _, err := ww.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := errors.Join(ww.Close(), file.Close()); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &fakeStream{}
+	if err := PlayWAV(context.Background(), &fakeClient{stream: stream}, "speaker", bytes.NewReader(b)); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Concat(stream.audioSent(t, "speaker", f)...); !bytes.Equal(got, data) {
+		t.Errorf("sent %d bytes, want %d", len(got), len(data))
+	}
+
+	// A truncated file is played as far as it goes, and the error reported.
+	stream = &fakeStream{}
+	err = PlayWAV(context.Background(), &fakeClient{stream: stream}, "speaker", bytes.NewReader(b[:len(b)-100]))
+	if !errors.Is(err, io.ErrUnexpectedEOF) || stream.closed != 1 {
+		t.Errorf("PlayWAV = %v, stream closed %d times", err, stream.closed)
+	}
+	if err := PlayWAV(context.Background(), &fakeClient{stream: &fakeStream{}}, "speaker", strings.NewReader("not a WAV file")); err == nil {
+		t.Error("PlayWAV of a text file succeeded")
+	}
+}
@@ -0,0 +1,144 @@
+// Package diffdrive models differential-drive bases as described by base.v1: it converts the velocities of
+// a base to the speeds of its wheels and back, turns MoveStraight and Spin calls into timed velocity
+// profiles, and integrates the positions of the wheels into an odometry estimate.
+//
+// Units and axes follow base.v1: distances are in millimeters and angles in degrees, the base moves forward
+// along +Y, and a positive angular velocity about +Z turns it counterclockwise, to the left.
+package diffdrive
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	commonpb "go.viam.com/api/common/v1"
+	basepb "go.viam.com/api/component/base/v1"
+)
+
+// Model describes a differential-drive base.
+type Model struct {
+	// WidthMM is the distance between the left and right wheels.
+	WidthMM float64
+	// WheelCircumferenceMM is the distance a wheel travels in one revolution.
+	WheelCircumferenceMM float64
+	// TurningRadiusMM is the smallest radius the base can turn with, or 0 if it can spin in place.
+	TurningRadiusMM float64
+	// MaxWheelSpeed is the highest speed of a wheel, in mm/s, or 0 if there is no limit.
+	MaxWheelSpeed float64
+	// MaxLinearAcceleration, in mm/s², and MaxAngularAcceleration, in deg/s², shape the profiles of
+	// MoveStraight and Spin. Zero means the velocity changes at once.
+	MaxLinearAcceleration  float64
+	MaxAngularAcceleration float64
+}
+
+// FromProperties returns the model of a base from its properties, which are in meters.
+func FromProperties(props *basepb.GetPropertiesResponse) (Model, error) {
+	m := Model{
+		WidthMM:              props.GetWidthMeters() * 1000,
+		WheelCircumferenceMM: props.GetWheelCircumferenceMeters() * 1000,
+		TurningRadiusMM:      props.GetTurningRadiusMeters() * 1000,
+	}
+	return m, m.Validate()
+}
+
+// Validate checks that the dimensions of the model are positive, and its limits not negative.
+func (m Model) Validate() error {
+	switch {
+	case !(m.WidthMM > 0) || math.IsInf(m.WidthMM, 0):
+		return fmt.Errorf("diffdrive: invalid width %v mm", m.WidthMM)
+	case !(m.WheelCircumferenceMM > 0) || math.IsInf(m.WheelCircumferenceMM, 0):
+		return fmt.Errorf("diffdrive: invalid wheel circumference %v mm", m.WheelCircumferenceMM)
+	case !(m.TurningRadiusMM >= 0):
+		return fmt.Errorf("diffdrive: invalid turning radius %v mm", m.TurningRadiusMM)
+	case !(m.MaxWheelSpeed >= 0) || !(m.MaxLinearAcceleration >= 0) || !(m.MaxAngularAcceleration >= 0):
+		return errors.New("diffdrive: limits must not be negative")
+	}
+	return nil
+}
+
+// Velocity is the velocity of a base: Linear along +Y in mm/s, and Angular about +Z in deg/s.
+type Velocity struct {
+	Linear  float64
+	Angular float64
+}
+
+// VelocityFromProto returns the velocity of a SetVelocityRequest. A differential-drive base cannot move
+// sideways or vertically, nor rotate about other axes than Z, so other components must be zero.
+func VelocityFromProto(linear, angular *commonpb.Vector3) (Velocity, error) {
+	if linear.GetX() != 0 || linear.GetZ() != 0 {
+		return Velocity{}, fmt.Errorf("diffdrive: cannot move along X or Z (linear velocity %v, %v, %v)",
+			linear.GetX(), linear.GetY(), linear.GetZ())
+	}
+	if angular.GetX() != 0 || angular.GetY() != 0 {
+		return Velocity{}, fmt.Errorf("diffdrive: cannot rotate about X or Y (angular velocity %v, %v, %v)",
+			angular.GetX(), angular.GetY(), angular.GetZ())
+	}
+	return Velocity{Linear: linear.GetY(), Angular: angular.GetZ()}, nil
+}
+
+// Proto returns the vectors of a SetVelocityRequest for v.
+func (v Velocity) Proto() (linear, angular *commonpb.Vector3) {
+	return &commonpb.Vector3{Y: v.Linear}, &commonpb.Vector3{Z: v.Angular}
+}
+
+// WheelSpeeds are the speeds of the left and right wheels, in mm/s. Positive speeds move the base forward.
+type WheelSpeeds struct {
+	Left  float64
+	Right float64
+}
+
+// WheelSpeeds returns the speeds of the wheels giving the base velocity v.
+func (m Model) WheelSpeeds(v Velocity) WheelSpeeds {
+	turn := radians(v.Angular) * m.WidthMM / 2
+	return WheelSpeeds{Left: v.Linear - turn, Right: v.Linear + turn}
+}
+
+// Velocity returns the velocity of the base when its wheels turn at w.
+func (m Model) Velocity(w WheelSpeeds) Velocity {
+	return Velocity{
+		Linear:  (w.Left + w.Right) / 2,
+		Angular: degrees((w.Right - w.Left) / m.WidthMM),
+	}
+}
+
+// RPM returns the speeds of w in revolutions per minute, as motor.v1.GoFor takes them.
+func (m Model) RPM(w WheelSpeeds) (left, right float64) {
+	return w.Left / m.WheelCircumferenceMM * 60, w.Right / m.WheelCircumferenceMM * 60
+}
+
+// Check reports whether the base can move at v: whether it turns no tighter than its turning radius, and
+// its wheels stay within their maximum speed.
+func (m Model) Check(v Velocity) error {
+	if math.IsNaN(v.Linear) || math.IsNaN(v.Angular) || math.IsInf(v.Linear, 0) || math.IsInf(v.Angular, 0) {
+		return fmt.Errorf("diffdrive: invalid velocity %v mm/s, %v deg/s", v.Linear, v.Angular)
+	}
+	if m.TurningRadiusMM > 0 && v.Angular != 0 {
+		if r := math.Abs(v.Linear / radians(v.Angular)); r < m.TurningRadiusMM {
+			return fmt.Errorf("diffdrive: turning radius of %.1f mm is below the minimum of %.1f mm", r, m.TurningRadiusMM)
+		}
+	}
+	if m.MaxWheelSpeed > 0 {
+		w := m.WheelSpeeds(v)
+		if s := math.Max(math.Abs(w.Left), math.Abs(w.Right)); s > m.MaxWheelSpeed {
+			return fmt.Errorf("diffdrive: wheel speed of %.1f mm/s exceeds the maximum of %.1f mm/s", s, m.MaxWheelSpeed)
+		}
+	}
+	return nil
+}
+
+// Limit scales v down, keeping the curvature of its path, so that no wheel exceeds the maximum speed.
+func (m Model) Limit(v Velocity) Velocity {
+	if m.MaxWheelSpeed <= 0 {
+		return v
+	}
+	w := m.WheelSpeeds(v)
+	if s := math.Max(math.Abs(w.Left), math.Abs(w.Right)); s > m.MaxWheelSpeed {
+		k := m.MaxWheelSpeed / s
+		return Velocity{Linear: v.Linear * k, Angular: v.Angular * k}
+	}
+	return v
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
@@ -0,0 +1,139 @@
+package diffdrive
+
+import (
+	"math"
+	"testing"
+
+	commonpb "go.viam.com/api/common/v1"
+	basepb "go.viam.com/api/component/base/v1"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6*math.Max(1, math.Abs(b))
+}
+
+func TestFromProperties(t *testing.T) {
+	m, err := FromProperties(&basepb.GetPropertiesResponse{WidthMeters: 0.4, WheelCircumferenceMeters: 0.2, TurningRadiusMeters: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(m.WidthMM, 400) || !near(m.WheelCircumferenceMM, 200) || !near(m.TurningRadiusMM, 500) {
+		t.Errorf("model = %+v", m)
+	}
+	if _, err := FromProperties(&basepb.GetPropertiesResponse{WidthMeters: 0.4}); err == nil {
+		t.Error("FromProperties accepted a base without wheels")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ok := Model{WidthMM: 400, WheelCircumferenceMM: 200}
+	for _, tc := range []struct {
+		name  string
+		edit  func(*Model)
+		valid bool
+	}{
+		{"valid", func(*Model) {}, true},
+		{"limits", func(m *Model) {
+			m.TurningRadiusMM, m.MaxWheelSpeed, m.MaxLinearAcceleration, m.MaxAngularAcceleration = 1, 2, 3, 4
+		}, true},
+		{"no width", func(m *Model) { m.WidthMM = 0 }, false},
+		{"NaN width", func(m *Model) { m.WidthMM = math.NaN() }, false},
+		{"infinite width", func(m *Model) { m.WidthMM = math.Inf(1) }, false},
+		{"negative circumference", func(m *Model) { m.WheelCircumferenceMM = -1 }, false},
+		{"negative turning radius", func(m *Model) { m.TurningRadiusMM = -1 }, false},
+		{"NaN turning radius", func(m *Model) { m.TurningRadiusMM = math.NaN() }, false},
+		{"negative speed", func(m *Model) { m.MaxWheelSpeed = -1 }, false},
+		{"negative acceleration", func(m *Model) { m.MaxAngularAcceleration = -1 }, false},
+	} {
+		m := ok
+		tc.edit(&m)
+		if err := m.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: Validate() = %v", tc.name, err)
+		}
+	}
+}
+
+func TestVelocityFromProto(t *testing.T) {
+	for _, tc := range []struct {
+		linear, angular *commonpb.Vector3
+		want            Velocity
+		ok              bool
+	}{
+		{&commonpb.Vector3{Y: 100}, &commonpb.Vector3{Z: 30}, Velocity{100, 30}, true},
+		{nil, nil, Velocity{}, true},
+		{&commonpb.Vector3{X: 1, Y: 100}, nil, Velocity{}, false},
+		{&commonpb.Vector3{Z: 1}, nil, Velocity{}, false},
+		{nil, &commonpb.Vector3{X: 1, Z: 30}, Velocity{}, false},
+		{nil, &commonpb.Vector3{Y: 1}, Velocity{}, false},
+	} {
+		got, err := VelocityFromProto(tc.linear, tc.angular)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("VelocityFromProto(%v, %v) = %v, %v", tc.linear, tc.angular, got, err)
+		}
+	}
+
+	linear, angular := Velocity{100, 30}.Proto()
+	if v, err := VelocityFromProto(linear, angular); err != nil || v != (Velocity{100, 30}) {
+		t.Errorf("round trip = %v, %v", v, err)
+	}
+}
+
+func TestWheelSpeeds(t *testing.T) {
+	m := Model{WidthMM: 400, WheelCircumferenceMM: 200}
+	for _, tc := range []struct {
+		v    Velocity
+		want WheelSpeeds
+	}{
+		{Velocity{100, 0}, WheelSpeeds{100, 100}},
+		{Velocity{-100, 0}, WheelSpeeds{-100, -100}},
+		// A quarter turn a second to the left: each wheel moves 200 mm × π/2 a second.
+		{Velocity{0, 90}, WheelSpeeds{-100 * math.Pi, 100 * math.Pi}},
+		{Velocity{100, -90}, WheelSpeeds{100 + 100*math.Pi, 100 - 100*math.Pi}},
+	} {
+		got := m.WheelSpeeds(tc.v)
+		if !near(got.Left, tc.want.Left) || !near(got.Right, tc.want.Right) {
+			t.Errorf("WheelSpeeds(%v) = %v, want %v", tc.v, got, tc.want)
+		}
+		if back := m.Velocity(got); !near(back.Linear, tc.v.Linear) || !near(back.Angular, tc.v.Angular) {
+			t.Errorf("Velocity(%v) = %v, want %v", got, back, tc.v)
+		}
+	}
+
+	if left, right := m.RPM(WheelSpeeds{100, -200}); !near(left, 30) || !near(right, -60) {
+		t.Errorf("RPM = %v, %v", left, right)
+	}
+}
+
+func TestCheckAndLimit(t *testing.T) {
+	m := Model{WidthMM: 400, WheelCircumferenceMM: 200, TurningRadiusMM: 500, MaxWheelSpeed: 300}
+	for _, tc := range []struct {
+		name string
+		v    Velocity
+		ok   bool
+	}{
+		{"straight", Velocity{300, 0}, true},
+		{"wide turn", Velocity{100, degrees(0.1)}, true},
+		{"tight turn", Velocity{100, degrees(0.5)}, false},
+		{"spin", Velocity{0, 10}, false},
+		{"too fast", Velocity{301, 0}, false},
+		{"outer wheel too fast", Velocity{250, degrees(0.4)}, false},
+		{"NaN", Velocity{math.NaN(), 0}, false},
+		{"infinite", Velocity{0, math.Inf(-1)}, false},
+	} {
+		if err := m.Check(tc.v); (err == nil) != tc.ok {
+			t.Errorf("%s: Check(%v) = %v", tc.name, tc.v, err)
+		}
+	}
+
+	// Limiting keeps the curvature, and brings the fastest wheel to the maximum speed.
+	v := m.Limit(Velocity{500, degrees(0.5)})
+	if w := m.WheelSpeeds(v); !near(w.Right, 300) || !near(v.Linear/radians(v.Angular), 1000) {
+		t.Errorf("Limit = %v, with wheel speeds %v", v, w)
+	}
+	if v := m.Limit(Velocity{100, 0}); v != (Velocity{100, 0}) {
+		t.Errorf("Limit of a velocity within the limit = %v", v)
+	}
+	if v := (Model{WidthMM: 400}).Limit(Velocity{1e6, 0}); v != (Velocity{1e6, 0}) {
+		t.Errorf("Limit without a maximum speed = %v", v)
+	}
+}
@@ -0,0 +1,187 @@
+package diffdrive
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "go.viam.com/api/common/v1"
+	basepb "go.viam.com/api/component/base/v1"
+)
+
+// DefaultWheelVariance is the variance of the distance traveled by a wheel per millimeter traveled, in
+// mm²/mm: about 1% of error after a meter.
+const DefaultWheelVariance = 0.1
+
+// An Option configures an Odometry.
+type Option func(*options)
+
+type options struct {
+	wheelVariance float64
+	start         Estimate
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{wheelVariance: DefaultWheelVariance}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithWheelVariance sets the variance of the distance traveled by each wheel per millimeter it travels,
+// which the covariance of the estimate grows with.
+func WithWheelVariance(v float64) Option {
+	return func(o *options) {
+		o.wheelVariance = v
+	}
+}
+
+// WithStart sets the pose the base starts at, from X, Y and Theta, and the covariance of that pose.
+func WithStart(e Estimate) Option {
+	return func(o *options) {
+		o.start = e
+	}
+}
+
+// Estimate is the odometry of a base: its pose in the plane relative to where the odometry started, and
+// its velocity.
+type Estimate struct {
+	// Time is the time of the wheel positions the estimate is made from.
+	Time time.Time
+	// X and Y are in mm, and Theta is the heading in degrees, counterclockwise from +Y.
+	X, Y, Theta float64
+	// Velocity is the average velocity since the previous update.
+	Velocity Velocity
+	// Covariance is the covariance of X, Y and Theta, in mm and degrees.
+	Covariance [3][3]float64
+}
+
+// Pose returns the pose of e, rotated about +Z by its heading.
+func (e Estimate) Pose() *commonpb.Pose {
+	return &commonpb.Pose{X: e.X, Y: e.Y, OZ: 1, Theta: e.Theta}
+}
+
+// GetOdometryResponse returns e as the response of base.v1.GetOdometry.
+func (e Estimate) GetOdometryResponse() *basepb.GetOdometryResponse {
+	linear, angular := e.Velocity.Proto()
+	return &basepb.GetOdometryResponse{
+		Pose:             e.Pose(),
+		LinearVelocity:   linear,
+		AngularVelocity:  angular,
+		ResponseMetadata: &commonpb.ResponseMetadata{CapturedAt: timestamppb.New(e.Time)},
+	}
+}
+
+// StreamOdometryResponse returns e as a response of base.v1.StreamOdometry.
+func (e Estimate) StreamOdometryResponse() *basepb.StreamOdometryResponse {
+	linear, angular := e.Velocity.Proto()
+	return &basepb.StreamOdometryResponse{
+		Pose:             e.Pose(),
+		LinearVelocity:   linear,
+		AngularVelocity:  angular,
+		ResponseMetadata: &commonpb.ResponseMetadata{CapturedAt: timestamppb.New(e.Time)},
+	}
+}
+
+// Odometry integrates the positions of the wheels of a base into an Estimate. It is safe for concurrent
+// use.
+type Odometry struct {
+	m Model
+	o *options
+
+	mu          sync.Mutex
+	started     bool
+	left, right float64
+	est         Estimate
+	// theta is the heading in radians, and cov the covariance with the heading in radians.
+	theta float64
+	cov   [3][3]float64
+}
+
+// NewOdometry returns an Odometry for a base described by m, whose model must be valid.
+func NewOdometry(m Model, opts ...Option) *Odometry {
+	o := newOptions(opts)
+	od := &Odometry{m: m, o: o, est: o.start, theta: radians(o.start.Theta)}
+	od.cov = scaleCovariance(o.start.Covariance, math.Pi/180)
+	return od
+}
+
+// Update integrates the positions of the left and right wheels at t, in revolutions, as motor.v1.GetPosition
+// returns them, and returns the new estimate. The first update only records the positions. Updates
+// that are not later than the previous one move the pose but keep the previous velocity.
+func (od *Odometry) Update(t time.Time, left, right float64) Estimate {
+	od.mu.Lock()
+	defer od.mu.Unlock()
+	if !od.started {
+		od.started = true
+		od.left, od.right = left, right
+		od.est.Time = t
+		return od.est
+	}
+	dl := (left - od.left) * od.m.WheelCircumferenceMM
+	dr := (right - od.right) * od.m.WheelCircumferenceMM
+	od.left, od.right = left, right
+
+	d := (dl + dr) / 2
+	dtheta := (dr - dl) / od.m.WidthMM
+	mid := od.theta + dtheta/2
+	sin, cos := math.Sincos(mid)
+
+	// The covariance is propagated through the motion, with independent errors on the distance traveled
+	// by each wheel.
+	fp := [3][3]float64{
+		{1, 0, -d * cos},
+		{0, 1, -d * sin},
+		{0, 0, 1},
+	}
+	w := od.m.WidthMM
+	fd := [3][2]float64{
+		{-sin/2 + d*cos/(2*w), -sin/2 - d*cos/(2*w)},
+		{cos/2 + d*sin/(2*w), cos/2 - d*sin/(2*w)},
+		{-1 / w, 1 / w},
+	}
+	ql, qr := od.o.wheelVariance*math.Abs(dl), od.o.wheelVariance*math.Abs(dr)
+	var cov [3][3]float64
+	for i := range 3 {
+		for j := range 3 {
+			var s float64
+			for k := range 3 {
+				for l := range 3 {
+					s += fp[i][k] * od.cov[k][l] * fp[j][l]
+				}
+			}
+			cov[i][j] = s + fd[i][0]*ql*fd[j][0] + fd[i][1]*qr*fd[j][1]
+		}
+	}
+	od.cov = cov
+
+	od.est.X -= d * sin
+	od.est.Y += d * cos
+	od.theta += dtheta
+	od.est.Theta = degrees(od.theta)
+	if dt := t.Sub(od.est.Time).Seconds(); dt > 0 {
+		od.est.Velocity = Velocity{Linear: d / dt, Angular: degrees(dtheta / dt)}
+		od.est.Time = t
+	}
+	od.est.Covariance = scaleCovariance(od.cov, 180/math.Pi)
+	return od.est
+}
+
+// Estimate returns the current estimate.
+func (od *Odometry) Estimate() Estimate {
+	od.mu.Lock()
+	defer od.mu.Unlock()
+	return od.est
+}
+
+// scaleCovariance scales the heading of a covariance of X, Y and a heading, by k.
+func scaleCovariance(c [3][3]float64, k float64) [3][3]float64 {
+	for i := range 3 {
+		c[i][2] *= k
+		c[2][i] *= k
+	}
+	return c
+}
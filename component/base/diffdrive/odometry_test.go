@@ -0,0 +1,155 @@
+package diffdrive
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// base is 200 mm wide, with wheels of 100 mm of circumference.
+var base = Model{WidthMM: 200, WheelCircumferenceMM: 100}
+
+func TestOdometry(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	// Turning a quarter turn in place takes each wheel 100 mm × π/2, or π/2 revolutions.
+	quarter := math.Pi / 2
+	for _, tc := range []struct {
+		name        string
+		opts        []Option
+		left, right float64
+		want        Estimate
+	}{
+		{"forward", nil, 10, 10, Estimate{Y: 1000, Velocity: Velocity{Linear: 1000}}},
+		{"backward", nil, -10, -10, Estimate{Y: -1000, Velocity: Velocity{Linear: -1000}}},
+		{"spin left", nil, -quarter, quarter, Estimate{Theta: 90, Velocity: Velocity{Angular: 90}}},
+		{"spin right", nil, quarter, -quarter, Estimate{Theta: -90, Velocity: Velocity{Angular: -90}}},
+		// Heading 90° is facing -X.
+		{"from a start", []Option{WithStart(Estimate{X: 100, Y: 50, Theta: 90})}, 10, 10, Estimate{X: -900, Y: 50, Theta: 90, Velocity: Velocity{Linear: 1000}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			od := NewOdometry(base, tc.opts...)
+			// The wheels need not start at 0.
+			if e := od.Update(t0, 5, 5); e.Time != t0 || e.Velocity != (Velocity{}) {
+				t.Errorf("first update = %+v", e)
+			}
+			e := od.Update(t0.Add(time.Second), 5+tc.left, 5+tc.right)
+			if !near(e.X, tc.want.X) || !near(e.Y, tc.want.Y) || !near(e.Theta, tc.want.Theta) {
+				t.Errorf("pose = %v, %v, %v; want %v, %v, %v", e.X, e.Y, e.Theta, tc.want.X, tc.want.Y, tc.want.Theta)
+			}
+			if !near(e.Velocity.Linear, tc.want.Velocity.Linear) || !near(e.Velocity.Angular, tc.want.Velocity.Angular) {
+				t.Errorf("velocity = %v, want %v", e.Velocity, tc.want.Velocity)
+			}
+			if e.Time != t0.Add(time.Second) || od.Estimate() != e {
+				t.Errorf("Estimate() = %+v, want %+v", od.Estimate(), e)
+			}
+		})
+	}
+}
+
+func TestOdometryArc(t *testing.T) {
+	// A quarter circle of radius 1 m to the left, in small steps, ends 1 m to the left and 1 m ahead,
+	// facing -X.
+	const radius, steps = 1000.0, 1000
+	od := NewOdometry(base)
+	t0 := time.Unix(0, 0)
+	od.Update(t0, 0, 0)
+	arc := func(r float64) float64 { return r * math.Pi / 2 / base.WheelCircumferenceMM }
+	var e Estimate
+	for i := 1; i <= steps; i++ {
+		f := float64(i) / steps
+		e = od.Update(t0.Add(time.Duration(i)*time.Millisecond), f*arc(radius-100), f*arc(radius+100))
+	}
+	if math.Abs(e.X+radius) > 0.01 || math.Abs(e.Y-radius) > 0.01 || !near(e.Theta, 90) {
+		t.Errorf("pose = %v, %v, %v", e.X, e.Y, e.Theta)
+	}
+	// Along the arc, the base moves at π/2 m/s and turns at 90°/s.
+	if !near(e.Velocity.Linear, radius*math.Pi/2) || !near(e.Velocity.Angular, 90) {
+		t.Errorf("velocity = %v", e.Velocity)
+	}
+}
+
+func TestOdometryStaleUpdate(t *testing.T) {
+	od := NewOdometry(base)
+	t0 := time.Unix(0, 0)
+	od.Update(t0, 0, 0)
+	od.Update(t0.Add(time.Second), 1, 1)
+	// An update at the same time moves the pose, but keeps the velocity and time.
+	e := od.Update(t0.Add(time.Second), 2, 2)
+	if !near(e.Y, 200) || !near(e.Velocity.Linear, 100) || e.Time != t0.Add(time.Second) {
+		t.Errorf("estimate = %+v", e)
+	}
+}
+
+func TestOdometryCovariance(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	od := NewOdometry(base)
+	od.Update(t0, 0, 0)
+	e := od.Update(t0.Add(time.Second), 10, 10)
+	// Each wheel traveled 1000 mm with a variance of 100 mm². The error on Y is their mean, and the error
+	// on X the heading error, (right - left) / width, over half the distance.
+	rad := 180 / math.Pi
+	want := [3][3]float64{
+		{1250, 0, -2.5 * rad},
+		{0, 50, 0},
+		{-2.5 * rad, 0, 0.005 * rad * rad},
+	}
+	for i := range 3 {
+		for j := range 3 {
+			if math.Abs(e.Covariance[i][j]-want[i][j]) > 1e-6*math.Max(1, math.Abs(want[i][j])) {
+				t.Errorf("covariance[%d][%d] = %v, want %v", i, j, e.Covariance[i][j], want[i][j])
+			}
+		}
+	}
+
+	// The covariance only grows, and stays symmetric.
+	prev := e.Covariance
+	e = od.Update(t0.Add(2*time.Second), 12, 15)
+	for i := range 3 {
+		if e.Covariance[i][i] < prev[i][i] {
+			t.Errorf("variance %d shrank from %v to %v", i, prev[i][i], e.Covariance[i][i])
+		}
+		for j := range 3 {
+			if !near(e.Covariance[i][j], e.Covariance[j][i]) {
+				t.Errorf("covariance is not symmetric: %v", e.Covariance)
+			}
+		}
+	}
+
+	// Without wheel errors, the covariance of the start stays as it is while driving straight ahead.
+	start := Estimate{Covariance: [3][3]float64{{4, 0, 0}, {0, 4, 0}, {0, 0, 0}}}
+	od = NewOdometry(base, WithWheelVariance(0), WithStart(start))
+	od.Update(t0, 0, 0)
+	if e := od.Update(t0.Add(time.Second), 10, 10); e.Covariance != start.Covariance {
+		t.Errorf("covariance = %v, want %v", e.Covariance, start.Covariance)
+	}
+}
+
+func TestOdometryResponses(t *testing.T) {
+	e := Estimate{
+		Time:     time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC),
+		X:        -900,
+		Y:        50,
+		Theta:    90,
+		Velocity: Velocity{Linear: 250, Angular: -15},
+	}
+	for _, resp := range []interface {
+		GetPose() *commonpb.Pose
+		GetLinearVelocity() *commonpb.Vector3
+		GetAngularVelocity() *commonpb.Vector3
+		GetResponseMetadata() *commonpb.ResponseMetadata
+	}{e.GetOdometryResponse(), e.StreamOdometryResponse()} {
+		p := resp.GetPose()
+		if p.GetX() != -900 || p.GetY() != 50 || p.GetZ() != 0 || p.GetOX() != 0 || p.GetOY() != 0 || p.GetOZ() != 1 || p.GetTheta() != 90 {
+			t.Errorf("pose = %v", p)
+		}
+		linear, angular := resp.GetLinearVelocity(), resp.GetAngularVelocity()
+		if v, err := VelocityFromProto(linear, angular); err != nil || v != e.Velocity {
+			t.Errorf("velocity = %v, %v; want %v", linear, angular, e.Velocity)
+		}
+		if at := resp.GetResponseMetadata().GetCapturedAt().AsTime(); !at.Equal(e.Time) {
+			t.Errorf("captured at %v, want %v", at, e.Time)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package diffdrive
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Segment is a part of a velocity profile, during which the velocity changes linearly from Start to End.
+type Segment struct {
+	Duration time.Duration
+	Start    Velocity
+	End      Velocity
+}
+
+// Profile is a sequence of segments, starting and ending at rest for the profiles of MoveStraight and
+// Spin.
+type Profile []Segment
+
+// Duration returns the total duration of the profile.
+func (p Profile) Duration() time.Duration {
+	var d time.Duration
+	for _, s := range p {
+		d += s.Duration
+	}
+	return d
+}
+
+// At returns the velocity at t since the start of the profile, which is zero outside of it.
+func (p Profile) At(t time.Duration) Velocity {
+	if t < 0 {
+		return Velocity{}
+	}
+	for _, s := range p {
+		if t < s.Duration {
+			f := float64(t) / float64(s.Duration)
+			return Velocity{
+				Linear:  s.Start.Linear + (s.End.Linear-s.Start.Linear)*f,
+				Angular: s.Start.Angular + (s.End.Angular-s.Start.Angular)*f,
+			}
+		}
+		t -= s.Duration
+	}
+	return Velocity{}
+}
+
+// Displacement returns the distance traveled over the profile, in mm, and the angle turned, in degrees.
+func (p Profile) Displacement() (distance, angle float64) {
+	for _, s := range p {
+		secs := s.Duration.Seconds()
+		distance += (s.Start.Linear + s.End.Linear) / 2 * secs
+		angle += (s.Start.Angular + s.End.Angular) / 2 * secs
+	}
+	return distance, angle
+}
+
+// MoveStraight returns the profile moving the base distanceMM at mmPerSec, as base.v1.MoveStraight does.
+// The base moves backwards if exactly one of them is negative. The speed is capped by MaxWheelSpeed, and
+// the base accelerates and decelerates at MaxLinearAcceleration, not reaching its speed if the distance is
+// too short for it.
+func (m Model) MoveStraight(distanceMM, mmPerSec float64) (Profile, error) {
+	if err := checkMove(distanceMM, mmPerSec); err != nil {
+		return nil, err
+	}
+	v := m.Limit(Velocity{Linear: mmPerSec}).Linear
+	linear := func(v float64) Velocity { return Velocity{Linear: v} }
+	return trapezoid(math.Abs(distanceMM), math.Abs(v), m.MaxLinearAcceleration, sign(distanceMM*mmPerSec), linear), nil
+}
+
+// Spin returns the profile turning the base angleDeg in place at degsPerSec, as base.v1.Spin does. The
+// base turns clockwise if exactly one of them is negative. The speed is capped by MaxWheelSpeed, and the
+// base accelerates and decelerates at MaxAngularAcceleration, not reaching its speed if the angle is too
+// small for it.
+func (m Model) Spin(angleDeg, degsPerSec float64) (Profile, error) {
+	if m.TurningRadiusMM > 0 {
+		return nil, fmt.Errorf("diffdrive: cannot spin in place with a turning radius of %.1f mm", m.TurningRadiusMM)
+	}
+	if err := checkMove(angleDeg, degsPerSec); err != nil {
+		return nil, err
+	}
+	v := m.Limit(Velocity{Angular: degsPerSec}).Angular
+	angular := func(v float64) Velocity { return Velocity{Angular: v} }
+	return trapezoid(math.Abs(angleDeg), math.Abs(v), m.MaxAngularAcceleration, sign(angleDeg*degsPerSec), angular), nil
+}
+
+func checkMove(amount, speed float64) error {
+	switch {
+	case math.IsNaN(amount) || math.IsInf(amount, 0):
+		return fmt.Errorf("diffdrive: invalid distance or angle %v", amount)
+	case speed == 0 || math.IsNaN(speed) || math.IsInf(speed, 0):
+		return fmt.Errorf("diffdrive: invalid speed %v", speed)
+	}
+	return nil
+}
+
+// trapezoid returns the profile covering amount at speed, accelerating and decelerating at accel, or at
+// once if it is 0. Velocities are made by velocity from signed speeds.
+func trapezoid(amount, speed, accel, dir float64, velocity func(float64) Velocity) Profile {
+	if amount == 0 {
+		return nil
+	}
+	seconds := func(s float64) time.Duration { return time.Duration(s * float64(time.Second)) }
+	top := velocity(dir * speed)
+	if accel <= 0 {
+		return Profile{{Duration: seconds(amount / speed), Start: top, End: top}}
+	}
+	ramp := speed * speed / (2 * accel)
+	if 2*ramp >= amount {
+		// The profile is a triangle: the base must decelerate before reaching speed.
+		peak := math.Sqrt(amount * accel)
+		top = velocity(dir * peak)
+		d := seconds(peak / accel)
+		return Profile{{Duration: d, End: top}, {Duration: d, Start: top}}
+	}
+	d := seconds(speed / accel)
+	return Profile{
+		{Duration: d, End: top},
+		{Duration: seconds((amount - 2*ramp) / speed), Start: top, End: top},
+		{Duration: d, Start: top},
+	}
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,136 @@
+package diffdrive
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoveStraight(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		m         Model
+		distance  float64
+		speed     float64
+		durations []time.Duration
+		top       float64
+	}{
+		{"constant", Model{}, 1000, 200, []time.Duration{5 * time.Second}, 200},
+		{"backwards", Model{}, -1000, 200, []time.Duration{5 * time.Second}, -200},
+		{"negative speed", Model{}, 1000, -200, []time.Duration{5 * time.Second}, -200},
+		{"both negative", Model{}, -1000, -200, []time.Duration{5 * time.Second}, 200},
+		{"trapezoid", Model{MaxLinearAcceleration: 100}, 1000, 200, []time.Duration{2 * time.Second, 3 * time.Second, 2 * time.Second}, 200},
+		{"triangle", Model{MaxLinearAcceleration: 100}, 100, 200, []time.Duration{time.Second, time.Second}, 100},
+		{"limited", Model{WidthMM: 400, MaxWheelSpeed: 100}, 1000, 200, []time.Duration{10 * time.Second}, 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := tc.m.MoveStraight(tc.distance, tc.speed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p) != len(tc.durations) {
+				t.Fatalf("profile = %+v", p)
+			}
+			var total time.Duration
+			for i, s := range p {
+				if s.Duration != tc.durations[i] {
+					t.Errorf("segment %d lasts %v, want %v", i, s.Duration, tc.durations[i])
+				}
+				total += s.Duration
+			}
+			if p.Duration() != total {
+				t.Errorf("Duration() = %v, want %v", p.Duration(), total)
+			}
+			// The profile peaks at its top speed, and ends at rest after covering the distance.
+			peak := p.At(tc.durations[0])
+			if len(p) == 1 {
+				peak = p.At(0)
+			}
+			if !near(peak.Linear, tc.top) || peak.Angular != 0 {
+				t.Errorf("peak velocity = %v, want %v", peak, tc.top)
+			}
+			if v := p.At(total); v != (Velocity{}) {
+				t.Errorf("velocity at the end = %v", v)
+			}
+			distance, angle := p.Displacement()
+			if want := math.Abs(tc.distance) * math.Copysign(1, tc.top); !near(distance, want) || angle != 0 {
+				t.Errorf("Displacement() = %v, %v; want %v", distance, angle, want)
+			}
+		})
+	}
+}
+
+func TestProfileAt(t *testing.T) {
+	p, err := Model{MaxLinearAcceleration: 100}.MoveStraight(1000, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		t    time.Duration
+		want float64
+	}{
+		{-time.Second, 0},
+		{0, 0},
+		{time.Second, 100},
+		{3 * time.Second, 200},
+		{6 * time.Second, 100},
+		{7 * time.Second, 0},
+		{time.Hour, 0},
+	} {
+		if got := p.At(tc.t); !near(got.Linear, tc.want) {
+			t.Errorf("At(%v) = %v, want %v", tc.t, got, tc.want)
+		}
+	}
+}
+
+func TestSpin(t *testing.T) {
+	m := Model{WidthMM: 400, MaxAngularAcceleration: 90}
+	p, err := m.Spin(-180, 90)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Duration() != 3*time.Second || !near(p.At(1500*time.Millisecond).Angular, -90) {
+		t.Errorf("profile = %+v", p)
+	}
+	if distance, angle := p.Displacement(); distance != 0 || !near(angle, -180) {
+		t.Errorf("Displacement() = %v, %v", distance, angle)
+	}
+
+	// The wheels of a base 400 mm wide turning at 1 rad/s move at 200 mm/s.
+	m.MaxWheelSpeed = 200
+	if p, err = m.Spin(360, 360); err != nil {
+		t.Fatal(err)
+	}
+	if top := p.At(p.Duration() / 2).Angular; !near(top, degrees(1)) {
+		t.Errorf("top speed = %v deg/s, want %v", top, degrees(1))
+	}
+
+	if _, err := (Model{WidthMM: 400, TurningRadiusMM: 100}).Spin(90, 45); err == nil {
+		t.Error("a base with a turning radius spun in place")
+	}
+}
+
+func TestMoveErrors(t *testing.T) {
+	var m Model
+	for _, tc := range []struct {
+		amount, speed float64
+	}{
+		{100, 0},
+		{100, math.NaN()},
+		{100, math.Inf(1)},
+		{math.NaN(), 100},
+		{math.Inf(-1), 100},
+	} {
+		if _, err := m.MoveStraight(tc.amount, tc.speed); err == nil {
+			t.Errorf("MoveStraight(%v, %v) succeeded", tc.amount, tc.speed)
+		}
+		if _, err := m.Spin(tc.amount, tc.speed); err == nil {
+			t.Errorf("Spin(%v, %v) succeeded", tc.amount, tc.speed)
+		}
+	}
+
+	// Not moving at all is an empty profile.
+	if p, err := m.MoveStraight(0, 100); err != nil || p != nil || p.Duration() != 0 || p.At(0) != (Velocity{}) {
+		t.Errorf("MoveStraight(0) = %v, %v", p, err)
+	}
+}
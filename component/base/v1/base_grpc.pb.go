@@ -48,6 +48,11 @@ type BaseServiceClient interface {
 	GetGeometries(ctx context.Context, in *v1.GetGeometriesRequest, opts ...grpc.CallOption) (*v1.GetGeometriesResponse, error)
 	// GetProperties returns the properties of a base in its current configuration
 	GetProperties(ctx context.Context, in *GetPropertiesRequest, opts ...grpc.CallOption) (*GetPropertiesResponse, error)
+	// GetOdometry returns the pose of a base relative to where its odometry started, and its measured
+	// linear and angular velocity
+	GetOdometry(ctx context.Context, in *GetOdometryRequest, opts ...grpc.CallOption) (*GetOdometryResponse, error)
+	// StreamOdometry streams the odometry of a base at the requested interval
+	StreamOdometry(ctx context.Context, in *StreamOdometryRequest, opts ...grpc.CallOption) (BaseService_StreamOdometryClient, error)
 }
 
 type baseServiceClient struct {
@@ -148,6 +153,47 @@ func (c *baseServiceClient) GetProperties(ctx context.Context, in *GetProperties
 	return out, nil
 }
 
+func (c *baseServiceClient) GetOdometry(ctx context.Context, in *GetOdometryRequest, opts ...grpc.CallOption) (*GetOdometryResponse, error) {
+	out := new(GetOdometryResponse)
+	err := c.cc.Invoke(ctx, "/viam.component.base.v1.BaseService/GetOdometry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *baseServiceClient) StreamOdometry(ctx context.Context, in *StreamOdometryRequest, opts ...grpc.CallOption) (BaseService_StreamOdometryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BaseService_ServiceDesc.Streams[0], "/viam.component.base.v1.BaseService/StreamOdometry", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &baseServiceStreamOdometryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BaseService_StreamOdometryClient interface {
+	Recv() (*StreamOdometryResponse, error)
+	grpc.ClientStream
+}
+
+type baseServiceStreamOdometryClient struct {
+	grpc.ClientStream
+}
+
+func (x *baseServiceStreamOdometryClient) Recv() (*StreamOdometryResponse, error) {
+	m := new(StreamOdometryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BaseServiceServer is the server API for BaseService service.
 // All implementations must embed UnimplementedBaseServiceServer
 // for forward compatibility
@@ -177,6 +223,11 @@ type BaseServiceServer interface {
 	GetGeometries(context.Context, *v1.GetGeometriesRequest) (*v1.GetGeometriesResponse, error)
 	// GetProperties returns the properties of a base in its current configuration
 	GetProperties(context.Context, *GetPropertiesRequest) (*GetPropertiesResponse, error)
+	// GetOdometry returns the pose of a base relative to where its odometry started, and its measured
+	// linear and angular velocity
+	GetOdometry(context.Context, *GetOdometryRequest) (*GetOdometryResponse, error)
+	// StreamOdometry streams the odometry of a base at the requested interval
+	StreamOdometry(*StreamOdometryRequest, BaseService_StreamOdometryServer) error
 	mustEmbedUnimplementedBaseServiceServer()
 }
 
@@ -214,6 +265,12 @@ func (UnimplementedBaseServiceServer) GetGeometries(context.Context, *v1.GetGeom
 func (UnimplementedBaseServiceServer) GetProperties(context.Context, *GetPropertiesRequest) (*GetPropertiesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProperties not implemented")
 }
+func (UnimplementedBaseServiceServer) GetOdometry(context.Context, *GetOdometryRequest) (*GetOdometryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOdometry not implemented")
+}
+func (UnimplementedBaseServiceServer) StreamOdometry(*StreamOdometryRequest, BaseService_StreamOdometryServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOdometry not implemented")
+}
 func (UnimplementedBaseServiceServer) mustEmbedUnimplementedBaseServiceServer() {}
 
 // UnsafeBaseServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -407,6 +464,45 @@ func _BaseService_GetProperties_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BaseService_GetOdometry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOdometryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BaseServiceServer).GetOdometry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/viam.component.base.v1.BaseService/GetOdometry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BaseServiceServer).GetOdometry(ctx, req.(*GetOdometryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BaseService_StreamOdometry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOdometryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BaseServiceServer).StreamOdometry(m, &baseServiceStreamOdometryServer{stream})
+}
+
+type BaseService_StreamOdometryServer interface {
+	Send(*StreamOdometryResponse) error
+	grpc.ServerStream
+}
+
+type baseServiceStreamOdometryServer struct {
+	grpc.ServerStream
+}
+
+func (x *baseServiceStreamOdometryServer) Send(m *StreamOdometryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // BaseService_ServiceDesc is the grpc.ServiceDesc for BaseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -454,7 +550,17 @@ var BaseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProperties",
 			Handler:    _BaseService_GetProperties_Handler,
 		},
+		{
+			MethodName: "GetOdometry",
+			Handler:    _BaseService_GetOdometry_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOdometry",
+			Handler:       _BaseService_StreamOdometry_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "component/base/v1/base.proto",
 }
@@ -0,0 +1,293 @@
+// Package buses implements the I2C, SPI and UART methods of BoardService on Linux, through the i2c-dev
+// and spidev interfaces of the kernel and termios.
+//
+// Buses and ports are named as the kernel names their devices: I2C bus "1" is /dev/i2c-1, chip select
+// "1" of SPI bus "0" is /dev/spidev0.1, and serial port "ttyS0" is /dev/ttyS0, unless options map them to
+// other devices. Serial ports can also be named by their path under /dev, such as "/dev/pts/3", so that
+// pseudo-terminals can stand in for them. Only terminals are opened by name: /dev/tty*, /dev/pts/* and
+// /dev/serial/by-id/*. Other devices must be mapped with WithUARTPort. On other platforms, every method
+// fails with Unimplemented.
+package buses
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+const (
+	// maxI2CAddress is the largest 10-bit address. Addresses above 0x7f are sent as 10-bit addresses.
+	maxI2CAddress = 0x3ff
+	// maxI2CMessages is the largest number of messages the kernel accepts in a transaction.
+	maxI2CMessages = 42
+	// maxI2CLength is the largest number of bytes of a message.
+	maxI2CLength = 0xffff
+)
+
+var errUnsupported = errors.New("buses are not supported on this platform")
+
+// An Option configures a Handler.
+type Option func(*options)
+
+type options struct {
+	i2c  map[string]string
+	spi  map[spiDevice]string
+	uart map[string]string
+}
+
+type spiDevice struct {
+	bus, chipSelect string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		i2c:  make(map[string]string),
+		spi:  make(map[spiDevice]string),
+		uart: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithI2CBus maps an I2C bus to the device at path.
+func WithI2CBus(name, path string) Option {
+	return func(o *options) {
+		o.i2c[name] = path
+	}
+}
+
+// WithSPIDevice maps a chip select of an SPI bus to the device at path.
+func WithSPIDevice(bus, chipSelect, path string) Option {
+	return func(o *options) {
+		o.spi[spiDevice{bus, chipSelect}] = path
+	}
+}
+
+// WithUARTPort maps a serial port to the device at path, which need not be a terminal under /dev.
+func WithUARTPort(name, path string) Option {
+	return func(o *options) {
+		o.uart[name] = path
+	}
+}
+
+// Handler serves the ReadI2C, WriteI2C, TransactI2C, TransferSPI and StreamUART methods. A
+// BoardServiceServer implements them by calling a Handler, once it has checked the name of the board.
+type Handler struct {
+	o *options
+
+	mu sync.Mutex
+	// spiLocks serialize the transfers of each SPI device, whose mode is set before each of them.
+	spiLocks map[string]*sync.Mutex
+}
+
+// NewHandler returns a Handler.
+func NewHandler(opts ...Option) *Handler {
+	return &Handler{o: newOptions(opts), spiLocks: make(map[string]*sync.Mutex)}
+}
+
+// ReadI2C reads bytes from a device, after writing the register to read from, if any, in the same
+// transaction.
+func (h *Handler) ReadI2C(ctx context.Context, req *boardpb.ReadI2CRequest) (*boardpb.ReadI2CResponse, error) {
+	var msgs []i2cMessage
+	if req.Register != nil {
+		reg, err := register(req.GetRegister())
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, i2cMessage{data: []byte{reg}})
+	}
+	if req.GetLength() > maxI2CLength {
+		return nil, status.Errorf(codes.InvalidArgument, "buses: cannot read %d bytes at once", req.GetLength())
+	}
+	data := make([]byte, req.GetLength())
+	msgs = append(msgs, i2cMessage{read: true, data: data})
+	if err := h.transactI2C(req.GetBus(), req.GetAddress(), msgs); err != nil {
+		return nil, err
+	}
+	return &boardpb.ReadI2CResponse{Data: data}, nil
+}
+
+// WriteI2C writes bytes to a device, preceded by the register to write to, if any.
+func (h *Handler) WriteI2C(ctx context.Context, req *boardpb.WriteI2CRequest) (*boardpb.WriteI2CResponse, error) {
+	var data []byte
+	if req.Register != nil {
+		reg, err := register(req.GetRegister())
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, reg)
+	}
+	data = append(data, req.GetData()...)
+	if err := h.transactI2C(req.GetBus(), req.GetAddress(), []i2cMessage{{data: data}}); err != nil {
+		return nil, err
+	}
+	return &boardpb.WriteI2CResponse{}, nil
+}
+
+// TransactI2C runs the messages of the request as one transaction.
+func (h *Handler) TransactI2C(ctx context.Context, req *boardpb.TransactI2CRequest) (*boardpb.TransactI2CResponse, error) {
+	msgs := make([]i2cMessage, len(req.GetMessages()))
+	for i, m := range req.GetMessages() {
+		switch op := m.GetOperation().(type) {
+		case *boardpb.I2CMessage_Write:
+			msgs[i] = i2cMessage{data: op.Write}
+		case *boardpb.I2CMessage_ReadLength:
+			if op.ReadLength > maxI2CLength {
+				return nil, status.Errorf(codes.InvalidArgument, "buses: cannot read %d bytes at once", op.ReadLength)
+			}
+			msgs[i] = i2cMessage{read: true, data: make([]byte, op.ReadLength)}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "buses: message %d is neither a read nor a write", i)
+		}
+	}
+	if err := h.transactI2C(req.GetBus(), req.GetAddress(), msgs); err != nil {
+		return nil, err
+	}
+	resp := &boardpb.TransactI2CResponse{}
+	for _, m := range msgs {
+		if m.read {
+			resp.Reads = append(resp.Reads, m.data)
+		}
+	}
+	return resp, nil
+}
+
+// i2cMessage is a message of an I2C transaction: bytes to write, or a buffer to read into.
+type i2cMessage struct {
+	read bool
+	data []byte
+}
+
+func (h *Handler) transactI2C(bus string, address uint32, msgs []i2cMessage) error {
+	switch {
+	case address > maxI2CAddress:
+		return status.Errorf(codes.InvalidArgument, "buses: invalid I2C address %#x", address)
+	case len(msgs) == 0:
+		return status.Error(codes.InvalidArgument, "buses: I2C transaction has no messages")
+	case len(msgs) > maxI2CMessages:
+		return status.Errorf(codes.InvalidArgument, "buses: I2C transaction has more than %d messages", maxI2CMessages)
+	}
+	for _, m := range msgs {
+		if len(m.data) > maxI2CLength {
+			return status.Errorf(codes.InvalidArgument, "buses: I2C message of %d bytes is too long", len(m.data))
+		}
+	}
+	p, err := h.i2cPath(bus)
+	if err != nil {
+		return err
+	}
+	if err := i2cTransfer(p, uint16(address), msgs); err != nil {
+		return deviceError("I2C bus "+bus, err)
+	}
+	return nil
+}
+
+func register(reg uint32) (byte, error) {
+	if reg > 0xff {
+		return 0, status.Errorf(codes.InvalidArgument, "buses: invalid I2C register %#x", reg)
+	}
+	return byte(reg), nil
+}
+
+// TransferSPI sends the bytes of the request and returns the bytes received meanwhile.
+func (h *Handler) TransferSPI(ctx context.Context, req *boardpb.TransferSPIRequest) (*boardpb.TransferSPIResponse, error) {
+	if req.GetMode() > 3 {
+		return nil, status.Errorf(codes.InvalidArgument, "buses: invalid SPI mode %d", req.GetMode())
+	}
+	p, err := h.spiPath(req.GetBus(), req.GetChipSelect())
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	lock, ok := h.spiLocks[p]
+	if !ok {
+		lock = &sync.Mutex{}
+		h.spiLocks[p] = lock
+	}
+	h.mu.Unlock()
+
+	rx := make([]byte, len(req.GetData()))
+	lock.Lock()
+	err = spiTransfer(p, uint8(req.GetMode()), req.GetSpeedHz(), req.GetData(), rx)
+	lock.Unlock()
+	if err != nil {
+		return nil, deviceError("SPI bus "+req.GetBus(), err)
+	}
+	return &boardpb.TransferSPIResponse{Data: rx}, nil
+}
+
+func (h *Handler) i2cPath(bus string) (string, error) {
+	if p, ok := h.o.i2c[bus]; ok {
+		return p, nil
+	}
+	if !validName(bus) {
+		return "", status.Errorf(codes.InvalidArgument, "buses: invalid I2C bus %q", bus)
+	}
+	return "/dev/i2c-" + bus, nil
+}
+
+func (h *Handler) spiPath(bus, chipSelect string) (string, error) {
+	if p, ok := h.o.spi[spiDevice{bus, chipSelect}]; ok {
+		return p, nil
+	}
+	if !validName(bus) || !validName(chipSelect) {
+		return "", status.Errorf(codes.InvalidArgument, "buses: invalid SPI bus %q or chip select %q", bus, chipSelect)
+	}
+	return "/dev/spidev" + bus + "." + chipSelect, nil
+}
+
+func (h *Handler) uartPath(port string) (string, error) {
+	if p, ok := h.o.uart[port]; ok {
+		return p, nil
+	}
+	name := strings.TrimPrefix(path.Clean(path.Join("/dev", strings.TrimPrefix(port, "/dev/"))), "/dev/")
+	if port == "" || !terminalName(name) {
+		return "", status.Errorf(codes.InvalidArgument, "buses: invalid serial port %q", port)
+	}
+	return "/dev/" + name, nil
+}
+
+// terminalName reports whether name, a path under /dev, names a terminal, so that opening it by name does
+// not touch other devices, some of which act as soon as they are opened.
+func terminalName(name string) bool {
+	dir, file := path.Split(name)
+	switch dir {
+	case "":
+		return strings.HasPrefix(file, "tty") && len(file) > len("tty")
+	case "pts/", "serial/by-id/":
+		return file != ""
+	}
+	return false
+}
+
+// validName reports whether a bus or chip select can be part of the name of a device.
+func validName(s string) bool {
+	return s != "" && !strings.ContainsAny(s, "/.")
+}
+
+// deviceError converts an error using a device into a status.
+func deviceError(device string, err error) error {
+	code := codes.Unavailable
+	switch {
+	case errors.Is(err, errUnsupported):
+		code = codes.Unimplemented
+	case errors.Is(err, fs.ErrNotExist):
+		code = codes.NotFound
+	case errors.Is(err, fs.ErrPermission):
+		code = codes.PermissionDenied
+	case errors.Is(err, syscall.EINVAL), errors.Is(err, syscall.EMSGSIZE):
+		code = codes.InvalidArgument
+	}
+	return status.Errorf(code, "buses: %s: %v", device, err)
+}
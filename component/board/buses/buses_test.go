@@ -0,0 +1,274 @@
+package buses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// missingDevice is the code of the error of a device that does not exist.
+func missingDevice() codes.Code {
+	if runtime.GOOS == "linux" {
+		return codes.NotFound
+	}
+	return codes.Unimplemented
+}
+
+func TestUARTPath(t *testing.T) {
+	h := NewHandler(WithUARTPort("gps", "/dev/mem"), WithUARTPort("/dev/ttyS1", "/dev/ttyAMA0"))
+	for _, tc := range []struct {
+		port string
+		want string
+	}{
+		{"ttyS0", "/dev/ttyS0"},
+		{"/dev/ttyUSB0", "/dev/ttyUSB0"},
+		{"pts/3", "/dev/pts/3"},
+		{"/dev/pts/3", "/dev/pts/3"},
+		{"serial/by-id/usb-FTDI_FT232R-if00-port0", "/dev/serial/by-id/usb-FTDI_FT232R-if00-port0"},
+		{"/dev/serial/by-id/usb-FTDI", "/dev/serial/by-id/usb-FTDI"},
+		// Mapped ports can be any device.
+		{"gps", "/dev/mem"},
+		{"/dev/ttyS1", "/dev/ttyAMA0"},
+		// Only terminals are opened by name.
+		{"", ""},
+		{"tty", ""},
+		{"mem", ""},
+		{"watchdog", ""},
+		{"sda", ""},
+		{"/dev/watchdog0", ""},
+		{"/etc/passwd", ""},
+		{"pts/", ""},
+		{"pts/../mem", ""},
+		{"/dev/pts/../mem", ""},
+		{"/dev/ttyS0/../mem", ""},
+		{"../../etc/passwd", ""},
+		{"serial/by-id/", ""},
+		{"serial/by-path/platform-uart", ""},
+		{"pts/3/x", ""},
+		{"bus/ttyS0", ""},
+	} {
+		got, err := h.uartPath(tc.port)
+		switch {
+		case tc.want == "" && status.Code(err) != codes.InvalidArgument:
+			t.Errorf("uartPath(%q) = %q, %v; want InvalidArgument", tc.port, got, err)
+		case tc.want != "" && (err != nil || got != tc.want):
+			t.Errorf("uartPath(%q) = %q, %v; want %q", tc.port, got, err, tc.want)
+		}
+	}
+}
+
+func TestI2CAndSPIPaths(t *testing.T) {
+	h := NewHandler(WithI2CBus("imu", "/dev/i2c-7"), WithSPIDevice("adc", "a", "/dev/spidev3.0"))
+	for _, tc := range []struct {
+		name string
+		path func() (string, error)
+		want string
+	}{
+		{"i2c", func() (string, error) { return h.i2cPath("1") }, "/dev/i2c-1"},
+		{"mapped i2c", func() (string, error) { return h.i2cPath("imu") }, "/dev/i2c-7"},
+		{"empty i2c", func() (string, error) { return h.i2cPath("") }, ""},
+		{"i2c path", func() (string, error) { return h.i2cPath("../mem") }, ""},
+		{"spi", func() (string, error) { return h.spiPath("0", "1") }, "/dev/spidev0.1"},
+		{"mapped spi", func() (string, error) { return h.spiPath("adc", "a") }, "/dev/spidev3.0"},
+		{"spi bus with a dot", func() (string, error) { return h.spiPath("0.1", "1") }, ""},
+		{"empty chip select", func() (string, error) { return h.spiPath("0", "") }, ""},
+		{"chip select path", func() (string, error) { return h.spiPath("0", "1/../../mem") }, ""},
+	} {
+		got, err := tc.path()
+		switch {
+		case tc.want == "" && status.Code(err) != codes.InvalidArgument:
+			t.Errorf("%s: got %q, %v; want InvalidArgument", tc.name, got, err)
+		case tc.want != "" && (err != nil || got != tc.want):
+			t.Errorf("%s: got %q, %v; want %q", tc.name, got, err, tc.want)
+		}
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	ctx := context.Background()
+	// The device exists nowhere: requests that get as far as opening it fail with NotFound.
+	missing := filepath.Join(t.TempDir(), "missing")
+	h := NewHandler(WithI2CBus("1", missing), WithSPIDevice("0", "0", missing))
+	tooMany := make([]*boardpb.I2CMessage, maxI2CMessages+1)
+	for i := range tooMany {
+		tooMany[i] = &boardpb.I2CMessage{Operation: &boardpb.I2CMessage_Write{Write: []byte{1}}}
+	}
+	for _, tc := range []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"read", func() error {
+			_, err := h.ReadI2C(ctx, &boardpb.ReadI2CRequest{Bus: "1", Address: 0x40, Register: proto.Uint32(2), Length: 2})
+			return err
+		}, missingDevice()},
+		{"read from a 10-bit address", func() error {
+			_, err := h.ReadI2C(ctx, &boardpb.ReadI2CRequest{Bus: "1", Address: 0x3ff, Length: 2})
+			return err
+		}, missingDevice()},
+		{"invalid address", func() error {
+			_, err := h.ReadI2C(ctx, &boardpb.ReadI2CRequest{Bus: "1", Address: 0x400, Length: 2})
+			return err
+		}, codes.InvalidArgument},
+		{"invalid register", func() error {
+			_, err := h.ReadI2C(ctx, &boardpb.ReadI2CRequest{Bus: "1", Address: 0x40, Register: proto.Uint32(0x100), Length: 2})
+			return err
+		}, codes.InvalidArgument},
+		{"read too long", func() error {
+			_, err := h.ReadI2C(ctx, &boardpb.ReadI2CRequest{Bus: "1", Address: 0x40, Length: maxI2CLength + 1})
+			return err
+		}, codes.InvalidArgument},
+		{"invalid bus", func() error {
+			_, err := h.ReadI2C(ctx, &boardpb.ReadI2CRequest{Bus: "../1", Address: 0x40, Length: 2})
+			return err
+		}, codes.InvalidArgument},
+		{"write", func() error {
+			_, err := h.WriteI2C(ctx, &boardpb.WriteI2CRequest{Bus: "1", Address: 0x40, Data: []byte{1}})
+			return err
+		}, missingDevice()},
+		{"write too long with its register", func() error {
+			_, err := h.WriteI2C(ctx, &boardpb.WriteI2CRequest{Bus: "1", Address: 0x40, Register: proto.Uint32(1), Data: make([]byte, maxI2CLength)})
+			return err
+		}, codes.InvalidArgument},
+		{"invalid write register", func() error {
+			_, err := h.WriteI2C(ctx, &boardpb.WriteI2CRequest{Bus: "1", Address: 0x40, Register: proto.Uint32(0x100)})
+			return err
+		}, codes.InvalidArgument},
+		{"transaction", func() error {
+			_, err := h.TransactI2C(ctx, &boardpb.TransactI2CRequest{Bus: "1", Address: 0x40, Messages: tooMany[:2]})
+			return err
+		}, missingDevice()},
+		{"empty transaction", func() error {
+			_, err := h.TransactI2C(ctx, &boardpb.TransactI2CRequest{Bus: "1", Address: 0x40})
+			return err
+		}, codes.InvalidArgument},
+		{"too many messages", func() error {
+			_, err := h.TransactI2C(ctx, &boardpb.TransactI2CRequest{Bus: "1", Address: 0x40, Messages: tooMany})
+			return err
+		}, codes.InvalidArgument},
+		{"message without operation", func() error {
+			_, err := h.TransactI2C(ctx, &boardpb.TransactI2CRequest{Bus: "1", Address: 0x40, Messages: []*boardpb.I2CMessage{{}}})
+			return err
+		}, codes.InvalidArgument},
+		{"transaction read too long", func() error {
+			_, err := h.TransactI2C(ctx, &boardpb.TransactI2CRequest{Bus: "1", Address: 0x40, Messages: []*boardpb.I2CMessage{
+				{Operation: &boardpb.I2CMessage_ReadLength{ReadLength: maxI2CLength + 1}},
+			}})
+			return err
+		}, codes.InvalidArgument},
+		{"spi", func() error {
+			_, err := h.TransferSPI(ctx, &boardpb.TransferSPIRequest{Bus: "0", ChipSelect: "0", Data: []byte{1}})
+			return err
+		}, missingDevice()},
+		{"invalid spi mode", func() error {
+			_, err := h.TransferSPI(ctx, &boardpb.TransferSPIRequest{Bus: "0", ChipSelect: "0", Mode: 4})
+			return err
+		}, codes.InvalidArgument},
+		{"invalid chip select", func() error {
+			_, err := h.TransferSPI(ctx, &boardpb.TransferSPIRequest{Bus: "0", ChipSelect: "../0"})
+			return err
+		}, codes.InvalidArgument},
+	} {
+		if err := tc.call(); status.Code(err) != tc.code {
+			t.Errorf("%s: %v, want %v", tc.name, err, tc.code)
+		}
+	}
+}
+
+func TestDeviceError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{errUnsupported, codes.Unimplemented},
+		{&fs.PathError{Op: "open", Path: "/dev/i2c-1", Err: fs.ErrNotExist}, codes.NotFound},
+		{&fs.PathError{Op: "open", Path: "/dev/i2c-1", Err: fs.ErrPermission}, codes.PermissionDenied},
+		{syscall.EINVAL, codes.InvalidArgument},
+		{fmt.Errorf("ioctl: %w", syscall.EMSGSIZE), codes.InvalidArgument},
+		{syscall.EIO, codes.Unavailable},
+		{errors.New("gone"), codes.Unavailable},
+	} {
+		err := deviceError("I2C bus 1", tc.err)
+		if status.Code(err) != tc.code || status.Convert(err).Message() != "buses: I2C bus 1: "+tc.err.Error() {
+			t.Errorf("deviceError(%v) = %v, want %v", tc.err, err, tc.code)
+		}
+	}
+}
+
+func TestCheckUARTConfig(t *testing.T) {
+	for _, tc := range []struct {
+		cfg *boardpb.UARTConfig
+		ok  bool
+	}{
+		{&boardpb.UARTConfig{BaudRate: 9600}, true},
+		{&boardpb.UARTConfig{BaudRate: 250000, DataBits: 7, StopBits: 2, Parity: boardpb.UARTParity_UART_PARITY_EVEN}, true},
+		{&boardpb.UARTConfig{BaudRate: 9600, DataBits: 5}, true},
+		{&boardpb.UARTConfig{}, false},
+		{&boardpb.UARTConfig{BaudRate: 9600, DataBits: 4}, false},
+		{&boardpb.UARTConfig{BaudRate: 9600, DataBits: 9}, false},
+		{&boardpb.UARTConfig{BaudRate: 9600, StopBits: 3}, false},
+		{&boardpb.UARTConfig{BaudRate: 9600, Parity: 7}, false},
+	} {
+		err := checkUARTConfig(tc.cfg)
+		if tc.ok && err != nil || !tc.ok && status.Code(err) != codes.InvalidArgument {
+			t.Errorf("checkUARTConfig(%v) = %v", tc.cfg, err)
+		}
+	}
+}
+
+// uartStream is a StreamUART server stream receiving reqs.
+type uartStream struct {
+	grpc.ServerStream
+	reqs []*boardpb.StreamUARTRequest
+}
+
+func (s *uartStream) Recv() (*boardpb.StreamUARTRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *uartStream) Send(*boardpb.StreamUARTResponse) error {
+	return nil
+}
+
+func configRequest(cfg *boardpb.UARTConfig) *boardpb.StreamUARTRequest {
+	return &boardpb.StreamUARTRequest{Request: &boardpb.StreamUARTRequest_Config{Config: cfg}}
+}
+
+func TestStreamUARTErrors(t *testing.T) {
+	h := NewHandler(WithUARTPort("missing", filepath.Join(t.TempDir(), "missing")))
+	for _, tc := range []struct {
+		name string
+		reqs []*boardpb.StreamUARTRequest
+		code codes.Code
+	}{
+		{"data first", []*boardpb.StreamUARTRequest{{Request: &boardpb.StreamUARTRequest_Data{Data: []byte("hi")}}}, codes.InvalidArgument},
+		{"invalid config", []*boardpb.StreamUARTRequest{configRequest(&boardpb.UARTConfig{Port: "ttyS0"})}, codes.InvalidArgument},
+		{"not a terminal", []*boardpb.StreamUARTRequest{configRequest(&boardpb.UARTConfig{Port: "watchdog", BaudRate: 9600})}, codes.InvalidArgument},
+		{"missing device", []*boardpb.StreamUARTRequest{configRequest(&boardpb.UARTConfig{Port: "missing", BaudRate: 9600})}, missingDevice()},
+	} {
+		if err := h.StreamUART(&uartStream{reqs: tc.reqs}); status.Code(err) != tc.code {
+			t.Errorf("%s: %v, want %v", tc.name, err, tc.code)
+		}
+	}
+	if err := h.StreamUART(&uartStream{}); !errors.Is(err, io.EOF) {
+		t.Errorf("StreamUART without requests = %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !linux
+
+package buses
+
+import (
+	"os"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+func i2cTransfer(path string, address uint16, msgs []i2cMessage) error {
+	return errUnsupported
+}
+
+func spiTransfer(path string, mode uint8, speedHz uint32, tx, rx []byte) error {
+	return errUnsupported
+}
+
+// uart is an open serial port.
+type uart struct {
+	*os.File
+}
+
+func openUART(path string, cfg *boardpb.UARTConfig) (*uart, error) {
+	return nil, errUnsupported
+}
+
+func (u *uart) configure(cfg *boardpb.UARTConfig, drain bool) error {
+	return errUnsupported
+}
+
+func (u *uart) drain() error {
+	return errUnsupported
+}
@@ -0,0 +1,203 @@
+package buses
+
+import (
+	"os"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// ioctl numbers of i2c-dev and spidev, from linux/i2c-dev.h and linux/spi/spidev.h. The SPI ones use the
+// generic encoding of ioctl numbers, which arm, arm64, riscv and x86 share.
+const (
+	i2cRdwr = 0x0707
+	// i2cMsgRead and i2cMsgTen are flags of a message: a read, to a 10-bit address.
+	i2cMsgRead = 0x0001
+	i2cMsgTen  = 0x0010
+
+	spiIOCWrMode   = 0x40016b01
+	spiIOCMessage1 = 0x40206b00
+)
+
+// i2cMsg is struct i2c_msg.
+type i2cMsg struct {
+	addr  uint16
+	flags uint16
+	len   uint16
+	buf   *byte
+}
+
+// i2cRdwrData is struct i2c_rdwr_ioctl_data.
+type i2cRdwrData struct {
+	msgs  *i2cMsg
+	nmsgs uint32
+}
+
+// spiIOCTransfer is struct spi_ioc_transfer.
+type spiIOCTransfer struct {
+	txBuf          uint64
+	rxBuf          uint64
+	len            uint32
+	speedHz        uint32
+	delayUsecs     uint16
+	bitsPerWord    uint8
+	csChange       uint8
+	txNbits        uint8
+	rxNbits        uint8
+	wordDelayUsecs uint8
+	pad            uint8
+}
+
+func ioctl(f *os.File, req uintptr, arg unsafe.Pointer) error {
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var errno unix.Errno
+	if err := rc.Control(func(fd uintptr) {
+		_, _, errno = unix.Syscall(unix.SYS_IOCTL, fd, req, uintptr(arg))
+	}); err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func i2cTransfer(path string, address uint16, msgs []i2cMessage) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer f.Close()
+	ms := make([]i2cMsg, len(msgs))
+	for i, m := range msgs {
+		ms[i] = i2cMsg{addr: address, len: uint16(len(m.data))}
+		if m.read {
+			ms[i].flags |= i2cMsgRead
+		}
+		if address > 0x7f {
+			ms[i].flags |= i2cMsgTen
+		}
+		if len(m.data) > 0 {
+			ms[i].buf = &m.data[0]
+		}
+	}
+	data := i2cRdwrData{msgs: &ms[0], nmsgs: uint32(len(ms))}
+	err = ioctl(f, i2cRdwr, unsafe.Pointer(&data))
+	runtime.KeepAlive(ms)
+	return err
+}
+
+func spiTransfer(path string, mode uint8, speedHz uint32, tx, rx []byte) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer f.Close()
+	if err := ioctl(f, spiIOCWrMode, unsafe.Pointer(&mode)); err != nil {
+		return err
+	}
+	if len(tx) == 0 {
+		return nil
+	}
+	t := spiIOCTransfer{
+		txBuf:   uint64(uintptr(unsafe.Pointer(&tx[0]))),
+		rxBuf:   uint64(uintptr(unsafe.Pointer(&rx[0]))),
+		len:     uint32(len(tx)),
+		speedHz: speedHz,
+	}
+	err = ioctl(f, spiIOCMessage1, unsafe.Pointer(&t))
+	runtime.KeepAlive(tx)
+	runtime.KeepAlive(rx)
+	return err
+}
+
+// uart is an open serial port.
+type uart struct {
+	*os.File
+}
+
+func openUART(path string, cfg *boardpb.UARTConfig) (*uart, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, err
+	}
+	u := &uart{f}
+	if err := u.configure(cfg, false); err != nil {
+		//nolint:errcheck
+		f.Close()
+		return nil, err
+	}
+	return u, nil
+}
+
+// configure puts the port in raw mode with the given settings, once its output is sent if drain is set.
+// The baud rate is set as is, so that any rate the driver supports can be used.
+func (u *uart) configure(cfg *boardpb.UARTConfig, drain bool) error {
+	return control(u.File, func(fd int) error {
+		t, err := unix.IoctlGetTermios(fd, unix.TCGETS2)
+		if err != nil {
+			return err
+		}
+		t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL |
+			unix.IXON | unix.IXOFF | unix.INPCK
+		t.Oflag &^= unix.OPOST
+		t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+		t.Cflag &^= unix.CSIZE | unix.PARENB | unix.PARODD | unix.CSTOPB | unix.CBAUD | unix.CRTSCTS
+		t.Cflag |= unix.CREAD | unix.CLOCAL | unix.BOTHER
+		switch cfg.GetDataBits() {
+		case 5:
+			t.Cflag |= unix.CS5
+		case 6:
+			t.Cflag |= unix.CS6
+		case 7:
+			t.Cflag |= unix.CS7
+		default:
+			t.Cflag |= unix.CS8
+		}
+		switch cfg.GetParity() {
+		case boardpb.UARTParity_UART_PARITY_EVEN:
+			t.Cflag |= unix.PARENB
+			t.Iflag |= unix.INPCK
+		case boardpb.UARTParity_UART_PARITY_ODD:
+			t.Cflag |= unix.PARENB | unix.PARODD
+			t.Iflag |= unix.INPCK
+		}
+		if cfg.GetStopBits() == 2 {
+			t.Cflag |= unix.CSTOPB
+		}
+		t.Ispeed, t.Ospeed = cfg.GetBaudRate(), cfg.GetBaudRate()
+		t.Cc[unix.VMIN], t.Cc[unix.VTIME] = 1, 0
+		req := uint(unix.TCSETS2)
+		if drain {
+			req = unix.TCSETSW2
+		}
+		return unix.IoctlSetTermios(fd, req, t)
+	})
+}
+
+// drain waits until the bytes written to the port are sent.
+func (u *uart) drain() error {
+	return control(u.File, func(fd int) error {
+		return unix.IoctlSetInt(fd, unix.TCSBRK, 1)
+	})
+}
+
+func control(f *os.File, fn func(fd int) error) error {
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var fnErr error
+	if err := rc.Control(func(fd uintptr) { fnErr = fn(int(fd)) }); err != nil {
+		return err
+	}
+	return fnErr
+}
@@ -0,0 +1,150 @@
+package buses
+
+import (
+	"errors"
+	"io"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// uartReadSize is the most bytes sent in a response.
+const uartReadSize = 4096
+
+// StreamUART opens the port configured by the first request, then writes the data of the requests to it
+// and sends the data read from it. Once the client closes its side of the stream, the bytes written are
+// sent out and the stream ends.
+func (h *Handler) StreamUART(stream boardpb.BoardService_StreamUARTServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	cfg := req.GetConfig()
+	if cfg == nil {
+		return status.Error(codes.InvalidArgument, "buses: the first request must configure the serial port")
+	}
+	if err := checkUARTConfig(cfg); err != nil {
+		return err
+	}
+	p, err := h.uartPath(cfg.GetPort())
+	if err != nil {
+		return err
+	}
+	port, err := openUART(p, cfg)
+	if err != nil {
+		return deviceError("serial port "+cfg.GetPort(), err)
+	}
+	//nolint:errcheck
+	defer port.Close()
+
+	readDone := make(chan error, 1)
+	go func() {
+		buf := make([]byte, uartReadSize)
+		for {
+			n, err := port.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&boardpb.StreamUARTResponse{Data: slices.Clone(buf[:n])}); err != nil {
+					readDone <- err
+					return
+				}
+			}
+			if err != nil {
+				readDone <- deviceError("serial port "+cfg.GetPort(), err)
+				return
+			}
+		}
+	}()
+	// The requests are received by their own goroutine, which only touches the stream: once the handler
+	// returns, its pending Recv fails and it ends.
+	reqs := make(chan *boardpb.StreamUARTRequest)
+	recvDone := make(chan error, 1)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvDone <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	writeDone := make(chan error, 1)
+	go func() {
+		writeDone <- writeUART(reqs, recvDone, stop, port, cfg.GetPort())
+	}()
+
+	// Neither the reader nor the writer may outlive the handler, since both use the port: whichever ends
+	// first, the port is closed and the other one is waited for.
+	select {
+	case err = <-readDone:
+		close(stop)
+		//nolint:errcheck
+		port.Close()
+		<-writeDone
+	case err = <-writeDone:
+		close(stop)
+		//nolint:errcheck
+		port.Close()
+		<-readDone
+	}
+	return err
+}
+
+// writeUART writes the data of the requests to the port, and applies their configurations, until the
+// client closes its side of the stream or stop is closed.
+func writeUART(
+	reqs <-chan *boardpb.StreamUARTRequest, recvDone <-chan error, stop <-chan struct{}, port *uart, name string,
+) error {
+	for {
+		var req *boardpb.StreamUARTRequest
+		select {
+		case req = <-reqs:
+		case err := <-recvDone:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			if err := port.drain(); err != nil {
+				return deviceError("serial port "+name, err)
+			}
+			return nil
+		case <-stop:
+			return nil
+		}
+		switch r := req.GetRequest().(type) {
+		case *boardpb.StreamUARTRequest_Config:
+			if err := checkUARTConfig(r.Config); err != nil {
+				return err
+			}
+			if err := port.configure(r.Config, true); err != nil {
+				return deviceError("serial port "+name, err)
+			}
+		case *boardpb.StreamUARTRequest_Data:
+			if _, err := port.Write(r.Data); err != nil {
+				return deviceError("serial port "+name, err)
+			}
+		}
+	}
+}
+
+func checkUARTConfig(cfg *boardpb.UARTConfig) error {
+	switch {
+	case cfg.GetBaudRate() == 0:
+		return status.Error(codes.InvalidArgument, "buses: serial port has no baud rate")
+	case cfg.GetDataBits() != 0 && (cfg.GetDataBits() < 5 || cfg.GetDataBits() > 8):
+		return status.Errorf(codes.InvalidArgument, "buses: invalid number of data bits %d", cfg.GetDataBits())
+	case cfg.GetStopBits() > 2:
+		return status.Errorf(codes.InvalidArgument, "buses: invalid number of stop bits %d", cfg.GetStopBits())
+	}
+	if _, ok := boardpb.UARTParity_name[int32(cfg.GetParity())]; !ok {
+		return status.Errorf(codes.InvalidArgument, "buses: invalid parity %v", cfg.GetParity())
+	}
+	return nil
+}
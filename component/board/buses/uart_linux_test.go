@@ -0,0 +1,251 @@
+package buses
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// openPTY returns the master side of a new pseudo-terminal and the path of its slave, which stands in
+// for a serial port.
+func openPTY(t *testing.T) (*os.File, string) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminals: %v", err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		master.Close()
+	})
+	fd := int(master.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		t.Fatal(err)
+	}
+	n, err := unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The master side must not echo or translate what it is sent either.
+	tio, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tio.Lflag &^= unix.ECHO | unix.ICANON
+	tio.Oflag &^= unix.OPOST
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, tio); err != nil {
+		t.Fatal(err)
+	}
+	return master, fmt.Sprintf("/dev/pts/%d", n)
+}
+
+type boardServer struct {
+	boardpb.UnimplementedBoardServiceServer
+	h    *Handler
+	done chan error
+}
+
+func (s *boardServer) StreamUART(stream boardpb.BoardService_StreamUARTServer) error {
+	err := s.h.StreamUART(stream)
+	s.done <- err
+	return err
+}
+
+// streamUART opens a StreamUART stream to port, and returns it with a channel receiving what the
+// handler returns.
+func streamUART(t *testing.T, ctx context.Context, port string) (boardpb.BoardService_StreamUARTClient, <-chan error) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	s := &boardServer{h: NewHandler(), done: make(chan error, 1)}
+	boardpb.RegisterBoardServiceServer(srv, s)
+	go func() {
+		//nolint:errcheck
+		srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		conn.Close()
+	})
+	stream, err := boardpb.NewBoardServiceClient(conn).StreamUART(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(configRequest(&boardpb.UARTConfig{Port: port, BaudRate: 115200})); err != nil {
+		t.Fatal(err)
+	}
+	return stream, s.done
+}
+
+func dataRequest(data string) *boardpb.StreamUARTRequest {
+	return &boardpb.StreamUARTRequest{Request: &boardpb.StreamUARTRequest_Data{Data: []byte(data)}}
+}
+
+// readFull reads n bytes from the master side of a pseudo-terminal.
+func readFull(t *testing.T, master *os.File, n int) string {
+	t.Helper()
+	if err := master.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(master, buf); err != nil {
+		t.Fatalf("read %q: %v", buf, err)
+	}
+	return string(buf)
+}
+
+func handlerResult(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler did not return")
+		return nil
+	}
+}
+
+func TestStreamUART(t *testing.T) {
+	master, port := openPTY(t)
+	stream, done := streamUART(t, context.Background(), port)
+
+	if err := stream.Send(dataRequest("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFull(t, master, 5); got != "hello" {
+		t.Errorf("port received %q", got)
+	}
+	if _, err := master.Write([]byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	for len(got) < 5 {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got += string(resp.GetData())
+	}
+	if got != "world" {
+		t.Errorf("client received %q", got)
+	}
+
+	// The port can be configured again mid-stream.
+	cfg := &boardpb.UARTConfig{Port: port, BaudRate: 9600, DataBits: 7, Parity: boardpb.UARTParity_UART_PARITY_ODD, StopBits: 2}
+	if err := stream.Send(configRequest(cfg)); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(dataRequest("again")); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFull(t, master, 5); got != "again" {
+		t.Errorf("port received %q", got)
+	}
+
+	// An invalid configuration ends the stream.
+	if err := stream.Send(configRequest(&boardpb.UARTConfig{Port: port, BaudRate: 9600, DataBits: 9})); err != nil {
+		t.Fatal(err)
+	}
+	if err := handlerResult(t, done); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("StreamUART = %v", err)
+	}
+}
+
+func TestStreamUARTDrainsOnClose(t *testing.T) {
+	master, port := openPTY(t)
+	stream, done := streamUART(t, context.Background(), port)
+
+	// More than the terminal buffers is written, so the writes block until the other side reads them.
+	var want strings.Builder
+	for i := range 2000 {
+		fmt.Fprintf(&want, "line %04d\n", i)
+	}
+	received := make(chan string, 1)
+	go func() {
+		var got bytes.Buffer
+		//nolint:errcheck
+		master.SetReadDeadline(time.Now().Add(10 * time.Second))
+		buf := make([]byte, 4096)
+		for got.Len() < want.Len() {
+			n, err := master.Read(buf)
+			got.Write(buf[:n])
+			if err != nil {
+				break
+			}
+		}
+		received <- got.String()
+	}()
+	for data := want.String(); len(data) > 0; {
+		n := min(len(data), 1000)
+		if err := stream.Send(dataRequest(data[:n])); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every request sent before the client closed its side is written before the stream ends.
+	if err := handlerResult(t, done); err != nil {
+		t.Errorf("StreamUART = %v", err)
+	}
+	if got := <-received; got != want.String() {
+		t.Errorf("port received %d bytes, want %d", len(got), want.Len())
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv = %v, want io.EOF", err)
+	}
+}
+
+func TestStreamUARTEnds(t *testing.T) {
+	t.Run("port hung up", func(t *testing.T) {
+		master, port := openPTY(t)
+		stream, done := streamUART(t, context.Background(), port)
+		if err := stream.Send(dataRequest("x")); err != nil {
+			t.Fatal(err)
+		}
+		readFull(t, master, 1)
+		if err := master.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := handlerResult(t, done); status.Code(err) != codes.Unavailable {
+			t.Errorf("StreamUART = %v", err)
+		}
+	})
+	t.Run("client gone", func(t *testing.T) {
+		_, port := openPTY(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, done := streamUART(t, ctx, port)
+		if err := stream.Send(dataRequest("x")); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+		if err := handlerResult(t, done); status.Code(err) != codes.Canceled {
+			t.Errorf("StreamUART = %v", err)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+package pulse
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// quadratureSteps gives the change in position of a quadrature encoder between two states, each made of
+// the level of a in the high bit and the level of b in the low bit. Forwards, a leads b through the states
+// 0, 2, 3, 1. A transition changing both levels is marked with 2.
+var quadratureSteps = [4][4]int{
+	{0, -1, 1, 2},
+	{1, 0, 2, -1},
+	{-1, 2, 0, 1},
+	{2, 1, -1, 0},
+}
+
+type pinState struct {
+	known bool
+	high  bool
+	// last is the time up to which the level of the pin has been accounted for.
+	last uint64
+	// rise is the time of the last rising edge, if hasRise.
+	rise    uint64
+	hasRise bool
+
+	// The totals of the current window.
+	edges     int
+	periods   int
+	periodSum uint64
+	highTime  uint64
+	knownTime uint64
+	widths    Histogram
+}
+
+// advance accounts for the level of the pin up to t.
+func (p *pinState) advance(t uint64) {
+	if t <= p.last {
+		return
+	}
+	if p.known {
+		p.knownTime += t - p.last
+		if p.high {
+			p.highTime += t - p.last
+		}
+	}
+	p.last = t
+}
+
+type quadratureState struct {
+	quadraturePins
+	// state is the levels of a and b, or -1 until both are known.
+	state  int
+	count  int64
+	start  int64
+	errors int
+}
+
+// Analyzer computes measurements from ticks. It is not safe for concurrent use.
+type Analyzer struct {
+	o          *options
+	pins       map[string]*pinState
+	quadrature []*quadratureState
+
+	started bool
+	origin  uint64
+	// The current window is [start, end), and now is the time of the latest tick.
+	start, end uint64
+	now        uint64
+}
+
+// NewAnalyzer returns an Analyzer. Pins are followed from their first tick.
+func NewAnalyzer(opts ...Option) *Analyzer {
+	a := &Analyzer{o: newOptions(opts), pins: make(map[string]*pinState)}
+	for _, q := range a.o.quadrature {
+		a.quadrature = append(a.quadrature, &quadratureState{quadraturePins: q, state: -1})
+	}
+	return a
+}
+
+// Add accounts for a tick, and returns the measurements it completes: those of the windows that ended
+// before it, and the pulse it ends on an RC pin. Ticks older than the latest one are taken as happening
+// at its time.
+func (a *Analyzer) Add(tick *boardpb.StreamTicksResponse) []Measurement {
+	t := max(tick.GetTime(), a.now)
+	if !a.started {
+		a.started = true
+		a.origin, a.start, a.end = t, t, t+uint64(a.o.window)
+	}
+	a.now = t
+
+	var out []Measurement
+	if t >= a.end {
+		out = a.closeWindow(a.end)
+		if t >= a.end+uint64(a.o.window) {
+			// Merge the windows without ticks into one, ending at the start of the window of t.
+			idleEnd := a.origin + (t-a.origin)/uint64(a.o.window)*uint64(a.o.window)
+			out = append(out, a.closeWindow(idleEnd)...)
+		}
+	}
+
+	name := tick.GetPinName()
+	p := a.pins[name]
+	if p == nil {
+		p = &pinState{last: t, widths: newHistogram(a.o.bounds)}
+		a.pins[name] = p
+	}
+	high := tick.GetHigh()
+	if p.known && p.high == high {
+		// A repeated level is not an edge.
+		return out
+	}
+	p.advance(t)
+	switch {
+	case high:
+		if p.hasRise {
+			p.periods++
+			p.periodSum += t - p.rise
+		}
+		p.rise, p.hasRise = t, true
+	case p.known && p.hasRise:
+		width := time.Duration(t - p.rise)
+		p.widths.add(width)
+		if a.o.rcPins[name] {
+			out = append(out, a.rcPulse(name, t, width))
+		}
+	}
+	p.known, p.high = true, high
+	p.edges++
+
+	for _, q := range a.quadrature {
+		if q.a == name || q.b == name {
+			a.step(q)
+		}
+	}
+	return out
+}
+
+// Flush returns the measurements of the current window, cut short at the time of the latest tick, and
+// starts the next window there.
+func (a *Analyzer) Flush() []Measurement {
+	if !a.started || a.now <= a.start {
+		return nil
+	}
+	return a.closeWindow(a.now)
+}
+
+func (a *Analyzer) rcPulse(name string, t uint64, width time.Duration) RCPulse {
+	span := a.o.rcMax - a.o.rcMin
+	v := 2*float64(width-a.o.rcMin)/float64(span) - 1
+	return RCPulse{
+		Pin:   name,
+		Time:  t,
+		Width: width,
+		Value: math.Max(-1, math.Min(1, v)),
+		Valid: width >= a.o.rcMin-span/4 && width <= a.o.rcMax+span/4,
+	}
+}
+
+func (a *Analyzer) step(q *quadratureState) {
+	pa, pb := a.pins[q.a], a.pins[q.b]
+	if pa == nil || pb == nil || !pa.known || !pb.known {
+		return
+	}
+	state := 0
+	if pa.high {
+		state |= 2
+	}
+	if pb.high {
+		state |= 1
+	}
+	if q.state >= 0 {
+		switch d := quadratureSteps[q.state][state]; d {
+		case 2:
+			q.errors++
+		default:
+			q.count += int64(d)
+		}
+	}
+	q.state = state
+}
+
+// closeWindow returns the measurements of the current window, ending it at end.
+func (a *Analyzer) closeWindow(end uint64) []Measurement {
+	names := make([]string, 0, len(a.pins))
+	for name := range a.pins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	out := make([]Measurement, 0, len(names)+len(a.quadrature))
+	for _, name := range names {
+		p := a.pins[name]
+		p.advance(end)
+		s := PinStats{Pin: name, Start: a.start, End: end, Edges: p.edges, High: p.high, Widths: p.widths}
+		if p.periods > 0 {
+			s.Frequency = float64(p.periods) / time.Duration(p.periodSum).Seconds()
+		}
+		if p.knownTime > 0 {
+			s.DutyCycle = float64(p.highTime) / float64(p.knownTime)
+		}
+		out = append(out, s)
+		p.edges, p.periods, p.periodSum, p.highTime, p.knownTime = 0, 0, 0, 0, 0
+		p.widths = newHistogram(a.o.bounds)
+	}
+	for _, q := range a.quadrature {
+		delta := q.count - q.start
+		out = append(out, QuadratureStats{
+			A:        q.a,
+			B:        q.b,
+			Start:    a.start,
+			End:      end,
+			Count:    q.count,
+			Delta:    delta,
+			Velocity: float64(delta) / time.Duration(end-a.start).Seconds(),
+			Errors:   q.errors,
+		})
+		q.start, q.errors = q.count, 0
+	}
+	a.start = end
+	a.end = end + uint64(a.o.window)
+	return out
+}
@@ -0,0 +1,207 @@
+// Package pulse turns the edges streamed by the StreamTicks method of BoardService into measurements.
+//
+// An Analyzer follows any number of pins at once. Time is divided into windows of a fixed length, counted
+// from the first tick by the board's own timestamps, and for each window every pin gets a PinStats with
+// its frequency, duty cycle and a histogram of the widths of its high pulses. Windows in which no pin
+// changes are merged into a single one, so that a quiet board produces few measurements. Decoders run
+// alongside: pins of RC receivers yield an RCPulse for every pulse, and quadrature encoder pairs yield a
+// QuadratureStats for every window. Watch streams the ticks of a board through an Analyzer and delivers
+// the measurements on a channel.
+package pulse
+
+import "time"
+
+const (
+	// DefaultWindow is the length of the measurement windows by default.
+	DefaultWindow = time.Second
+	// DefaultRCMin and DefaultRCMax are the pulse widths of the ends of an RC channel's range by default.
+	DefaultRCMin = 1000 * time.Microsecond
+	DefaultRCMax = 2000 * time.Microsecond
+)
+
+// DefaultHistogramBounds are the upper bounds of the pulse width histogram buckets by default, in 1-2-5
+// steps from 10µs to 1s.
+var DefaultHistogramBounds = []time.Duration{
+	10 * time.Microsecond, 20 * time.Microsecond, 50 * time.Microsecond,
+	100 * time.Microsecond, 200 * time.Microsecond, 500 * time.Microsecond,
+	time.Millisecond, 2 * time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 20 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 200 * time.Millisecond, 500 * time.Millisecond,
+	time.Second,
+}
+
+// Measurement is one of PinStats, RCPulse or QuadratureStats.
+type Measurement interface {
+	measurement()
+}
+
+// PinStats describes the signal on a pin during a window. Times are the board's, in nanoseconds.
+type PinStats struct {
+	Pin        string
+	Start, End uint64
+	// Edges is the number of level changes.
+	Edges int
+	// Frequency is the mean frequency, in Hz, of the periods between rising edges that ended in the
+	// window, including the one started in the previous window. It is 0 without any such period.
+	Frequency float64
+	// DutyCycle is the fraction of the window the pin was high, from 0 to 1, counting only the time since
+	// its level became known.
+	DutyCycle float64
+	// High is the level of the pin at the end of the window.
+	High bool
+	// Widths are the widths of the high pulses that ended in the window.
+	Widths Histogram
+}
+
+// RCPulse is a pulse from a channel of an RC receiver.
+type RCPulse struct {
+	Pin string
+	// Time is when the pulse ended, in nanoseconds.
+	Time  uint64
+	Width time.Duration
+	// Value is the position of the width in the channel's range, from -1 to 1, clamped.
+	Value float64
+	// Valid is false for widths more than a quarter of the range outside of it, which are not RC pulses.
+	Valid bool
+}
+
+// QuadratureStats describes the movement of a quadrature encoder during a window, in counts of edges of
+// either channel.
+type QuadratureStats struct {
+	A, B       string
+	Start, End uint64
+	// Count is the position at the end of the window, counted from when both levels became known.
+	Count int64
+	// Delta is the change in position during the window, and Velocity its rate in counts per second.
+	Delta    int64
+	Velocity float64
+	// Errors counts the transitions in which both channels changed at once, which lose the direction.
+	Errors int
+}
+
+func (PinStats) measurement()        {}
+func (RCPulse) measurement()         {}
+func (QuadratureStats) measurement() {}
+
+// Histogram counts durations in buckets.
+type Histogram struct {
+	// Bounds are the exclusive upper bounds of all buckets but the last.
+	Bounds []time.Duration
+	// Counts has one more element than Bounds, counting the durations of at least the last bound.
+	Counts []int
+	Total  int
+	Sum    time.Duration
+	Min    time.Duration
+	Max    time.Duration
+}
+
+func newHistogram(bounds []time.Duration) Histogram {
+	return Histogram{Bounds: bounds, Counts: make([]int, len(bounds)+1)}
+}
+
+func (h *Histogram) add(d time.Duration) {
+	i := 0
+	for i < len(h.Bounds) && d >= h.Bounds[i] {
+		i++
+	}
+	h.Counts[i]++
+	if h.Total == 0 || d < h.Min {
+		h.Min = d
+	}
+	if d > h.Max {
+		h.Max = d
+	}
+	h.Total++
+	h.Sum += d
+}
+
+// Mean returns the mean duration, or 0 if the histogram is empty.
+func (h Histogram) Mean() time.Duration {
+	if h.Total == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Total)
+}
+
+type quadraturePins struct {
+	a, b string
+}
+
+type options struct {
+	window     time.Duration
+	bounds     []time.Duration
+	rcPins     map[string]bool
+	rcMin      time.Duration
+	rcMax      time.Duration
+	quadrature []quadraturePins
+	buffer     int
+}
+
+// Option configures an Analyzer.
+type Option func(*options)
+
+// WithWindow sets the length of the measurement windows.
+func WithWindow(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.window = d
+		}
+	}
+}
+
+// WithHistogramBounds sets the upper bounds of the pulse width histogram buckets, in increasing order.
+func WithHistogramBounds(bounds ...time.Duration) Option {
+	return func(o *options) {
+		o.bounds = bounds
+	}
+}
+
+// WithRC decodes the pulses on the given pins as the channels of an RC receiver.
+func WithRC(pins ...string) Option {
+	return func(o *options) {
+		for _, p := range pins {
+			o.rcPins[p] = true
+		}
+	}
+}
+
+// WithRCRange sets the pulse widths of the ends of the range of RC channels.
+func WithRCRange(minWidth, maxWidth time.Duration) Option {
+	return func(o *options) {
+		if minWidth < maxWidth {
+			o.rcMin, o.rcMax = minWidth, maxWidth
+		}
+	}
+}
+
+// WithQuadrature decodes the pins a and b as the channels of a quadrature encoder, which counts up when a
+// leads b. It may be given for several encoders.
+func WithQuadrature(a, b string) Option {
+	return func(o *options) {
+		o.quadrature = append(o.quadrature, quadraturePins{a: a, b: b})
+	}
+}
+
+// WithBuffer sets how many measurements Watch buffers before it stops reading ticks. The default is 64.
+func WithBuffer(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.buffer = n
+		}
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		window: DefaultWindow,
+		bounds: DefaultHistogramBounds,
+		rcPins: make(map[string]bool),
+		rcMin:  DefaultRCMin,
+		rcMax:  DefaultRCMax,
+		buffer: 64,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,301 @@
+package pulse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+func tick(pin string, at time.Duration, high bool) *boardpb.StreamTicksResponse {
+	return &boardpb.StreamTicksResponse{PinName: pin, Time: uint64(at), High: high}
+}
+
+// square returns the ticks of a square wave on pin from start to end, rising every period and high for
+// width of it.
+func square(pin string, start, end, period, width time.Duration) []*boardpb.StreamTicksResponse {
+	var ticks []*boardpb.StreamTicksResponse
+	for t := start; t < end; t += period {
+		ticks = append(ticks, tick(pin, t, true), tick(pin, t+width, false))
+	}
+	return ticks
+}
+
+func feed(a *Analyzer, ticks []*boardpb.StreamTicksResponse) []Measurement {
+	var out []Measurement
+	for _, t := range ticks {
+		out = append(out, a.Add(t)...)
+	}
+	return out
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestHistogram(t *testing.T) {
+	h := newHistogram([]time.Duration{10, 20})
+	if h.Mean() != 0 {
+		t.Errorf("empty Mean() = %v", h.Mean())
+	}
+	for _, d := range []time.Duration{5, 10, 19, 20, 100} {
+		h.add(d)
+	}
+	if !slices.Equal(h.Counts, []int{1, 2, 2}) || h.Total != 5 || h.Min != 5 || h.Max != 100 || h.Mean() != 30 {
+		t.Errorf("histogram = %+v, mean %v", h, h.Mean())
+	}
+}
+
+func TestPinStats(t *testing.T) {
+	a := NewAnalyzer(WithWindow(10 * time.Millisecond))
+	ticks := square("p", 0, 20*time.Millisecond, time.Millisecond, 250*time.Microsecond)
+	out := feed(a, append(ticks, tick("p", 20*time.Millisecond, true)))
+	if len(out) != 2 {
+		t.Fatalf("got %d measurements, want 2: %+v", len(out), out)
+	}
+	for i, m := range out {
+		s, ok := m.(PinStats)
+		if !ok {
+			t.Fatalf("measurement %d is %T", i, m)
+		}
+		start := uint64(i) * uint64(10*time.Millisecond)
+		if s.Pin != "p" || s.Start != start || s.End != start+uint64(10*time.Millisecond) || s.Edges != 20 || s.High {
+			t.Errorf("window %d = %+v", i, s)
+		}
+		if !near(s.Frequency, 1000) || !near(s.DutyCycle, 0.25) {
+			t.Errorf("window %d: frequency %v, duty cycle %v", i, s.Frequency, s.DutyCycle)
+		}
+		if w := s.Widths; w.Total != 10 || w.Min != 250*time.Microsecond || w.Max != 250*time.Microsecond {
+			t.Errorf("window %d: widths %+v", i, w)
+		}
+	}
+
+	// The rest of the window is flushed up to the latest tick, and only once.
+	if out := a.Flush(); out != nil {
+		t.Errorf("Flush at the start of a window = %+v", out)
+	}
+	a.Add(tick("p", 20*time.Millisecond+100*time.Microsecond, false))
+	out = a.Flush()
+	if len(out) != 1 {
+		t.Fatalf("Flush = %+v", out)
+	}
+	if s := out[0].(PinStats); s.Start != uint64(20*time.Millisecond) || s.End != s.Start+uint64(100*time.Microsecond) || s.High || s.Edges != 2 || s.DutyCycle != 1 {
+		t.Errorf("flushed %+v", s)
+	}
+	if out := a.Flush(); out != nil {
+		t.Errorf("second Flush = %+v", out)
+	}
+}
+
+func TestPinStatsEdgeCases(t *testing.T) {
+	ms := time.Millisecond
+	a := NewAnalyzer(WithWindow(10 * ms))
+	out := feed(a, []*boardpb.StreamTicksResponse{
+		// Windows start at the first tick, and the level is unknown until then.
+		tick("p", 2*ms, false),
+		tick("p", 4*ms, true),
+		// A repeated level is not an edge.
+		tick("p", 5*ms, true),
+		// An older tick counts as happening at the time of the latest one.
+		tick("p", 3*ms, false),
+		tick("p", 12*ms, false),
+	})
+	if len(out) != 1 {
+		t.Fatalf("got %+v", out)
+	}
+	s := out[0].(PinStats)
+	if s.Edges != 3 || s.Frequency != 0 || !near(s.DutyCycle, 0.1) || s.Widths.Total != 1 || s.Widths.Min != ms {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestIdleWindowsMerged(t *testing.T) {
+	ms := time.Millisecond
+	a := NewAnalyzer(WithWindow(10 * ms))
+	out := feed(a, []*boardpb.StreamTicksResponse{tick("p", 0, true), tick("p", 55*ms, false), tick("p", 61*ms, true)})
+	var windows [][2]uint64
+	for _, m := range out {
+		s := m.(PinStats)
+		windows = append(windows, [2]uint64{s.Start, s.End})
+	}
+	want := [][2]uint64{{0, uint64(10 * ms)}, {uint64(10 * ms), uint64(50 * ms)}, {uint64(50 * ms), uint64(60 * ms)}}
+	if !slices.Equal(windows, want) {
+		t.Errorf("windows = %v, want %v", windows, want)
+	}
+	if s := out[1].(PinStats); s.Edges != 0 || s.DutyCycle != 1 || !s.High {
+		t.Errorf("idle window = %+v", s)
+	}
+}
+
+func TestRC(t *testing.T) {
+	us := time.Microsecond
+	a := NewAnalyzer(WithRC("ch"))
+	var pulses []RCPulse
+	for i, width := range []time.Duration{1000 * us, 1500 * us, 2000 * us, 2250 * us, 2300 * us, 750 * us, 700 * us} {
+		start := time.Duration(i) * 20 * time.Millisecond
+		for _, m := range feed(a, []*boardpb.StreamTicksResponse{tick("ch", start, true), tick("ch", start+width, false)}) {
+			if p, ok := m.(RCPulse); ok {
+				pulses = append(pulses, p)
+			}
+		}
+	}
+	want := []RCPulse{
+		{Width: 1000 * us, Value: -1, Valid: true},
+		{Width: 1500 * us, Value: 0, Valid: true},
+		{Width: 2000 * us, Value: 1, Valid: true},
+		{Width: 2250 * us, Value: 1, Valid: true},
+		{Width: 2300 * us, Value: 1, Valid: false},
+		{Width: 750 * us, Value: -1, Valid: true},
+		{Width: 700 * us, Value: -1, Valid: false},
+	}
+	if len(pulses) != len(want) {
+		t.Fatalf("got %d pulses, want %d", len(pulses), len(want))
+	}
+	for i, p := range pulses {
+		w := want[i]
+		if p.Pin != "ch" || p.Time != uint64(time.Duration(i)*20*time.Millisecond+w.Width) || p.Width != w.Width || !near(p.Value, w.Value) || p.Valid != w.Valid {
+			t.Errorf("pulse %d = %+v, want %+v", i, p, w)
+		}
+	}
+
+	// A pin that is not an RC channel yields no pulses, and the range can be changed.
+	a = NewAnalyzer(WithRC("ch"), WithRCRange(500*us, 2500*us))
+	out := feed(a, []*boardpb.StreamTicksResponse{tick("other", 0, true), tick("other", 1000*us, false), tick("ch", 2000*us, true), tick("ch", 3000*us, false)})
+	if len(out) != 1 || !near(out[0].(RCPulse).Value, -0.5) {
+		t.Errorf("got %+v", out)
+	}
+}
+
+func TestQuadrature(t *testing.T) {
+	ms := time.Millisecond
+	a := NewAnalyzer(WithWindow(10*ms), WithQuadrature("a", "b"))
+	var ticks []*boardpb.StreamTicksResponse
+	at := time.Duration(0)
+	add := func(pin string, high bool) {
+		ticks = append(ticks, tick(pin, at, high))
+		at += ms / 2
+	}
+	add("a", false)
+	add("b", false)
+	// Forwards a full cycle, a leading b.
+	add("a", true)
+	add("b", true)
+	add("a", false)
+	add("b", false)
+	// Back one step.
+	add("b", true)
+	at = 10 * ms
+	add("a", false)
+
+	out := feed(a, ticks)
+	var q QuadratureStats
+	for _, m := range out {
+		if s, ok := m.(QuadratureStats); ok {
+			q = s
+		}
+	}
+	if q.A != "a" || q.B != "b" || q.Count != 3 || q.Delta != 3 || !near(q.Velocity, 300) || q.Errors != 0 {
+		t.Errorf("quadrature = %+v", q)
+	}
+
+	// A transition changing both levels at once is counted as an error, and does not move the count.
+	a.pins["a"].high, a.pins["b"].high = true, false
+	a.step(a.quadrature[0])
+	a.Add(tick("other", 11*ms, true))
+	out = a.Flush()
+	if q := out[len(out)-1].(QuadratureStats); q.Errors != 1 || q.Count != 3 || q.Delta != 0 {
+		t.Errorf("quadrature = %+v", q)
+	}
+}
+
+type fakeClient struct {
+	req   *boardpb.StreamTicksRequest
+	ticks []*boardpb.StreamTicksResponse
+	err   error
+}
+
+func (c *fakeClient) StreamTicks(ctx context.Context, in *boardpb.StreamTicksRequest, _ ...grpc.CallOption) (boardpb.BoardService_StreamTicksClient, error) {
+	c.req = in
+	return &fakeStream{ctx: ctx, ticks: c.ticks, err: c.err}, nil
+}
+
+type fakeStream struct {
+	grpc.ClientStream
+	ctx   context.Context
+	ticks []*boardpb.StreamTicksResponse
+	err   error
+}
+
+func (s *fakeStream) Recv() (*boardpb.StreamTicksResponse, error) {
+	if len(s.ticks) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	t := s.ticks[0]
+	s.ticks = s.ticks[1:]
+	return t, nil
+}
+
+func TestWatch(t *testing.T) {
+	ms := time.Millisecond
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{"ended", nil},
+		{"failed", status.Error(codes.Unavailable, "gone")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeClient{ticks: square("p", 0, 25*ms, ms, ms/2), err: tc.err}
+			w, err := Watch(context.Background(), client, "board", []string{"p", "enc-a"},
+				WithWindow(10*ms), WithRC("rc2", "rc1"), WithQuadrature("enc-a", "enc-b"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := client.req.GetPinNames(), []string{"p", "enc-a", "rc1", "rc2", "enc-b"}; client.req.GetName() != "board" || !slices.Equal(got, want) {
+				t.Errorf("request = %v, want pins %v", client.req, want)
+			}
+			var ends []uint64
+			for m := range w.C {
+				if s, ok := m.(PinStats); ok {
+					ends = append(ends, s.End)
+				}
+			}
+			// The last window is flushed when the stream ends, whether or not it failed.
+			if want := []uint64{uint64(10 * ms), uint64(20 * ms), uint64(24*ms + ms/2)}; !slices.Equal(ends, want) {
+				t.Errorf("windows end at %v, want %v", ends, want)
+			}
+			if err := w.Err(); !errors.Is(err, tc.err) {
+				t.Errorf("Err() = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWatchCanceled(t *testing.T) {
+	ms := time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &fakeClient{ticks: square("p", 0, 100*ms, ms, ms/2)}
+	w, err := Watch(ctx, client, "board", []string{"p"}, WithWindow(ms), WithBuffer(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-w.C
+	cancel()
+	for range w.C {
+	}
+	if err := w.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v", err)
+	}
+}
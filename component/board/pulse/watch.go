@@ -0,0 +1,102 @@
+package pulse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	"google.golang.org/grpc"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// Client is the subset of boardpb.BoardServiceClient used by Watch.
+type Client interface {
+	StreamTicks(ctx context.Context, in *boardpb.StreamTicksRequest, opts ...grpc.CallOption) (boardpb.BoardService_StreamTicksClient, error)
+}
+
+// Watcher delivers the measurements of a stream of ticks.
+type Watcher struct {
+	// C receives the measurements, and is closed when the stream ends.
+	C <-chan Measurement
+
+	done chan struct{}
+	err  error
+}
+
+// Watch streams the ticks of the given pins of a board through an Analyzer configured by opts. The pins
+// of RC channels and quadrature encoders are added to pins. The stream lasts until the board ends it or
+// ctx is done. Measurements are buffered, and ticks are no longer read while the buffer is full.
+func Watch(ctx context.Context, client Client, board string, pins []string, opts ...Option) (*Watcher, error) {
+	a := NewAnalyzer(opts...)
+	req := &boardpb.StreamTicksRequest{Name: board, PinNames: a.watchedPins(pins)}
+	stream, err := client.StreamTicks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan Measurement, a.o.buffer)
+	w := &Watcher{C: c, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		defer close(c)
+		send := func(ms []Measurement) bool {
+			for _, m := range ms {
+				select {
+				case c <- m:
+				case <-ctx.Done():
+					w.err = ctx.Err()
+					return false
+				}
+			}
+			return true
+		}
+		for {
+			tick, err := stream.Recv()
+			if err != nil {
+				if send(a.Flush()) && !errors.Is(err, io.EOF) {
+					w.err = err
+				}
+				return
+			}
+			if !send(a.Add(tick)) {
+				return
+			}
+		}
+	}()
+	return w, nil
+}
+
+// Err returns why the stream ended, once C is closed: nil if the board ended it, or an error.
+func (w *Watcher) Err() error {
+	<-w.done
+	return w.err
+}
+
+// watchedPins returns pins followed by the RC and quadrature pins not among them.
+func (a *Analyzer) watchedPins(pins []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	for _, p := range pins {
+		add(p)
+	}
+	rc := make([]string, 0, len(a.o.rcPins))
+	for p := range a.o.rcPins {
+		rc = append(rc, p)
+	}
+	slices.Sort(rc)
+	for _, p := range rc {
+		add(p)
+	}
+	for _, q := range a.o.quadrature {
+		add(q.a)
+		add(q.b)
+	}
+	return out
+}
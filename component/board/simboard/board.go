@@ -0,0 +1,338 @@
+// Package simboard implements BoardService with a deterministic simulation, so that code driving a board
+// can be tested without hardware.
+//
+// A Board is described by a Scenario: its analog readers, with fixed or scheduled values, and its digital
+// interrupts, driven by scripted waveforms and by the GPIO pins wired to them. Pins are created as they are
+// used. Time is simulated, starting at 0: it only moves when Advance is called, or when DoCommand asks for
+// it with {"advance": "100ms"}, and every edge of every waveform and PWM output happens at its exact time.
+// Digital interrupts count rising edges, and StreamTicks reports their edges stamped with simulated time.
+// For interactive use, Run moves simulated time along with real time.
+package simboard
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+type pin struct {
+	high bool
+	// pwm is the wave of the pin while it outputs PWM, with duty and frequency, which is 0 for the
+	// board's default.
+	pwm       *wave
+	duty      float64
+	frequency uint64
+	// wires are the digital interrupts and analog readers the pin is wired to.
+	wires []string
+}
+
+type analogReader struct {
+	cfg      AnalogReader
+	schedule *wave
+	// value overrides the configured value and schedule once set.
+	value *int32
+}
+
+type interrupt struct {
+	high  bool
+	count int64
+	wave  *wave
+}
+
+// Board is a simulated board. It is safe for concurrent use.
+type Board struct {
+	boardpb.UnimplementedBoardServiceServer
+
+	name             string
+	defaultFrequency uint64
+
+	mu         sync.Mutex
+	now        uint64
+	pins       map[string]*pin
+	analogs    map[string]*analogReader
+	interrupts map[string]*interrupt
+	power      boardpb.PowerMode
+	// powerUntil is when the power mode returns to normal, or 0.
+	powerUntil  uint64
+	subscribers map[*subscriber]bool
+}
+
+// New returns a Board simulating s. At time 0, digital interrupts take the levels of their waveforms
+// without counting an edge.
+func New(s Scenario) (*Board, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	b := &Board{
+		name:             s.Name,
+		defaultFrequency: s.DefaultPWMFrequencyHz,
+		pins:             make(map[string]*pin),
+		analogs:          make(map[string]*analogReader),
+		interrupts:       make(map[string]*interrupt),
+		power:            boardpb.PowerMode_POWER_MODE_NORMAL,
+		subscribers:      make(map[*subscriber]bool),
+	}
+	if b.defaultFrequency == 0 {
+		b.defaultFrequency = DefaultPWMFrequency
+	}
+	for name, cfg := range s.AnalogReaders {
+		a := &analogReader{cfg: cfg}
+		if len(cfg.Steps) > 0 {
+			a.schedule = &wave{period: uint64(cfg.Period)}
+			for _, st := range cfg.Steps {
+				a.schedule.steps = append(a.schedule.steps, step{at: uint64(st.At), value: st.Value})
+			}
+		}
+		b.analogs[name] = a
+	}
+	for name, cfg := range s.DigitalInterrupts {
+		di := &interrupt{}
+		if cfg.Waveform != nil {
+			//nolint:errcheck
+			di.wave, _ = cfg.Waveform.wave()
+			di.high = di.wave.level(0)
+		}
+		b.interrupts[name] = di
+	}
+	for _, w := range s.Wires {
+		p := b.pin(w.From)
+		p.wires = append(p.wires, w.To)
+	}
+	return b, nil
+}
+
+// Now returns the simulated time, in nanoseconds.
+func (b *Board) Now() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Duration(b.now)
+}
+
+// Advance moves simulated time forward by d, applying every edge on the way in order.
+func (b *Board) Advance(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if d > 0 {
+		b.advanceTo(b.now + uint64(d))
+	}
+}
+
+// Run moves simulated time along with real time, every interval, until ctx is done.
+func (b *Board) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			b.Advance(now.Sub(last))
+			last = now
+		}
+	}
+}
+
+// SetAnalog sets the value of an analog reader, which keeps it until it is set again.
+func (b *Board) SetAnalog(name string, value int32) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	a, ok := b.analogs[name]
+	if !ok {
+		return status.Errorf(codes.NotFound, "simboard: no analog reader named %q", name)
+	}
+	a.value = &value
+	return nil
+}
+
+// SetInterrupt drives the input of a digital interrupt to a level, as a wired pin would.
+func (b *Board) SetInterrupt(name string, high bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.interrupts[name]; !ok {
+		return status.Errorf(codes.NotFound, "simboard: no digital interrupt named %q", name)
+	}
+	b.drive(name, high)
+	return nil
+}
+
+// PowerMode returns the current power mode.
+func (b *Board) PowerMode() boardpb.PowerMode {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.power
+}
+
+func (b *Board) pin(name string) *pin {
+	p, ok := b.pins[name]
+	if !ok {
+		p = &pin{}
+		b.pins[name] = p
+	}
+	return p
+}
+
+// advanceTo applies the edges and power mode change up to t, in order of time, then moves the clock to t.
+func (b *Board) advanceTo(t uint64) {
+	for {
+		next, ok := b.nextEvent()
+		if !ok || next > t {
+			break
+		}
+		b.now = next
+		if b.powerUntil != 0 && b.powerUntil == next {
+			b.power, b.powerUntil = boardpb.PowerMode_POWER_MODE_NORMAL, 0
+		}
+		// Every edge due at this time is applied, in a fixed order so that runs are repeatable.
+		for _, name := range sortedKeys(b.interrupts) {
+			di := b.interrupts[name]
+			if di.wave == nil {
+				continue
+			}
+			if s, at, ok := di.wave.next(next - 1); ok && at == next {
+				b.drive(name, s.high)
+			}
+		}
+		for _, name := range sortedKeys(b.pins) {
+			p := b.pins[name]
+			if p.pwm == nil {
+				continue
+			}
+			if s, at, ok := p.pwm.next(next - 1); ok && at == next {
+				b.setLevel(p, s.high)
+			}
+		}
+	}
+	b.now = t
+}
+
+// nextEvent returns the time of the first edge or power mode change after the current time.
+func (b *Board) nextEvent() (uint64, bool) {
+	next, ok := uint64(math.MaxUint64), false
+	consider := func(t uint64) {
+		if t < next {
+			next, ok = t, true
+		}
+	}
+	if b.powerUntil > b.now {
+		consider(b.powerUntil)
+	}
+	for _, di := range b.interrupts {
+		if di.wave != nil {
+			if _, at, found := di.wave.next(b.now); found {
+				consider(at)
+			}
+		}
+	}
+	for _, p := range b.pins {
+		if p.pwm != nil {
+			if _, at, found := p.pwm.next(b.now); found {
+				consider(at)
+			}
+		}
+	}
+	return next, ok
+}
+
+// setLevel sets the output level of a pin, which the digital interrupts wired to it follow.
+func (b *Board) setLevel(p *pin, high bool) {
+	p.high = high
+	for _, w := range p.wires {
+		if _, ok := b.interrupts[w]; ok {
+			b.drive(w, high)
+		}
+	}
+}
+
+// drive sets the input level of a digital interrupt, counting and reporting an edge if it changes.
+func (b *Board) drive(name string, high bool) {
+	di := b.interrupts[name]
+	if di.high == high {
+		return
+	}
+	di.high = high
+	if high {
+		di.count++
+	}
+	tick := &boardpb.StreamTicksResponse{PinName: name, Time: b.now, High: high}
+	for s := range b.subscribers {
+		s.deliver(tick)
+	}
+}
+
+// startPWM makes a pin output PWM with its duty cycle and frequency from now, or stops it.
+func (b *Board) startPWM(p *pin) error {
+	if p.duty == 0 {
+		p.pwm = nil
+		b.setLevel(p, false)
+		return nil
+	}
+	frequency := p.frequency
+	if frequency == 0 {
+		frequency = b.defaultFrequency
+	}
+	w, err := squareWave(b.now, float64(frequency), p.duty)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "simboard: %v", err)
+	}
+	p.pwm = w
+	b.setLevel(p, w.level(b.now))
+	return nil
+}
+
+func (a *analogReader) read(now uint64) int32 {
+	switch {
+	case a.value != nil:
+		return *a.value
+	case a.schedule != nil:
+		if s, ok := a.schedule.at(now); ok {
+			return s.value
+		}
+	}
+	return a.cfg.Value
+}
+
+// subscriber is a StreamTicks call, whose ticks are queued so that the simulation never waits for it.
+type subscriber struct {
+	pins   map[string]bool
+	mu     sync.Mutex
+	queue  []*boardpb.StreamTicksResponse
+	notify chan struct{}
+}
+
+func (s *subscriber) deliver(tick *boardpb.StreamTicksResponse) {
+	if !s.pins[tick.GetPinName()] {
+		return
+	}
+	s.mu.Lock()
+	s.queue = append(s.queue, tick)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) take() []*boardpb.StreamTicksResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := s.queue
+	s.queue = nil
+	return q
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,485 @@
+package simboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/api/common/structcodec"
+	commonpb "go.viam.com/api/common/v1"
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// newBoard returns a board simulating the example scenario, with pin 12 wired to the battery reader.
+func newBoard(t *testing.T) *Board {
+	t.Helper()
+	var s Scenario
+	if err := json.Unmarshal([]byte(exampleScenario), &s); err != nil {
+		t.Fatal(err)
+	}
+	s.Wires = append(s.Wires, Wire{From: "12", To: "battery"})
+	b, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func interruptValue(t *testing.T, b *Board, name string) int64 {
+	t.Helper()
+	resp, err := b.GetDigitalInterruptValue(context.Background(), &boardpb.GetDigitalInterruptValueRequest{BoardName: "board", DigitalInterruptName: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.GetValue()
+}
+
+func gpio(t *testing.T, b *Board, pin string) bool {
+	t.Helper()
+	resp, err := b.GetGPIO(context.Background(), &boardpb.GetGPIORequest{Name: "board", Pin: pin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.GetHigh()
+}
+
+func analog(t *testing.T, b *Board, name string) int32 {
+	t.Helper()
+	resp, err := b.ReadAnalogReader(context.Background(), &boardpb.ReadAnalogReaderRequest{BoardName: "board", AnalogReaderName: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.GetValue()
+}
+
+func TestWaveforms(t *testing.T) {
+	b := newBoard(t)
+	// The flow meter starts high, which is not an edge, and rises every 25 ms.
+	b.Advance(time.Second)
+	if got := interruptValue(t, b, "flow"); got != 40 {
+		t.Errorf("flow = %d, want 40", got)
+	}
+	if got := interruptValue(t, b, "button"); got != 1 {
+		t.Errorf("button = %d after it was pressed, want 1", got)
+	}
+	b.Advance(time.Second)
+	if got := interruptValue(t, b, "button"); got != 1 {
+		t.Errorf("button = %d after it was released, want 1", got)
+	}
+	if b.Now() != 2*time.Second {
+		t.Errorf("Now() = %v", b.Now())
+	}
+
+	// Driving an interrupt counts its rising edges only.
+	for _, high := range []bool{true, true, false, true} {
+		if err := b.SetInterrupt("echo", high); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := interruptValue(t, b, "echo"); got != 2 {
+		t.Errorf("echo = %d, want 2", got)
+	}
+	if err := b.SetInterrupt("nothing", true); status.Code(err) != codes.NotFound {
+		t.Errorf("SetInterrupt = %v", err)
+	}
+	if _, err := b.GetDigitalInterruptValue(context.Background(), &boardpb.GetDigitalInterruptValueRequest{BoardName: "board", DigitalInterruptName: "nothing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetDigitalInterruptValue = %v", err)
+	}
+}
+
+func TestPWM(t *testing.T) {
+	ctx := context.Background()
+	b := newBoard(t)
+	if _, err := b.SetPWMFrequency(ctx, &boardpb.SetPWMFrequencyRequest{Name: "board", Pin: "11", FrequencyHz: 100}); err != nil {
+		t.Fatal(err)
+	}
+	for pin, want := range map[string]uint64{"11": 100, "12": DefaultPWMFrequency} {
+		resp, err := b.PWMFrequency(ctx, &boardpb.PWMFrequencyRequest{Name: "board", Pin: pin})
+		if err != nil || resp.GetFrequencyHz() != want {
+			t.Errorf("PWMFrequency(%s) = %v, %v; want %d", pin, resp, err, want)
+		}
+	}
+
+	// The echo interrupt follows pin 11, which rises at once and then every 10 ms.
+	if _, err := b.SetPWM(ctx, &boardpb.SetPWMRequest{Name: "board", Pin: "11", DutyCyclePct: 0.25}); err != nil {
+		t.Fatal(err)
+	}
+	if got := interruptValue(t, b, "echo"); got != 1 {
+		t.Errorf("echo = %d, want 1", got)
+	}
+	b.Advance(time.Second)
+	if got := interruptValue(t, b, "echo"); got != 101 {
+		t.Errorf("echo = %d, want 101", got)
+	}
+	if !gpio(t, b, "11") {
+		t.Error("pin 11 is low at the start of a period")
+	}
+	b.Advance(3 * time.Millisecond)
+	if gpio(t, b, "11") {
+		t.Error("pin 11 is high after its duty cycle")
+	}
+	if resp, err := b.PWM(ctx, &boardpb.PWMRequest{Name: "board", Pin: "11"}); err != nil || resp.GetDutyCyclePct() != 0.25 {
+		t.Errorf("PWM = %v, %v", resp, err)
+	}
+
+	// Invalid settings leave the output as it was.
+	if _, err := b.SetPWM(ctx, &boardpb.SetPWMRequest{Name: "board", Pin: "11", DutyCyclePct: 1.5}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SetPWM = %v", err)
+	}
+	if _, err := b.SetPWMFrequency(ctx, &boardpb.SetPWMFrequencyRequest{Name: "board", Pin: "11", FrequencyHz: 1e10}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SetPWMFrequency = %v", err)
+	}
+	if resp, err := b.PWMFrequency(ctx, &boardpb.PWMFrequencyRequest{Name: "board", Pin: "11"}); err != nil || resp.GetFrequencyHz() != 100 {
+		t.Errorf("PWMFrequency = %v, %v", resp, err)
+	}
+
+	// Setting the level stops PWM.
+	if _, err := b.SetGPIO(ctx, &boardpb.SetGPIORequest{Name: "board", Pin: "11", High: false}); err != nil {
+		t.Fatal(err)
+	}
+	b.Advance(time.Second)
+	if got := interruptValue(t, b, "echo"); got != 101 {
+		t.Errorf("echo = %d after PWM stopped, want 101", got)
+	}
+	if resp, err := b.PWM(ctx, &boardpb.PWMRequest{Name: "board", Pin: "11"}); err != nil || resp.GetDutyCyclePct() != 0 {
+		t.Errorf("PWM = %v, %v", resp, err)
+	}
+	if _, err := b.SetGPIO(ctx, &boardpb.SetGPIORequest{Name: "board", Pin: "11", High: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := interruptValue(t, b, "echo"); got != 102 || !gpio(t, b, "11") {
+		t.Errorf("echo = %d, want 102", got)
+	}
+	if _, err := b.SetPWM(ctx, &boardpb.SetPWMRequest{Name: "board", Pin: "11", DutyCyclePct: 0}); err != nil || gpio(t, b, "11") {
+		t.Errorf("SetPWM(0) = %v, pin is still high", err)
+	}
+}
+
+func TestAnalogReaders(t *testing.T) {
+	ctx := context.Background()
+	b := newBoard(t)
+	resp, err := b.ReadAnalogReader(ctx, &boardpb.ReadAnalogReaderRequest{BoardName: "board", AnalogReaderName: "battery"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetValue() != 2048 || resp.GetMaxRange() != 3.3 || resp.GetStepSize() != 0.0008 {
+		t.Errorf("battery = %v", resp)
+	}
+
+	// The light reader follows its schedule, every second.
+	for _, tc := range []struct {
+		advance time.Duration
+		want    int32
+	}{{0, 100}, {600 * time.Millisecond, 900}, {500 * time.Millisecond, 100}, {400 * time.Millisecond, 900}} {
+		b.Advance(tc.advance)
+		if got := analog(t, b, "light"); got != tc.want {
+			t.Errorf("light at %v = %d, want %d", b.Now(), got, tc.want)
+		}
+	}
+	if err := b.SetAnalog("light", 5); err != nil {
+		t.Fatal(err)
+	}
+	b.Advance(time.Second)
+	if got := analog(t, b, "light"); got != 5 {
+		t.Errorf("light = %d after it was set, want 5", got)
+	}
+
+	// Pin 12 is wired to the battery reader, but pin 13 to nothing.
+	for _, pin := range []string{"12", "13"} {
+		if _, err := b.WriteAnalog(ctx, &boardpb.WriteAnalogRequest{Name: "board", Pin: pin, Value: int32(len(pin) * 100)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := analog(t, b, "battery"); got != 200 {
+		t.Errorf("battery = %d, want 200", got)
+	}
+
+	if err := b.SetAnalog("nothing", 1); status.Code(err) != codes.NotFound {
+		t.Errorf("SetAnalog = %v", err)
+	}
+	if _, err := b.ReadAnalogReader(ctx, &boardpb.ReadAnalogReaderRequest{BoardName: "board", AnalogReaderName: "nothing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("ReadAnalogReader = %v", err)
+	}
+}
+
+func TestPowerMode(t *testing.T) {
+	ctx := context.Background()
+	b := newBoard(t)
+	deep := boardpb.PowerMode_POWER_MODE_OFFLINE_DEEP
+	if _, err := b.SetPowerMode(ctx, &boardpb.SetPowerModeRequest{Name: "board", PowerMode: deep, Duration: durationpb.New(time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.GetGPIO(ctx, &boardpb.GetGPIORequest{Name: "board", Pin: "11"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("GetGPIO while offline = %v", err)
+	}
+	// The waveforms go on meanwhile.
+	b.Advance(time.Second - 1)
+	if b.PowerMode() != deep {
+		t.Errorf("power mode = %v before the end of the duration", b.PowerMode())
+	}
+	b.Advance(1)
+	if b.PowerMode() != boardpb.PowerMode_POWER_MODE_NORMAL {
+		t.Errorf("power mode = %v after the duration", b.PowerMode())
+	}
+	if got := interruptValue(t, b, "flow"); got != 40 {
+		t.Errorf("flow = %d, want 40", got)
+	}
+
+	// Without a duration, the mode lasts until it is changed.
+	if _, err := b.SetPowerMode(ctx, &boardpb.SetPowerModeRequest{Name: "board", PowerMode: deep}); err != nil {
+		t.Fatal(err)
+	}
+	b.Advance(time.Hour)
+	if _, err := b.SetPowerMode(ctx, &boardpb.SetPowerModeRequest{Name: "board", PowerMode: boardpb.PowerMode_POWER_MODE_NORMAL}); err != nil {
+		t.Fatal(err)
+	}
+	if b.PowerMode() != boardpb.PowerMode_POWER_MODE_NORMAL {
+		t.Errorf("power mode = %v", b.PowerMode())
+	}
+
+	for _, tc := range []struct {
+		req  *boardpb.SetPowerModeRequest
+		code codes.Code
+	}{
+		{&boardpb.SetPowerModeRequest{Name: "other", PowerMode: deep}, codes.NotFound},
+		{&boardpb.SetPowerModeRequest{Name: "board"}, codes.InvalidArgument},
+		{&boardpb.SetPowerModeRequest{Name: "board", PowerMode: 99}, codes.InvalidArgument},
+		{&boardpb.SetPowerModeRequest{Name: "board", PowerMode: deep, Duration: durationpb.New(-time.Second)}, codes.InvalidArgument},
+	} {
+		if _, err := b.SetPowerMode(ctx, tc.req); status.Code(err) != tc.code {
+			t.Errorf("SetPowerMode(%v) = %v, want %v", tc.req, err, tc.code)
+		}
+	}
+}
+
+func TestOtherBoard(t *testing.T) {
+	ctx := context.Background()
+	b := newBoard(t)
+	for name, call := range map[string]func() error{
+		"SetGPIO": func() error {
+			_, err := b.SetGPIO(ctx, &boardpb.SetGPIORequest{Name: "other"})
+			return err
+		},
+		"ReadAnalogReader": func() error {
+			_, err := b.ReadAnalogReader(ctx, &boardpb.ReadAnalogReaderRequest{BoardName: "other", AnalogReaderName: "battery"})
+			return err
+		},
+		"GetStatus": func() error {
+			_, err := b.GetStatus(ctx, &commonpb.GetStatusRequest{Name: "other"})
+			return err
+		},
+		"DoCommand": func() error {
+			_, err := b.DoCommand(ctx, &commonpb.DoCommandRequest{Name: "other"})
+			return err
+		},
+		"GetGeometries": func() error {
+			_, err := b.GetGeometries(ctx, &commonpb.GetGeometriesRequest{Name: "other"})
+			return err
+		},
+	} {
+		if err := call(); status.Code(err) != codes.NotFound {
+			t.Errorf("%s = %v", name, err)
+		}
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	b := newBoard(t)
+	b.Advance(1500 * time.Millisecond)
+	resp, err := b.GetStatus(context.Background(), &commonpb.GetStatusRequest{Name: "board"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var st simStatus
+	if err := structcodec.Unmarshal(resp.GetResult(), &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.Time != 1500*time.Millisecond || st.PowerMode != "POWER_MODE_NORMAL" {
+		t.Errorf("status = %+v", st)
+	}
+	if st.Analogs["battery"] != 2048 || st.Analogs["light"] != 900 || st.DigitalInterrupts["flow"] != 60 || st.DigitalInterrupts["button"] != 1 {
+		t.Errorf("status = %+v", st)
+	}
+}
+
+func TestDoCommand(t *testing.T) {
+	b := newBoard(t)
+	for _, tc := range []struct {
+		name    string
+		command map[string]any
+		code    codes.Code
+		time    string
+	}{
+		{"advance", map[string]any{"advance": "100ms"}, codes.OK, "100ms"},
+		{"advance in seconds", map[string]any{"advance": 0.5}, codes.OK, "600ms"},
+		{"set", map[string]any{"set_analog": map[string]any{"name": "battery", "value": 7}, "set_interrupt": map[string]any{"name": "echo", "high": true}}, codes.OK, "600ms"},
+		{"unknown command", map[string]any{"reboot": true}, codes.InvalidArgument, ""},
+		{"backwards", map[string]any{"advance": "-1s"}, codes.InvalidArgument, ""},
+		{"bad duration", map[string]any{"advance": "soon"}, codes.InvalidArgument, ""},
+		{"unknown reader", map[string]any{"set_analog": map[string]any{"name": "nothing", "value": 7}}, codes.NotFound, ""},
+		{"unknown interrupt", map[string]any{"set_interrupt": map[string]any{"name": "nothing", "high": true}}, codes.NotFound, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := structpb.NewStruct(tc.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := b.DoCommand(context.Background(), &commonpb.DoCommandRequest{Name: "board", Command: cmd})
+			if status.Code(err) != tc.code {
+				t.Fatalf("DoCommand = %v, want %v", err, tc.code)
+			}
+			if got := resp.GetResult().GetFields()["time"].GetStringValue(); got != tc.time {
+				t.Errorf("time = %q, want %q", got, tc.time)
+			}
+		})
+	}
+	if got := analog(t, b, "battery"); got != 7 {
+		t.Errorf("battery = %d, want 7", got)
+	}
+	if got := interruptValue(t, b, "echo"); got != 1 {
+		t.Errorf("echo = %d, want 1", got)
+	}
+}
+
+// serve returns a client of b served over gRPC.
+func serve(t *testing.T, b *Board) boardpb.BoardServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	boardpb.RegisterBoardServiceServer(srv, b)
+	go func() {
+		//nolint:errcheck
+		srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		conn.Close()
+	})
+	return boardpb.NewBoardServiceClient(conn)
+}
+
+// waitSubscribers waits until b has n StreamTicks calls.
+func waitSubscribers(t *testing.T, b *Board, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		b.mu.Lock()
+		got := len(b.subscribers)
+		b.mu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d subscribers, want %d", got, n)
+		}
+	}
+}
+
+func TestStreamTicks(t *testing.T) {
+	b := newBoard(t)
+	client := serve(t, b)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.StreamTicks(ctx, &boardpb.StreamTicksRequest{Name: "board", PinNames: []string{"flow", "echo"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitSubscribers(t, b, 1)
+
+	// The button is not streamed. The flow meter falls at 12.5 ms and rises at 25 ms, and echo follows a
+	// level set through DoCommand.
+	cmd, err := structpb.NewStruct(map[string]any{"set_interrupt": map[string]any{"name": "echo", "high": true}, "advance": "30ms"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DoCommand(ctx, &commonpb.DoCommandRequest{Name: "board", Command: cmd}); err != nil {
+		t.Fatal(err)
+	}
+	b.Advance(time.Second)
+	want := []*boardpb.StreamTicksResponse{
+		{PinName: "echo", Time: 0, High: true},
+		{PinName: "flow", Time: uint64(12500 * time.Microsecond), High: false},
+		{PinName: "flow", Time: uint64(25 * time.Millisecond), High: true},
+	}
+	for i, w := range want {
+		tick, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tick.GetPinName() != w.GetPinName() || tick.GetTime() != w.GetTime() || tick.GetHigh() != w.GetHigh() {
+			t.Errorf("tick %d = %v, want %v", i, tick, w)
+		}
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("Recv after cancel = %v", err)
+	}
+	waitSubscribers(t, b, 0)
+
+	for _, req := range []*boardpb.StreamTicksRequest{{Name: "other"}, {Name: "board", PinNames: []string{"nothing"}}} {
+		stream, err := client.StreamTicks(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+			t.Errorf("StreamTicks(%v) = %v", req, err)
+		}
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	ticks := func() []string {
+		b := newBoard(t)
+		s := &subscriber{pins: map[string]bool{"flow": true, "button": true, "echo": true}, notify: make(chan struct{}, 1)}
+		b.subscribers[s] = true
+		if _, err := b.SetPWM(context.Background(), &boardpb.SetPWMRequest{Name: "board", Pin: "11", DutyCyclePct: 0.3}); err != nil {
+			t.Fatal(err)
+		}
+		for range 10 {
+			b.Advance(123 * time.Millisecond)
+		}
+		var out []string
+		for _, tick := range s.take() {
+			out = append(out, fmt.Sprintf("%s %d %v", tick.GetPinName(), tick.GetTime(), tick.GetHigh()))
+		}
+		return out
+	}
+	first, second := ticks(), ticks()
+	if len(first) == 0 || len(first) != len(second) {
+		t.Fatalf("got %d and %d ticks", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("tick %d differs: %s and %s", i, first[i], second[i])
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	b := newBoard(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	b.Run(ctx, time.Millisecond)
+	if b.Now() <= 0 || b.Now() > time.Second {
+		t.Errorf("Now() = %v after running for 50ms", b.Now())
+	}
+}
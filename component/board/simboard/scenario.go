@@ -0,0 +1,192 @@
+package simboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultPWMFrequency is the PWM frequency of pins by default, in Hz.
+const DefaultPWMFrequency = 800
+
+// Scenario describes a simulated board. It is usually loaded from a JSON file, in which durations are
+// strings such as "20ms" or numbers of seconds:
+//
+//	{
+//	  "name": "board",
+//	  "analog_readers": {
+//	    "battery": {"value": 2048, "max_range": 3.3, "step_size": 0.0008},
+//	    "light": {"steps": [{"at": "0s", "value": 100}, {"at": "500ms", "value": 900}], "period": "1s"}
+//	  },
+//	  "digital_interrupts": {
+//	    "flow": {"waveform": {"frequency_hz": 40, "duty_cycle": 0.5}},
+//	    "button": {"waveform": {"edges": [{"at": "1s", "high": true}, {"at": "1.2s", "high": false}]}},
+//	    "echo": {}
+//	  },
+//	  "wires": [{"from": "11", "to": "echo"}]
+//	}
+type Scenario struct {
+	// Name is the name of the board, which requests must use.
+	Name string `json:"name"`
+	// DefaultPWMFrequencyHz is the frequency of PWM pins whose frequency is not set, or set to 0. Zero
+	// means DefaultPWMFrequency.
+	DefaultPWMFrequencyHz uint64                      `json:"default_pwm_frequency_hz,omitempty"`
+	AnalogReaders         map[string]AnalogReader     `json:"analog_readers,omitempty"`
+	DigitalInterrupts     map[string]DigitalInterrupt `json:"digital_interrupts,omitempty"`
+	Wires                 []Wire                      `json:"wires,omitempty"`
+}
+
+// AnalogReader describes an analog reader. Its value is Value, or follows Steps if there are any, until
+// it is set by SetAnalog or written through a wire.
+type AnalogReader struct {
+	Value    int32   `json:"value,omitempty"`
+	MinRange float32 `json:"min_range,omitempty"`
+	MaxRange float32 `json:"max_range,omitempty"`
+	StepSize float32 `json:"step_size,omitempty"`
+	// Steps are values taken at times since the start of the simulation, in increasing order. Before the
+	// first step, the value is Value.
+	Steps []AnalogStep `json:"steps,omitempty"`
+	// Period repeats Steps, whose times must then be less than it.
+	Period Duration `json:"period,omitempty"`
+}
+
+// AnalogStep is a value an analog reader takes at a given time.
+type AnalogStep struct {
+	At    Duration `json:"at"`
+	Value int32    `json:"value"`
+}
+
+// DigitalInterrupt describes a digital interrupt, which counts rising edges. Its input follows its
+// waveform, if any, and the pins wired to it.
+type DigitalInterrupt struct {
+	Waveform *Waveform `json:"waveform,omitempty"`
+}
+
+// Waveform is a scripted digital signal: either a square wave or a list of edges.
+type Waveform struct {
+	// FrequencyHz and DutyCycle, from 0 to 1, describe a square wave that rises at Start.
+	FrequencyHz float64 `json:"frequency_hz,omitempty"`
+	DutyCycle   float64 `json:"duty_cycle,omitempty"`
+	// Edges are level changes at times since Start, in increasing order. The signal is low before the
+	// first one.
+	Edges []Edge `json:"edges,omitempty"`
+	// Period repeats Edges, whose times must then be less than it.
+	Period Duration `json:"period,omitempty"`
+	// Start is when the waveform starts, since the start of the simulation.
+	Start Duration `json:"start,omitempty"`
+}
+
+// Edge is a level a signal takes at a given time.
+type Edge struct {
+	At   Duration `json:"at"`
+	High bool     `json:"high"`
+}
+
+// Wire connects a GPIO pin to a digital interrupt, which then follows the level of the pin, including
+// PWM, or to an analog reader, which then reads the values written to the pin with WriteAnalog.
+type Wire struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Duration is a time.Duration read from JSON as a string in time.ParseDuration form or a number of
+// seconds.
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(v * float64(time.Second))
+	default:
+		return fmt.Errorf("simboard: invalid duration %s", b)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadScenario reads a Scenario from a JSON file. Unknown fields are rejected, to catch typos.
+func LoadScenario(path string) (Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s Scenario
+	if err := dec.Decode(&s); err != nil {
+		return Scenario{}, fmt.Errorf("simboard: reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// validate checks the waveforms, schedules and wires of the scenario.
+func (s Scenario) validate() error {
+	if s.Name == "" {
+		return errors.New("simboard: scenario has no name")
+	}
+	for name, a := range s.AnalogReaders {
+		steps := make([]step, len(a.Steps))
+		for i, st := range a.Steps {
+			steps[i] = step{at: uint64(st.At)}
+		}
+		if err := checkSteps(steps, uint64(a.Period)); err != nil {
+			return fmt.Errorf("simboard: analog reader %q: %w", name, err)
+		}
+	}
+	for name, di := range s.DigitalInterrupts {
+		if di.Waveform == nil {
+			continue
+		}
+		if _, err := di.Waveform.wave(); err != nil {
+			return fmt.Errorf("simboard: digital interrupt %q: %w", name, err)
+		}
+	}
+	for _, w := range s.Wires {
+		_, isInterrupt := s.DigitalInterrupts[w.To]
+		_, isAnalog := s.AnalogReaders[w.To]
+		switch {
+		case w.From == "":
+			return fmt.Errorf("simboard: wire to %q has no pin", w.To)
+		case !isInterrupt && !isAnalog:
+			return fmt.Errorf("simboard: wire from pin %q to unknown %q", w.From, w.To)
+		}
+	}
+	return nil
+}
+
+// wave returns the steps of the waveform.
+func (w *Waveform) wave() (*wave, error) {
+	start := uint64(w.Start)
+	if w.FrequencyHz != 0 || w.DutyCycle != 0 {
+		if len(w.Edges) > 0 {
+			return nil, errors.New("waveform has both a square wave and edges")
+		}
+		return squareWave(start, w.FrequencyHz, w.DutyCycle)
+	}
+	steps := make([]step, len(w.Edges))
+	for i, e := range w.Edges {
+		steps[i] = step{at: uint64(e.At), high: e.High}
+	}
+	if err := checkSteps(steps, uint64(w.Period)); err != nil {
+		return nil, err
+	}
+	return &wave{start: start, period: uint64(w.Period), steps: steps}, nil
+}
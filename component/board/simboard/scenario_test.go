@@ -0,0 +1,135 @@
+package simboard
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exampleScenario is the example of the documentation of Scenario.
+const exampleScenario = `{
+  "name": "board",
+  "analog_readers": {
+    "battery": {"value": 2048, "max_range": 3.3, "step_size": 0.0008},
+    "light": {"steps": [{"at": "0s", "value": 100}, {"at": "500ms", "value": 900}], "period": "1s"}
+  },
+  "digital_interrupts": {
+    "flow": {"waveform": {"frequency_hz": 40, "duty_cycle": 0.5}},
+    "button": {"waveform": {"edges": [{"at": "1s", "high": true}, {"at": "1.2s", "high": false}]}},
+    "echo": {}
+  },
+  "wires": [{"from": "11", "to": "echo"}]
+}`
+
+func TestDuration(t *testing.T) {
+	for _, tc := range []struct {
+		json string
+		want time.Duration
+		ok   bool
+	}{
+		{`"20ms"`, 20 * time.Millisecond, true},
+		{`"1h2m"`, time.Hour + 2*time.Minute, true},
+		{`1.5`, 1500 * time.Millisecond, true},
+		{`0`, 0, true},
+		{`"soon"`, 0, false},
+		{`true`, 0, false},
+		{`{}`, 0, false},
+	} {
+		var d Duration
+		err := json.Unmarshal([]byte(tc.json), &d)
+		if (err == nil) != tc.ok || time.Duration(d) != tc.want {
+			t.Errorf("unmarshaling %s = %v, %v; want %v", tc.json, time.Duration(d), err, tc.want)
+		}
+	}
+
+	b, err := json.Marshal(Duration(1500 * time.Millisecond))
+	if err != nil || string(b) != `"1.5s"` {
+		t.Errorf("Marshal = %s, %v", b, err)
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	s, err := LoadScenario(write("example.json", exampleScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	light := s.AnalogReaders["light"]
+	if s.Name != "board" || len(light.Steps) != 2 || light.Steps[1].At != Duration(500*time.Millisecond) || light.Period != Duration(time.Second) {
+		t.Errorf("scenario = %+v", s)
+	}
+	if w := s.DigitalInterrupts["flow"].Waveform; w == nil || w.FrequencyHz != 40 || w.DutyCycle != 0.5 {
+		t.Errorf("flow waveform = %+v", w)
+	}
+	if len(s.Wires) != 1 || s.Wires[0] != (Wire{From: "11", To: "echo"}) {
+		t.Errorf("wires = %+v", s.Wires)
+	}
+	if _, err := New(s); err != nil {
+		t.Errorf("New = %v", err)
+	}
+
+	if _, err := LoadScenario(write("typo.json", `{"name": "board", "digital_interupts": {}}`)); err == nil || !strings.Contains(err.Error(), "digital_interupts") {
+		t.Errorf("LoadScenario with an unknown field = %v", err)
+	}
+	if _, err := LoadScenario(filepath.Join(dir, "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("LoadScenario of a missing file = %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	square := func(frequency, duty float64) map[string]DigitalInterrupt {
+		return map[string]DigitalInterrupt{"di": {Waveform: &Waveform{FrequencyHz: frequency, DutyCycle: duty}}}
+	}
+	for _, tc := range []struct {
+		name string
+		s    Scenario
+		msg  string
+	}{
+		{"no name", Scenario{}, "no name"},
+		{
+			"analog steps out of order",
+			Scenario{Name: "b", AnalogReaders: map[string]AnalogReader{"a": {Steps: []AnalogStep{{At: 2}, {At: 1}}}}},
+			`analog reader "a"`,
+		},
+		{
+			"analog step outside the period",
+			Scenario{Name: "b", AnalogReaders: map[string]AnalogReader{"a": {Steps: []AnalogStep{{At: 2}}, Period: 2}}},
+			"not within the period",
+		},
+		{"invalid duty cycle", Scenario{Name: "b", DigitalInterrupts: square(10, 2)}, `digital interrupt "di"`},
+		{"too fast", Scenario{Name: "b", DigitalInterrupts: square(1e10, 0.5)}, "too high"},
+		{
+			"square wave and edges",
+			Scenario{Name: "b", DigitalInterrupts: map[string]DigitalInterrupt{
+				"di": {Waveform: &Waveform{FrequencyHz: 10, DutyCycle: 0.5, Edges: []Edge{{At: 1, High: true}}}},
+			}},
+			"both",
+		},
+		{
+			"edges outside the period",
+			Scenario{Name: "b", DigitalInterrupts: map[string]DigitalInterrupt{
+				"di": {Waveform: &Waveform{Edges: []Edge{{At: 5, High: true}}, Period: 5}},
+			}},
+			"not within the period",
+		},
+		{"wire without a pin", Scenario{Name: "b", DigitalInterrupts: square(10, 0.5), Wires: []Wire{{To: "di"}}}, "no pin"},
+		{"wire to nothing", Scenario{Name: "b", Wires: []Wire{{From: "1", To: "di"}}}, `unknown "di"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := New(tc.s); err == nil || !strings.Contains(err.Error(), tc.msg) {
+				t.Errorf("New = %v, want an error about %q", err, tc.msg)
+			}
+		})
+	}
+}
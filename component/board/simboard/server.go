@@ -0,0 +1,333 @@
+package simboard
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/api/common/structcodec"
+	commonpb "go.viam.com/api/common/v1"
+	boardpb "go.viam.com/api/component/board/v1"
+)
+
+// Board serves BoardService.
+var _ boardpb.BoardServiceServer = (*Board)(nil)
+
+// check fails calls to another board, and calls other than SetPowerMode while the board is not in the
+// normal power mode. It must be called with b.mu held.
+func (b *Board) check(name string) error {
+	if name != b.name {
+		return status.Errorf(codes.NotFound, "simboard: no board named %q", name)
+	}
+	if b.power != boardpb.PowerMode_POWER_MODE_NORMAL {
+		return status.Errorf(codes.Unavailable, "simboard: board %q is in power mode %v", name, b.power)
+	}
+	return nil
+}
+
+// SetGPIO sets a pin high or low, stopping its PWM output.
+func (b *Board) SetGPIO(ctx context.Context, req *boardpb.SetGPIORequest) (*boardpb.SetGPIOResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	p := b.pin(req.GetPin())
+	p.pwm, p.duty = nil, 0
+	b.setLevel(p, req.GetHigh())
+	return &boardpb.SetGPIOResponse{}, nil
+}
+
+// GetGPIO returns the level of a pin, following its PWM output.
+func (b *Board) GetGPIO(ctx context.Context, req *boardpb.GetGPIORequest) (*boardpb.GetGPIOResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	return &boardpb.GetGPIOResponse{High: b.pin(req.GetPin()).high}, nil
+}
+
+// PWM returns the duty cycle of a pin.
+func (b *Board) PWM(ctx context.Context, req *boardpb.PWMRequest) (*boardpb.PWMResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	return &boardpb.PWMResponse{DutyCyclePct: b.pin(req.GetPin()).duty}, nil
+}
+
+// SetPWM sets the duty cycle of a pin, from 0 to 1, and starts a new period.
+func (b *Board) SetPWM(ctx context.Context, req *boardpb.SetPWMRequest) (*boardpb.SetPWMResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	duty := req.GetDutyCyclePct()
+	if duty < 0 || duty > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "simboard: duty cycle %v is not between 0 and 1", duty)
+	}
+	p := b.pin(req.GetPin())
+	old := p.duty
+	p.duty = duty
+	if err := b.startPWM(p); err != nil {
+		p.duty = old
+		return nil, err
+	}
+	return &boardpb.SetPWMResponse{}, nil
+}
+
+// PWMFrequency returns the PWM frequency of a pin, which is the board's default unless it was set.
+func (b *Board) PWMFrequency(ctx context.Context, req *boardpb.PWMFrequencyRequest) (*boardpb.PWMFrequencyResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	frequency := b.pin(req.GetPin()).frequency
+	if frequency == 0 {
+		frequency = b.defaultFrequency
+	}
+	return &boardpb.PWMFrequencyResponse{FrequencyHz: frequency}, nil
+}
+
+// SetPWMFrequency sets the PWM frequency of a pin, or restores the board's default with 0. A pin that is
+// outputting PWM starts a new period.
+func (b *Board) SetPWMFrequency(ctx context.Context, req *boardpb.SetPWMFrequencyRequest) (*boardpb.SetPWMFrequencyResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	p := b.pin(req.GetPin())
+	old := p.frequency
+	p.frequency = req.GetFrequencyHz()
+	if p.pwm != nil {
+		if err := b.startPWM(p); err != nil {
+			p.frequency = old
+			return nil, err
+		}
+	}
+	return &boardpb.SetPWMFrequencyResponse{}, nil
+}
+
+// ReadAnalogReader returns the current value of an analog reader, with its range and step size.
+func (b *Board) ReadAnalogReader(ctx context.Context, req *boardpb.ReadAnalogReaderRequest) (*boardpb.ReadAnalogReaderResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetBoardName()); err != nil {
+		return nil, err
+	}
+	a, ok := b.analogs[req.GetAnalogReaderName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "simboard: no analog reader named %q", req.GetAnalogReaderName())
+	}
+	return &boardpb.ReadAnalogReaderResponse{
+		Value:    a.read(b.now),
+		MinRange: a.cfg.MinRange,
+		MaxRange: a.cfg.MaxRange,
+		StepSize: a.cfg.StepSize,
+	}, nil
+}
+
+// WriteAnalog writes a value to a pin, which the analog readers wired to it then read.
+func (b *Board) WriteAnalog(ctx context.Context, req *boardpb.WriteAnalogRequest) (*boardpb.WriteAnalogResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetName()); err != nil {
+		return nil, err
+	}
+	value := req.GetValue()
+	for _, w := range b.pin(req.GetPin()).wires {
+		if a, ok := b.analogs[w]; ok {
+			a.value = &value
+		}
+	}
+	return &boardpb.WriteAnalogResponse{}, nil
+}
+
+// GetDigitalInterruptValue returns the number of rising edges a digital interrupt has seen.
+func (b *Board) GetDigitalInterruptValue(
+	ctx context.Context, req *boardpb.GetDigitalInterruptValueRequest,
+) (*boardpb.GetDigitalInterruptValueResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(req.GetBoardName()); err != nil {
+		return nil, err
+	}
+	di, ok := b.interrupts[req.GetDigitalInterruptName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "simboard: no digital interrupt named %q", req.GetDigitalInterruptName())
+	}
+	return &boardpb.GetDigitalInterruptValueResponse{Value: di.count}, nil
+}
+
+// StreamTicks streams the edges of the given digital interrupts, or of all of them if none are given,
+// until the client goes away.
+func (b *Board) StreamTicks(req *boardpb.StreamTicksRequest, stream boardpb.BoardService_StreamTicksServer) error {
+	s := &subscriber{pins: make(map[string]bool), notify: make(chan struct{}, 1)}
+	b.mu.Lock()
+	if err := b.check(req.GetName()); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	names := req.GetPinNames()
+	if len(names) == 0 {
+		names = sortedKeys(b.interrupts)
+	}
+	for _, name := range names {
+		if _, ok := b.interrupts[name]; !ok {
+			b.mu.Unlock()
+			return status.Errorf(codes.NotFound, "simboard: no digital interrupt named %q", name)
+		}
+		s.pins[name] = true
+	}
+	b.subscribers[s] = true
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.subscribers, s)
+		b.mu.Unlock()
+	}()
+
+	ctx := stream.Context()
+	for {
+		for _, tick := range s.take() {
+			if err := stream.Send(tick); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.notify:
+		}
+	}
+}
+
+// SetPowerMode sets the power mode. Any mode other than normal lasts for the given duration of simulated
+// time, if any, and makes every other call fail with Unavailable meanwhile.
+func (b *Board) SetPowerMode(ctx context.Context, req *boardpb.SetPowerModeRequest) (*boardpb.SetPowerModeResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if req.GetName() != b.name {
+		return nil, status.Errorf(codes.NotFound, "simboard: no board named %q", req.GetName())
+	}
+	mode := req.GetPowerMode()
+	if _, ok := boardpb.PowerMode_name[int32(mode)]; !ok || mode == boardpb.PowerMode_POWER_MODE_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "simboard: invalid power mode %v", mode)
+	}
+	b.power, b.powerUntil = mode, 0
+	if d := req.GetDuration(); d != nil && mode != boardpb.PowerMode_POWER_MODE_NORMAL {
+		if err := d.CheckValid(); err != nil || d.AsDuration() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "simboard: invalid duration %v", d.AsDuration())
+		}
+		b.powerUntil = b.now + uint64(d.AsDuration())
+	}
+	return &boardpb.SetPowerModeResponse{}, nil
+}
+
+// simStatus is the result of GetStatus.
+type simStatus struct {
+	Time              time.Duration    `structpb:"time"`
+	PowerMode         string           `structpb:"power_mode"`
+	Analogs           map[string]int32 `structpb:"analogs"`
+	DigitalInterrupts map[string]int64 `structpb:"digital_interrupts"`
+}
+
+// GetStatus returns the simulated time, the power mode, and the values of the analog readers and digital
+// interrupts.
+func (b *Board) GetStatus(ctx context.Context, req *commonpb.GetStatusRequest) (*commonpb.GetStatusResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if req.GetName() != b.name {
+		return nil, status.Errorf(codes.NotFound, "simboard: no board named %q", req.GetName())
+	}
+	st := simStatus{
+		Time:              time.Duration(b.now),
+		PowerMode:         b.power.String(),
+		Analogs:           make(map[string]int32, len(b.analogs)),
+		DigitalInterrupts: make(map[string]int64, len(b.interrupts)),
+	}
+	for name, a := range b.analogs {
+		st.Analogs[name] = a.read(b.now)
+	}
+	for name, di := range b.interrupts {
+		st.DigitalInterrupts[name] = di.count
+	}
+	result, err := structcodec.Marshal(st)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "simboard: %v", err)
+	}
+	return &commonpb.GetStatusResponse{Result: result}, nil
+}
+
+// simCommand is the command of DoCommand. Each field that is set is applied, in order.
+type simCommand struct {
+	SetAnalog    *setAnalogCommand    `structpb:"set_analog"`
+	SetInterrupt *setInterruptCommand `structpb:"set_interrupt"`
+	Advance      time.Duration        `structpb:"advance"`
+}
+
+type setAnalogCommand struct {
+	Name  string `structpb:"name"`
+	Value int32  `structpb:"value"`
+}
+
+type setInterruptCommand struct {
+	Name string `structpb:"name"`
+	High bool   `structpb:"high"`
+}
+
+// DoCommand controls the simulation from a client: {"set_analog": {"name": ..., "value": ...}} sets an
+// analog reader, {"set_interrupt": {"name": ..., "high": ...}} drives a digital interrupt, and
+// {"advance": "100ms"} moves simulated time forward. The result holds the simulated time in "time".
+func (b *Board) DoCommand(ctx context.Context, req *commonpb.DoCommandRequest) (*commonpb.DoCommandResponse, error) {
+	if req.GetName() != b.name {
+		return nil, status.Errorf(codes.NotFound, "simboard: no board named %q", req.GetName())
+	}
+	var cmd simCommand
+	if err := structcodec.Unmarshal(req.GetCommand(), &cmd); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "simboard: %v", err)
+	}
+	for key := range req.GetCommand().GetFields() {
+		switch key {
+		case "set_analog", "set_interrupt", "advance":
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "simboard: unknown command %q", key)
+		}
+	}
+	if c := cmd.SetAnalog; c != nil {
+		if err := b.SetAnalog(c.Name, c.Value); err != nil {
+			return nil, err
+		}
+	}
+	if c := cmd.SetInterrupt; c != nil {
+		if err := b.SetInterrupt(c.Name, c.High); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Advance < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "simboard: cannot advance by %v", cmd.Advance)
+	}
+	b.Advance(cmd.Advance)
+	result, err := structcodec.Marshal(struct {
+		Time time.Duration `structpb:"time"`
+	}{b.Now()})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "simboard: %v", err)
+	}
+	return &commonpb.DoCommandResponse{Result: result}, nil
+}
+
+// GetGeometries returns no geometries.
+func (b *Board) GetGeometries(ctx context.Context, req *commonpb.GetGeometriesRequest) (*commonpb.GetGeometriesResponse, error) {
+	if req.GetName() != b.name {
+		return nil, status.Errorf(codes.NotFound, "simboard: no board named %q", req.GetName())
+	}
+	return &commonpb.GetGeometriesResponse{}, nil
+}
@@ -0,0 +1,104 @@
+package simboard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// step is a value a signal takes at a time relative to the start of its wave: a level, or for analog
+// readers a value.
+type step struct {
+	at    uint64
+	high  bool
+	value int32
+}
+
+// wave is a signal made of steps, repeated every period if it is not 0. Times are nanoseconds of the
+// simulation's clock.
+type wave struct {
+	start  uint64
+	period uint64
+	steps  []step
+}
+
+func checkSteps(steps []step, period uint64) error {
+	for i, s := range steps {
+		if i > 0 && s.at < steps[i-1].at {
+			return errors.New("steps are not in increasing order of time")
+		}
+		if period > 0 && s.at >= period {
+			return fmt.Errorf("step at %v is not within the period of %v", time.Duration(s.at), time.Duration(period))
+		}
+	}
+	return nil
+}
+
+// squareWave returns a square wave rising at start. A duty cycle of 0 or 1 is a constant level.
+func squareWave(start uint64, frequency, duty float64) (*wave, error) {
+	switch {
+	case duty < 0 || duty > 1 || math.IsNaN(duty):
+		return nil, fmt.Errorf("duty cycle %v is not between 0 and 1", duty)
+	case frequency <= 0 || math.IsInf(frequency, 0) || math.IsNaN(frequency):
+		return nil, fmt.Errorf("invalid frequency %v", frequency)
+	}
+	if duty == 0 || duty == 1 {
+		return &wave{start: start, steps: []step{{high: duty == 1}}}, nil
+	}
+	period := uint64(float64(time.Second) / frequency)
+	high := uint64(float64(period) * duty)
+	if period < 2 || high == 0 || high >= period {
+		return nil, fmt.Errorf("frequency %v Hz is too high to simulate", frequency)
+	}
+	return &wave{start: start, period: period, steps: []step{{high: true}, {at: high}}}, nil
+}
+
+// at returns the step in effect at t, if any.
+func (w *wave) at(t uint64) (step, bool) {
+	if len(w.steps) == 0 || t < w.start {
+		return step{}, false
+	}
+	rel := t - w.start
+	if w.period > 0 {
+		if rel >= w.period && w.steps[0].at > rel%w.period {
+			// Before the first step of a cycle, the last step of the previous one is in effect.
+			return w.steps[len(w.steps)-1], true
+		}
+		rel %= w.period
+	}
+	found, ok := step{}, false
+	for _, s := range w.steps {
+		if s.at > rel {
+			break
+		}
+		found, ok = s, true
+	}
+	return found, ok
+}
+
+// level returns the level of the wave at t.
+func (w *wave) level(t uint64) bool {
+	s, _ := w.at(t)
+	return s.high
+}
+
+// next returns the first step after t, and its time.
+func (w *wave) next(t uint64) (step, uint64, bool) {
+	base := w.start
+	if w.period > 0 && t >= w.start {
+		base += (t - w.start) / w.period * w.period
+	}
+	for cycle := 0; cycle < 2; cycle++ {
+		for _, s := range w.steps {
+			if at := base + s.at; at > t {
+				return s, at, true
+			}
+		}
+		if w.period == 0 {
+			break
+		}
+		base += w.period
+	}
+	return step{}, 0, false
+}
@@ -0,0 +1,112 @@
+package simboard
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSquareWave(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		frequency   float64
+		duty        float64
+		period      uint64
+		highUntil   uint64
+		constant    bool
+		constLevel  bool
+		errExpected bool
+	}{
+		{name: "square", frequency: 100, duty: 0.25, period: 10e6, highUntil: 2.5e6},
+		{name: "always high", frequency: 100, duty: 1, constant: true, constLevel: true},
+		{name: "always low", frequency: 100, duty: 0, constant: true},
+		{name: "negative duty", frequency: 100, duty: -0.1, errExpected: true},
+		{name: "duty over 1", frequency: 100, duty: 1.1, errExpected: true},
+		{name: "NaN duty", frequency: 100, duty: math.NaN(), errExpected: true},
+		{name: "zero frequency", duty: 0.5, errExpected: true},
+		{name: "infinite frequency", frequency: math.Inf(1), duty: 0.5, errExpected: true},
+		{name: "too fast", frequency: 1e9, duty: 0.5, errExpected: true},
+		{name: "duty too fine", frequency: 1e8, duty: 0.01, errExpected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := squareWave(7, tc.frequency, tc.duty)
+			if tc.errExpected {
+				if err == nil {
+					t.Errorf("squareWave succeeded: %+v", w)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.constant {
+				if w.period != 0 || w.level(7) != tc.constLevel || w.level(1e12) != tc.constLevel {
+					t.Errorf("wave = %+v", w)
+				}
+				if _, _, ok := w.next(7); ok {
+					t.Error("a constant wave has a next step")
+				}
+				return
+			}
+			if w.period != tc.period || len(w.steps) != 2 || w.steps[1].at != tc.highUntil {
+				t.Errorf("wave = %+v", w)
+			}
+		})
+	}
+}
+
+func TestWave(t *testing.T) {
+	square := &wave{start: 100, period: 10, steps: []step{{high: true}, {at: 3}}}
+	// A wave whose cycles start low: the last step of the previous cycle holds until the first step.
+	delayed := &wave{period: 10, steps: []step{{at: 2, high: true}, {at: 6}}}
+	once := &wave{start: 100, steps: []step{{at: 5, high: true}, {at: 8}}}
+	for _, tc := range []struct {
+		name  string
+		w     *wave
+		t     uint64
+		found bool
+		high  bool
+		next  uint64
+		more  bool
+	}{
+		{"before start", square, 50, false, false, 100, true},
+		{"first step", square, 100, true, true, 103, true},
+		{"second step", square, 103, true, false, 110, true},
+		{"later cycle", square, 1001, true, true, 1003, true},
+		{"end of a cycle", square, 109, true, false, 110, true},
+		{"before first step", delayed, 1, false, false, 2, true},
+		{"before first step of a cycle", delayed, 11, true, false, 12, true},
+		{"within a cycle", delayed, 13, true, true, 16, true},
+		{"end of a cycle with a late start", delayed, 19, true, false, 22, true},
+		{"not repeated", once, 106, true, true, 108, true},
+		{"after the last step", once, 108, true, false, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, found := tc.w.at(tc.t)
+			if found != tc.found || s.high != tc.high {
+				t.Errorf("at(%d) = %+v, %v; want high %v, %v", tc.t, s, found, tc.high, tc.found)
+			}
+			_, next, more := tc.w.next(tc.t)
+			if next != tc.next || more != tc.more {
+				t.Errorf("next(%d) = %d, %v; want %d, %v", tc.t, next, more, tc.next, tc.more)
+			}
+		})
+	}
+}
+
+func TestCheckSteps(t *testing.T) {
+	for _, tc := range []struct {
+		steps  []step
+		period uint64
+		ok     bool
+	}{
+		{[]step{{at: 0}, {at: 5}, {at: 5}}, 0, true},
+		{[]step{{at: 0}, {at: 5}}, 6, true},
+		{[]step{{at: 5}, {at: 0}}, 0, false},
+		{[]step{{at: 0}, {at: 6}}, 6, false},
+		{nil, 6, true},
+	} {
+		if err := checkSteps(tc.steps, tc.period); (err == nil) != tc.ok {
+			t.Errorf("checkSteps(%v, %d) = %v", tc.steps, tc.period, err)
+		}
+	}
+}
@@ -48,6 +48,18 @@ type BoardServiceClient interface {
 	StreamTicks(ctx context.Context, in *StreamTicksRequest, opts ...grpc.CallOption) (BoardService_StreamTicksClient, error)
 	// `SetPowerMode` sets the power consumption mode of the board to the requested setting for the given duration.
 	SetPowerMode(ctx context.Context, in *SetPowerModeRequest, opts ...grpc.CallOption) (*SetPowerModeResponse, error)
+	// ReadI2C reads bytes from a device on an I2C bus, from the given register if any.
+	ReadI2C(ctx context.Context, in *ReadI2CRequest, opts ...grpc.CallOption) (*ReadI2CResponse, error)
+	// WriteI2C writes bytes to a device on an I2C bus, to the given register if any.
+	WriteI2C(ctx context.Context, in *WriteI2CRequest, opts ...grpc.CallOption) (*WriteI2CResponse, error)
+	// TransactI2C runs a sequence of reads and writes with a device on an I2C bus as a single transaction,
+	// with repeated starts between them.
+	TransactI2C(ctx context.Context, in *TransactI2CRequest, opts ...grpc.CallOption) (*TransactI2CResponse, error)
+	// TransferSPI sends bytes to a device on an SPI bus and returns the bytes received meanwhile.
+	TransferSPI(ctx context.Context, in *TransferSPIRequest, opts ...grpc.CallOption) (*TransferSPIResponse, error)
+	// StreamUART opens a serial port, configured by the first request, and exchanges bytes with it in both
+	// directions until the client closes its side of the stream.
+	StreamUART(ctx context.Context, opts ...grpc.CallOption) (BoardService_StreamUARTClient, error)
 	// GetGeometries returns the geometries of the component in their current configuration.
 	GetGeometries(ctx context.Context, in *v1.GetGeometriesRequest, opts ...grpc.CallOption) (*v1.GetGeometriesResponse, error)
 }
@@ -200,6 +212,73 @@ func (c *boardServiceClient) SetPowerMode(ctx context.Context, in *SetPowerModeR
 	return out, nil
 }
 
+func (c *boardServiceClient) ReadI2C(ctx context.Context, in *ReadI2CRequest, opts ...grpc.CallOption) (*ReadI2CResponse, error) {
+	out := new(ReadI2CResponse)
+	err := c.cc.Invoke(ctx, "/viam.component.board.v1.BoardService/ReadI2C", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) WriteI2C(ctx context.Context, in *WriteI2CRequest, opts ...grpc.CallOption) (*WriteI2CResponse, error) {
+	out := new(WriteI2CResponse)
+	err := c.cc.Invoke(ctx, "/viam.component.board.v1.BoardService/WriteI2C", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) TransactI2C(ctx context.Context, in *TransactI2CRequest, opts ...grpc.CallOption) (*TransactI2CResponse, error) {
+	out := new(TransactI2CResponse)
+	err := c.cc.Invoke(ctx, "/viam.component.board.v1.BoardService/TransactI2C", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) TransferSPI(ctx context.Context, in *TransferSPIRequest, opts ...grpc.CallOption) (*TransferSPIResponse, error) {
+	out := new(TransferSPIResponse)
+	err := c.cc.Invoke(ctx, "/viam.component.board.v1.BoardService/TransferSPI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) StreamUART(ctx context.Context, opts ...grpc.CallOption) (BoardService_StreamUARTClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BoardService_ServiceDesc.Streams[1], "/viam.component.board.v1.BoardService/StreamUART", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &boardServiceStreamUARTClient{stream}
+	return x, nil
+}
+
+type BoardService_StreamUARTClient interface {
+	Send(*StreamUARTRequest) error
+	Recv() (*StreamUARTResponse, error)
+	grpc.ClientStream
+}
+
+type boardServiceStreamUARTClient struct {
+	grpc.ClientStream
+}
+
+func (x *boardServiceStreamUARTClient) Send(m *StreamUARTRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *boardServiceStreamUARTClient) Recv() (*StreamUARTResponse, error) {
+	m := new(StreamUARTResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *boardServiceClient) GetGeometries(ctx context.Context, in *v1.GetGeometriesRequest, opts ...grpc.CallOption) (*v1.GetGeometriesResponse, error) {
 	out := new(v1.GetGeometriesResponse)
 	err := c.cc.Invoke(ctx, "/viam.component.board.v1.BoardService/GetGeometries", in, out, opts...)
@@ -238,6 +317,18 @@ type BoardServiceServer interface {
 	StreamTicks(*StreamTicksRequest, BoardService_StreamTicksServer) error
 	// `SetPowerMode` sets the power consumption mode of the board to the requested setting for the given duration.
 	SetPowerMode(context.Context, *SetPowerModeRequest) (*SetPowerModeResponse, error)
+	// ReadI2C reads bytes from a device on an I2C bus, from the given register if any.
+	ReadI2C(context.Context, *ReadI2CRequest) (*ReadI2CResponse, error)
+	// WriteI2C writes bytes to a device on an I2C bus, to the given register if any.
+	WriteI2C(context.Context, *WriteI2CRequest) (*WriteI2CResponse, error)
+	// TransactI2C runs a sequence of reads and writes with a device on an I2C bus as a single transaction,
+	// with repeated starts between them.
+	TransactI2C(context.Context, *TransactI2CRequest) (*TransactI2CResponse, error)
+	// TransferSPI sends bytes to a device on an SPI bus and returns the bytes received meanwhile.
+	TransferSPI(context.Context, *TransferSPIRequest) (*TransferSPIResponse, error)
+	// StreamUART opens a serial port, configured by the first request, and exchanges bytes with it in both
+	// directions until the client closes its side of the stream.
+	StreamUART(BoardService_StreamUARTServer) error
 	// GetGeometries returns the geometries of the component in their current configuration.
 	GetGeometries(context.Context, *v1.GetGeometriesRequest) (*v1.GetGeometriesResponse, error)
 	mustEmbedUnimplementedBoardServiceServer()
@@ -286,6 +377,21 @@ func (UnimplementedBoardServiceServer) StreamTicks(*StreamTicksRequest, BoardSer
 func (UnimplementedBoardServiceServer) SetPowerMode(context.Context, *SetPowerModeRequest) (*SetPowerModeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetPowerMode not implemented")
 }
+func (UnimplementedBoardServiceServer) ReadI2C(context.Context, *ReadI2CRequest) (*ReadI2CResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadI2C not implemented")
+}
+func (UnimplementedBoardServiceServer) WriteI2C(context.Context, *WriteI2CRequest) (*WriteI2CResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteI2C not implemented")
+}
+func (UnimplementedBoardServiceServer) TransactI2C(context.Context, *TransactI2CRequest) (*TransactI2CResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactI2C not implemented")
+}
+func (UnimplementedBoardServiceServer) TransferSPI(context.Context, *TransferSPIRequest) (*TransferSPIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferSPI not implemented")
+}
+func (UnimplementedBoardServiceServer) StreamUART(BoardService_StreamUARTServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUART not implemented")
+}
 func (UnimplementedBoardServiceServer) GetGeometries(context.Context, *v1.GetGeometriesRequest) (*v1.GetGeometriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetGeometries not implemented")
 }
@@ -539,6 +645,104 @@ func _BoardService_SetPowerMode_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BoardService_ReadI2C_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadI2CRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).ReadI2C(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/viam.component.board.v1.BoardService/ReadI2C",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).ReadI2C(ctx, req.(*ReadI2CRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_WriteI2C_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteI2CRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).WriteI2C(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/viam.component.board.v1.BoardService/WriteI2C",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).WriteI2C(ctx, req.(*WriteI2CRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_TransactI2C_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactI2CRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).TransactI2C(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/viam.component.board.v1.BoardService/TransactI2C",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).TransactI2C(ctx, req.(*TransactI2CRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_TransferSPI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferSPIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).TransferSPI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/viam.component.board.v1.BoardService/TransferSPI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).TransferSPI(ctx, req.(*TransferSPIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_StreamUART_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BoardServiceServer).StreamUART(&boardServiceStreamUARTServer{stream})
+}
+
+type BoardService_StreamUARTServer interface {
+	Send(*StreamUARTResponse) error
+	Recv() (*StreamUARTRequest, error)
+	grpc.ServerStream
+}
+
+type boardServiceStreamUARTServer struct {
+	grpc.ServerStream
+}
+
+func (x *boardServiceStreamUARTServer) Send(m *StreamUARTResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *boardServiceStreamUARTServer) Recv() (*StreamUARTRequest, error) {
+	m := new(StreamUARTRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _BoardService_GetGeometries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(v1.GetGeometriesRequest)
 	if err := dec(in); err != nil {
@@ -612,6 +816,22 @@ var BoardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetPowerMode",
 			Handler:    _BoardService_SetPowerMode_Handler,
 		},
+		{
+			MethodName: "ReadI2C",
+			Handler:    _BoardService_ReadI2C_Handler,
+		},
+		{
+			MethodName: "WriteI2C",
+			Handler:    _BoardService_WriteI2C_Handler,
+		},
+		{
+			MethodName: "TransactI2C",
+			Handler:    _BoardService_TransactI2C_Handler,
+		},
+		{
+			MethodName: "TransferSPI",
+			Handler:    _BoardService_TransferSPI_Handler,
+		},
 		{
 			MethodName: "GetGeometries",
 			Handler:    _BoardService_GetGeometries_Handler,
@@ -623,6 +843,12 @@ var BoardService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _BoardService_StreamTicks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamUART",
+			Handler:       _BoardService_StreamUART_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "component/board/v1/board.proto",
 }
@@ -24,6 +24,8 @@ var common_v1_common_pb = require('../../../common/v1/common_pb.js')
 
 var google_api_annotations_pb = require('../../../google/api/annotations_pb.js')
 
+var google_protobuf_duration_pb = require('google-protobuf/google/protobuf/duration_pb.js')
+
 var google_protobuf_struct_pb = require('google-protobuf/google/protobuf/struct_pb.js')
 const proto = {};
 proto.viam = {};
@@ -693,5 +695,122 @@ proto.viam.component.base.v1.BaseServicePromiseClient.prototype.getProperties =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.viam.component.base.v1.GetOdometryRequest,
+ *   !proto.viam.component.base.v1.GetOdometryResponse>}
+ */
+const methodDescriptor_BaseService_GetOdometry = new grpc.web.MethodDescriptor(
+  '/viam.component.base.v1.BaseService/GetOdometry',
+  grpc.web.MethodType.UNARY,
+  proto.viam.component.base.v1.GetOdometryRequest,
+  proto.viam.component.base.v1.GetOdometryResponse,
+  /**
+   * @param {!proto.viam.component.base.v1.GetOdometryRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.viam.component.base.v1.GetOdometryResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.viam.component.base.v1.GetOdometryRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.viam.component.base.v1.GetOdometryResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.base.v1.GetOdometryResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.base.v1.BaseServiceClient.prototype.getOdometry =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/viam.component.base.v1.BaseService/GetOdometry',
+      request,
+      metadata || {},
+      methodDescriptor_BaseService_GetOdometry,
+      callback);
+};
+
+
+/**
+ * @param {!proto.viam.component.base.v1.GetOdometryRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.viam.component.base.v1.GetOdometryResponse>}
+ *     Promise that resolves to the response
+ */
+proto.viam.component.base.v1.BaseServicePromiseClient.prototype.getOdometry =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/viam.component.base.v1.BaseService/GetOdometry',
+      request,
+      metadata || {},
+      methodDescriptor_BaseService_GetOdometry);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.viam.component.base.v1.StreamOdometryRequest,
+ *   !proto.viam.component.base.v1.StreamOdometryResponse>}
+ */
+const methodDescriptor_BaseService_StreamOdometry = new grpc.web.MethodDescriptor(
+  '/viam.component.base.v1.BaseService/StreamOdometry',
+  grpc.web.MethodType.SERVER_STREAMING,
+  proto.viam.component.base.v1.StreamOdometryRequest,
+  proto.viam.component.base.v1.StreamOdometryResponse,
+  /**
+   * @param {!proto.viam.component.base.v1.StreamOdometryRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.viam.component.base.v1.StreamOdometryResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.viam.component.base.v1.StreamOdometryRequest} request The request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.base.v1.StreamOdometryResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.base.v1.BaseServiceClient.prototype.streamOdometry =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/viam.component.base.v1.BaseService/StreamOdometry',
+      request,
+      metadata || {},
+      methodDescriptor_BaseService_StreamOdometry);
+};
+
+
+/**
+ * @param {!proto.viam.component.base.v1.StreamOdometryRequest} request The request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.base.v1.StreamOdometryResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.base.v1.BaseServicePromiseClient.prototype.streamOdometry =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/viam.component.base.v1.BaseService/StreamOdometry',
+      request,
+      metadata || {},
+      methodDescriptor_BaseService_StreamOdometry);
+};
+
+
 module.exports = proto.viam.component.base.v1;
 
@@ -4,6 +4,7 @@
 import * as jspb from "google-protobuf";
 import * as common_v1_common_pb from "../../../common/v1/common_pb";
 import * as google_api_annotations_pb from "../../../google/api/annotations_pb";
+import * as google_protobuf_duration_pb from "google-protobuf/google/protobuf/duration_pb";
 import * as google_protobuf_struct_pb from "google-protobuf/google/protobuf/struct_pb";
 
 export class MoveStraightRequest extends jspb.Message {
@@ -350,3 +351,141 @@ export namespace GetPropertiesResponse {
   }
 }
 
+export class GetOdometryRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetOdometryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetOdometryRequest): GetOdometryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetOdometryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetOdometryRequest;
+  static deserializeBinaryFromReader(message: GetOdometryRequest, reader: jspb.BinaryReader): GetOdometryRequest;
+}
+
+export namespace GetOdometryRequest {
+  export type AsObject = {
+    name: string,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+}
+
+export class GetOdometryResponse extends jspb.Message {
+  hasPose(): boolean;
+  clearPose(): void;
+  getPose(): common_v1_common_pb.Pose | undefined;
+  setPose(value?: common_v1_common_pb.Pose): void;
+
+  hasLinearVelocity(): boolean;
+  clearLinearVelocity(): void;
+  getLinearVelocity(): common_v1_common_pb.Vector3 | undefined;
+  setLinearVelocity(value?: common_v1_common_pb.Vector3): void;
+
+  hasAngularVelocity(): boolean;
+  clearAngularVelocity(): void;
+  getAngularVelocity(): common_v1_common_pb.Vector3 | undefined;
+  setAngularVelocity(value?: common_v1_common_pb.Vector3): void;
+
+  hasResponseMetadata(): boolean;
+  clearResponseMetadata(): void;
+  getResponseMetadata(): common_v1_common_pb.ResponseMetadata | undefined;
+  setResponseMetadata(value?: common_v1_common_pb.ResponseMetadata): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetOdometryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetOdometryResponse): GetOdometryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetOdometryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetOdometryResponse;
+  static deserializeBinaryFromReader(message: GetOdometryResponse, reader: jspb.BinaryReader): GetOdometryResponse;
+}
+
+export namespace GetOdometryResponse {
+  export type AsObject = {
+    pose?: common_v1_common_pb.Pose.AsObject,
+    linearVelocity?: common_v1_common_pb.Vector3.AsObject,
+    angularVelocity?: common_v1_common_pb.Vector3.AsObject,
+    responseMetadata?: common_v1_common_pb.ResponseMetadata.AsObject,
+  }
+}
+
+export class StreamOdometryRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  hasEvery(): boolean;
+  clearEvery(): void;
+  getEvery(): google_protobuf_duration_pb.Duration | undefined;
+  setEvery(value?: google_protobuf_duration_pb.Duration): void;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): StreamOdometryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: StreamOdometryRequest): StreamOdometryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: StreamOdometryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): StreamOdometryRequest;
+  static deserializeBinaryFromReader(message: StreamOdometryRequest, reader: jspb.BinaryReader): StreamOdometryRequest;
+}
+
+export namespace StreamOdometryRequest {
+  export type AsObject = {
+    name: string,
+    every?: google_protobuf_duration_pb.Duration.AsObject,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+}
+
+export class StreamOdometryResponse extends jspb.Message {
+  hasPose(): boolean;
+  clearPose(): void;
+  getPose(): common_v1_common_pb.Pose | undefined;
+  setPose(value?: common_v1_common_pb.Pose): void;
+
+  hasLinearVelocity(): boolean;
+  clearLinearVelocity(): void;
+  getLinearVelocity(): common_v1_common_pb.Vector3 | undefined;
+  setLinearVelocity(value?: common_v1_common_pb.Vector3): void;
+
+  hasAngularVelocity(): boolean;
+  clearAngularVelocity(): void;
+  getAngularVelocity(): common_v1_common_pb.Vector3 | undefined;
+  setAngularVelocity(value?: common_v1_common_pb.Vector3): void;
+
+  hasResponseMetadata(): boolean;
+  clearResponseMetadata(): void;
+  getResponseMetadata(): common_v1_common_pb.ResponseMetadata | undefined;
+  setResponseMetadata(value?: common_v1_common_pb.ResponseMetadata): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): StreamOdometryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: StreamOdometryResponse): StreamOdometryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: StreamOdometryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): StreamOdometryResponse;
+  static deserializeBinaryFromReader(message: StreamOdometryResponse, reader: jspb.BinaryReader): StreamOdometryResponse;
+}
+
+export namespace StreamOdometryResponse {
+  export type AsObject = {
+    pose?: common_v1_common_pb.Pose.AsObject,
+    linearVelocity?: common_v1_common_pb.Vector3.AsObject,
+    angularVelocity?: common_v1_common_pb.Vector3.AsObject,
+    responseMetadata?: common_v1_common_pb.ResponseMetadata.AsObject,
+  }
+}
+
@@ -19,8 +19,12 @@ var common_v1_common_pb = require('../../../common/v1/common_pb.js');
 goog.object.extend(proto, common_v1_common_pb);
 var google_api_annotations_pb = require('../../../google/api/annotations_pb.js');
 goog.object.extend(proto, google_api_annotations_pb);
+var google_protobuf_duration_pb = require('google-protobuf/google/protobuf/duration_pb.js');
+goog.object.extend(proto, google_protobuf_duration_pb);
 var google_protobuf_struct_pb = require('google-protobuf/google/protobuf/struct_pb.js');
 goog.object.extend(proto, google_protobuf_struct_pb);
+goog.exportSymbol('proto.viam.component.base.v1.GetOdometryRequest', null, global);
+goog.exportSymbol('proto.viam.component.base.v1.GetOdometryResponse', null, global);
 goog.exportSymbol('proto.viam.component.base.v1.GetPropertiesRequest', null, global);
 goog.exportSymbol('proto.viam.component.base.v1.GetPropertiesResponse', null, global);
 goog.exportSymbol('proto.viam.component.base.v1.IsMovingRequest', null, global);
@@ -35,6 +39,8 @@ goog.exportSymbol('proto.viam.component.base.v1.SpinRequest', null, global);
 goog.exportSymbol('proto.viam.component.base.v1.SpinResponse', null, global);
 goog.exportSymbol('proto.viam.component.base.v1.StopRequest', null, global);
 goog.exportSymbol('proto.viam.component.base.v1.StopResponse', null, global);
+goog.exportSymbol('proto.viam.component.base.v1.StreamOdometryRequest', null, global);
+goog.exportSymbol('proto.viam.component.base.v1.StreamOdometryResponse', null, global);
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -329,6 +335,90 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.viam.component.base.v1.GetPropertiesResponse.displayName = 'proto.viam.component.base.v1.GetPropertiesResponse';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.viam.component.base.v1.GetOdometryRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.viam.component.base.v1.GetOdometryRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.viam.component.base.v1.GetOdometryRequest.displayName = 'proto.viam.component.base.v1.GetOdometryRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.viam.component.base.v1.GetOdometryResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, 500, null, null);
+};
+goog.inherits(proto.viam.component.base.v1.GetOdometryResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.viam.component.base.v1.GetOdometryResponse.displayName = 'proto.viam.component.base.v1.GetOdometryResponse';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.viam.component.base.v1.StreamOdometryRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.viam.component.base.v1.StreamOdometryRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.viam.component.base.v1.StreamOdometryRequest.displayName = 'proto.viam.component.base.v1.StreamOdometryRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.viam.component.base.v1.StreamOdometryResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, 500, null, null);
+};
+goog.inherits(proto.viam.component.base.v1.StreamOdometryResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.viam.component.base.v1.StreamOdometryResponse.displayName = 'proto.viam.component.base.v1.StreamOdometryResponse';
+}
 
 
 
@@ -2694,4 +2784,1025 @@ proto.viam.component.base.v1.GetPropertiesResponse.prototype.setWheelCircumferen
 };
 
 
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.viam.component.base.v1.GetOdometryRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.viam.component.base.v1.GetOdometryRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.GetOdometryRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    extra: (f = msg.getExtra()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.viam.component.base.v1.GetOdometryRequest}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.viam.component.base.v1.GetOdometryRequest;
+  return proto.viam.component.base.v1.GetOdometryRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.viam.component.base.v1.GetOdometryRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.viam.component.base.v1.GetOdometryRequest}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 99:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setExtra(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.viam.component.base.v1.GetOdometryRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.viam.component.base.v1.GetOdometryRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.GetOdometryRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getExtra();
+  if (f != null) {
+    writer.writeMessage(
+      99,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.viam.component.base.v1.GetOdometryRequest} returns this
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.setName = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional google.protobuf.Struct extra = 99;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.getExtra = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 99));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.viam.component.base.v1.GetOdometryRequest} returns this
+*/
+proto.viam.component.base.v1.GetOdometryRequest.prototype.setExtra = function(value) {
+  return jspb.Message.setWrapperField(this, 99, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.GetOdometryRequest} returns this
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.clearExtra = function() {
+  return this.setExtra(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.GetOdometryRequest.prototype.hasExtra = function() {
+  return jspb.Message.getField(this, 99) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.viam.component.base.v1.GetOdometryResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.viam.component.base.v1.GetOdometryResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.GetOdometryResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    pose: (f = msg.getPose()) && common_v1_common_pb.Pose.toObject(includeInstance, f),
+    linearVelocity: (f = msg.getLinearVelocity()) && common_v1_common_pb.Vector3.toObject(includeInstance, f),
+    angularVelocity: (f = msg.getAngularVelocity()) && common_v1_common_pb.Vector3.toObject(includeInstance, f),
+    responseMetadata: (f = msg.getResponseMetadata()) && common_v1_common_pb.ResponseMetadata.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.viam.component.base.v1.GetOdometryResponse;
+  return proto.viam.component.base.v1.GetOdometryResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.viam.component.base.v1.GetOdometryResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new common_v1_common_pb.Pose;
+      reader.readMessage(value,common_v1_common_pb.Pose.deserializeBinaryFromReader);
+      msg.setPose(value);
+      break;
+    case 2:
+      var value = new common_v1_common_pb.Vector3;
+      reader.readMessage(value,common_v1_common_pb.Vector3.deserializeBinaryFromReader);
+      msg.setLinearVelocity(value);
+      break;
+    case 3:
+      var value = new common_v1_common_pb.Vector3;
+      reader.readMessage(value,common_v1_common_pb.Vector3.deserializeBinaryFromReader);
+      msg.setAngularVelocity(value);
+      break;
+    case 84260:
+      var value = new common_v1_common_pb.ResponseMetadata;
+      reader.readMessage(value,common_v1_common_pb.ResponseMetadata.deserializeBinaryFromReader);
+      msg.setResponseMetadata(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.viam.component.base.v1.GetOdometryResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.viam.component.base.v1.GetOdometryResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.GetOdometryResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPose();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      common_v1_common_pb.Pose.serializeBinaryToWriter
+    );
+  }
+  f = message.getLinearVelocity();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      common_v1_common_pb.Vector3.serializeBinaryToWriter
+    );
+  }
+  f = message.getAngularVelocity();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      common_v1_common_pb.Vector3.serializeBinaryToWriter
+    );
+  }
+  f = message.getResponseMetadata();
+  if (f != null) {
+    writer.writeMessage(
+      84260,
+      f,
+      common_v1_common_pb.ResponseMetadata.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional viam.common.v1.Pose pose = 1;
+ * @return {?proto.viam.common.v1.Pose}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.getPose = function() {
+  return /** @type{?proto.viam.common.v1.Pose} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.Pose, 1));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.Pose|undefined} value
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.GetOdometryResponse.prototype.setPose = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.clearPose = function() {
+  return this.setPose(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.hasPose = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional viam.common.v1.Vector3 linear_velocity = 2;
+ * @return {?proto.viam.common.v1.Vector3}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.getLinearVelocity = function() {
+  return /** @type{?proto.viam.common.v1.Vector3} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.Vector3, 2));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.Vector3|undefined} value
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.GetOdometryResponse.prototype.setLinearVelocity = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.clearLinearVelocity = function() {
+  return this.setLinearVelocity(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.hasLinearVelocity = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional viam.common.v1.Vector3 angular_velocity = 3;
+ * @return {?proto.viam.common.v1.Vector3}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.getAngularVelocity = function() {
+  return /** @type{?proto.viam.common.v1.Vector3} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.Vector3, 3));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.Vector3|undefined} value
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.GetOdometryResponse.prototype.setAngularVelocity = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.clearAngularVelocity = function() {
+  return this.setAngularVelocity(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.hasAngularVelocity = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional viam.common.v1.ResponseMetadata response_metadata = 84260;
+ * @return {?proto.viam.common.v1.ResponseMetadata}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.getResponseMetadata = function() {
+  return /** @type{?proto.viam.common.v1.ResponseMetadata} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.ResponseMetadata, 84260));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.ResponseMetadata|undefined} value
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.GetOdometryResponse.prototype.setResponseMetadata = function(value) {
+  return jspb.Message.setWrapperField(this, 84260, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.GetOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.clearResponseMetadata = function() {
+  return this.setResponseMetadata(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.GetOdometryResponse.prototype.hasResponseMetadata = function() {
+  return jspb.Message.getField(this, 84260) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.viam.component.base.v1.StreamOdometryRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.viam.component.base.v1.StreamOdometryRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    every: (f = msg.getEvery()) && google_protobuf_duration_pb.Duration.toObject(includeInstance, f),
+    extra: (f = msg.getExtra()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.viam.component.base.v1.StreamOdometryRequest;
+  return proto.viam.component.base.v1.StreamOdometryRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.viam.component.base.v1.StreamOdometryRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 2:
+      var value = new google_protobuf_duration_pb.Duration;
+      reader.readMessage(value,google_protobuf_duration_pb.Duration.deserializeBinaryFromReader);
+      msg.setEvery(value);
+      break;
+    case 99:
+      var value = new google_protobuf_struct_pb.Struct;
+      reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
+      msg.setExtra(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.viam.component.base.v1.StreamOdometryRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.viam.component.base.v1.StreamOdometryRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getEvery();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      google_protobuf_duration_pb.Duration.serializeBinaryToWriter
+    );
+  }
+  f = message.getExtra();
+  if (f != null) {
+    writer.writeMessage(
+      99,
+      f,
+      google_protobuf_struct_pb.Struct.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.setName = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional google.protobuf.Duration every = 2;
+ * @return {?proto.google.protobuf.Duration}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.getEvery = function() {
+  return /** @type{?proto.google.protobuf.Duration} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_duration_pb.Duration, 2));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Duration|undefined} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest} returns this
+*/
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.setEvery = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.clearEvery = function() {
+  return this.setEvery(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.hasEvery = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional google.protobuf.Struct extra = 99;
+ * @return {?proto.google.protobuf.Struct}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.getExtra = function() {
+  return /** @type{?proto.google.protobuf.Struct} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_struct_pb.Struct, 99));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Struct|undefined} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest} returns this
+*/
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.setExtra = function(value) {
+  return jspb.Message.setWrapperField(this, 99, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.StreamOdometryRequest} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.clearExtra = function() {
+  return this.setExtra(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.StreamOdometryRequest.prototype.hasExtra = function() {
+  return jspb.Message.getField(this, 99) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.viam.component.base.v1.StreamOdometryResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.viam.component.base.v1.StreamOdometryResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    pose: (f = msg.getPose()) && common_v1_common_pb.Pose.toObject(includeInstance, f),
+    linearVelocity: (f = msg.getLinearVelocity()) && common_v1_common_pb.Vector3.toObject(includeInstance, f),
+    angularVelocity: (f = msg.getAngularVelocity()) && common_v1_common_pb.Vector3.toObject(includeInstance, f),
+    responseMetadata: (f = msg.getResponseMetadata()) && common_v1_common_pb.ResponseMetadata.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.viam.component.base.v1.StreamOdometryResponse;
+  return proto.viam.component.base.v1.StreamOdometryResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.viam.component.base.v1.StreamOdometryResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new common_v1_common_pb.Pose;
+      reader.readMessage(value,common_v1_common_pb.Pose.deserializeBinaryFromReader);
+      msg.setPose(value);
+      break;
+    case 2:
+      var value = new common_v1_common_pb.Vector3;
+      reader.readMessage(value,common_v1_common_pb.Vector3.deserializeBinaryFromReader);
+      msg.setLinearVelocity(value);
+      break;
+    case 3:
+      var value = new common_v1_common_pb.Vector3;
+      reader.readMessage(value,common_v1_common_pb.Vector3.deserializeBinaryFromReader);
+      msg.setAngularVelocity(value);
+      break;
+    case 84260:
+      var value = new common_v1_common_pb.ResponseMetadata;
+      reader.readMessage(value,common_v1_common_pb.ResponseMetadata.deserializeBinaryFromReader);
+      msg.setResponseMetadata(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.viam.component.base.v1.StreamOdometryResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.viam.component.base.v1.StreamOdometryResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPose();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      common_v1_common_pb.Pose.serializeBinaryToWriter
+    );
+  }
+  f = message.getLinearVelocity();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      common_v1_common_pb.Vector3.serializeBinaryToWriter
+    );
+  }
+  f = message.getAngularVelocity();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      common_v1_common_pb.Vector3.serializeBinaryToWriter
+    );
+  }
+  f = message.getResponseMetadata();
+  if (f != null) {
+    writer.writeMessage(
+      84260,
+      f,
+      common_v1_common_pb.ResponseMetadata.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional viam.common.v1.Pose pose = 1;
+ * @return {?proto.viam.common.v1.Pose}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.getPose = function() {
+  return /** @type{?proto.viam.common.v1.Pose} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.Pose, 1));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.Pose|undefined} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.setPose = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.clearPose = function() {
+  return this.setPose(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.hasPose = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional viam.common.v1.Vector3 linear_velocity = 2;
+ * @return {?proto.viam.common.v1.Vector3}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.getLinearVelocity = function() {
+  return /** @type{?proto.viam.common.v1.Vector3} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.Vector3, 2));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.Vector3|undefined} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.setLinearVelocity = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.clearLinearVelocity = function() {
+  return this.setLinearVelocity(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.hasLinearVelocity = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional viam.common.v1.Vector3 angular_velocity = 3;
+ * @return {?proto.viam.common.v1.Vector3}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.getAngularVelocity = function() {
+  return /** @type{?proto.viam.common.v1.Vector3} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.Vector3, 3));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.Vector3|undefined} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.setAngularVelocity = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.clearAngularVelocity = function() {
+  return this.setAngularVelocity(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.hasAngularVelocity = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional viam.common.v1.ResponseMetadata response_metadata = 84260;
+ * @return {?proto.viam.common.v1.ResponseMetadata}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.getResponseMetadata = function() {
+  return /** @type{?proto.viam.common.v1.ResponseMetadata} */ (
+    jspb.Message.getWrapperField(this, common_v1_common_pb.ResponseMetadata, 84260));
+};
+
+
+/**
+ * @param {?proto.viam.common.v1.ResponseMetadata|undefined} value
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+*/
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.setResponseMetadata = function(value) {
+  return jspb.Message.setWrapperField(this, 84260, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.component.base.v1.StreamOdometryResponse} returns this
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.clearResponseMetadata = function() {
+  return this.setResponseMetadata(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.component.base.v1.StreamOdometryResponse.prototype.hasResponseMetadata = function() {
+  return jspb.Message.getField(this, 84260) != null;
+};
+
+
 goog.object.extend(exports, proto.viam.component.base.v1);
@@ -95,6 +95,24 @@ type BaseServiceGetProperties = {
   readonly responseType: typeof component_base_v1_base_pb.GetPropertiesResponse;
 };
 
+type BaseServiceGetOdometry = {
+  readonly methodName: string;
+  readonly service: typeof BaseService;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof component_base_v1_base_pb.GetOdometryRequest;
+  readonly responseType: typeof component_base_v1_base_pb.GetOdometryResponse;
+};
+
+type BaseServiceStreamOdometry = {
+  readonly methodName: string;
+  readonly service: typeof BaseService;
+  readonly requestStream: false;
+  readonly responseStream: true;
+  readonly requestType: typeof component_base_v1_base_pb.StreamOdometryRequest;
+  readonly responseType: typeof component_base_v1_base_pb.StreamOdometryResponse;
+};
+
 export class BaseService {
   static readonly serviceName: string;
   static readonly MoveStraight: BaseServiceMoveStraight;
@@ -107,6 +125,8 @@ export class BaseService {
   static readonly GetStatus: BaseServiceGetStatus;
   static readonly GetGeometries: BaseServiceGetGeometries;
   static readonly GetProperties: BaseServiceGetProperties;
+  static readonly GetOdometry: BaseServiceGetOdometry;
+  static readonly StreamOdometry: BaseServiceStreamOdometry;
 }
 
 export type ServiceError = { message: string, code: number; metadata: grpc.Metadata }
@@ -231,5 +251,15 @@ export class BaseServiceClient {
     requestMessage: component_base_v1_base_pb.GetPropertiesRequest,
     callback: (error: ServiceError|null, responseMessage: component_base_v1_base_pb.GetPropertiesResponse|null) => void
   ): UnaryResponse;
+  getOdometry(
+    requestMessage: component_base_v1_base_pb.GetOdometryRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: component_base_v1_base_pb.GetOdometryResponse|null) => void
+  ): UnaryResponse;
+  getOdometry(
+    requestMessage: component_base_v1_base_pb.GetOdometryRequest,
+    callback: (error: ServiceError|null, responseMessage: component_base_v1_base_pb.GetOdometryResponse|null) => void
+  ): UnaryResponse;
+  streamOdometry(requestMessage: component_base_v1_base_pb.StreamOdometryRequest, metadata?: grpc.Metadata): ResponseStream<component_base_v1_base_pb.StreamOdometryResponse>;
 }
 
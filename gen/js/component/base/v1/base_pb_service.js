@@ -101,6 +101,24 @@ BaseService.GetProperties = {
   responseType: component_base_v1_base_pb.GetPropertiesResponse
 };
 
+BaseService.GetOdometry = {
+  methodName: "GetOdometry",
+  service: BaseService,
+  requestStream: false,
+  responseStream: false,
+  requestType: component_base_v1_base_pb.GetOdometryRequest,
+  responseType: component_base_v1_base_pb.GetOdometryResponse
+};
+
+BaseService.StreamOdometry = {
+  methodName: "StreamOdometry",
+  service: BaseService,
+  requestStream: false,
+  responseStream: true,
+  requestType: component_base_v1_base_pb.StreamOdometryRequest,
+  responseType: component_base_v1_base_pb.StreamOdometryResponse
+};
+
 exports.BaseService = BaseService;
 
 function BaseServiceClient(serviceHost, options) {
@@ -418,5 +436,75 @@ BaseServiceClient.prototype.getProperties = function getProperties(requestMessag
   };
 };
 
+BaseServiceClient.prototype.getOdometry = function getOdometry(requestMessage, metadata, callback) {
+  if (arguments.length === 2) {
+    callback = arguments[1];
+  }
+  var client = grpc.unary(BaseService.GetOdometry, {
+    request: requestMessage,
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport,
+    debug: this.options.debug,
+    onEnd: function (response) {
+      if (callback) {
+        if (response.status !== grpc.Code.OK) {
+          var err = new Error(response.statusMessage);
+          err.code = response.status;
+          err.metadata = response.trailers;
+          callback(err, null);
+        } else {
+          callback(null, response.message);
+        }
+      }
+    }
+  });
+  return {
+    cancel: function () {
+      callback = null;
+      client.close();
+    }
+  };
+};
+
+BaseServiceClient.prototype.streamOdometry = function streamOdometry(requestMessage, metadata) {
+  var listeners = {
+    data: [],
+    end: [],
+    status: []
+  };
+  var client = grpc.invoke(BaseService.StreamOdometry, {
+    request: requestMessage,
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport,
+    debug: this.options.debug,
+    onMessage: function (responseMessage) {
+      listeners.data.forEach(function (handler) {
+        handler(responseMessage);
+      });
+    },
+    onEnd: function (status, statusMessage, trailers) {
+      listeners.status.forEach(function (handler) {
+        handler({ code: status, details: statusMessage, metadata: trailers });
+      });
+      listeners.end.forEach(function (handler) {
+        handler({ code: status, details: statusMessage, metadata: trailers });
+      });
+      listeners = null;
+    }
+  });
+  return {
+    on: function (type, handler) {
+      listeners[type].push(handler);
+      return this;
+    },
+    cancel: function () {
+      listeners = null;
+      client.close();
+    }
+  };
+};
+
 exports.BaseServiceClient = BaseServiceClient;
 
@@ -873,6 +873,250 @@ proto.viam.component.board.v1.BoardServicePromiseClient.prototype.setPowerMode =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.viam.component.board.v1.ReadI2CRequest,
+ *   !proto.viam.component.board.v1.ReadI2CResponse>}
+ */
+const methodDescriptor_BoardService_ReadI2C = new grpc.web.MethodDescriptor(
+  '/viam.component.board.v1.BoardService/ReadI2C',
+  grpc.web.MethodType.UNARY,
+  proto.viam.component.board.v1.ReadI2CRequest,
+  proto.viam.component.board.v1.ReadI2CResponse,
+  /**
+   * @param {!proto.viam.component.board.v1.ReadI2CRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.viam.component.board.v1.ReadI2CResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.viam.component.board.v1.ReadI2CRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.viam.component.board.v1.ReadI2CResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.board.v1.ReadI2CResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.board.v1.BoardServiceClient.prototype.readI2C =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/ReadI2C',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_ReadI2C,
+      callback);
+};
+
+
+/**
+ * @param {!proto.viam.component.board.v1.ReadI2CRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.viam.component.board.v1.ReadI2CResponse>}
+ *     Promise that resolves to the response
+ */
+proto.viam.component.board.v1.BoardServicePromiseClient.prototype.readI2C =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/ReadI2C',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_ReadI2C);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.viam.component.board.v1.WriteI2CRequest,
+ *   !proto.viam.component.board.v1.WriteI2CResponse>}
+ */
+const methodDescriptor_BoardService_WriteI2C = new grpc.web.MethodDescriptor(
+  '/viam.component.board.v1.BoardService/WriteI2C',
+  grpc.web.MethodType.UNARY,
+  proto.viam.component.board.v1.WriteI2CRequest,
+  proto.viam.component.board.v1.WriteI2CResponse,
+  /**
+   * @param {!proto.viam.component.board.v1.WriteI2CRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.viam.component.board.v1.WriteI2CResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.viam.component.board.v1.WriteI2CRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.viam.component.board.v1.WriteI2CResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.board.v1.WriteI2CResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.board.v1.BoardServiceClient.prototype.writeI2C =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/WriteI2C',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_WriteI2C,
+      callback);
+};
+
+
+/**
+ * @param {!proto.viam.component.board.v1.WriteI2CRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.viam.component.board.v1.WriteI2CResponse>}
+ *     Promise that resolves to the response
+ */
+proto.viam.component.board.v1.BoardServicePromiseClient.prototype.writeI2C =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/WriteI2C',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_WriteI2C);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.viam.component.board.v1.TransactI2CRequest,
+ *   !proto.viam.component.board.v1.TransactI2CResponse>}
+ */
+const methodDescriptor_BoardService_TransactI2C = new grpc.web.MethodDescriptor(
+  '/viam.component.board.v1.BoardService/TransactI2C',
+  grpc.web.MethodType.UNARY,
+  proto.viam.component.board.v1.TransactI2CRequest,
+  proto.viam.component.board.v1.TransactI2CResponse,
+  /**
+   * @param {!proto.viam.component.board.v1.TransactI2CRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.viam.component.board.v1.TransactI2CResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.viam.component.board.v1.TransactI2CRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.viam.component.board.v1.TransactI2CResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.board.v1.TransactI2CResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.board.v1.BoardServiceClient.prototype.transactI2C =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/TransactI2C',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_TransactI2C,
+      callback);
+};
+
+
+/**
+ * @param {!proto.viam.component.board.v1.TransactI2CRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.viam.component.board.v1.TransactI2CResponse>}
+ *     Promise that resolves to the response
+ */
+proto.viam.component.board.v1.BoardServicePromiseClient.prototype.transactI2C =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/TransactI2C',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_TransactI2C);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.viam.component.board.v1.TransferSPIRequest,
+ *   !proto.viam.component.board.v1.TransferSPIResponse>}
+ */
+const methodDescriptor_BoardService_TransferSPI = new grpc.web.MethodDescriptor(
+  '/viam.component.board.v1.BoardService/TransferSPI',
+  grpc.web.MethodType.UNARY,
+  proto.viam.component.board.v1.TransferSPIRequest,
+  proto.viam.component.board.v1.TransferSPIResponse,
+  /**
+   * @param {!proto.viam.component.board.v1.TransferSPIRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.viam.component.board.v1.TransferSPIResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.viam.component.board.v1.TransferSPIRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.viam.component.board.v1.TransferSPIResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.viam.component.board.v1.TransferSPIResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.viam.component.board.v1.BoardServiceClient.prototype.transferSPI =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/TransferSPI',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_TransferSPI,
+      callback);
+};
+
+
+/**
+ * @param {!proto.viam.component.board.v1.TransferSPIRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.viam.component.board.v1.TransferSPIResponse>}
+ *     Promise that resolves to the response
+ */
+proto.viam.component.board.v1.BoardServicePromiseClient.prototype.transferSPI =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/viam.component.board.v1.BoardService/TransferSPI',
+      request,
+      metadata || {},
+      methodDescriptor_BoardService_TransferSPI);
+};
+
+
 /**
  * @const
  * @type {!grpc.web.MethodDescriptor<
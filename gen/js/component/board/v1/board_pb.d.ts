@@ -603,6 +603,410 @@ export namespace SetPowerModeResponse {
   }
 }
 
+export class ReadI2CRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  getBus(): string;
+  setBus(value: string): void;
+
+  getAddress(): number;
+  setAddress(value: number): void;
+
+  hasRegister(): boolean;
+  clearRegister(): void;
+  getRegister(): number;
+  setRegister(value: number): void;
+
+  getLength(): number;
+  setLength(value: number): void;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReadI2CRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ReadI2CRequest): ReadI2CRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReadI2CRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReadI2CRequest;
+  static deserializeBinaryFromReader(message: ReadI2CRequest, reader: jspb.BinaryReader): ReadI2CRequest;
+}
+
+export namespace ReadI2CRequest {
+  export type AsObject = {
+    name: string,
+    bus: string,
+    address: number,
+    register: number,
+    length: number,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+}
+
+export class ReadI2CResponse extends jspb.Message {
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReadI2CResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ReadI2CResponse): ReadI2CResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReadI2CResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReadI2CResponse;
+  static deserializeBinaryFromReader(message: ReadI2CResponse, reader: jspb.BinaryReader): ReadI2CResponse;
+}
+
+export namespace ReadI2CResponse {
+  export type AsObject = {
+    data: Uint8Array | string,
+  }
+}
+
+export class WriteI2CRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  getBus(): string;
+  setBus(value: string): void;
+
+  getAddress(): number;
+  setAddress(value: number): void;
+
+  hasRegister(): boolean;
+  clearRegister(): void;
+  getRegister(): number;
+  setRegister(value: number): void;
+
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): WriteI2CRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: WriteI2CRequest): WriteI2CRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: WriteI2CRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): WriteI2CRequest;
+  static deserializeBinaryFromReader(message: WriteI2CRequest, reader: jspb.BinaryReader): WriteI2CRequest;
+}
+
+export namespace WriteI2CRequest {
+  export type AsObject = {
+    name: string,
+    bus: string,
+    address: number,
+    register: number,
+    data: Uint8Array | string,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+}
+
+export class WriteI2CResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): WriteI2CResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: WriteI2CResponse): WriteI2CResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: WriteI2CResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): WriteI2CResponse;
+  static deserializeBinaryFromReader(message: WriteI2CResponse, reader: jspb.BinaryReader): WriteI2CResponse;
+}
+
+export namespace WriteI2CResponse {
+  export type AsObject = {
+  }
+}
+
+export class I2CMessage extends jspb.Message {
+  hasWrite(): boolean;
+  clearWrite(): void;
+  getWrite(): Uint8Array | string;
+  getWrite_asU8(): Uint8Array;
+  getWrite_asB64(): string;
+  setWrite(value: Uint8Array | string): void;
+
+  hasReadLength(): boolean;
+  clearReadLength(): void;
+  getReadLength(): number;
+  setReadLength(value: number): void;
+
+  getOperationCase(): I2CMessage.OperationCase;
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): I2CMessage.AsObject;
+  static toObject(includeInstance: boolean, msg: I2CMessage): I2CMessage.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: I2CMessage, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): I2CMessage;
+  static deserializeBinaryFromReader(message: I2CMessage, reader: jspb.BinaryReader): I2CMessage;
+}
+
+export namespace I2CMessage {
+  export type AsObject = {
+    write: Uint8Array | string,
+    readLength: number,
+  }
+
+  export enum OperationCase {
+    OPERATION_NOT_SET = 0,
+    WRITE = 1,
+    READ_LENGTH = 2,
+  }
+}
+
+export class TransactI2CRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  getBus(): string;
+  setBus(value: string): void;
+
+  getAddress(): number;
+  setAddress(value: number): void;
+
+  clearMessagesList(): void;
+  getMessagesList(): Array<I2CMessage>;
+  setMessagesList(value: Array<I2CMessage>): void;
+  addMessages(value?: I2CMessage, index?: number): I2CMessage;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): TransactI2CRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: TransactI2CRequest): TransactI2CRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: TransactI2CRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): TransactI2CRequest;
+  static deserializeBinaryFromReader(message: TransactI2CRequest, reader: jspb.BinaryReader): TransactI2CRequest;
+}
+
+export namespace TransactI2CRequest {
+  export type AsObject = {
+    name: string,
+    bus: string,
+    address: number,
+    messagesList: Array<I2CMessage.AsObject>,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+}
+
+export class TransactI2CResponse extends jspb.Message {
+  clearReadsList(): void;
+  getReadsList(): Array<Uint8Array | string>;
+  getReadsList_asU8(): Array<Uint8Array>;
+  getReadsList_asB64(): Array<string>;
+  setReadsList(value: Array<Uint8Array | string>): void;
+  addReads(value: Uint8Array | string, index?: number): Uint8Array | string;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): TransactI2CResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: TransactI2CResponse): TransactI2CResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: TransactI2CResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): TransactI2CResponse;
+  static deserializeBinaryFromReader(message: TransactI2CResponse, reader: jspb.BinaryReader): TransactI2CResponse;
+}
+
+export namespace TransactI2CResponse {
+  export type AsObject = {
+    readsList: Array<Uint8Array | string>,
+  }
+}
+
+export class TransferSPIRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  getBus(): string;
+  setBus(value: string): void;
+
+  getChipSelect(): string;
+  setChipSelect(value: string): void;
+
+  getMode(): number;
+  setMode(value: number): void;
+
+  getSpeedHz(): number;
+  setSpeedHz(value: number): void;
+
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): TransferSPIRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: TransferSPIRequest): TransferSPIRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: TransferSPIRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): TransferSPIRequest;
+  static deserializeBinaryFromReader(message: TransferSPIRequest, reader: jspb.BinaryReader): TransferSPIRequest;
+}
+
+export namespace TransferSPIRequest {
+  export type AsObject = {
+    name: string,
+    bus: string,
+    chipSelect: string,
+    mode: number,
+    speedHz: number,
+    data: Uint8Array | string,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+}
+
+export class TransferSPIResponse extends jspb.Message {
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): TransferSPIResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: TransferSPIResponse): TransferSPIResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: TransferSPIResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): TransferSPIResponse;
+  static deserializeBinaryFromReader(message: TransferSPIResponse, reader: jspb.BinaryReader): TransferSPIResponse;
+}
+
+export namespace TransferSPIResponse {
+  export type AsObject = {
+    data: Uint8Array | string,
+  }
+}
+
+export class UARTConfig extends jspb.Message {
+  getPort(): string;
+  setPort(value: string): void;
+
+  getBaudRate(): number;
+  setBaudRate(value: number): void;
+
+  getDataBits(): number;
+  setDataBits(value: number): void;
+
+  getParity(): UARTParityMap[keyof UARTParityMap];
+  setParity(value: UARTParityMap[keyof UARTParityMap]): void;
+
+  getStopBits(): number;
+  setStopBits(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UARTConfig.AsObject;
+  static toObject(includeInstance: boolean, msg: UARTConfig): UARTConfig.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UARTConfig, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UARTConfig;
+  static deserializeBinaryFromReader(message: UARTConfig, reader: jspb.BinaryReader): UARTConfig;
+}
+
+export namespace UARTConfig {
+  export type AsObject = {
+    port: string,
+    baudRate: number,
+    dataBits: number,
+    parity: UARTParityMap[keyof UARTParityMap],
+    stopBits: number,
+  }
+}
+
+export class StreamUARTRequest extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  hasConfig(): boolean;
+  clearConfig(): void;
+  getConfig(): UARTConfig | undefined;
+  setConfig(value?: UARTConfig): void;
+
+  hasData(): boolean;
+  clearData(): void;
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  hasExtra(): boolean;
+  clearExtra(): void;
+  getExtra(): google_protobuf_struct_pb.Struct | undefined;
+  setExtra(value?: google_protobuf_struct_pb.Struct): void;
+
+  getRequestCase(): StreamUARTRequest.RequestCase;
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): StreamUARTRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: StreamUARTRequest): StreamUARTRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: StreamUARTRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): StreamUARTRequest;
+  static deserializeBinaryFromReader(message: StreamUARTRequest, reader: jspb.BinaryReader): StreamUARTRequest;
+}
+
+export namespace StreamUARTRequest {
+  export type AsObject = {
+    name: string,
+    config?: UARTConfig.AsObject,
+    data: Uint8Array | string,
+    extra?: google_protobuf_struct_pb.Struct.AsObject,
+  }
+
+  export enum RequestCase {
+    REQUEST_NOT_SET = 0,
+    CONFIG = 2,
+    DATA = 3,
+  }
+}
+
+export class StreamUARTResponse extends jspb.Message {
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): StreamUARTResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: StreamUARTResponse): StreamUARTResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: StreamUARTResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): StreamUARTResponse;
+  static deserializeBinaryFromReader(message: StreamUARTResponse, reader: jspb.BinaryReader): StreamUARTResponse;
+}
+
+export namespace StreamUARTResponse {
+  export type AsObject = {
+    data: Uint8Array | string,
+  }
+}
+
 export interface PowerModeMap {
   POWER_MODE_UNSPECIFIED: 0;
   POWER_MODE_NORMAL: 1;
@@ -611,3 +1015,12 @@ export interface PowerModeMap {
 
 export const PowerMode: PowerModeMap;
 
+export interface UARTParityMap {
+  UART_PARITY_UNSPECIFIED: 0;
+  UART_PARITY_NONE: 1;
+  UART_PARITY_EVEN: 2;
+  UART_PARITY_ODD: 3;
+}
+
+export const UARTParity: UARTParityMap;
+
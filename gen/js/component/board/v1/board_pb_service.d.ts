@@ -122,6 +122,51 @@ type BoardServiceSetPowerMode = {
   readonly responseType: typeof component_board_v1_board_pb.SetPowerModeResponse;
 };
 
+type BoardServiceReadI2C = {
+  readonly methodName: string;
+  readonly service: typeof BoardService;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof component_board_v1_board_pb.ReadI2CRequest;
+  readonly responseType: typeof component_board_v1_board_pb.ReadI2CResponse;
+};
+
+type BoardServiceWriteI2C = {
+  readonly methodName: string;
+  readonly service: typeof BoardService;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof component_board_v1_board_pb.WriteI2CRequest;
+  readonly responseType: typeof component_board_v1_board_pb.WriteI2CResponse;
+};
+
+type BoardServiceTransactI2C = {
+  readonly methodName: string;
+  readonly service: typeof BoardService;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof component_board_v1_board_pb.TransactI2CRequest;
+  readonly responseType: typeof component_board_v1_board_pb.TransactI2CResponse;
+};
+
+type BoardServiceTransferSPI = {
+  readonly methodName: string;
+  readonly service: typeof BoardService;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof component_board_v1_board_pb.TransferSPIRequest;
+  readonly responseType: typeof component_board_v1_board_pb.TransferSPIResponse;
+};
+
+type BoardServiceStreamUART = {
+  readonly methodName: string;
+  readonly service: typeof BoardService;
+  readonly requestStream: true;
+  readonly responseStream: true;
+  readonly requestType: typeof component_board_v1_board_pb.StreamUARTRequest;
+  readonly responseType: typeof component_board_v1_board_pb.StreamUARTResponse;
+};
+
 type BoardServiceGetGeometries = {
   readonly methodName: string;
   readonly service: typeof BoardService;
@@ -146,6 +191,11 @@ export class BoardService {
   static readonly GetDigitalInterruptValue: BoardServiceGetDigitalInterruptValue;
   static readonly StreamTicks: BoardServiceStreamTicks;
   static readonly SetPowerMode: BoardServiceSetPowerMode;
+  static readonly ReadI2C: BoardServiceReadI2C;
+  static readonly WriteI2C: BoardServiceWriteI2C;
+  static readonly TransactI2C: BoardServiceTransactI2C;
+  static readonly TransferSPI: BoardServiceTransferSPI;
+  static readonly StreamUART: BoardServiceStreamUART;
   static readonly GetGeometries: BoardServiceGetGeometries;
 }
 
@@ -290,6 +340,43 @@ export class BoardServiceClient {
     requestMessage: component_board_v1_board_pb.SetPowerModeRequest,
     callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.SetPowerModeResponse|null) => void
   ): UnaryResponse;
+  readI2C(
+    requestMessage: component_board_v1_board_pb.ReadI2CRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.ReadI2CResponse|null) => void
+  ): UnaryResponse;
+  readI2C(
+    requestMessage: component_board_v1_board_pb.ReadI2CRequest,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.ReadI2CResponse|null) => void
+  ): UnaryResponse;
+  writeI2C(
+    requestMessage: component_board_v1_board_pb.WriteI2CRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.WriteI2CResponse|null) => void
+  ): UnaryResponse;
+  writeI2C(
+    requestMessage: component_board_v1_board_pb.WriteI2CRequest,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.WriteI2CResponse|null) => void
+  ): UnaryResponse;
+  transactI2C(
+    requestMessage: component_board_v1_board_pb.TransactI2CRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.TransactI2CResponse|null) => void
+  ): UnaryResponse;
+  transactI2C(
+    requestMessage: component_board_v1_board_pb.TransactI2CRequest,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.TransactI2CResponse|null) => void
+  ): UnaryResponse;
+  transferSPI(
+    requestMessage: component_board_v1_board_pb.TransferSPIRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.TransferSPIResponse|null) => void
+  ): UnaryResponse;
+  transferSPI(
+    requestMessage: component_board_v1_board_pb.TransferSPIRequest,
+    callback: (error: ServiceError|null, responseMessage: component_board_v1_board_pb.TransferSPIResponse|null) => void
+  ): UnaryResponse;
+  streamUART(metadata?: grpc.Metadata): BidirectionalStream<component_board_v1_board_pb.StreamUARTRequest, component_board_v1_board_pb.StreamUARTResponse>;
   getGeometries(
     requestMessage: common_v1_common_pb.GetGeometriesRequest,
     metadata: grpc.Metadata,
@@ -128,6 +128,51 @@ BoardService.SetPowerMode = {
   responseType: component_board_v1_board_pb.SetPowerModeResponse
 };
 
+BoardService.ReadI2C = {
+  methodName: "ReadI2C",
+  service: BoardService,
+  requestStream: false,
+  responseStream: false,
+  requestType: component_board_v1_board_pb.ReadI2CRequest,
+  responseType: component_board_v1_board_pb.ReadI2CResponse
+};
+
+BoardService.WriteI2C = {
+  methodName: "WriteI2C",
+  service: BoardService,
+  requestStream: false,
+  responseStream: false,
+  requestType: component_board_v1_board_pb.WriteI2CRequest,
+  responseType: component_board_v1_board_pb.WriteI2CResponse
+};
+
+BoardService.TransactI2C = {
+  methodName: "TransactI2C",
+  service: BoardService,
+  requestStream: false,
+  responseStream: false,
+  requestType: component_board_v1_board_pb.TransactI2CRequest,
+  responseType: component_board_v1_board_pb.TransactI2CResponse
+};
+
+BoardService.TransferSPI = {
+  methodName: "TransferSPI",
+  service: BoardService,
+  requestStream: false,
+  responseStream: false,
+  requestType: component_board_v1_board_pb.TransferSPIRequest,
+  responseType: component_board_v1_board_pb.TransferSPIResponse
+};
+
+BoardService.StreamUART = {
+  methodName: "StreamUART",
+  service: BoardService,
+  requestStream: true,
+  responseStream: true,
+  requestType: component_board_v1_board_pb.StreamUARTRequest,
+  responseType: component_board_v1_board_pb.StreamUARTResponse
+};
+
 BoardService.GetGeometries = {
   methodName: "GetGeometries",
   service: BoardService,
@@ -555,6 +600,175 @@ BoardServiceClient.prototype.setPowerMode = function setPowerMode(requestMessage
   };
 };
 
+BoardServiceClient.prototype.readI2C = function readI2C(requestMessage, metadata, callback) {
+  if (arguments.length === 2) {
+    callback = arguments[1];
+  }
+  var client = grpc.unary(BoardService.ReadI2C, {
+    request: requestMessage,
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport,
+    debug: this.options.debug,
+    onEnd: function (response) {
+      if (callback) {
+        if (response.status !== grpc.Code.OK) {
+          var err = new Error(response.statusMessage);
+          err.code = response.status;
+          err.metadata = response.trailers;
+          callback(err, null);
+        } else {
+          callback(null, response.message);
+        }
+      }
+    }
+  });
+  return {
+    cancel: function () {
+      callback = null;
+      client.close();
+    }
+  };
+};
+
+BoardServiceClient.prototype.writeI2C = function writeI2C(requestMessage, metadata, callback) {
+  if (arguments.length === 2) {
+    callback = arguments[1];
+  }
+  var client = grpc.unary(BoardService.WriteI2C, {
+    request: requestMessage,
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport,
+    debug: this.options.debug,
+    onEnd: function (response) {
+      if (callback) {
+        if (response.status !== grpc.Code.OK) {
+          var err = new Error(response.statusMessage);
+          err.code = response.status;
+          err.metadata = response.trailers;
+          callback(err, null);
+        } else {
+          callback(null, response.message);
+        }
+      }
+    }
+  });
+  return {
+    cancel: function () {
+      callback = null;
+      client.close();
+    }
+  };
+};
+
+BoardServiceClient.prototype.transactI2C = function transactI2C(requestMessage, metadata, callback) {
+  if (arguments.length === 2) {
+    callback = arguments[1];
+  }
+  var client = grpc.unary(BoardService.TransactI2C, {
+    request: requestMessage,
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport,
+    debug: this.options.debug,
+    onEnd: function (response) {
+      if (callback) {
+        if (response.status !== grpc.Code.OK) {
+          var err = new Error(response.statusMessage);
+          err.code = response.status;
+          err.metadata = response.trailers;
+          callback(err, null);
+        } else {
+          callback(null, response.message);
+        }
+      }
+    }
+  });
+  return {
+    cancel: function () {
+      callback = null;
+      client.close();
+    }
+  };
+};
+
+BoardServiceClient.prototype.transferSPI = function transferSPI(requestMessage, metadata, callback) {
+  if (arguments.length === 2) {
+    callback = arguments[1];
+  }
+  var client = grpc.unary(BoardService.TransferSPI, {
+    request: requestMessage,
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport,
+    debug: this.options.debug,
+    onEnd: function (response) {
+      if (callback) {
+        if (response.status !== grpc.Code.OK) {
+          var err = new Error(response.statusMessage);
+          err.code = response.status;
+          err.metadata = response.trailers;
+          callback(err, null);
+        } else {
+          callback(null, response.message);
+        }
+      }
+    }
+  });
+  return {
+    cancel: function () {
+      callback = null;
+      client.close();
+    }
+  };
+};
+
+BoardServiceClient.prototype.streamUART = function streamUART(metadata) {
+  var listeners = {
+    data: [],
+    end: [],
+    status: []
+  };
+  var client = grpc.client(BoardService.StreamUART, {
+    host: this.serviceHost,
+    metadata: metadata,
+    transport: this.options.transport
+  });
+  client.onEnd(function (status, statusMessage, trailers) {
+    listeners.status.forEach(function (handler) {
+      handler({ code: status, details: statusMessage, metadata: trailers });
+    });
+    listeners.end.forEach(function (handler) {
+      handler({ code: status, details: statusMessage, metadata: trailers });
+    });
+    listeners = null;
+  });
+  client.onMessage(function (message) {
+    listeners.data.forEach(function (handler) {
+      handler(message);
+    })
+  });
+  client.start(metadata);
+  return {
+    on: function (type, handler) {
+      listeners[type].push(handler);
+      return this;
+    },
+    write: function (requestMessage) {
+      client.send(requestMessage);
+      return this;
+    },
+    end: function () {
+      client.finishSend();
+    },
+    cancel: function () {
+      listeners = null;
+      client.close();
+    }
+  };
+};
+
 BoardServiceClient.prototype.getGeometries = function getGeometries(requestMessage, metadata, callback) {
   if (arguments.length === 2) {
     callback = arguments[1];
@@ -14,6 +14,17 @@ export class ShellRequest extends jspb.Message {
   getDataIn(): string;
   setDataIn(value: string): void;
 
+  hasWindowSize(): boolean;
+  clearWindowSize(): void;
+  getWindowSize(): WindowSize | undefined;
+  setWindowSize(value?: WindowSize): void;
+
+  getSignal(): string;
+  setSignal(value: string): void;
+
+  getTerm(): string;
+  setTerm(value: string): void;
+
   hasExtra(): boolean;
   clearExtra(): void;
   getExtra(): google_protobuf_struct_pb.Struct | undefined;
@@ -33,10 +44,45 @@ export namespace ShellRequest {
   export type AsObject = {
     name: string,
     dataIn: string,
+    windowSize?: WindowSize.AsObject,
+    signal: string,
+    term: string,
     extra?: google_protobuf_struct_pb.Struct.AsObject,
   }
 }
 
+export class WindowSize extends jspb.Message {
+  getRows(): number;
+  setRows(value: number): void;
+
+  getCols(): number;
+  setCols(value: number): void;
+
+  getWidthPixels(): number;
+  setWidthPixels(value: number): void;
+
+  getHeightPixels(): number;
+  setHeightPixels(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): WindowSize.AsObject;
+  static toObject(includeInstance: boolean, msg: WindowSize): WindowSize.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: WindowSize, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): WindowSize;
+  static deserializeBinaryFromReader(message: WindowSize, reader: jspb.BinaryReader): WindowSize;
+}
+
+export namespace WindowSize {
+  export type AsObject = {
+    rows: number,
+    cols: number,
+    widthPixels: number,
+    heightPixels: number,
+  }
+}
+
 export class ShellResponse extends jspb.Message {
   getDataOut(): string;
   setDataOut(value: string): void;
@@ -47,6 +93,11 @@ export class ShellResponse extends jspb.Message {
   getEof(): boolean;
   setEof(value: boolean): void;
 
+  hasExitStatus(): boolean;
+  clearExitStatus(): void;
+  getExitStatus(): ExitStatus | undefined;
+  setExitStatus(value?: ExitStatus): void;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): ShellResponse.AsObject;
   static toObject(includeInstance: boolean, msg: ShellResponse): ShellResponse.AsObject;
@@ -62,6 +113,31 @@ export namespace ShellResponse {
     dataOut: string,
     dataErr: string,
     eof: boolean,
+    exitStatus?: ExitStatus.AsObject,
+  }
+}
+
+export class ExitStatus extends jspb.Message {
+  getCode(): number;
+  setCode(value: number): void;
+
+  getSignal(): string;
+  setSignal(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ExitStatus.AsObject;
+  static toObject(includeInstance: boolean, msg: ExitStatus): ExitStatus.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ExitStatus, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ExitStatus;
+  static deserializeBinaryFromReader(message: ExitStatus, reader: jspb.BinaryReader): ExitStatus;
+}
+
+export namespace ExitStatus {
+  export type AsObject = {
+    code: number,
+    signal: string,
   }
 }
 
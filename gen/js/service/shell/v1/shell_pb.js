@@ -34,9 +34,11 @@ goog.exportSymbol('proto.viam.service.shell.v1.CopyFilesToMachineRequest', null,
 goog.exportSymbol('proto.viam.service.shell.v1.CopyFilesToMachineRequest.RequestCase', null, global);
 goog.exportSymbol('proto.viam.service.shell.v1.CopyFilesToMachineRequestMetadata', null, global);
 goog.exportSymbol('proto.viam.service.shell.v1.CopyFilesToMachineResponse', null, global);
+goog.exportSymbol('proto.viam.service.shell.v1.ExitStatus', null, global);
 goog.exportSymbol('proto.viam.service.shell.v1.FileData', null, global);
 goog.exportSymbol('proto.viam.service.shell.v1.ShellRequest', null, global);
 goog.exportSymbol('proto.viam.service.shell.v1.ShellResponse', null, global);
+goog.exportSymbol('proto.viam.service.shell.v1.WindowSize', null, global);
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -58,6 +60,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.viam.service.shell.v1.ShellRequest.displayName = 'proto.viam.service.shell.v1.ShellRequest';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.viam.service.shell.v1.WindowSize = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.viam.service.shell.v1.WindowSize, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.viam.service.shell.v1.WindowSize.displayName = 'proto.viam.service.shell.v1.WindowSize';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -79,6 +102,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.viam.service.shell.v1.ShellResponse.displayName = 'proto.viam.service.shell.v1.ShellResponse';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.viam.service.shell.v1.ExitStatus = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.viam.service.shell.v1.ExitStatus, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.viam.service.shell.v1.ExitStatus.displayName = 'proto.viam.service.shell.v1.ExitStatus';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -281,6 +325,9 @@ proto.viam.service.shell.v1.ShellRequest.toObject = function(includeInstance, ms
   var f, obj = {
     name: jspb.Message.getFieldWithDefault(msg, 1, ""),
     dataIn: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    windowSize: (f = msg.getWindowSize()) && proto.viam.service.shell.v1.WindowSize.toObject(includeInstance, f),
+    signal: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    term: jspb.Message.getFieldWithDefault(msg, 5, ""),
     extra: (f = msg.getExtra()) && google_protobuf_struct_pb.Struct.toObject(includeInstance, f)
   };
 
@@ -326,6 +373,19 @@ proto.viam.service.shell.v1.ShellRequest.deserializeBinaryFromReader = function(
       var value = /** @type {string} */ (reader.readString());
       msg.setDataIn(value);
       break;
+    case 3:
+      var value = new proto.viam.service.shell.v1.WindowSize;
+      reader.readMessage(value,proto.viam.service.shell.v1.WindowSize.deserializeBinaryFromReader);
+      msg.setWindowSize(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSignal(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTerm(value);
+      break;
     case 99:
       var value = new google_protobuf_struct_pb.Struct;
       reader.readMessage(value,google_protobuf_struct_pb.Struct.deserializeBinaryFromReader);
@@ -374,6 +434,28 @@ proto.viam.service.shell.v1.ShellRequest.serializeBinaryToWriter = function(mess
       f
     );
   }
+  f = message.getWindowSize();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      proto.viam.service.shell.v1.WindowSize.serializeBinaryToWriter
+    );
+  }
+  f = message.getSignal();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getTerm();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
   f = message.getExtra();
   if (f != null) {
     writer.writeMessage(
@@ -421,6 +503,79 @@ proto.viam.service.shell.v1.ShellRequest.prototype.setDataIn = function(value) {
 };
 
 
+/**
+ * optional WindowSize window_size = 3;
+ * @return {?proto.viam.service.shell.v1.WindowSize}
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.getWindowSize = function() {
+  return /** @type{?proto.viam.service.shell.v1.WindowSize} */ (
+    jspb.Message.getWrapperField(this, proto.viam.service.shell.v1.WindowSize, 3));
+};
+
+
+/**
+ * @param {?proto.viam.service.shell.v1.WindowSize|undefined} value
+ * @return {!proto.viam.service.shell.v1.ShellRequest} returns this
+*/
+proto.viam.service.shell.v1.ShellRequest.prototype.setWindowSize = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.service.shell.v1.ShellRequest} returns this
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.clearWindowSize = function() {
+  return this.setWindowSize(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.hasWindowSize = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional string signal = 4;
+ * @return {string}
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.getSignal = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.viam.service.shell.v1.ShellRequest} returns this
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.setSignal = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional string term = 5;
+ * @return {string}
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.getTerm = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.viam.service.shell.v1.ShellRequest} returns this
+ */
+proto.viam.service.shell.v1.ShellRequest.prototype.setTerm = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
 /**
  * optional google.protobuf.Struct extra = 99;
  * @return {?proto.google.protobuf.Struct}
@@ -461,6 +616,226 @@ proto.viam.service.shell.v1.ShellRequest.prototype.hasExtra = function() {
 
 
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.toObject = function(opt_includeInstance) {
+  return proto.viam.service.shell.v1.WindowSize.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.viam.service.shell.v1.WindowSize} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.service.shell.v1.WindowSize.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    rows: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    cols: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    widthPixels: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    heightPixels: jspb.Message.getFieldWithDefault(msg, 4, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.viam.service.shell.v1.WindowSize}
+ */
+proto.viam.service.shell.v1.WindowSize.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.viam.service.shell.v1.WindowSize;
+  return proto.viam.service.shell.v1.WindowSize.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.viam.service.shell.v1.WindowSize} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.viam.service.shell.v1.WindowSize}
+ */
+proto.viam.service.shell.v1.WindowSize.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setRows(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setCols(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setWidthPixels(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setHeightPixels(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.viam.service.shell.v1.WindowSize.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.viam.service.shell.v1.WindowSize} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.service.shell.v1.WindowSize.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getRows();
+  if (f !== 0) {
+    writer.writeUint32(
+      1,
+      f
+    );
+  }
+  f = message.getCols();
+  if (f !== 0) {
+    writer.writeUint32(
+      2,
+      f
+    );
+  }
+  f = message.getWidthPixels();
+  if (f !== 0) {
+    writer.writeUint32(
+      3,
+      f
+    );
+  }
+  f = message.getHeightPixels();
+  if (f !== 0) {
+    writer.writeUint32(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional uint32 rows = 1;
+ * @return {number}
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.getRows = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.viam.service.shell.v1.WindowSize} returns this
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.setRows = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional uint32 cols = 2;
+ * @return {number}
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.getCols = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.viam.service.shell.v1.WindowSize} returns this
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.setCols = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional uint32 width_pixels = 3;
+ * @return {number}
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.getWidthPixels = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.viam.service.shell.v1.WindowSize} returns this
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.setWidthPixels = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional uint32 height_pixels = 4;
+ * @return {number}
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.getHeightPixels = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.viam.service.shell.v1.WindowSize} returns this
+ */
+proto.viam.service.shell.v1.WindowSize.prototype.setHeightPixels = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+
+
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Creates an object representation of this proto.
@@ -492,7 +867,8 @@ proto.viam.service.shell.v1.ShellResponse.toObject = function(includeInstance, m
   var f, obj = {
     dataOut: jspb.Message.getFieldWithDefault(msg, 1, ""),
     dataErr: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    eof: jspb.Message.getBooleanFieldWithDefault(msg, 3, false)
+    eof: jspb.Message.getBooleanFieldWithDefault(msg, 3, false),
+    exitStatus: (f = msg.getExitStatus()) && proto.viam.service.shell.v1.ExitStatus.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -541,6 +917,11 @@ proto.viam.service.shell.v1.ShellResponse.deserializeBinaryFromReader = function
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setEof(value);
       break;
+    case 4:
+      var value = new proto.viam.service.shell.v1.ExitStatus;
+      reader.readMessage(value,proto.viam.service.shell.v1.ExitStatus.deserializeBinaryFromReader);
+      msg.setExitStatus(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -591,6 +972,14 @@ proto.viam.service.shell.v1.ShellResponse.serializeBinaryToWriter = function(mes
       f
     );
   }
+  f = message.getExitStatus();
+  if (f != null) {
+    writer.writeMessage(
+      4,
+      f,
+      proto.viam.service.shell.v1.ExitStatus.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -648,6 +1037,203 @@ proto.viam.service.shell.v1.ShellResponse.prototype.setEof = function(value) {
 };
 
 
+/**
+ * optional ExitStatus exit_status = 4;
+ * @return {?proto.viam.service.shell.v1.ExitStatus}
+ */
+proto.viam.service.shell.v1.ShellResponse.prototype.getExitStatus = function() {
+  return /** @type{?proto.viam.service.shell.v1.ExitStatus} */ (
+    jspb.Message.getWrapperField(this, proto.viam.service.shell.v1.ExitStatus, 4));
+};
+
+
+/**
+ * @param {?proto.viam.service.shell.v1.ExitStatus|undefined} value
+ * @return {!proto.viam.service.shell.v1.ShellResponse} returns this
+*/
+proto.viam.service.shell.v1.ShellResponse.prototype.setExitStatus = function(value) {
+  return jspb.Message.setWrapperField(this, 4, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.viam.service.shell.v1.ShellResponse} returns this
+ */
+proto.viam.service.shell.v1.ShellResponse.prototype.clearExitStatus = function() {
+  return this.setExitStatus(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.viam.service.shell.v1.ShellResponse.prototype.hasExitStatus = function() {
+  return jspb.Message.getField(this, 4) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.viam.service.shell.v1.ExitStatus.prototype.toObject = function(opt_includeInstance) {
+  return proto.viam.service.shell.v1.ExitStatus.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.viam.service.shell.v1.ExitStatus} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.service.shell.v1.ExitStatus.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    code: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    signal: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.viam.service.shell.v1.ExitStatus}
+ */
+proto.viam.service.shell.v1.ExitStatus.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.viam.service.shell.v1.ExitStatus;
+  return proto.viam.service.shell.v1.ExitStatus.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.viam.service.shell.v1.ExitStatus} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.viam.service.shell.v1.ExitStatus}
+ */
+proto.viam.service.shell.v1.ExitStatus.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setCode(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSignal(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.viam.service.shell.v1.ExitStatus.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.viam.service.shell.v1.ExitStatus.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.viam.service.shell.v1.ExitStatus} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.viam.service.shell.v1.ExitStatus.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getCode();
+  if (f !== 0) {
+    writer.writeInt32(
+      1,
+      f
+    );
+  }
+  f = message.getSignal();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional int32 code = 1;
+ * @return {number}
+ */
+proto.viam.service.shell.v1.ExitStatus.prototype.getCode = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.viam.service.shell.v1.ExitStatus} returns this
+ */
+proto.viam.service.shell.v1.ExitStatus.prototype.setCode = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional string signal = 2;
+ * @return {string}
+ */
+proto.viam.service.shell.v1.ExitStatus.prototype.getSignal = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.viam.service.shell.v1.ExitStatus} returns this
+ */
+proto.viam.service.shell.v1.ExitStatus.prototype.setSignal = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
 
 
 
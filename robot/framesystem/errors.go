@@ -0,0 +1,54 @@
+package framesystem
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DuplicateFrameError is returned when two frames share a name, or a frame is named after the world frame.
+type DuplicateFrameError struct {
+	Name string
+}
+
+func (e *DuplicateFrameError) Error() string {
+	return fmt.Sprintf("frame %q is defined more than once", e.Name)
+}
+
+// MissingParentError is returned when a frame names a parent that is not part of the frame system.
+type MissingParentError struct {
+	Frame  string
+	Parent string
+}
+
+func (e *MissingParentError) Error() string {
+	return fmt.Sprintf("frame %q has parent %q which does not exist", e.Frame, e.Parent)
+}
+
+// CycleError is returned when following parents from a frame never reaches the world frame. Frames lists
+// the frames forming the cycle in parent order.
+type CycleError struct {
+	Frames []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("frames form a cycle: %s -> %s", strings.Join(e.Frames, " -> "), e.Frames[0])
+}
+
+// UnknownFrameError is returned when a query names a frame that is not part of the frame system.
+type UnknownFrameError struct {
+	Name string
+}
+
+func (e *UnknownFrameError) Error() string {
+	return fmt.Sprintf("frame %q does not exist", e.Name)
+}
+
+// DynamicFrameError is returned when answering a query would require the current state of a frame with
+// kinematics, such as an arm or gantry, which cannot be known without asking the machine.
+type DynamicFrameError struct {
+	Name string
+}
+
+func (e *DynamicFrameError) Error() string {
+	return fmt.Sprintf("frame %q has kinematics and its pose cannot be computed locally", e.Name)
+}
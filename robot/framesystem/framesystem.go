@@ -0,0 +1,229 @@
+// Package framesystem answers frame system queries locally from a machine's frame system config.
+//
+// A FrameSystem is built from the FrameSystemConfig entries returned by RobotService.FrameSystemConfig,
+// optionally extended with supplemental transforms, and can then serve TransformPose and GetPose without a
+// round trip to the machine. Only static frames can be resolved: a frame that carries kinematics moves with
+// its joints, so any query whose answer depends on such a frame fails with a DynamicFrameError.
+package framesystem
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/api/common/spatialmath"
+	commonpb "go.viam.com/api/common/v1"
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+// World is the name of the root frame of every frame system.
+const World = "world"
+
+type frame struct {
+	parent  string
+	offset  spatialmath.Pose
+	dynamic bool
+}
+
+// FrameSystem is an immutable tree of frames rooted at World.
+type FrameSystem struct {
+	frames map[string]*frame
+	names  []string
+}
+
+// New builds a frame system from configs and supplemental transforms. Frames without a parent are attached
+// to World.
+func New(configs []*robotpb.FrameSystemConfig, supplemental ...*commonpb.Transform) (*FrameSystem, error) {
+	fs := &FrameSystem{frames: map[string]*frame{}}
+	for _, cfg := range configs {
+		if err := fs.add(cfg.GetFrame(), cfg.GetKinematics()); err != nil {
+			return nil, err
+		}
+	}
+	for _, tf := range supplemental {
+		if err := fs.add(tf, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := fs.validate(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// NewFromResponse builds a frame system from a FrameSystemConfig response.
+func NewFromResponse(resp *robotpb.FrameSystemConfigResponse, supplemental ...*commonpb.Transform) (*FrameSystem, error) {
+	return New(resp.GetFrameSystemConfigs(), supplemental...)
+}
+
+// With returns a copy of fs extended with the given supplemental transforms. fs itself is not modified.
+func (fs *FrameSystem) With(supplemental ...*commonpb.Transform) (*FrameSystem, error) {
+	if len(supplemental) == 0 {
+		return fs, nil
+	}
+	out := &FrameSystem{frames: make(map[string]*frame, len(fs.frames)), names: slices.Clone(fs.names)}
+	for name, f := range fs.frames {
+		out.frames[name] = f
+	}
+	for _, tf := range supplemental {
+		if err := out.add(tf, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := out.validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Frames returns the names of all frames other than World in the order they were added.
+func (fs *FrameSystem) Frames() []string {
+	return slices.Clone(fs.names)
+}
+
+// Parent returns the name of the parent of the named frame.
+func (fs *FrameSystem) Parent(name string) (string, error) {
+	f, ok := fs.frames[name]
+	if !ok {
+		return "", &UnknownFrameError{Name: name}
+	}
+	return f.parent, nil
+}
+
+// Transform re-expresses pose, observed in frame from, in frame to. An empty frame name means World.
+func (fs *FrameSystem) Transform(pose spatialmath.Pose, from, to string) (spatialmath.Pose, error) {
+	from, to = orWorld(from), orWorld(to)
+	fromChain, err := fs.ancestors(from)
+	if err != nil {
+		return spatialmath.Pose{}, err
+	}
+	toChain, err := fs.ancestors(to)
+	if err != nil {
+		return spatialmath.Pose{}, err
+	}
+
+	// Strip the common ancestors so that only the frames between from, to and their lowest common ancestor
+	// contribute. This keeps dynamic frames above both ends from blocking the query.
+	for len(fromChain) > 0 && len(toChain) > 0 && fromChain[len(fromChain)-1] == toChain[len(toChain)-1] {
+		fromChain = fromChain[:len(fromChain)-1]
+		toChain = toChain[:len(toChain)-1]
+	}
+	fromInCommon, err := fs.compose(fromChain)
+	if err != nil {
+		return spatialmath.Pose{}, err
+	}
+	toInCommon, err := fs.compose(toChain)
+	if err != nil {
+		return spatialmath.Pose{}, err
+	}
+	return spatialmath.PoseBetween(toInCommon, spatialmath.Compose(fromInCommon, pose)), nil
+}
+
+// TransformPose answers a RobotService.TransformPose request locally.
+func (fs *FrameSystem) TransformPose(req *robotpb.TransformPoseRequest) (*robotpb.TransformPoseResponse, error) {
+	fs, err := fs.With(req.GetSupplementalTransforms()...)
+	if err != nil {
+		return nil, err
+	}
+	dst := orWorld(req.GetDestination())
+	pose, err := fs.Transform(spatialmath.PoseFromProto(req.GetSource().GetPose()), req.GetSource().GetReferenceFrame(), dst)
+	if err != nil {
+		return nil, err
+	}
+	return &robotpb.TransformPoseResponse{
+		Pose: &commonpb.PoseInFrame{ReferenceFrame: dst, Pose: spatialmath.PoseToProto(pose)},
+	}, nil
+}
+
+// GetPose answers a RobotService.GetPose request locally.
+func (fs *FrameSystem) GetPose(req *robotpb.GetPoseRequest) (*robotpb.GetPoseResponse, error) {
+	fs, err := fs.With(req.GetSupplementalTransforms()...)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fs.frames[req.GetComponentName()]; !ok {
+		return nil, &UnknownFrameError{Name: req.GetComponentName()}
+	}
+	dst := orWorld(req.GetDestinationFrame())
+	pose, err := fs.Transform(spatialmath.NewZeroPose(), req.GetComponentName(), dst)
+	if err != nil {
+		return nil, err
+	}
+	return &robotpb.GetPoseResponse{
+		Pose: &commonpb.PoseInFrame{ReferenceFrame: dst, Pose: spatialmath.PoseToProto(pose)},
+	}, nil
+}
+
+func (fs *FrameSystem) add(tf *commonpb.Transform, kinematics *structpb.Struct) error {
+	name := tf.GetReferenceFrame()
+	if _, ok := fs.frames[name]; ok || name == World {
+		return &DuplicateFrameError{Name: name}
+	}
+	fs.frames[name] = &frame{
+		parent:  orWorld(tf.GetPoseInObserverFrame().GetReferenceFrame()),
+		offset:  spatialmath.PoseFromProto(tf.GetPoseInObserverFrame().GetPose()),
+		dynamic: len(kinematics.GetFields()) > 0,
+	}
+	fs.names = append(fs.names, name)
+	return nil
+}
+
+// validate checks that every frame has a parent in the frame system and that every chain of parents ends
+// at World.
+func (fs *FrameSystem) validate() error {
+	reachesWorld := map[string]bool{World: true}
+	for _, name := range fs.names {
+		var path []string
+		onPath := map[string]int{}
+		for cur := name; !reachesWorld[cur]; {
+			if i, ok := onPath[cur]; ok {
+				return &CycleError{Frames: path[i:]}
+			}
+			f := fs.frames[cur]
+			if _, ok := fs.frames[f.parent]; !ok && f.parent != World {
+				return &MissingParentError{Frame: cur, Parent: f.parent}
+			}
+			onPath[cur] = len(path)
+			path = append(path, cur)
+			cur = f.parent
+		}
+		for _, n := range path {
+			reachesWorld[n] = true
+		}
+	}
+	return nil
+}
+
+// ancestors returns name followed by each of its ancestors, ending with World.
+func (fs *FrameSystem) ancestors(name string) ([]string, error) {
+	chain := []string{name}
+	for name != World {
+		f, ok := fs.frames[name]
+		if !ok {
+			return nil, &UnknownFrameError{Name: name}
+		}
+		name = f.parent
+		chain = append(chain, name)
+	}
+	return chain, nil
+}
+
+// compose returns the pose of chain[0] in the parent of the last frame in chain.
+func (fs *FrameSystem) compose(chain []string) (spatialmath.Pose, error) {
+	pose := spatialmath.NewZeroPose()
+	for _, name := range chain {
+		f := fs.frames[name]
+		if f.dynamic {
+			return spatialmath.Pose{}, &DynamicFrameError{Name: name}
+		}
+		pose = spatialmath.Compose(f.offset, pose)
+	}
+	return pose, nil
+}
+
+func orWorld(name string) string {
+	if name == "" {
+		return World
+	}
+	return name
+}
@@ -0,0 +1,182 @@
+package framesystem
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/api/common/spatialmath"
+	commonpb "go.viam.com/api/common/v1"
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+func transform(name, parent string, x, y, z, theta float64) *commonpb.Transform {
+	return &commonpb.Transform{
+		ReferenceFrame: name,
+		PoseInObserverFrame: &commonpb.PoseInFrame{
+			ReferenceFrame: parent,
+			Pose:           &commonpb.Pose{X: x, Y: y, Z: z, OZ: 1, Theta: theta},
+		},
+	}
+}
+
+func config(name, parent string, x, y, z, theta float64) *robotpb.FrameSystemConfig {
+	return &robotpb.FrameSystemConfig{Frame: transform(name, parent, x, y, z, theta)}
+}
+
+// newTestSystem returns world -> base (+10 X, turned 90°) -> camera (+5 Y), and world -> arm, which has
+// kinematics, -> gripper.
+func newTestSystem(t *testing.T) *FrameSystem {
+	t.Helper()
+	kinematics, err := structpb.NewStruct(map[string]any{"links": []any{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	arm := config("arm", "", 0, 0, 100, 0)
+	arm.Kinematics = kinematics
+	fs, err := New([]*robotpb.FrameSystemConfig{
+		config("base", World, 10, 0, 0, 90),
+		config("camera", "base", 0, 5, 0, 0),
+		arm,
+		config("gripper", "arm", 0, 0, 50, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func pointNear(t *testing.T, got spatialmath.Pose, x, y, z float64) {
+	t.Helper()
+	if d := got.Point.Sub(spatialmath.Vector{X: x, Y: y, Z: z}).Norm(); d > 1e-9 {
+		t.Errorf("point = %v, want (%v, %v, %v)", got.Point, x, y, z)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	fs := newTestSystem(t)
+	if got := fs.Frames(); !slices.Equal(got, []string{"base", "camera", "arm", "gripper"}) {
+		t.Errorf("Frames() = %v", got)
+	}
+	if p, err := fs.Parent("arm"); err != nil || p != World {
+		t.Errorf("Parent(arm) = %q, %v", p, err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		from, to string
+		point    spatialmath.Vector
+		want     spatialmath.Vector
+	}{
+		{"camera origin in world", "camera", World, spatialmath.Vector{}, spatialmath.Vector{X: 5}},
+		{"empty names are world", "camera", "", spatialmath.Vector{X: 1}, spatialmath.Vector{X: 5, Y: 1}},
+		{"world origin in camera", World, "camera", spatialmath.Vector{}, spatialmath.Vector{Y: 5}},
+		{"same frame", "base", "base", spatialmath.Vector{X: 1, Y: 2, Z: 3}, spatialmath.Vector{X: 1, Y: 2, Z: 3}},
+		{"below a dynamic frame", "gripper", "arm", spatialmath.Vector{}, spatialmath.Vector{Z: 50}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fs.Transform(spatialmath.NewPose(tc.point, nil), tc.from, tc.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pointNear(t, got, tc.want.X, tc.want.Y, tc.want.Z)
+		})
+	}
+
+	var dynamic *DynamicFrameError
+	if _, err := fs.Transform(spatialmath.NewZeroPose(), "gripper", World); !errors.As(err, &dynamic) || dynamic.Name != "arm" {
+		t.Errorf("gripper in world: %v, want a DynamicFrameError for arm", err)
+	}
+	var unknown *UnknownFrameError
+	if _, err := fs.Transform(spatialmath.NewZeroPose(), "lidar", World); !errors.As(err, &unknown) {
+		t.Errorf("unknown frame: %v, want an UnknownFrameError", err)
+	}
+}
+
+func TestInvalidSystems(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		configs []*robotpb.FrameSystemConfig
+		check   func(error) bool
+	}{
+		{
+			"duplicate",
+			[]*robotpb.FrameSystemConfig{config("a", "", 0, 0, 0, 0), config("a", "", 0, 0, 0, 0)},
+			func(err error) bool { var e *DuplicateFrameError; return errors.As(err, &e) && e.Name == "a" },
+		},
+		{
+			"named world",
+			[]*robotpb.FrameSystemConfig{config(World, "", 0, 0, 0, 0)},
+			func(err error) bool { var e *DuplicateFrameError; return errors.As(err, &e) },
+		},
+		{
+			"missing parent",
+			[]*robotpb.FrameSystemConfig{config("a", "b", 0, 0, 0, 0)},
+			func(err error) bool {
+				var e *MissingParentError
+				return errors.As(err, &e) && e.Frame == "a" && e.Parent == "b"
+			},
+		},
+		{
+			"cycle",
+			[]*robotpb.FrameSystemConfig{config("a", "b", 0, 0, 0, 0), config("b", "a", 0, 0, 0, 0)},
+			func(err error) bool { var e *CycleError; return errors.As(err, &e) && len(e.Frames) == 2 },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := New(tc.configs); !tc.check(err) {
+				t.Errorf("New() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	fs := newTestSystem(t)
+	ext, err := fs.With(transform("marker", "camera", 0, 0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Parent("marker"); err == nil {
+		t.Error("With modified the original frame system")
+	}
+	got, err := ext.Transform(spatialmath.NewZeroPose(), "marker", World)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pointNear(t, got, 5, 0, 1)
+	if _, err := fs.With(transform("camera", "", 0, 0, 0, 0)); err == nil {
+		t.Error("With accepted a duplicate frame")
+	}
+}
+
+func TestRequests(t *testing.T) {
+	fs := newTestSystem(t)
+	resp, err := fs.GetPose(&robotpb.GetPoseRequest{ComponentName: "camera"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pose := resp.GetPose()
+	if pose.GetReferenceFrame() != World || math.Abs(pose.GetPose().GetX()-5) > 1e-9 || math.Abs(pose.GetPose().GetTheta()-90) > 1e-9 {
+		t.Errorf("GetPose(camera) = %v", pose)
+	}
+	if _, err := fs.GetPose(&robotpb.GetPoseRequest{ComponentName: "lidar"}); err == nil {
+		t.Error("GetPose of an unknown component succeeded")
+	}
+
+	tp, err := fs.TransformPose(&robotpb.TransformPoseRequest{
+		Source:                 &commonpb.PoseInFrame{ReferenceFrame: "marker", Pose: &commonpb.Pose{OZ: 1}},
+		Destination:            "base",
+		SupplementalTransforms: []*commonpb.Transform{transform("marker", "camera", 1, 0, 0, 0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := tp.GetPose().GetPose()
+	if tp.GetPose().GetReferenceFrame() != "base" || math.Abs(p.GetX()-1) > 1e-9 || math.Abs(p.GetY()-5) > 1e-9 {
+		t.Errorf("TransformPose = %v", tp.GetPose())
+	}
+}
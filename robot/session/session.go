@@ -0,0 +1,314 @@
+// Package session keeps a RobotService session alive from the client side.
+//
+// A Manager starts a session with StartSession, sends SendSessionHeartbeat often enough to stay within the
+// heartbeat window the machine asked for, and attaches the session id to outgoing calls through gRPC client
+// interceptors. When heartbeats stop getting through for a whole window, or the machine reports the session
+// as expired, the context returned by Manager.Context is cancelled with ErrExpired so that any motion tied
+// to it can stop. The Manager then starts a replacement session, resuming the old one when the machine
+// still knows it.
+package session
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+// MetadataKey is the outgoing gRPC metadata key that carries the session id.
+const MetadataKey = "viam-sid"
+
+// The session calls themselves never carry a session id.
+const (
+	startSessionMethod         = "/viam.robot.v1.RobotService/StartSession"
+	sendSessionHeartbeatMethod = "/viam.robot.v1.RobotService/SendSessionHeartbeat"
+)
+
+// defaultHeartbeatsPerWindow is how many heartbeats are sent per heartbeat window by default, so that a few
+// can be lost before the window lapses.
+const defaultHeartbeatsPerWindow = 5
+
+var (
+	// ErrExpired is the cause of a session context that was cancelled because the session lapsed.
+	ErrExpired = errors.New("session expired")
+	// ErrClosed is the cause of a session context that was cancelled because the Manager was closed.
+	ErrClosed = errors.New("session manager closed")
+)
+
+// Client is the subset of robotpb.RobotServiceClient used by a Manager.
+type Client interface {
+	StartSession(ctx context.Context, in *robotpb.StartSessionRequest, opts ...grpc.CallOption) (*robotpb.StartSessionResponse, error)
+	SendSessionHeartbeat(
+		ctx context.Context, in *robotpb.SendSessionHeartbeatRequest, opts ...grpc.CallOption,
+	) (*robotpb.SendSessionHeartbeatResponse, error)
+}
+
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithHeartbeatsPerWindow sets how many heartbeats are sent per heartbeat window. The default is 5.
+func WithHeartbeatsPerWindow(n int) Option {
+	return func(m *Manager) {
+		if n > 0 {
+			m.perWindow = n
+		}
+	}
+}
+
+// WithOnExpired registers a function called, from the heartbeat goroutine, whenever a session lapses.
+func WithOnExpired(f func(id string, err error)) Option {
+	return func(m *Manager) {
+		m.onExpired = f
+	}
+}
+
+// Manager owns a single session and the goroutine that keeps it alive.
+type Manager struct {
+	perWindow int
+	onExpired func(id string, err error)
+
+	mu      sync.Mutex
+	client  Client
+	id      string
+	window  time.Duration
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	expiry  *time.Timer
+	stop    context.CancelFunc
+	done    chan struct{}
+	closed  bool
+	started bool
+}
+
+// NewManager returns a Manager that has not started a session yet. The Manager is created before the
+// connection so that its interceptors can be installed on it; call Start once the connection is up.
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{perWindow: defaultHeartbeatsPerWindow}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Start starts a session using client and begins sending heartbeats. ctx only bounds the initial
+// StartSession call; heartbeats continue until Close.
+func (m *Manager) Start(ctx context.Context, client Client) error {
+	m.mu.Lock()
+	if m.started {
+		m.mu.Unlock()
+		return errors.New("session manager already started")
+	}
+	m.started, m.client = true, client
+	m.mu.Unlock()
+
+	if err := m.startSession(ctx, ""); err != nil {
+		m.mu.Lock()
+		m.started = false
+		m.mu.Unlock()
+		return err
+	}
+
+	runCtx, stop := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.stop, m.done = stop, make(chan struct{})
+	m.mu.Unlock()
+	go m.run(runCtx)
+	return nil
+}
+
+// ID returns the id of the current session, or an empty string while no session is alive: before Start,
+// from a lapse until a replacement session starts, and after Close. Calls made meanwhile carry no session
+// id.
+func (m *Manager) ID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.id
+}
+
+// Context returns a context that is cancelled when the current session lapses or the Manager is closed.
+// context.Cause reports ErrExpired or ErrClosed respectively. After a lapse a new session is started, and
+// Context must be called again to observe it. Before Start, and after a lapse until the new session starts,
+// there is no session, and the context returned is already cancelled with ErrExpired.
+func (m *Manager) Context() context.Context {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ctx == nil {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(ErrExpired)
+		return ctx
+	}
+	return m.ctx
+}
+
+// Bind returns a copy of parent that is also cancelled when the current session lapses. The returned
+// cancel function must be called to release resources.
+func (m *Manager) Bind(parent context.Context) (context.Context, context.CancelFunc) {
+	sess := m.Context()
+	ctx, cancel := context.WithCancelCause(parent)
+	stop := context.AfterFunc(sess, func() { cancel(context.Cause(sess)) })
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// Close stops the heartbeat goroutine and cancels the session context with ErrClosed. The session itself
+// is left to lapse on the machine.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	stop, done := m.stop, m.done
+	m.endLocked(ErrClosed)
+	m.mu.Unlock()
+
+	if stop != nil {
+		stop()
+		<-done
+	}
+	return nil
+}
+
+// UnaryClientInterceptor attaches the current session id to every unary call other than the session
+// calls themselves.
+func (m *Manager) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		return invoker(m.attach(ctx, method), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor attaches the current session id to every streaming call.
+func (m *Manager) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(m.attach(ctx, method), desc, cc, method, opts...)
+	}
+}
+
+func (m *Manager) attach(ctx context.Context, method string) context.Context {
+	if method == startSessionMethod || method == sendSessionHeartbeatMethod {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx
+	}
+	if id := m.ID(); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	}
+	return ctx
+}
+
+// startSession starts a session, resuming resume if it is not empty, and installs it as current.
+func (m *Manager) startSession(ctx context.Context, resume string) error {
+	resp, err := m.client.StartSession(ctx, &robotpb.StartSessionRequest{Resume: resume})
+	if err != nil {
+		return err
+	}
+	window := resp.GetHeartbeatWindow().AsDuration()
+	if resp.GetId() == "" || window <= 0 {
+		return errors.New("machine returned an invalid session")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.endLocked(ErrExpired)
+	m.id, m.window = resp.GetId(), window
+	m.ctx, m.cancel = context.WithCancelCause(context.Background())
+	id := m.id
+	m.expiry = time.AfterFunc(window, func() { m.lapse(id, ErrExpired) })
+	return nil
+}
+
+// endLocked cancels the current session context, if any, with cause, and forgets the session id.
+func (m *Manager) endLocked(cause error) {
+	m.id = ""
+	if m.expiry != nil {
+		m.expiry.Stop()
+		m.expiry = nil
+	}
+	if m.cancel != nil {
+		m.cancel(cause)
+		m.cancel = nil
+	}
+}
+
+// lapse ends the session id if it is still current.
+func (m *Manager) lapse(id string, cause error) {
+	m.mu.Lock()
+	if m.id != id || m.cancel == nil {
+		m.mu.Unlock()
+		return
+	}
+	m.endLocked(cause)
+	onExpired := m.onExpired
+	m.mu.Unlock()
+	if onExpired != nil {
+		onExpired(id, cause)
+	}
+}
+
+func (m *Manager) run(ctx context.Context) {
+	defer close(m.done)
+	// resume is the id of the last session, kept to ask for it back after it lapses.
+	var resume string
+	for {
+		m.mu.Lock()
+		id, window := m.id, m.window
+		m.mu.Unlock()
+		if id != "" {
+			resume = id
+		}
+		interval := window / time.Duration(m.perWindow)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, interval)
+		if id == "" {
+			// The session lapsed. Ask for it back; the machine starts a new one if it no longer knows it.
+			// Failures are retried on the next tick.
+			//nolint:errcheck
+			m.startSession(callCtx, resume)
+			cancel()
+			continue
+		}
+
+		_, err := m.client.SendSessionHeartbeat(callCtx, &robotpb.SendSessionHeartbeatRequest{Id: id})
+		cancel()
+		switch {
+		case err == nil:
+			m.mu.Lock()
+			if m.id == id && m.expiry != nil {
+				m.expiry.Reset(window)
+			}
+			m.mu.Unlock()
+		case isExpired(err):
+			m.lapse(id, ErrExpired)
+		}
+		// Any other error is treated as transient; the expiry timer fires if it persists for a whole window.
+	}
+}
+
+// isExpired reports whether err is the machine saying the session no longer exists.
+func isExpired(err error) bool {
+	return strings.Contains(status.Convert(err).Message(), "SESSION_EXPIRED")
+}
@@ -0,0 +1,276 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+const window = 100 * time.Millisecond
+
+// fakeClient hands out sessions named s1, s2, ... and answers heartbeats according to heartbeat.
+type fakeClient struct {
+	mu        sync.Mutex
+	n         int
+	resumes   []string
+	beats     int
+	heartbeat func(id string) error
+}
+
+func (c *fakeClient) StartSession(
+	_ context.Context, in *robotpb.StartSessionRequest, _ ...grpc.CallOption,
+) (*robotpb.StartSessionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+	c.resumes = append(c.resumes, in.GetResume())
+	return &robotpb.StartSessionResponse{Id: fmt.Sprintf("s%d", c.n), HeartbeatWindow: durationpb.New(window)}, nil
+}
+
+func (c *fakeClient) SendSessionHeartbeat(
+	_ context.Context, in *robotpb.SendSessionHeartbeatRequest, _ ...grpc.CallOption,
+) (*robotpb.SendSessionHeartbeatResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.beats++
+	if c.heartbeat != nil {
+		if err := c.heartbeat(in.GetId()); err != nil {
+			return nil, err
+		}
+	}
+	return &robotpb.SendSessionHeartbeatResponse{}, nil
+}
+
+func (c *fakeClient) setHeartbeat(f func(id string) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.heartbeat = f
+}
+
+func (c *fakeClient) starts() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.resumes...)
+}
+
+func start(t *testing.T, c Client, opts ...Option) *Manager {
+	t.Helper()
+	m := NewManager(opts...)
+	if err := m.Start(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		m.Close()
+	})
+	return m
+}
+
+// waitFor polls cond until it holds or a few windows pass.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(20 * window)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(window / 20)
+	}
+}
+
+func TestBeforeStart(t *testing.T) {
+	m := NewManager()
+	if id := m.ID(); id != "" {
+		t.Errorf("ID() = %q", id)
+	}
+	ctx := m.Context()
+	if ctx.Err() == nil || !errors.Is(context.Cause(ctx), ErrExpired) {
+		t.Errorf("Context() cause = %v, want ErrExpired", context.Cause(ctx))
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+}
+
+func TestHeartbeatsKeepSessionAlive(t *testing.T) {
+	c := &fakeClient{}
+	m := start(t, c)
+	if m.ID() != "s1" {
+		t.Fatalf("ID() = %q", m.ID())
+	}
+	ctx := m.Context()
+	time.Sleep(3 * window)
+	if ctx.Err() != nil {
+		t.Errorf("session lapsed: %v", context.Cause(ctx))
+	}
+	c.mu.Lock()
+	beats := c.beats
+	c.mu.Unlock()
+	if beats < 5 {
+		t.Errorf("sent %d heartbeats in three windows", beats)
+	}
+	if err := m.Start(context.Background(), c); err == nil {
+		t.Error("second Start() succeeded")
+	}
+}
+
+func TestExpiredSessionIsResumed(t *testing.T) {
+	c := &fakeClient{}
+	var mu sync.Mutex
+	var expired []string
+	m := start(t, c, WithOnExpired(func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		expired = append(expired, id)
+	}))
+	ctx := m.Context()
+
+	// The machine forgets s1; until the replacement starts, no session id is attached.
+	c.setHeartbeat(func(id string) error {
+		if id == "s1" {
+			return status.Error(codes.InvalidArgument, "SESSION_EXPIRED")
+		}
+		return nil
+	})
+	<-ctx.Done()
+	if !errors.Is(context.Cause(ctx), ErrExpired) {
+		t.Errorf("cause = %v, want ErrExpired", context.Cause(ctx))
+	}
+	waitFor(t, "the replacement session", func() bool { return m.ID() == "s2" })
+
+	if starts := c.starts(); len(starts) != 2 || starts[0] != "" || starts[1] != "s1" {
+		t.Errorf("StartSession resumes = %q", starts)
+	}
+	mu.Lock()
+	if len(expired) != 1 || expired[0] != "s1" {
+		t.Errorf("onExpired ids = %q", expired)
+	}
+	mu.Unlock()
+	if m.Context().Err() != nil {
+		t.Error("the replacement session context is already done")
+	}
+}
+
+func TestLostHeartbeatsLapse(t *testing.T) {
+	c := &fakeClient{}
+	m := start(t, c)
+	ctx := m.Context()
+	c.setHeartbeat(func(string) error { return status.Error(codes.Unavailable, "connection lost") })
+	select {
+	case <-ctx.Done():
+	case <-time.After(10 * window):
+		t.Fatal("the session did not lapse")
+	}
+	if !errors.Is(context.Cause(ctx), ErrExpired) {
+		t.Errorf("cause = %v, want ErrExpired", context.Cause(ctx))
+	}
+	if id := m.ID(); id != "" {
+		t.Errorf("ID() after a lapse = %q", id)
+	}
+}
+
+func TestClose(t *testing.T) {
+	m := start(t, &fakeClient{})
+	bound, cancel := m.Bind(context.Background())
+	defer cancel()
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	<-bound.Done()
+	if !errors.Is(context.Cause(bound), ErrClosed) {
+		t.Errorf("bound context cause = %v, want ErrClosed", context.Cause(bound))
+	}
+	if id := m.ID(); id != "" {
+		t.Errorf("ID() after Close = %q", id)
+	}
+}
+
+func TestBindCancel(t *testing.T) {
+	m := start(t, &fakeClient{})
+	bound, cancel := m.Bind(context.Background())
+	cancel()
+	if bound.Err() == nil || m.Context().Err() != nil {
+		t.Errorf("bound = %v, session = %v", bound.Err(), m.Context().Err())
+	}
+}
+
+type invalidClient struct{ fakeClient }
+
+func (c *invalidClient) StartSession(
+	context.Context, *robotpb.StartSessionRequest, ...grpc.CallOption,
+) (*robotpb.StartSessionResponse, error) {
+	return &robotpb.StartSessionResponse{Id: "s"}, nil
+}
+
+func TestStartErrors(t *testing.T) {
+	m := NewManager()
+	if err := m.Start(context.Background(), &invalidClient{}); err == nil {
+		t.Fatal("Start() accepted a session without a heartbeat window")
+	}
+	// A failed Start can be retried.
+	if err := m.Start(context.Background(), &fakeClient{}); err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	m.Close()
+}
+
+func TestAttach(t *testing.T) {
+	m := start(t, &fakeClient{})
+	for _, tc := range []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   []string
+	}{
+		{"call", context.Background(), "/viam.robot.v1.RobotService/GetStatus", []string{"s1"}},
+		{"start session", context.Background(), startSessionMethod, nil},
+		{"heartbeat", context.Background(), sendSessionHeartbeatMethod, nil},
+		{
+			"explicit id",
+			metadata.AppendToOutgoingContext(context.Background(), MetadataKey, "other"),
+			"/viam.robot.v1.RobotService/GetStatus",
+			[]string{"other"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			err := m.UnaryClientInterceptor()(tc.ctx, tc.method, nil, nil, nil,
+				func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					got = md.Get(MetadataKey)
+					return nil
+				})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("session ids = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	//nolint:errcheck
+	m.Close()
+	_, err := m.StreamClientInterceptor()(context.Background(), nil, nil, "/viam.robot.v1.RobotService/StreamStatus",
+		func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+			if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(MetadataKey)) > 0 {
+				t.Errorf("closed manager attached %q", md.Get(MetadataKey))
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
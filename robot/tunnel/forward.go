@@ -0,0 +1,156 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+type options struct {
+	onError func(error)
+}
+
+// Option configures a port forward.
+type Option func(*options)
+
+// WithOnError registers a function called with the error that ended a forwarded connection, if any. It is
+// called from the goroutine serving the connection.
+func WithOnError(f func(error)) Option {
+	return func(o *options) {
+		o.onError = f
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{onError: func(error) {}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// ForwardLocalPort listens on the local TCP address localAddr and forwards every connection accepted to
+// remotePort on the machine, through a tunnel of its own. It returns once ctx is done and the forwarded
+// connections, which end with it, are closed.
+func ForwardLocalPort(ctx context.Context, client Client, localAddr string, remotePort uint32, opts ...Option) error {
+	var lc net.ListenConfig
+	l, err := lc.Listen(ctx, "tcp", localAddr)
+	if err != nil {
+		return err
+	}
+	return Forward(ctx, client, l, remotePort, opts...)
+}
+
+// Forward is ForwardLocalPort with a listener of the caller's, which it closes. Before accepting any
+// connection, it checks that the machine lists remotePort among its tunnels, unless the machine does not
+// implement ListTunnels.
+func Forward(ctx context.Context, client Client, l net.Listener, remotePort uint32, opts ...Option) error {
+	o := newOptions(opts)
+	var conns sync.WaitGroup
+	defer conns.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		//nolint:errcheck
+		l.Close()
+	}()
+
+	if err := checkAllowed(ctx, client, remotePort); err != nil {
+		return err
+	}
+	for {
+		local, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			if err := forwardConn(ctx, client, local, remotePort); err != nil {
+				o.onError(err)
+			}
+		}()
+	}
+}
+
+func checkAllowed(ctx context.Context, client Client, port uint32) error {
+	resp, err := client.ListTunnels(ctx, &robotpb.ListTunnelsRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, t := range resp.GetTunnels() {
+		if t.GetPort() == port {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "tunnel: port %d is not allowed by the machine", port)
+}
+
+func forwardConn(ctx context.Context, client Client, local net.Conn, port uint32) error {
+	//nolint:errcheck
+	defer local.Close()
+	remote, err := Dial(ctx, client, port)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer remote.Close()
+	if err := join(local, remote); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// join copies data both ways between a and b until both directions have ended, half-closing each side as
+// the other finishes writing to it. If a direction fails, both connections are closed.
+func join(a, b net.Conn) error {
+	errs := make(chan error, 2)
+	copyTo := func(dst, src net.Conn) {
+		_, err := io.Copy(dst, src)
+		if err == nil {
+			err = closeWrite(dst)
+		}
+		errs <- err
+	}
+	go copyTo(a, b)
+	go copyTo(b, a)
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			//nolint:errcheck
+			a.Close()
+			//nolint:errcheck
+			b.Close()
+			if i == 0 {
+				<-errs
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// closeWrite half-closes c if it supports it, as TCP connections and Conn do.
+func closeWrite(c net.Conn) error {
+	cw, ok := c.(interface{ CloseWrite() error })
+	if !ok {
+		return nil
+	}
+	if err := cw.CloseWrite(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	apppb "go.viam.com/api/app/v1"
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+// DefaultConnectionTimeout is how long dialing a destination may take when its tunnel sets no timeout.
+const DefaultConnectionTimeout = 10 * time.Second
+
+// Handler serves the Tunnel and ListTunnels methods for a set of allowed tunnels, dialing destinations on
+// localhost. A RobotServiceServer implements those methods by calling a Handler.
+type Handler struct {
+	tunnels []*robotpb.Tunnel
+	timeout map[uint32]time.Duration
+}
+
+// NewHandler returns a Handler allowing the given tunnels.
+func NewHandler(tunnels []*robotpb.Tunnel) *Handler {
+	h := &Handler{tunnels: tunnels, timeout: make(map[uint32]time.Duration, len(tunnels))}
+	for _, t := range tunnels {
+		timeout := DefaultConnectionTimeout
+		if t.GetConnectionTimeout() != nil {
+			timeout = t.GetConnectionTimeout().AsDuration()
+		}
+		h.timeout[t.GetPort()] = timeout
+	}
+	return h
+}
+
+// TunnelsFromConfig returns the tunnels described by the traffic tunnel endpoints of a machine's network
+// configuration. Endpoints with ports out of range are left out.
+func TunnelsFromConfig(endpoints []*apppb.TrafficTunnelEndpoint) []*robotpb.Tunnel {
+	tunnels := make([]*robotpb.Tunnel, 0, len(endpoints))
+	for _, e := range endpoints {
+		if e.GetPort() <= 0 || e.GetPort() > 65535 {
+			continue
+		}
+		t := &robotpb.Tunnel{Port: uint32(e.GetPort())}
+		if d := e.GetConnectionTimeout(); d != nil {
+			t.ConnectionTimeout = durationpb.New(d.AsDuration())
+		}
+		tunnels = append(tunnels, t)
+	}
+	return tunnels
+}
+
+// ListTunnels lists the allowed tunnels.
+func (h *Handler) ListTunnels(ctx context.Context, req *robotpb.ListTunnelsRequest) (*robotpb.ListTunnelsResponse, error) {
+	return &robotpb.ListTunnelsResponse{Tunnels: h.tunnels}, nil
+}
+
+// Tunnel dials the destination port named by the first request, failing with PermissionDenied if no
+// tunnel allows it and with Unavailable if it cannot be dialed within the tunnel's connection timeout.
+// It then copies data both ways, and returns once the destination has closed its side of the connection.
+func (h *Handler) Tunnel(stream robotpb.RobotService_TunnelServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	port := req.GetDestinationPort()
+	timeout, ok := h.timeout[port]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "tunnel: port %d is not allowed", port)
+	}
+	ctx := stream.Context()
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(dialCtx, "tcp", net.JoinHostPort("localhost", strconv.Itoa(int(port))))
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Unavailable, "tunnel: %v", err)
+	}
+	//nolint:errcheck
+	defer conn.Close()
+
+	if len(req.GetData()) > 0 {
+		if _, err := conn.Write(req.GetData()); err != nil {
+			return status.Errorf(codes.Unavailable, "tunnel: %v", err)
+		}
+	}
+	// The input is copied until the client closes its side of the stream, or the stream ends when this
+	// method returns.
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					//nolint:errcheck
+					closeWrite(conn)
+				} else {
+					//nolint:errcheck
+					conn.Close()
+				}
+				return
+			}
+			if _, err := conn.Write(req.GetData()); err != nil {
+				//nolint:errcheck
+				conn.Close()
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, maxChunkSize)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&robotpb.TunnelResponse{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return status.Errorf(codes.Unavailable, "tunnel: %v", err)
+		}
+	}
+}
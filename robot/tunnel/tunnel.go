@@ -0,0 +1,318 @@
+// Package tunnel carries TCP connections over RobotService.Tunnel.
+//
+// Each connection is one Tunnel stream. The first TunnelRequest names the destination port on the machine,
+// and later requests carry the data written by the client; the machine streams what the destination
+// writes back in TunnelResponse messages. The client half-closes a connection by closing its side of the
+// stream, and the machine ends the stream once the destination has closed its side, which ends the whole
+// connection: gRPC gives the machine no way to stop sending while still receiving.
+//
+// On the client side, Dial opens a connection as a net.Conn, and ForwardLocalPort serves a local TCP port
+// by opening one tunnel per accepted connection. On the machine side, a Handler dials the destinations of
+// the tunnels allowed by the machine's configuration.
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+// maxChunkSize is the largest amount of data sent in a single message.
+const maxChunkSize = 32 << 10
+
+// Client is the subset of robotpb.RobotServiceClient used to open tunnels.
+type Client interface {
+	Tunnel(ctx context.Context, opts ...grpc.CallOption) (robotpb.RobotService_TunnelClient, error)
+	ListTunnels(ctx context.Context, in *robotpb.ListTunnelsRequest, opts ...grpc.CallOption) (*robotpb.ListTunnelsResponse, error)
+}
+
+// Addr is the address of an end of a tunnel.
+type Addr struct {
+	// Port is the destination port on the machine, or 0 for the client end.
+	Port uint32
+}
+
+// Network returns "tunnel".
+func (a Addr) Network() string {
+	return "tunnel"
+}
+
+func (a Addr) String() string {
+	return fmt.Sprintf("tunnel:%d", a.Port)
+}
+
+// Conn is a connection to a port on the machine, carried by a Tunnel stream. It supports deadlines and,
+// with CloseWrite, half-closing.
+//
+// A deadline cannot interrupt a message that is already being sent, so after a Write times out some of
+// the data it did not report as written may still reach the destination.
+type Conn struct {
+	stream robotpb.RobotService_TunnelClient
+	cancel context.CancelFunc
+	port   uint32
+
+	// sending holds a token while a message is being sent, since a stream only allows one sender.
+	sending     chan struct{}
+	writeClosed bool
+
+	readMu  sync.Mutex
+	data    chan []byte
+	pending []byte
+	// recvErr is the error that ended the stream, set before data is closed.
+	recvErr error
+
+	readDeadline  deadline
+	writeDeadline deadline
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Dial opens a tunnel to the given port on the machine. The connection lasts until it is closed or ctx is
+// done. Whether the machine allows the port is only known once the connection is used: the first Read or
+// Write then fails with the machine's error.
+func Dial(ctx context.Context, client Client, port uint32) (*Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := client.Tunnel(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := stream.Send(&robotpb.TunnelRequest{DestinationPort: port}); err != nil {
+		if errors.Is(err, io.EOF) {
+			_, err = stream.Recv()
+		}
+		cancel()
+		return nil, err
+	}
+	c := &Conn{
+		stream:        stream,
+		cancel:        cancel,
+		port:          port,
+		sending:       make(chan struct{}, 1),
+		data:          make(chan []byte),
+		readDeadline:  makeDeadline(),
+		writeDeadline: makeDeadline(),
+		closed:        make(chan struct{}),
+	}
+	go c.receive()
+	return c, nil
+}
+
+func (c *Conn) receive() {
+	for {
+		resp, err := c.stream.Recv()
+		if err != nil {
+			c.recvErr = err
+			close(c.data)
+			return
+		}
+		if len(resp.GetData()) == 0 {
+			continue
+		}
+		select {
+		case c.data <- resp.GetData():
+		case <-c.closed:
+			c.recvErr = net.ErrClosed
+			close(c.data)
+			return
+		}
+	}
+}
+
+// Read reads data sent by the destination. It returns io.EOF once the machine has ended the stream.
+func (c *Conn) Read(b []byte) (int, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	if c.isClosed() {
+		return 0, net.ErrClosed
+	}
+	if len(c.pending) == 0 {
+		select {
+		case data, ok := <-c.data:
+			if !ok {
+				if c.isClosed() {
+					return 0, net.ErrClosed
+				}
+				return 0, c.recvErr
+			}
+			c.pending = data
+		case <-c.readDeadline.wait():
+			return 0, os.ErrDeadlineExceeded
+		case <-c.closed:
+			return 0, net.ErrClosed
+		}
+	}
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// Write sends b to the destination, in messages of at most 32 KiB.
+func (c *Conn) Write(b []byte) (int, error) {
+	var n int
+	for len(b) > 0 {
+		chunk := b[:min(len(b), maxChunkSize)]
+		if err := c.send(chunk); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		b = b[len(chunk):]
+	}
+	return n, nil
+}
+
+func (c *Conn) send(chunk []byte) error {
+	deadline := c.writeDeadline.wait()
+	select {
+	case c.sending <- struct{}{}:
+	case <-deadline:
+		return os.ErrDeadlineExceeded
+	case <-c.closed:
+		return net.ErrClosed
+	}
+	if c.writeClosed {
+		<-c.sending
+		return net.ErrClosed
+	}
+	// The message is sent from another goroutine so that the deadline can be honored. It keeps the token
+	// until the message is sent, so that messages stay in order. It sends a copy of the chunk, since Write
+	// may return before the message is sent.
+	data := slices.Clone(chunk)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.stream.Send(&robotpb.TunnelRequest{Data: data})
+		<-c.sending
+	}()
+	select {
+	case err := <-done:
+		if errors.Is(err, io.EOF) {
+			// The stream has ended, and why is reported by Read.
+			return io.ErrClosedPipe
+		}
+		return err
+	case <-deadline:
+		return os.ErrDeadlineExceeded
+	case <-c.closed:
+		return net.ErrClosed
+	}
+}
+
+// CloseWrite closes the client's side of the connection: the destination reads the end of its input,
+// and the connection can still be read.
+func (c *Conn) CloseWrite() error {
+	select {
+	case c.sending <- struct{}{}:
+	case <-c.closed:
+		return net.ErrClosed
+	}
+	defer func() { <-c.sending }()
+	if c.writeClosed {
+		return nil
+	}
+	c.writeClosed = true
+	return c.stream.CloseSend()
+}
+
+// Close ends the connection by cancelling the stream.
+func (c *Conn) Close() error {
+	err := net.ErrClosed
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.cancel()
+		err = nil
+	})
+	return err
+}
+
+func (c *Conn) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// LocalAddr returns the client end of the tunnel.
+func (c *Conn) LocalAddr() net.Addr {
+	return Addr{}
+}
+
+// RemoteAddr returns the destination port of the tunnel.
+func (c *Conn) RemoteAddr() net.Addr {
+	return Addr{Port: c.port}
+}
+
+// SetDeadline sets the read and write deadlines.
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	c.writeDeadline.set(t)
+	return nil
+}
+
+// SetReadDeadline sets the deadline of pending and future Read calls. A zero value means no deadline.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	return nil
+}
+
+// SetWriteDeadline sets the deadline of pending and future Write calls. A zero value means no deadline.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.writeDeadline.set(t)
+	return nil
+}
+
+// deadline is a channel closed when a deadline passes, which is replaced when the deadline is moved after
+// passing.
+type deadline struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	expired chan struct{}
+}
+
+func makeDeadline() deadline {
+	return deadline{expired: make(chan struct{})}
+}
+
+func (d *deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil && !d.timer.Stop() {
+		// The timer has fired, or is firing, and closes the current channel.
+		<-d.expired
+	}
+	d.timer = nil
+
+	select {
+	case <-d.expired:
+		d.expired = make(chan struct{})
+	default:
+	}
+	if t.IsZero() {
+		return
+	}
+	wait := time.Until(t)
+	if wait <= 0 {
+		close(d.expired)
+		return
+	}
+	expired := d.expired
+	d.timer = time.AfterFunc(wait, func() { close(expired) })
+}
+
+func (d *deadline) wait() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.expired
+}
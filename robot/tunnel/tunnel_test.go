@@ -0,0 +1,414 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	apppb "go.viam.com/api/app/v1"
+	robotpb "go.viam.com/api/robot/v1"
+)
+
+type robotServer struct {
+	robotpb.UnimplementedRobotServiceServer
+	h *Handler
+}
+
+func (s *robotServer) Tunnel(stream robotpb.RobotService_TunnelServer) error {
+	return s.h.Tunnel(stream)
+}
+
+func (s *robotServer) ListTunnels(ctx context.Context, req *robotpb.ListTunnelsRequest) (*robotpb.ListTunnelsResponse, error) {
+	return s.h.ListTunnels(ctx, req)
+}
+
+// serve returns a client of a machine allowing tunnels to ports, or one that does not implement tunnels
+// if ports is nil.
+func serve(t *testing.T, ports ...uint32) robotpb.RobotServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	if ports == nil {
+		robotpb.RegisterRobotServiceServer(srv, robotpb.UnimplementedRobotServiceServer{})
+	} else {
+		var tunnels []*robotpb.Tunnel
+		for _, p := range ports {
+			tunnels = append(tunnels, &robotpb.Tunnel{Port: p, ConnectionTimeout: durationpb.New(time.Second)})
+		}
+		robotpb.RegisterRobotServiceServer(srv, &robotServer{h: NewHandler(tunnels)})
+	}
+	go func() {
+		//nolint:errcheck
+		srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		conn.Close()
+	})
+	return robotpb.NewRobotServiceClient(conn)
+}
+
+// upperServer listens on localhost and, for each connection, reads until the end of its input, then
+// writes it back in upper case and closes the connection.
+func upperServer(t *testing.T) uint32 {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		l.Close()
+	})
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				//nolint:errcheck
+				defer c.Close()
+				data, err := io.ReadAll(c)
+				if err == nil {
+					//nolint:errcheck
+					c.Write(bytes.ToUpper(data))
+				}
+			}()
+		}
+	}()
+	return uint32(l.Addr().(*net.TCPAddr).Port)
+}
+
+// unusedPort returns a localhost port that nothing listens on.
+func unusedPort(t *testing.T) uint32 {
+	t.Helper()
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func TestDial(t *testing.T) {
+	port := upperServer(t)
+	client := serve(t, port)
+	c, err := Dial(context.Background(), client, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer c.Close()
+
+	// Writes larger than a message are split.
+	data := bytes.Repeat([]byte("abcdefgh"), maxChunkSize/4)
+	if n, err := c.Write(data); err != nil || n != len(data) {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	if err := c.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CloseWrite(); err != nil {
+		t.Errorf("second CloseWrite = %v", err)
+	}
+	if _, err := c.Write([]byte("x")); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Write after CloseWrite = %v", err)
+	}
+	got, err := io.ReadAll(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, bytes.ToUpper(data)) {
+		t.Errorf("read %d bytes, want %d", len(got), len(data))
+	}
+	if c.RemoteAddr().String() != (Addr{Port: port}).String() || c.LocalAddr().Network() != "tunnel" {
+		t.Errorf("addresses = %v, %v", c.LocalAddr(), c.RemoteAddr())
+	}
+}
+
+func TestDialErrors(t *testing.T) {
+	port, closed := upperServer(t), unusedPort(t)
+	client := serve(t, port, closed)
+	for _, tc := range []struct {
+		name string
+		port uint32
+		code codes.Code
+	}{
+		{"not allowed", port + 1, codes.PermissionDenied},
+		{"nothing listening", closed, codes.Unavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Dial(context.Background(), client, tc.port)
+			if err != nil {
+				t.Fatal(err)
+			}
+			//nolint:errcheck
+			defer c.Close()
+			if _, err := c.Read(make([]byte, 1)); status.Code(err) != tc.code {
+				t.Errorf("Read = %v, want code %v", err, tc.code)
+			}
+		})
+	}
+}
+
+func TestDeadlinesAndClose(t *testing.T) {
+	port := upperServer(t)
+	c, err := Dial(context.Background(), serve(t, port), port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server answers only once the input ends, so reads wait.
+	if err := c.SetReadDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Read(make([]byte, 1)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read = %v, want a timeout", err)
+	}
+	// Moving the deadline makes reads wait again.
+	if err := c.SetDeadline(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetReadDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetWriteDeadline(time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Write([]byte("x")); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Write past its deadline = %v", err)
+	}
+	if err := c.SetWriteDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	read := make(chan error, 1)
+	go func() {
+		_, err := c.Read(make([]byte, 1))
+		read <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-read; !errors.Is(err, net.ErrClosed) {
+		t.Errorf("pending Read = %v", err)
+	}
+	if err := c.Close(); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("second Close = %v", err)
+	}
+	if _, err := c.Write([]byte("x")); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Write after Close = %v", err)
+	}
+}
+
+// recordingStream is a tunnel stream that records the requests sent, and sends nothing back until it is
+// closed.
+type recordingStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	mu   sync.Mutex
+	sent []*robotpb.TunnelRequest
+	// block, if set, holds up Send until it is closed.
+	block chan struct{}
+}
+
+func (s *recordingStream) Send(req *robotpb.TunnelRequest) error {
+	if s.block != nil && req.GetDestinationPort() == 0 {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func (s *recordingStream) Recv() (*robotpb.TunnelResponse, error) {
+	<-s.ctx.Done()
+	return nil, status.FromContextError(s.ctx.Err()).Err()
+}
+
+func (s *recordingStream) data() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out [][]byte
+	for _, req := range s.sent[1:] {
+		out = append(out, req.GetData())
+	}
+	return out
+}
+
+type fakeClient struct {
+	stream *recordingStream
+}
+
+func (f *fakeClient) Tunnel(ctx context.Context, _ ...grpc.CallOption) (robotpb.RobotService_TunnelClient, error) {
+	f.stream.ctx = ctx
+	return f.stream, nil
+}
+
+func (f *fakeClient) ListTunnels(context.Context, *robotpb.ListTunnelsRequest, ...grpc.CallOption) (*robotpb.ListTunnelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "")
+}
+
+func TestWriteClonesChunks(t *testing.T) {
+	stream := &recordingStream{}
+	c, err := Dial(context.Background(), &fakeClient{stream: stream}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer c.Close()
+	buf := []byte("first")
+	if _, err := c.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	// The caller may reuse its buffer as soon as Write returns, even if the stream holds on to messages.
+	copy(buf, "reuse")
+	if _, err := c.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := stream.data(); len(got) != 2 || string(got[0]) != "first" || string(got[1]) != "reuse" {
+		t.Errorf("sent %q", got)
+	}
+}
+
+func TestWriteDeadlineWhileSending(t *testing.T) {
+	stream := &recordingStream{block: make(chan struct{})}
+	c, err := Dial(context.Background(), &fakeClient{stream: stream}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer c.Close()
+	if err := c.SetWriteDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	buf := []byte("blocked")
+	if _, err := c.Write(buf); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("Write = %v, want a timeout", err)
+	}
+	copy(buf, "changed")
+	// A later write waits for the message in flight, which is still sent whole and in order.
+	if err := c.SetWriteDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	close(stream.block)
+	if _, err := c.Write([]byte("next")); err != nil {
+		t.Fatal(err)
+	}
+	if got := stream.data(); !slices.EqualFunc(got, []string{"blocked", "next"}, func(b []byte, s string) bool { return string(b) == s }) {
+		t.Errorf("sent %q", got)
+	}
+}
+
+func TestForward(t *testing.T) {
+	port := upperServer(t)
+	client := serve(t, port)
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var errs []error
+	var mu sync.Mutex
+	done := make(chan error, 1)
+	go func() {
+		done <- Forward(ctx, client, l, port, WithOnError(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}))
+	}()
+
+	for _, msg := range []string{"one", "two"} {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(conn, msg); err != nil {
+			t.Fatal(err)
+		}
+		if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(conn)
+		if err != nil || string(got) != string(bytes.ToUpper([]byte(msg))) {
+			t.Errorf("read %q, %v", got, err)
+		}
+		//nolint:errcheck
+		conn.Close()
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Forward = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 0 {
+		t.Errorf("connection errors: %v", errs)
+	}
+}
+
+func TestForwardChecksPort(t *testing.T) {
+	port := upperServer(t)
+	if err := ForwardLocalPort(context.Background(), serve(t, port), "localhost:0", port+1); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ForwardLocalPort = %v", err)
+	}
+
+	// A machine that cannot list its tunnels is not checked.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ForwardLocalPort(ctx, serve(t), "localhost:0", port); err != nil {
+		t.Errorf("ForwardLocalPort = %v", err)
+	}
+}
+
+func TestTunnelsFromConfig(t *testing.T) {
+	got := TunnelsFromConfig([]*apppb.TrafficTunnelEndpoint{
+		{Port: 8080},
+		{Port: 0},
+		{Port: 70000},
+		{Port: 22, ConnectionTimeout: durationpb.New(time.Minute)},
+	})
+	if len(got) != 2 || got[0].GetPort() != 8080 || got[0].GetConnectionTimeout() != nil ||
+		got[1].GetPort() != 22 || got[1].GetConnectionTimeout().AsDuration() != time.Minute {
+		t.Errorf("TunnelsFromConfig = %v", got)
+	}
+
+	h := NewHandler(got)
+	if h.timeout[8080] != DefaultConnectionTimeout || h.timeout[22] != time.Minute {
+		t.Errorf("timeouts = %v", h.timeout)
+	}
+	resp, err := h.ListTunnels(context.Background(), &robotpb.ListTunnelsRequest{})
+	if err != nil || len(resp.GetTunnels()) != 2 {
+		t.Errorf("ListTunnels = %v, %v", resp, err)
+	}
+}
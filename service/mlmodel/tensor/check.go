@@ -0,0 +1,107 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	mlmodelpb "go.viam.com/api/service/mlmodel/v1"
+)
+
+// dataTypeAliases maps alternative spellings found in model metadata to the canonical data types.
+var dataTypeAliases = map[string]DataType{
+	"float":  Float32,
+	"double": Float64,
+}
+
+// ParseDataType normalizes a TensorInfo.data_type value.
+func ParseDataType(s string) DataType {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if dt, ok := dataTypeAliases[s]; ok {
+		return dt
+	}
+	return DataType(s)
+}
+
+// Check validates t against info: its data must match its shape, its data type must match
+// info.data_type when one is declared, and its shape must match info.shape when one is declared.
+func Check(t *mlmodelpb.FlatTensor, info *mlmodelpb.TensorInfo) error {
+	name := info.GetName()
+	if err := Validate(t); err != nil {
+		var se *ShapeError
+		if errors.As(err, &se) {
+			se.Name = name
+			return se
+		}
+		return fmt.Errorf("%s%w", prefix(name), err)
+	}
+
+	if want := ParseDataType(info.GetDataType()); want != "" {
+		got, err := TypeOf(t)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return &DataTypeError{Name: name, Want: want, Got: got}
+		}
+	}
+
+	if want := info.GetShape(); len(want) > 0 {
+		got := Shape(t)
+		mismatch := len(want) != len(got)
+		for i := 0; !mismatch && i < len(want); i++ {
+			mismatch = want[i] != -1 && int(want[i]) != got[i]
+		}
+		if mismatch {
+			return &ShapeMismatchError{Name: name, Want: want, Got: got}
+		}
+	}
+	return nil
+}
+
+// CheckInputs validates tensors against the input_info of md. Every problem found is reported, joined
+// with errors.Join, so that callers can see all mismatches at once.
+func CheckInputs(tensors *mlmodelpb.FlatTensors, md *mlmodelpb.Metadata) error {
+	return checkAll(tensors, md.GetInputInfo())
+}
+
+// CheckOutputs validates tensors against the output_info of md.
+func CheckOutputs(tensors *mlmodelpb.FlatTensors, md *mlmodelpb.Metadata) error {
+	return checkAll(tensors, md.GetOutputInfo())
+}
+
+func checkAll(tensors *mlmodelpb.FlatTensors, infos []*mlmodelpb.TensorInfo) error {
+	var errs []error
+	declared := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		declared[info.GetName()] = true
+		t, ok := tensors.GetTensors()[info.GetName()]
+		if !ok {
+			errs = append(errs, &MissingTensorError{Name: info.GetName()})
+			continue
+		}
+		if err := Check(t, info); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(tensors.GetTensors())) {
+		if !declared[name] {
+			errs = append(errs, &UnexpectedTensorError{Name: name})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewInferRequest builds an InferRequest for the named model service after checking tensors against md.
+// md may be nil to skip the check.
+func NewInferRequest(name string, tensors map[string]*mlmodelpb.FlatTensor, md *mlmodelpb.Metadata) (*mlmodelpb.InferRequest, error) {
+	inputs := &mlmodelpb.FlatTensors{Tensors: tensors}
+	if md != nil {
+		if err := CheckInputs(inputs, md); err != nil {
+			return nil, err
+		}
+	}
+	return &mlmodelpb.InferRequest{Name: name, InputTensors: inputs}, nil
+}
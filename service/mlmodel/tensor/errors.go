@@ -0,0 +1,73 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNoData = errors.New("tensor has no data")
+
+// ShapeError is returned when the amount of data in a tensor does not match its shape.
+type ShapeError struct {
+	Name     string
+	Shape    []int
+	Elements int
+	// Packed is set for 16-bit tensors, where Elements counts both halves of every fixed32 entry.
+	Packed bool
+}
+
+func (e *ShapeError) Error() string {
+	what := fmt.Sprintf("%d elements", e.Elements)
+	if e.Packed {
+		what = fmt.Sprintf("%d packed 16-bit slots", e.Elements)
+	}
+	return fmt.Sprintf("%sshape %v does not match %s", prefix(e.Name), e.Shape, what)
+}
+
+// DataTypeError is returned when a tensor holds a different data type than expected.
+type DataTypeError struct {
+	Name string
+	Want DataType
+	Got  DataType
+}
+
+func (e *DataTypeError) Error() string {
+	return fmt.Sprintf("%sexpected data type %s, got %s", prefix(e.Name), e.Want, e.Got)
+}
+
+// ShapeMismatchError is returned when a tensor's shape does not match the shape declared by its TensorInfo.
+// A -1 extent in Want matches any extent.
+type ShapeMismatchError struct {
+	Name string
+	Want []int32
+	Got  []int
+}
+
+func (e *ShapeMismatchError) Error() string {
+	return fmt.Sprintf("%sexpected shape %v, got %v", prefix(e.Name), e.Want, e.Got)
+}
+
+// MissingTensorError is returned when a tensor declared by the model metadata is not provided.
+type MissingTensorError struct {
+	Name string
+}
+
+func (e *MissingTensorError) Error() string {
+	return fmt.Sprintf("tensor %q is required by the model but was not provided", e.Name)
+}
+
+// UnexpectedTensorError is returned when a tensor is provided that the model metadata does not declare.
+type UnexpectedTensorError struct {
+	Name string
+}
+
+func (e *UnexpectedTensorError) Error() string {
+	return fmt.Sprintf("tensor %q is not an input of the model", e.Name)
+}
+
+func prefix(name string) string {
+	if name == "" {
+		return "tensor: "
+	}
+	return fmt.Sprintf("tensor %q: ", name)
+}
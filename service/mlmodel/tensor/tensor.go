@@ -0,0 +1,305 @@
+// Package tensor converts between mlmodel.v1.FlatTensor values and Go slices, and checks tensors against
+// the TensorInfo entries of a model's Metadata before they are sent.
+//
+// FlatTensor packs int16 and uint16 data two to a fixed32 entry, little-endian, and stores int8 and uint8
+// data as bytes. The helpers here hide that packing and always derive the number of elements from the
+// tensor's shape.
+package tensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	mlmodelpb "go.viam.com/api/service/mlmodel/v1"
+)
+
+// Number is the set of Go element types a FlatTensor can hold.
+type Number interface {
+	int8 | uint8 | int16 | uint16 | int32 | uint32 | int64 | uint64 | float32 | float64
+}
+
+// DataType names a tensor element type as it appears in TensorInfo.data_type.
+type DataType string
+
+// The data types a FlatTensor can hold.
+const (
+	Int8    DataType = "int8"
+	Uint8   DataType = "uint8"
+	Int16   DataType = "int16"
+	Uint16  DataType = "uint16"
+	Int32   DataType = "int32"
+	Uint32  DataType = "uint32"
+	Int64   DataType = "int64"
+	Uint64  DataType = "uint64"
+	Float32 DataType = "float32"
+	Float64 DataType = "float64"
+)
+
+// New returns a FlatTensor holding data with the given shape. The product of shape must equal len(data).
+func New[T Number](shape []int, data []T) (*mlmodelpb.FlatTensor, error) {
+	n, err := elements(shape)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(data) {
+		return nil, &ShapeError{Shape: shape, Elements: len(data)}
+	}
+	t := &mlmodelpb.FlatTensor{Shape: make([]uint64, len(shape))}
+	for i, d := range shape {
+		t.Shape[i] = uint64(d)
+	}
+
+	switch d := any(data).(type) {
+	case []int8:
+		b := make([]byte, len(d))
+		for i, v := range d {
+			b[i] = byte(v)
+		}
+		t.Tensor = &mlmodelpb.FlatTensor_Int8Tensor{Int8Tensor: &mlmodelpb.FlatTensorDataInt8{Data: b}}
+	case []uint8:
+		t.Tensor = &mlmodelpb.FlatTensor_Uint8Tensor{Uint8Tensor: &mlmodelpb.FlatTensorDataUInt8{Data: d}}
+	case []int16:
+		u := make([]uint16, len(d))
+		for i, v := range d {
+			u[i] = uint16(v)
+		}
+		t.Tensor = &mlmodelpb.FlatTensor_Int16Tensor{Int16Tensor: &mlmodelpb.FlatTensorDataInt16{Data: pack16(u)}}
+	case []uint16:
+		t.Tensor = &mlmodelpb.FlatTensor_Uint16Tensor{Uint16Tensor: &mlmodelpb.FlatTensorDataUInt16{Data: pack16(d)}}
+	case []int32:
+		t.Tensor = &mlmodelpb.FlatTensor_Int32Tensor{Int32Tensor: &mlmodelpb.FlatTensorDataInt32{Data: d}}
+	case []uint32:
+		t.Tensor = &mlmodelpb.FlatTensor_Uint32Tensor{Uint32Tensor: &mlmodelpb.FlatTensorDataUInt32{Data: d}}
+	case []int64:
+		t.Tensor = &mlmodelpb.FlatTensor_Int64Tensor{Int64Tensor: &mlmodelpb.FlatTensorDataInt64{Data: d}}
+	case []uint64:
+		t.Tensor = &mlmodelpb.FlatTensor_Uint64Tensor{Uint64Tensor: &mlmodelpb.FlatTensorDataUInt64{Data: d}}
+	case []float32:
+		t.Tensor = &mlmodelpb.FlatTensor_FloatTensor{FloatTensor: &mlmodelpb.FlatTensorDataFloat{Data: d}}
+	case []float64:
+		t.Tensor = &mlmodelpb.FlatTensor_DoubleTensor{DoubleTensor: &mlmodelpb.FlatTensorDataDouble{Data: d}}
+	}
+	return t, nil
+}
+
+// Values returns the elements of t. T must match the data type of t exactly.
+func Values[T Number](t *mlmodelpb.FlatTensor) ([]T, error) {
+	vals, err := decode(t)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := vals.([]T)
+	if !ok {
+		dt, _ := TypeOf(t)
+		return nil, &DataTypeError{Want: dataTypeOf[T](), Got: dt}
+	}
+	return out, nil
+}
+
+// Float64s returns the elements of t converted to float64, whatever its data type. 64-bit integers
+// larger than 2^53 lose precision.
+func Float64s(t *mlmodelpb.FlatTensor) ([]float64, error) {
+	vals, err := decode(t)
+	if err != nil {
+		return nil, err
+	}
+	switch v := vals.(type) {
+	case []int8:
+		return convert(v), nil
+	case []uint8:
+		return convert(v), nil
+	case []int16:
+		return convert(v), nil
+	case []uint16:
+		return convert(v), nil
+	case []int32:
+		return convert(v), nil
+	case []uint32:
+		return convert(v), nil
+	case []int64:
+		return convert(v), nil
+	case []uint64:
+		return convert(v), nil
+	case []float32:
+		return convert(v), nil
+	default:
+		return vals.([]float64), nil
+	}
+}
+
+// Shape returns the shape of t.
+func Shape(t *mlmodelpb.FlatTensor) []int {
+	shape := make([]int, len(t.GetShape()))
+	for i, d := range t.GetShape() {
+		shape[i] = int(d)
+	}
+	return shape
+}
+
+// TypeOf returns the data type of t.
+func TypeOf(t *mlmodelpb.FlatTensor) (DataType, error) {
+	switch t.GetTensor().(type) {
+	case *mlmodelpb.FlatTensor_Int8Tensor:
+		return Int8, nil
+	case *mlmodelpb.FlatTensor_Uint8Tensor:
+		return Uint8, nil
+	case *mlmodelpb.FlatTensor_Int16Tensor:
+		return Int16, nil
+	case *mlmodelpb.FlatTensor_Uint16Tensor:
+		return Uint16, nil
+	case *mlmodelpb.FlatTensor_Int32Tensor:
+		return Int32, nil
+	case *mlmodelpb.FlatTensor_Uint32Tensor:
+		return Uint32, nil
+	case *mlmodelpb.FlatTensor_Int64Tensor:
+		return Int64, nil
+	case *mlmodelpb.FlatTensor_Uint64Tensor:
+		return Uint64, nil
+	case *mlmodelpb.FlatTensor_FloatTensor:
+		return Float32, nil
+	case *mlmodelpb.FlatTensor_DoubleTensor:
+		return Float64, nil
+	case nil:
+		return "", errNoData
+	default:
+		return "", fmt.Errorf("unsupported tensor type %T", t.GetTensor())
+	}
+}
+
+// Validate checks that the data held by t matches its shape.
+func Validate(t *mlmodelpb.FlatTensor) error {
+	_, err := decode(t)
+	return err
+}
+
+// decode returns the elements of t as a slice of the matching Go type.
+func decode(t *mlmodelpb.FlatTensor) (any, error) {
+	shape := Shape(t)
+	n, err := elements(shape)
+	if err != nil {
+		return nil, err
+	}
+	check := func(got int) error {
+		if got != n {
+			return &ShapeError{Shape: shape, Elements: got}
+		}
+		return nil
+	}
+
+	switch d := t.GetTensor().(type) {
+	case *mlmodelpb.FlatTensor_Int8Tensor:
+		b := d.Int8Tensor.GetData()
+		out := make([]int8, len(b))
+		for i, v := range b {
+			out[i] = int8(v)
+		}
+		return out, check(len(out))
+	case *mlmodelpb.FlatTensor_Uint8Tensor:
+		return d.Uint8Tensor.GetData(), check(len(d.Uint8Tensor.GetData()))
+	case *mlmodelpb.FlatTensor_Int16Tensor:
+		u, err := unpack16(d.Int16Tensor.GetData(), n, shape)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]int16, len(u))
+		for i, v := range u {
+			out[i] = int16(v)
+		}
+		return out, nil
+	case *mlmodelpb.FlatTensor_Uint16Tensor:
+		return unpack16(d.Uint16Tensor.GetData(), n, shape)
+	case *mlmodelpb.FlatTensor_Int32Tensor:
+		return d.Int32Tensor.GetData(), check(len(d.Int32Tensor.GetData()))
+	case *mlmodelpb.FlatTensor_Uint32Tensor:
+		return d.Uint32Tensor.GetData(), check(len(d.Uint32Tensor.GetData()))
+	case *mlmodelpb.FlatTensor_Int64Tensor:
+		return d.Int64Tensor.GetData(), check(len(d.Int64Tensor.GetData()))
+	case *mlmodelpb.FlatTensor_Uint64Tensor:
+		return d.Uint64Tensor.GetData(), check(len(d.Uint64Tensor.GetData()))
+	case *mlmodelpb.FlatTensor_FloatTensor:
+		return d.FloatTensor.GetData(), check(len(d.FloatTensor.GetData()))
+	case *mlmodelpb.FlatTensor_DoubleTensor:
+		return d.DoubleTensor.GetData(), check(len(d.DoubleTensor.GetData()))
+	default:
+		_, err := TypeOf(t)
+		return nil, err
+	}
+}
+
+// pack16 stores 16-bit values two to a word, the first in the low half.
+func pack16(vals []uint16) []uint32 {
+	out := make([]uint32, (len(vals)+1)/2)
+	var buf [4]byte
+	for i := range out {
+		binary.LittleEndian.PutUint16(buf[:2], vals[2*i])
+		buf[2], buf[3] = 0, 0
+		if 2*i+1 < len(vals) {
+			binary.LittleEndian.PutUint16(buf[2:], vals[2*i+1])
+		}
+		out[i] = binary.LittleEndian.Uint32(buf[:])
+	}
+	return out
+}
+
+// unpack16 reverses pack16 for a tensor of n elements.
+func unpack16(words []uint32, n int, shape []int) ([]uint16, error) {
+	if len(words) != (n+1)/2 {
+		return nil, &ShapeError{Shape: shape, Elements: 2 * len(words), Packed: true}
+	}
+	out := make([]uint16, n)
+	var buf [4]byte
+	for i := range out {
+		binary.LittleEndian.PutUint32(buf[:], words[i/2])
+		out[i] = binary.LittleEndian.Uint16(buf[2*(i%2):])
+	}
+	return out, nil
+}
+
+// elements returns the number of elements of a tensor with the given shape.
+func elements(shape []int) (int, error) {
+	n := 1
+	for _, d := range shape {
+		if d < 0 {
+			return 0, fmt.Errorf("tensor shape %v has a negative extent", shape)
+		}
+		if d != 0 && n > math.MaxInt32/d {
+			return 0, fmt.Errorf("tensor shape %v is too large", shape)
+		}
+		n *= d
+	}
+	return n, nil
+}
+
+func convert[T Number](vals []T) []float64 {
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+func dataTypeOf[T Number]() DataType {
+	switch any(*new(T)).(type) {
+	case int8:
+		return Int8
+	case uint8:
+		return Uint8
+	case int16:
+		return Int16
+	case uint16:
+		return Uint16
+	case int32:
+		return Int32
+	case uint32:
+		return Uint32
+	case int64:
+		return Int64
+	case uint64:
+		return Uint64
+	case float32:
+		return Float32
+	default:
+		return Float64
+	}
+}
@@ -0,0 +1,193 @@
+package tensor
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	mlmodelpb "go.viam.com/api/service/mlmodel/v1"
+)
+
+func mustNew[T Number](t *testing.T, shape []int, data []T) *mlmodelpb.FlatTensor {
+	t.Helper()
+	ft, err := New(shape, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ft
+}
+
+func roundTrip[T Number](t *testing.T, want DataType, shape []int, data []T) {
+	t.Helper()
+	ft := mustNew(t, shape, data)
+	if dt, err := TypeOf(ft); err != nil || dt != want {
+		t.Errorf("TypeOf = %q, %v, want %q", dt, err, want)
+	}
+	got, err := Values[T](ft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, data) {
+		t.Errorf("Values = %v, want %v", got, data)
+	}
+	fs, err := Float64s(ft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		if fs[i] != float64(data[i]) {
+			t.Errorf("Float64s[%d] = %v, want %v", i, fs[i], float64(data[i]))
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("int8", func(t *testing.T) { roundTrip(t, Int8, []int{3}, []int8{-128, 0, 127}) })
+	t.Run("uint8", func(t *testing.T) { roundTrip(t, Uint8, []int{1, 2}, []uint8{0, 255}) })
+	t.Run("int16 odd", func(t *testing.T) { roundTrip(t, Int16, []int{3}, []int16{-32768, 1, 32767}) })
+	t.Run("uint16 even", func(t *testing.T) { roundTrip(t, Uint16, []int{2, 2}, []uint16{1, 2, 65535, 0}) })
+	t.Run("int32", func(t *testing.T) { roundTrip(t, Int32, []int{2}, []int32{-1, 1}) })
+	t.Run("uint32", func(t *testing.T) { roundTrip(t, Uint32, []int{1}, []uint32{1 << 31}) })
+	t.Run("int64", func(t *testing.T) { roundTrip(t, Int64, []int{2}, []int64{-1 << 40, 1 << 40}) })
+	t.Run("uint64", func(t *testing.T) { roundTrip(t, Uint64, []int{1}, []uint64{1 << 50}) })
+	t.Run("float32", func(t *testing.T) { roundTrip(t, Float32, []int{2, 1}, []float32{0.5, -2}) })
+	t.Run("float64", func(t *testing.T) { roundTrip(t, Float64, []int{0}, []float64{}) })
+}
+
+func TestPacking(t *testing.T) {
+	ft := mustNew(t, []int{3}, []uint16{0x0201, 0x0403, 0x0605})
+	if got := ft.GetUint16Tensor().GetData(); !slices.Equal(got, []uint32{0x04030201, 0x0605}) {
+		t.Errorf("packed = %#x", got)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	var se *ShapeError
+	if _, err := New([]int{2, 2}, []float32{1, 2, 3}); !errors.As(err, &se) || se.Elements != 3 {
+		t.Errorf("New with short data: %v", err)
+	}
+	if _, err := New([]int{-1}, []float32{}); err == nil {
+		t.Error("New accepted a negative extent")
+	}
+	if _, err := New([]int{1 << 20, 1 << 20}, []float32{}); err == nil {
+		t.Error("New accepted a shape too large")
+	}
+}
+
+func TestValuesErrors(t *testing.T) {
+	ft := mustNew(t, []int{2}, []float32{1, 2})
+	var dte *DataTypeError
+	if _, err := Values[float64](ft); !errors.As(err, &dte) || dte.Want != Float64 || dte.Got != Float32 {
+		t.Errorf("Values of the wrong type: %v", err)
+	}
+	if _, err := TypeOf(&mlmodelpb.FlatTensor{}); !errors.Is(err, errNoData) {
+		t.Errorf("TypeOf without data: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		t      *mlmodelpb.FlatTensor
+		packed bool
+	}{
+		{"float", &mlmodelpb.FlatTensor{Shape: []uint64{3}, Tensor: &mlmodelpb.FlatTensor_FloatTensor{
+			FloatTensor: &mlmodelpb.FlatTensorDataFloat{Data: []float32{1}},
+		}}, false},
+		{"int8", &mlmodelpb.FlatTensor{Shape: []uint64{1}, Tensor: &mlmodelpb.FlatTensor_Int8Tensor{
+			Int8Tensor: &mlmodelpb.FlatTensorDataInt8{Data: []byte{1, 2}},
+		}}, false},
+		{"int16", &mlmodelpb.FlatTensor{Shape: []uint64{3}, Tensor: &mlmodelpb.FlatTensor_Int16Tensor{
+			Int16Tensor: &mlmodelpb.FlatTensorDataInt16{Data: []uint32{1}},
+		}}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var se *ShapeError
+			if err := Validate(tc.t); !errors.As(err, &se) || se.Packed != tc.packed {
+				t.Errorf("Validate = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseDataType(t *testing.T) {
+	for in, want := range map[string]DataType{
+		"float32":  Float32,
+		" Float ":  Float32,
+		"DOUBLE":   Float64,
+		"uint8":    Uint8,
+		"":         "",
+		"bfloat16": "bfloat16",
+	} {
+		if got := ParseDataType(in); got != want {
+			t.Errorf("ParseDataType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	img := mustNew(t, []int{1, 4, 4, 3}, make([]uint8, 48))
+	for _, tc := range []struct {
+		name  string
+		info  *mlmodelpb.TensorInfo
+		check func(error) bool
+	}{
+		{"no constraints", &mlmodelpb.TensorInfo{Name: "image"}, func(err error) bool { return err == nil }},
+		{"matching", &mlmodelpb.TensorInfo{Name: "image", DataType: "uint8", Shape: []int32{1, -1, -1, 3}},
+			func(err error) bool { return err == nil }},
+		{"data type", &mlmodelpb.TensorInfo{Name: "image", DataType: "float"}, func(err error) bool {
+			var e *DataTypeError
+			return errors.As(err, &e) && e.Name == "image" && e.Want == Float32
+		}},
+		{"rank", &mlmodelpb.TensorInfo{Name: "image", Shape: []int32{4, 4, 3}}, func(err error) bool {
+			var e *ShapeMismatchError
+			return errors.As(err, &e)
+		}},
+		{"extent", &mlmodelpb.TensorInfo{Name: "image", Shape: []int32{1, 4, 4, 1}}, func(err error) bool {
+			var e *ShapeMismatchError
+			return errors.As(err, &e) && slices.Equal(e.Got, []int{1, 4, 4, 3})
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Check(img, tc.info); !tc.check(err) {
+				t.Errorf("Check = %v", err)
+			}
+		})
+	}
+
+	bad := &mlmodelpb.FlatTensor{Shape: []uint64{2}, Tensor: &mlmodelpb.FlatTensor_DoubleTensor{
+		DoubleTensor: &mlmodelpb.FlatTensorDataDouble{Data: []float64{1}},
+	}}
+	var se *ShapeError
+	if err := Check(bad, &mlmodelpb.TensorInfo{Name: "x"}); !errors.As(err, &se) || se.Name != "x" {
+		t.Errorf("Check of an invalid tensor = %v", err)
+	}
+}
+
+func TestCheckInputs(t *testing.T) {
+	md := &mlmodelpb.Metadata{InputInfo: []*mlmodelpb.TensorInfo{
+		{Name: "a", DataType: "float32"},
+		{Name: "b"},
+	}}
+	tensors := map[string]*mlmodelpb.FlatTensor{
+		"a": mustNew(t, []int{1}, []float64{1}),
+		"c": mustNew(t, []int{1}, []float32{1}),
+	}
+	err := CheckInputs(&mlmodelpb.FlatTensors{Tensors: tensors}, md)
+	var dte *DataTypeError
+	var missing *MissingTensorError
+	var unexpected *UnexpectedTensorError
+	if !errors.As(err, &dte) || !errors.As(err, &missing) || missing.Name != "b" ||
+		!errors.As(err, &unexpected) || unexpected.Name != "c" {
+		t.Errorf("CheckInputs = %v", err)
+	}
+
+	if _, err := NewInferRequest("model", tensors, md); err == nil {
+		t.Error("NewInferRequest accepted invalid inputs")
+	}
+	req, err := NewInferRequest("model", tensors, nil)
+	if err != nil || req.GetName() != "model" || len(req.GetInputTensors().GetTensors()) != 2 {
+		t.Errorf("NewInferRequest without metadata = %v, %v", req, err)
+	}
+	if err := CheckOutputs(&mlmodelpb.FlatTensors{}, &mlmodelpb.Metadata{}); err != nil {
+		t.Errorf("CheckOutputs with nothing declared = %v", err)
+	}
+}
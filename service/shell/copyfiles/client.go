@@ -0,0 +1,181 @@
+package copyfiles
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// CopyToMachine copies local paths to destination on the machine, through the shell service with the
+// given name. Directories are only copied WithRecursive.
+func CopyToMachine(
+	ctx context.Context, client shellpb.ShellServiceClient, name string, paths []string, destination string, opts ...Option,
+) error {
+	o := newOptions(opts)
+	srcs, err := localSources(paths)
+	if err != nil {
+		return err
+	}
+	sourceType, err := sourceTypeOf(srcs)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.CopyFilesToMachine(ctx)
+	if err != nil {
+		return err
+	}
+	// Send reports io.EOF when the machine has ended the stream, whose status is only available from Recv.
+	streamErr := func(err error) error {
+		if errors.Is(err, io.EOF) {
+			if _, err = stream.Recv(); err == nil || errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+		}
+		return err
+	}
+	if err := stream.Send(&shellpb.CopyFilesToMachineRequest{
+		Request: &shellpb.CopyFilesToMachineRequest_Metadata{Metadata: &shellpb.CopyFilesToMachineRequestMetadata{
+			Name:        name,
+			SourceType:  sourceType,
+			Destination: destination,
+			Preserve:    o.preserve,
+		}},
+	}); err != nil {
+		return streamErr(err)
+	}
+
+	err = sendFiles(srcs, o,
+		func(fd *shellpb.FileData) error {
+			if err := stream.Send(&shellpb.CopyFilesToMachineRequest{
+				Request: &shellpb.CopyFilesToMachineRequest_FileData{FileData: fd},
+			}); err != nil {
+				return streamErr(err)
+			}
+			return nil
+		},
+		func() error {
+			_, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	// Wait for the machine to finish, which reports any error.
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = protocolError("unexpected acknowledgement")
+		}
+		return err
+	}
+	return nil
+}
+
+// CopyFromMachine copies paths on the machine to the local destination, through the shell service with the
+// given name. Directories are only copied WithRecursive. The files are written inside the destination, or
+// inside its parent directory if the destination does not exist yet, and never outside of it.
+func CopyFromMachine(
+	ctx context.Context, client shellpb.ShellServiceClient, name string, paths []string, destination string, opts ...Option,
+) error {
+	o := newOptions(opts)
+	abs, err := filepath.Abs(destination)
+	if err != nil {
+		return err
+	}
+	rootDir, target := filepath.Dir(abs), filepath.Base(abs)
+	if rootDir == abs {
+		// The destination is the filesystem root.
+		target = "."
+	}
+	root, err := os.OpenRoot(rootDir)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer root.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.CopyFilesFromMachine(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&shellpb.CopyFilesFromMachineRequest{
+		Request: &shellpb.CopyFilesFromMachineRequest_Metadata{Metadata: &shellpb.CopyFilesFromMachineRequestMetadata{
+			Name:           name,
+			Paths:          paths,
+			AllowRecursion: o.recursive,
+			Preserve:       o.preserve,
+		}},
+	}); err != nil {
+		if errors.Is(err, io.EOF) {
+			_, err = stream.Recv()
+		}
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	md := resp.GetMetadata()
+	if md == nil {
+		return protocolError("first response must be metadata")
+	}
+
+	r, err := newReceiver(root, target, md.GetSourceType(), o.preserve, o)
+	if err != nil {
+		return err
+	}
+	err = r.run(
+		func() (*shellpb.FileData, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			fd := resp.GetFileData()
+			if fd == nil {
+				return nil, protocolError("metadata may only be sent first")
+			}
+			return fd, nil
+		},
+		func() error {
+			return stream.Send(&shellpb.CopyFilesFromMachineRequest{
+				Request: &shellpb.CopyFilesFromMachineRequest_AckLastFile{AckLastFile: true},
+			})
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return stream.CloseSend()
+}
+
+// localSources returns the sources for local paths, each sent under its base name.
+func localSources(paths []string) ([]source, error) {
+	srcs := make([]source, 0, len(paths))
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		dir, base := filepath.Dir(abs), filepath.Base(abs)
+		if dir == abs {
+			return nil, errors.New("copyfiles: cannot copy the filesystem root")
+		}
+		srcs = append(srcs, source{fsys: os.DirFS(dir), name: base, wire: base})
+	}
+	return srcs, nil
+}
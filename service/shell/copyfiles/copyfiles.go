@@ -0,0 +1,137 @@
+// Package copyfiles implements the file copy protocol of ShellService, CopyFilesToMachine and
+// CopyFilesFromMachine, on both the client and the machine side.
+//
+// Files are sent one at a time. The first FileData of a file carries its name, size, whether it is a
+// directory and, when preserving, its mode and modification time; the contents follow in chunks, and a
+// FileData with only eof set ends the file. The receiver acknowledges each file before the next is sent.
+// Names on the wire are slash-separated paths relative to the copied sources, so copying the directory
+// "photos" sends "photos", "photos/a.jpg" and so on, directories before their contents.
+//
+// The receiver places files according to the source type, as cp and scp do: a single file is written to
+// the destination, or into it if it is a directory; a single directory is copied to the destination, or
+// into it if it already exists; multiple files are copied into the destination directory. Names that are
+// absolute or climb out of the destination with ".." are rejected, and every path on the machine side is
+// resolved inside a root directory that symbolic links cannot escape.
+package copyfiles
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// DefaultChunkSize is the size of the file chunks sent when no chunk size is configured.
+const DefaultChunkSize = 64 << 10
+
+// Progress is called as a file is transferred, with its name on the wire, the bytes transferred so far and
+// its size. It is called at least once per file, after the file is complete.
+type Progress func(name string, done, total int64)
+
+type options struct {
+	chunkSize int
+	preserve  bool
+	recursive bool
+	progress  Progress
+}
+
+// Option configures a copy.
+type Option func(*options)
+
+// WithChunkSize sets the size of the file chunks sent.
+func WithChunkSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.chunkSize = n
+		}
+	}
+}
+
+// WithPreserve preserves the permissions and modification times of the copied files. On the machine side,
+// the request decides instead. Modification times are only preserved when receiving on linux and darwin.
+func WithPreserve() Option {
+	return func(o *options) {
+		o.preserve = true
+	}
+}
+
+// WithRecursive allows directories to be copied. On the machine side, the request decides instead.
+func WithRecursive() Option {
+	return func(o *options) {
+		o.recursive = true
+	}
+}
+
+// WithProgress reports the progress of each file sent or received.
+func WithProgress(fn Progress) Option {
+	return func(o *options) {
+		o.progress = fn
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{chunkSize: DefaultChunkSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) report(name string, done, total int64) {
+	if o.progress != nil {
+		o.progress(name, done, total)
+	}
+}
+
+// sourceTypeOf returns the source type of a copy of srcs.
+func sourceTypeOf(srcs []source) (shellpb.CopyFilesSourceType, error) {
+	switch len(srcs) {
+	case 0:
+		return shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_UNSPECIFIED, errors.New("copyfiles: no paths to copy")
+	case 1:
+		info, err := fs.Stat(srcs[0].fsys, srcs[0].name)
+		if err != nil {
+			return shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_UNSPECIFIED, err
+		}
+		if info.IsDir() {
+			return shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_DIRECTORY, nil
+		}
+		return shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_FILE, nil
+	default:
+		return shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_MULTIPLE_FILES, nil
+	}
+}
+
+// protocolError reports a peer that does not follow the protocol.
+func protocolError(format string, args ...any) error {
+	return status.Error(codes.InvalidArgument, "copyfiles: "+fmt.Sprintf(format, args...))
+}
+
+// toStatus converts filesystem errors into the matching gRPC status.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, fs.ErrExist):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, fs.ErrPermission):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		var pe *os.PathError
+		if errors.As(err, &pe) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
@@ -0,0 +1,403 @@
+package copyfiles
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+type shellServer struct {
+	shellpb.UnimplementedShellServiceServer
+	h *Handler
+}
+
+func (s *shellServer) CopyFilesToMachine(stream shellpb.ShellService_CopyFilesToMachineServer) error {
+	return s.h.CopyFilesToMachine(stream)
+}
+
+func (s *shellServer) CopyFilesFromMachine(stream shellpb.ShellService_CopyFilesFromMachineServer) error {
+	return s.h.CopyFilesFromMachine(stream)
+}
+
+// serve returns a client of a shell service serving files under root.
+func serve(t *testing.T, root string, opts ...Option) shellpb.ShellServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	shellpb.RegisterShellServiceServer(srv, &shellServer{h: NewHandler(root, opts...)})
+	go func() {
+		//nolint:errcheck
+		srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		conn.Close()
+	})
+	return shellpb.NewShellServiceClient(conn)
+}
+
+// writeTree creates files under dir, with names ending in "/" creating directories.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, data := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if name[len(name)-1] == '/' {
+			if err := os.MkdirAll(p, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readTree returns the files under dir, with directories ending in "/".
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	out := map[string]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == dir {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			out[filepath.ToSlash(rel)+"/"] = ""
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		out[filepath.ToSlash(rel)] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func equalTrees(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+var tree = map[string]string{"photos/a.jpg": "aaaa", "photos/sub/b.jpg": "b", "photos/empty.txt": "", "notes.txt": "hello"}
+
+func TestCopyToMachine(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		existing map[string]string
+		paths    []string
+		dest     string
+		opts     []Option
+		want     map[string]string
+	}{
+		{"file", nil, []string{"notes.txt"}, "copy.txt", nil, map[string]string{"copy.txt": "hello"}},
+		{"file into directory", map[string]string{"dir/": ""}, []string{"notes.txt"}, "dir", nil,
+			map[string]string{"dir/": "", "dir/notes.txt": "hello"}},
+		{"file to a new directory", nil, []string{"notes.txt"}, "new/copy.txt", nil, map[string]string{"new/": "", "new/copy.txt": "hello"}},
+		{"directory", nil, []string{"photos"}, "/pics", []Option{WithRecursive()}, map[string]string{
+			"pics/": "", "pics/a.jpg": "aaaa", "pics/empty.txt": "", "pics/sub/": "", "pics/sub/b.jpg": "b",
+		}},
+		{"directory into directory", map[string]string{"dir/": ""}, []string{"photos"}, "dir", []Option{WithRecursive()}, map[string]string{
+			"dir/": "", "dir/photos/": "", "dir/photos/a.jpg": "aaaa", "dir/photos/empty.txt": "", "dir/photos/sub/": "", "dir/photos/sub/b.jpg": "b",
+		}},
+		{"multiple files", nil, []string{"notes.txt", "photos/a.jpg"}, "out", nil, map[string]string{
+			"out/": "", "out/notes.txt": "hello", "out/a.jpg": "aaaa",
+		}},
+		{"small chunks", nil, []string{"photos/a.jpg"}, "a", []Option{WithChunkSize(1)}, map[string]string{"a": "aaaa"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			local, remote := t.TempDir(), t.TempDir()
+			writeTree(t, local, tree)
+			writeTree(t, remote, tc.existing)
+			client := serve(t, remote)
+			var paths []string
+			for _, p := range tc.paths {
+				paths = append(paths, filepath.Join(local, p))
+			}
+			if err := CopyToMachine(context.Background(), client, "shell", paths, tc.dest, tc.opts...); err != nil {
+				t.Fatal(err)
+			}
+			if got := readTree(t, remote); !equalTrees(got, tc.want) {
+				t.Errorf("copied %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCopyFromMachine(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		existing map[string]string
+		paths    []string
+		dest     string
+		want     map[string]string
+	}{
+		{"file", nil, []string{"/notes.txt"}, "copy.txt", map[string]string{"copy.txt": "hello"}},
+		{"file into directory", map[string]string{"dir/": ""}, []string{"notes.txt"}, "dir", map[string]string{"dir/": "", "dir/notes.txt": "hello"}},
+		{"directory", nil, []string{"photos"}, "pics", map[string]string{
+			"pics/": "", "pics/a.jpg": "aaaa", "pics/empty.txt": "", "pics/sub/": "", "pics/sub/b.jpg": "b",
+		}},
+		{"multiple files", nil, []string{"notes.txt", "photos/sub/b.jpg"}, "out", map[string]string{
+			"out/": "", "out/notes.txt": "hello", "out/b.jpg": "b",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			local, remote := t.TempDir(), t.TempDir()
+			writeTree(t, remote, tree)
+			writeTree(t, local, tc.existing)
+			client := serve(t, remote, WithChunkSize(2))
+			if err := CopyFromMachine(context.Background(), client, "shell", tc.paths, filepath.Join(local, tc.dest), WithRecursive()); err != nil {
+				t.Fatal(err)
+			}
+			if got := readTree(t, local); !equalTrees(got, tc.want) {
+				t.Errorf("copied %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCopyErrors(t *testing.T) {
+	local, remote := t.TempDir(), t.TempDir()
+	writeTree(t, local, tree)
+	writeTree(t, remote, tree)
+	client := serve(t, remote)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"directory without recursion", CopyToMachine(ctx, client, "shell", []string{filepath.Join(local, "photos")}, "x"), codes.FailedPrecondition},
+		{"missing local file", CopyToMachine(ctx, client, "shell", []string{filepath.Join(local, "missing")}, "x"), codes.Unknown},
+		{"no paths", CopyToMachine(ctx, client, "shell", nil, "x"), codes.Unknown},
+		{"destination outside the root", CopyToMachine(ctx, client, "shell", []string{filepath.Join(local, "notes.txt")}, "../x"),
+			codes.InvalidArgument},
+		{"destination under a file", CopyToMachine(ctx, client, "shell", []string{filepath.Join(local, "notes.txt")}, "notes.txt/x"),
+			codes.FailedPrecondition},
+		{"remote directory without recursion", CopyFromMachine(ctx, client, "shell", []string{"photos"}, filepath.Join(local, "x")),
+			codes.FailedPrecondition},
+		{"missing remote file", CopyFromMachine(ctx, client, "shell", []string{"missing"}, filepath.Join(local, "x")), codes.NotFound},
+		{"remote path outside the root", CopyFromMachine(ctx, client, "shell", []string{"../etc/passwd"}, filepath.Join(local, "x")),
+			codes.InvalidArgument},
+	} {
+		if tc.err == nil {
+			t.Errorf("%s: copy succeeded", tc.name)
+		} else if code := status.Code(tc.err); code != tc.code {
+			t.Errorf("%s: error = %v, want code %v", tc.name, tc.err, tc.code)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(remote), "x")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("a file was written outside the root: %v", err)
+	}
+}
+
+func TestSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links need privileges on windows")
+	}
+	local, remote, outside := t.TempDir(), t.TempDir(), t.TempDir()
+	writeTree(t, local, tree)
+	if err := os.Symlink(outside, filepath.Join(remote, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	client := serve(t, remote)
+	ctx := context.Background()
+
+	// The machine side does not follow links out of its root.
+	err := CopyToMachine(ctx, client, "shell", []string{filepath.Join(local, "notes.txt")}, "escape/notes.txt")
+	if err == nil {
+		t.Error("copied through a link out of the root")
+	}
+	if entries, _ := os.ReadDir(outside); len(entries) != 0 {
+		t.Errorf("wrote %v outside the root", entries)
+	}
+
+	// A link to a directory is not copied, since it could form a cycle.
+	if err := os.Symlink(filepath.Join(local, "photos"), filepath.Join(local, "photos", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	err = CopyToMachine(ctx, client, "shell", []string{filepath.Join(local, "photos")}, "photos", WithRecursive())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("copying a directory link = %v", err)
+	}
+}
+
+func TestPreserve(t *testing.T) {
+	local, remote := t.TempDir(), t.TempDir()
+	writeTree(t, local, tree)
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, name := range []string{"photos/a.jpg", "photos/sub", "photos"} {
+		if err := os.Chtimes(filepath.Join(local, name), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(filepath.Join(local, "photos/a.jpg"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client := serve(t, remote)
+	var names []string
+	progress := WithProgress(func(name string, done, total int64) {
+		if done == total {
+			names = append(names, name)
+		}
+	})
+	if err := CopyToMachine(context.Background(), client, "shell", []string{filepath.Join(local, "photos")}, "photos",
+		WithRecursive(), WithPreserve(), progress); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"photos", "photos/a.jpg", "photos/empty.txt", "photos/sub", "photos/sub/b.jpg"}; !slices.Equal(names, want) {
+		t.Errorf("progress completed %q, want %q", names, want)
+	}
+
+	info, err := os.Stat(filepath.Join(remote, "photos/a.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v", info.Mode())
+	}
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		return
+	}
+	for _, name := range []string{"photos/a.jpg", "photos/sub", "photos"} {
+		info, err := os.Stat(filepath.Join(remote, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !info.ModTime().Equal(mtime) {
+			t.Errorf("%s modified at %v, want %v", name, info.ModTime(), mtime)
+		}
+	}
+	// Without preserving, the copy is modified now.
+	if err := CopyToMachine(context.Background(), client, "shell", []string{filepath.Join(local, "notes.txt")}, "notes.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filepath.Join(remote, "notes.txt")); err != nil || time.Since(info.ModTime()) > time.Minute {
+		t.Errorf("unpreserved copy modified at %v, %v", info.ModTime(), err)
+	}
+}
+
+// receive runs a receiver of the single directory "d", copied to "dest", on the data sent after "d" itself.
+func receive(t *testing.T, dir string, fds ...*shellpb.FileData) error {
+	t.Helper()
+	fds = append([]*shellpb.FileData{{Name: "d", IsDir: true}, {Eof: true}}, fds...)
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:errcheck
+	defer root.Close()
+	r, err := newReceiver(root, "dest", shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_DIRECTORY, false, newOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.run(func() (*shellpb.FileData, error) {
+		if len(fds) == 0 {
+			return nil, io.EOF
+		}
+		fd := fds[0]
+		fds = fds[1:]
+		return fd, nil
+	}, func() error { return nil })
+}
+
+func TestReceiverRejects(t *testing.T) {
+	eof := &shellpb.FileData{Eof: true}
+	for _, tc := range []struct {
+		name string
+		fds  []*shellpb.FileData
+	}{
+		{"climbing name", []*shellpb.FileData{{Name: "d/../../x", Size: 1, Data: []byte("x")}, eof}},
+		{"absolute name", []*shellpb.FileData{{Name: "/etc/x", Size: 1, Data: []byte("x")}, eof}},
+		{"backslash", []*shellpb.FileData{{Name: `d\..\..\x`, Size: 1, Data: []byte("x")}, eof}},
+		{"empty name", []*shellpb.FileData{{Size: 0}, eof}},
+		{"too much data", []*shellpb.FileData{{Name: "d/x", Size: 1, Data: []byte("xx")}, eof}},
+		{"too little data", []*shellpb.FileData{{Name: "d/x", Size: 3, Data: []byte("xx")}, eof}},
+		{"data for a directory", []*shellpb.FileData{{Name: "d/e", IsDir: true, Data: []byte("x")}, eof}},
+		{"interleaved files", []*shellpb.FileData{{Name: "d/x", Size: 2, Data: []byte("x")}, {Name: "d/y", Data: []byte("y")}}},
+		{"truncated stream", []*shellpb.FileData{{Name: "d/x", Size: 2, Data: []byte("x")}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := receive(t, dir, tc.fds...); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v", err)
+			}
+			// A partially received file is removed.
+			if _, err := os.Stat(filepath.Join(dir, "dest", "x")); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("dest/x was left behind: %v", err)
+			}
+		})
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	for in, want := range map[string]string{"": ".", "/": ".", "/a/b": "a/b", "a/./b/..": "a", "a/../..x": "..x"} {
+		if got, err := localPath(in); err != nil || got != want {
+			t.Errorf("localPath(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"..", "../a", "/a/../../b"} {
+		if _, err := localPath(in); err == nil {
+			t.Errorf("localPath(%q) succeeded", in)
+		}
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{status.Error(codes.Aborted, "x"), codes.Aborted},
+		{&fs.PathError{Op: "open", Err: fs.ErrNotExist}, codes.NotFound},
+		{&fs.PathError{Op: "mkdir", Err: fs.ErrExist}, codes.AlreadyExists},
+		{&fs.PathError{Op: "open", Err: fs.ErrPermission}, codes.PermissionDenied},
+		{&fs.PathError{Op: "open", Err: errors.New("not a directory")}, codes.FailedPrecondition},
+		{errors.New("other"), codes.Unknown},
+	} {
+		if got := status.Code(toStatus(tc.err)); got != tc.code {
+			t.Errorf("toStatus(%v) has code %v, want %v", tc.err, got, tc.code)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package copyfiles
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// receiver writes received files under a destination inside root.
+type receiver struct {
+	root *os.Root
+	// target is the destination, relative to root.
+	target      string
+	targetIsDir bool
+	sourceType  shellpb.CopyFilesSourceType
+	preserve    bool
+	o           *options
+
+	cur *incoming
+	// dirTimes holds the modification times to give directories once their contents are written, since
+	// writing the contents changes them.
+	dirTimes []dirTime
+}
+
+type incoming struct {
+	fd      *shellpb.FileData
+	path    string
+	f       *os.File
+	written int64
+}
+
+type dirTime struct {
+	path  string
+	mtime time.Time
+}
+
+// newReceiver returns a receiver of files of the given source type copied to target, a path relative to
+// root.
+func newReceiver(root *os.Root, target string, sourceType shellpb.CopyFilesSourceType, preserve bool, o *options) (*receiver, error) {
+	r := &receiver{root: root, target: filepath.Clean(target), sourceType: sourceType, preserve: preserve, o: o}
+	info, err := root.Stat(r.target)
+	switch {
+	case err == nil:
+		r.targetIsDir = info.IsDir()
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	switch sourceType {
+	case shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_FILE, shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_DIRECTORY:
+		if !r.targetIsDir {
+			if err := mkdirAll(root, filepath.Dir(r.target)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if err := mkdirAll(root, r.target); err != nil {
+			return nil, err
+		}
+		r.targetIsDir = true
+	}
+	return r, nil
+}
+
+// dest returns the path, relative to the root, that the file with the given wire name is written to.
+func (r *receiver) dest(name string) (string, error) {
+	if name == "" || !filepath.IsLocal(filepath.FromSlash(name)) || strings.Contains(name, `\`) {
+		return "", protocolError("invalid file name %q", name)
+	}
+	name = path.Clean(name)
+	switch r.sourceType {
+	case shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_FILE:
+		if r.targetIsDir {
+			return filepath.Join(r.target, path.Base(name)), nil
+		}
+		return r.target, nil
+	case shellpb.CopyFilesSourceType_COPY_FILES_SOURCE_TYPE_SINGLE_DIRECTORY:
+		if r.targetIsDir {
+			return filepath.Join(r.target, filepath.FromSlash(name)), nil
+		}
+		// The copied directory takes the name of the target.
+		_, rest, _ := strings.Cut(name, "/")
+		return filepath.Join(r.target, filepath.FromSlash(rest)), nil
+	default:
+		return filepath.Join(r.target, filepath.FromSlash(name)), nil
+	}
+}
+
+// run receives files until next returns io.EOF, calling ack after each one.
+func (r *receiver) run(next func() (*shellpb.FileData, error), ack func() error) (err error) {
+	defer func() {
+		if err != nil {
+			r.abort()
+		}
+	}()
+	for {
+		fd, err := next()
+		if errors.Is(err, io.EOF) {
+			if r.cur != nil {
+				return protocolError("stream ended before the end of %q", r.cur.fd.GetName())
+			}
+			return r.finish()
+		}
+		if err != nil {
+			return err
+		}
+		if r.cur == nil {
+			if err := r.start(fd); err != nil {
+				return err
+			}
+		} else if fd.GetName() != "" && fd.GetName() != r.cur.fd.GetName() {
+			return protocolError("data for %q before the end of %q", fd.GetName(), r.cur.fd.GetName())
+		}
+		if err := r.write(fd.GetData()); err != nil {
+			return err
+		}
+		if fd.GetEof() {
+			if err := r.complete(); err != nil {
+				return err
+			}
+			if err := ack(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *receiver) start(fd *shellpb.FileData) error {
+	p, err := r.dest(fd.GetName())
+	if err != nil {
+		return err
+	}
+	r.cur = &incoming{fd: fd, path: p}
+	if fd.GetIsDir() {
+		return mkdirAll(r.root, p)
+	}
+	r.cur.f, err = r.root.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	return err
+}
+
+func (r *receiver) write(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if r.cur.f == nil {
+		return protocolError("data sent for directory %q", r.cur.fd.GetName())
+	}
+	if r.cur.written+int64(len(data)) > r.cur.fd.GetSize() {
+		return protocolError("%q is larger than its size %d", r.cur.fd.GetName(), r.cur.fd.GetSize())
+	}
+	n, err := r.cur.f.Write(data)
+	r.cur.written += int64(n)
+	if err == nil {
+		r.o.report(r.cur.fd.GetName(), r.cur.written, r.cur.fd.GetSize())
+	}
+	return err
+}
+
+// complete finishes the current file, applying its metadata when preserving.
+func (r *receiver) complete() error {
+	cur := r.cur
+	fd := cur.fd
+	if cur.f == nil {
+		if r.preserve && fd.Mode != nil {
+			if err := chmod(r.root, cur.path, fd.GetMode()); err != nil {
+				return err
+			}
+		}
+		if r.preserve && fd.ModTime != nil {
+			r.dirTimes = append(r.dirTimes, dirTime{path: cur.path, mtime: fd.GetModTime().AsTime()})
+		}
+		r.cur = nil
+		r.o.report(fd.GetName(), 0, 0)
+		return nil
+	}
+
+	if cur.written != fd.GetSize() {
+		return protocolError("%q ended after %d of %d bytes", fd.GetName(), cur.written, fd.GetSize())
+	}
+	if r.preserve && fd.Mode != nil {
+		if err := cur.f.Chmod(fs.FileMode(fd.GetMode()).Perm()); err != nil {
+			return err
+		}
+	}
+	if r.preserve && fd.ModTime != nil {
+		if err := setModTime(cur.f, fd.GetModTime().AsTime()); err != nil {
+			return err
+		}
+	}
+	if err := cur.f.Close(); err != nil {
+		return err
+	}
+	cur.f = nil
+	r.cur = nil
+	if fd.GetSize() == 0 {
+		r.o.report(fd.GetName(), 0, 0)
+	}
+	return nil
+}
+
+// finish sets the modification times of the received directories, deepest first.
+func (r *receiver) finish() error {
+	slices.Reverse(r.dirTimes)
+	for _, dt := range r.dirTimes {
+		if err := chtimes(r.root, dt.path, dt.mtime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// abort removes a partially received file.
+func (r *receiver) abort() {
+	if r.cur == nil || r.cur.f == nil {
+		return
+	}
+	//nolint:errcheck
+	r.cur.f.Close()
+	//nolint:errcheck
+	r.root.Remove(r.cur.path)
+	r.cur = nil
+}
+
+// chtimes sets the modification time of p, opening it through root so that it cannot be swapped for a
+// link leading out of it.
+func chtimes(root *os.Root, p string, mtime time.Time) error {
+	f, err := root.Open(p)
+	if err != nil {
+		return err
+	}
+	if err := setModTime(f, mtime); err != nil {
+		//nolint:errcheck
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func chmod(root *os.Root, p string, mode uint32) error {
+	f, err := root.Open(p)
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(fs.FileMode(mode).Perm()); err != nil {
+		//nolint:errcheck
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// mkdirAll creates the directory p inside root along with any missing parents.
+func mkdirAll(root *os.Root, p string) error {
+	p = filepath.Clean(p)
+	if p == "." {
+		return nil
+	}
+	var dir string
+	for _, elem := range strings.Split(p, string(filepath.Separator)) {
+		dir = filepath.Join(dir, elem)
+		err := root.Mkdir(dir, 0o755)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		info, serr := root.Stat(dir)
+		if serr != nil {
+			return serr
+		}
+		if !info.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: errors.New("not a directory")}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package copyfiles
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// source is a file or directory to send: name in fsys, sent as wire.
+type source struct {
+	fsys fs.FS
+	name string
+	wire string
+}
+
+// sendFiles sends srcs one file at a time, calling ack after each file to wait for the receiver.
+func sendFiles(srcs []source, o *options, send func(*shellpb.FileData) error, ack func() error) error {
+	for _, src := range srcs {
+		info, err := fs.Stat(src.fsys, src.name)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && !o.recursive {
+			return status.Errorf(codes.FailedPrecondition, "copyfiles: %s is a directory and recursion is not allowed", src.wire)
+		}
+		err = fs.WalkDir(src.fsys, src.name, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Symbolic links are followed, except to directories, which could form cycles.
+			info, err := fs.Stat(src.fsys, p)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() && d.Type()&fs.ModeSymlink != 0 {
+				return status.Errorf(codes.FailedPrecondition, "copyfiles: %s is a symbolic link to a directory", p)
+			}
+			if !info.IsDir() && !info.Mode().IsRegular() {
+				// Devices, sockets and pipes have no contents to copy.
+				return nil
+			}
+			return sendFile(src.fsys, p, wireName(src, p), info, o, send, ack)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wireName returns the name that p, found by walking src, is sent as.
+func wireName(src source, p string) string {
+	if p == src.name {
+		return src.wire
+	}
+	if src.name == "." {
+		return path.Join(src.wire, p)
+	}
+	return path.Join(src.wire, strings.TrimPrefix(p, src.name+"/"))
+}
+
+func sendFile(
+	fsys fs.FS, name, wire string, info fs.FileInfo, o *options, send func(*shellpb.FileData) error, ack func() error,
+) error {
+	first := &shellpb.FileData{Name: wire, IsDir: info.IsDir()}
+	if !info.IsDir() {
+		first.Size = info.Size()
+	}
+	if o.preserve {
+		mode := uint32(info.Mode().Perm())
+		first.Mode = &mode
+		first.ModTime = timestamppb.New(info.ModTime())
+	}
+
+	if info.IsDir() {
+		if err := send(first); err != nil {
+			return err
+		}
+	} else {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		//nolint:errcheck
+		defer f.Close()
+		var sent int64
+		msg := first
+		for {
+			// Each chunk gets its own buffer, since the stream may hold on to a message after Send returns.
+			buf := make([]byte, o.chunkSize)
+			n, rerr := io.ReadFull(f, buf)
+			if rerr != nil && !errors.Is(rerr, io.EOF) && !errors.Is(rerr, io.ErrUnexpectedEOF) {
+				return rerr
+			}
+			// The first message is sent even for an empty file, since it names the file.
+			if n > 0 || msg == first {
+				msg.Data = buf[:n]
+				if err := send(msg); err != nil {
+					return err
+				}
+				sent += int64(n)
+				o.report(wire, sent, first.Size)
+			}
+			if rerr != nil {
+				break
+			}
+			msg = &shellpb.FileData{}
+		}
+		if sent != first.Size {
+			return fmt.Errorf("copyfiles: %s changed size while being copied", wire)
+		}
+	}
+
+	if err := send(&shellpb.FileData{Eof: true}); err != nil {
+		return err
+	}
+	if err := ack(); err != nil {
+		return err
+	}
+	if info.IsDir() {
+		o.report(wire, 0, 0)
+	}
+	return nil
+}
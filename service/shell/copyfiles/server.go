@@ -0,0 +1,145 @@
+package copyfiles
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// Handler serves CopyFilesToMachine and CopyFilesFromMachine inside a root directory. Paths in requests
+// are relative to the root, with a leading slash ignored, and cannot refer outside of it. A
+// ShellServiceServer implements its copy methods by calling a Handler.
+type Handler struct {
+	root string
+	o    *options
+}
+
+// NewHandler returns a Handler serving files under root. Only WithChunkSize and WithProgress apply; the
+// requests decide whether to preserve metadata and recurse into directories.
+func NewHandler(root string, opts ...Option) *Handler {
+	return &Handler{root: root, o: newOptions(opts)}
+}
+
+// CopyFilesToMachine receives files from a client.
+func (h *Handler) CopyFilesToMachine(stream shellpb.ShellService_CopyFilesToMachineServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	md := req.GetMetadata()
+	if md == nil {
+		return protocolError("first request must be metadata")
+	}
+	target, err := localPath(md.GetDestination())
+	if err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(h.root)
+	if err != nil {
+		return toStatus(err)
+	}
+	//nolint:errcheck
+	defer root.Close()
+
+	r, err := newReceiver(root, filepath.FromSlash(target), md.GetSourceType(), md.GetPreserve(), h.o)
+	if err != nil {
+		return toStatus(err)
+	}
+	return toStatus(r.run(
+		func() (*shellpb.FileData, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			fd := req.GetFileData()
+			if fd == nil {
+				return nil, protocolError("metadata may only be sent first")
+			}
+			return fd, nil
+		},
+		func() error {
+			return stream.Send(&shellpb.CopyFilesToMachineResponse{AckLastFile: true})
+		},
+	))
+}
+
+// CopyFilesFromMachine sends files to a client.
+func (h *Handler) CopyFilesFromMachine(stream shellpb.ShellService_CopyFilesFromMachineServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	md := req.GetMetadata()
+	if md == nil {
+		return protocolError("first request must be metadata")
+	}
+	root, err := os.OpenRoot(h.root)
+	if err != nil {
+		return toStatus(err)
+	}
+	//nolint:errcheck
+	defer root.Close()
+
+	fsys := root.FS()
+	srcs := make([]source, 0, len(md.GetPaths()))
+	for _, p := range md.GetPaths() {
+		name, err := localPath(p)
+		if err != nil {
+			return err
+		}
+		wire := path.Base(name)
+		if name == "." {
+			wire = filepath.Base(h.root)
+		}
+		srcs = append(srcs, source{fsys: fsys, name: name, wire: wire})
+	}
+	sourceType, err := sourceTypeOf(srcs)
+	if err != nil {
+		return toStatus(err)
+	}
+	if err := stream.Send(&shellpb.CopyFilesFromMachineResponse{
+		Response: &shellpb.CopyFilesFromMachineResponse_Metadata{
+			Metadata: &shellpb.CopyFilesFromMachineResponseMetadata{SourceType: sourceType},
+		},
+	}); err != nil {
+		return err
+	}
+
+	o := *h.o
+	o.preserve = md.GetPreserve()
+	o.recursive = md.GetAllowRecursion()
+	return toStatus(sendFiles(srcs, &o,
+		func(fd *shellpb.FileData) error {
+			return stream.Send(&shellpb.CopyFilesFromMachineResponse{
+				Response: &shellpb.CopyFilesFromMachineResponse_FileData{FileData: fd},
+			})
+		},
+		func() error {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return protocolError("stream ended before the last file was acknowledged")
+			}
+			if err != nil {
+				return err
+			}
+			if _, ok := req.GetRequest().(*shellpb.CopyFilesFromMachineRequest_AckLastFile); !ok {
+				return protocolError("expected an acknowledgement")
+			}
+			return nil
+		},
+	))
+}
+
+// localPath returns p as a slash-separated path relative to the root, rejecting paths outside of it.
+func localPath(p string) (string, error) {
+	rel := path.Clean(strings.TrimLeft(filepath.ToSlash(p), "/"))
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", protocolError("path %q is outside of the root", p)
+	}
+	return rel, nil
+}
@@ -0,0 +1,27 @@
+//go:build linux || darwin
+
+package copyfiles
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// setModTime sets the access and modification times of the open file f to mtime.
+func setModTime(f *os.File, mtime time.Time) error {
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	tv := unix.NsecToTimeval(mtime.UnixNano())
+	var fnErr error
+	if err := rc.Control(func(fd uintptr) { fnErr = unix.Futimes(int(fd), []unix.Timeval{tv, tv}) }); err != nil {
+		return err
+	}
+	if fnErr != nil {
+		return &os.PathError{Op: "futimes", Path: f.Name(), Err: fnErr}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package ptyshell
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// Attach runs an interactive session with the shell service with the given name, until the shell exits.
+// When stdin is a terminal, it is put in raw mode for the duration of the session, so that keys such as
+// Ctrl-C reach the shell as input, and resizes of the terminal are forwarded; otherwise an interrupt of
+// the process is forwarded to the shell as a signal, and the end of stdin closes the shell's input.
+//
+// Attach returns an *ExitError if the shell exits with a non-zero code or is killed. Reading stdin cannot
+// be interrupted, so a read in progress when the shell exits continues in the background, and the data
+// it reads is discarded.
+func Attach(ctx context.Context, client shellpb.ShellServiceClient, name string, opts ...Option) error {
+	o := newOptions(opts)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	first := &shellpb.ShellRequest{Name: name}
+	fd, tty := terminal(o.stdin)
+	if tty {
+		ws, err := windowSize(fd)
+		if err != nil {
+			return err
+		}
+		first.WindowSize = ws
+		first.Term = os.Getenv("TERM")
+		restore, err := makeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	stream, err := client.Shell(ctx)
+	if err != nil {
+		return err
+	}
+	// Requests are sent from the input and signal goroutines, and a stream only allows one sender.
+	var mu sync.Mutex
+	send := func(req *shellpb.ShellRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(req)
+	}
+	if err := send(first); err != nil {
+		if errors.Is(err, io.EOF) {
+			_, err = stream.Recv()
+		}
+		return err
+	}
+
+	sigs := make(chan os.Signal, 1)
+	if tty {
+		notifyResize(sigs)
+	} else {
+		signal.Notify(sigs, os.Interrupt)
+	}
+	defer signal.Stop(sigs)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigs:
+				req := &shellpb.ShellRequest{Signal: "INT"}
+				if sig != os.Interrupt {
+					ws, err := windowSize(fd)
+					if err != nil {
+						continue
+					}
+					req = &shellpb.ShellRequest{WindowSize: ws}
+				}
+				if send(req) != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		var u utf8Buffer
+		buf := make([]byte, 32<<10)
+		for {
+			n, err := o.stdin.Read(buf)
+			if ctx.Err() != nil {
+				return
+			}
+			if n > 0 {
+				if s := u.text(buf[:n]); s != "" {
+					if send(&shellpb.ShellRequest{DataIn: s}) != nil {
+						return
+					}
+				}
+			}
+			if err != nil {
+				if s := u.flush(); s != "" {
+					if send(&shellpb.ShellRequest{DataIn: s}) != nil {
+						return
+					}
+				}
+				mu.Lock()
+				//nolint:errcheck
+				stream.CloseSend()
+				mu.Unlock()
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			// The machine ended the session without an exit status.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if s := resp.GetDataOut(); s != "" {
+			if _, err := io.WriteString(o.stdout, s); err != nil {
+				return err
+			}
+		}
+		if s := resp.GetDataErr(); s != "" {
+			if _, err := io.WriteString(o.stderr, s); err != nil {
+				return err
+			}
+		}
+		if resp.GetEof() {
+			st := resp.GetExitStatus()
+			if st.GetCode() != 0 || st.GetSignal() != "" {
+				return &ExitError{Code: int(st.GetCode()), Signal: st.GetSignal()}
+			}
+			return nil
+		}
+	}
+}
+
+// terminal returns the file descriptor of r, and whether it is a terminal.
+func terminal(r io.Reader) (int, bool) {
+	f, ok := r.(*os.File)
+	if !ok {
+		return -1, false
+	}
+	fd := int(f.Fd())
+	return fd, isTerminal(fd)
+}
@@ -0,0 +1,47 @@
+//go:build !linux && !darwin
+
+package ptyshell
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+var errUnsupported = errors.New("shells are not supported on this platform")
+
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (func(), error) {
+	return nil, errUnsupported
+}
+
+func windowSize(fd int) (*shellpb.WindowSize, error) {
+	return nil, errUnsupported
+}
+
+func notifyResize(c chan<- os.Signal) {}
+
+func startProcess(cmd *exec.Cmd, ws *shellpb.WindowSize) (*process, error) {
+	return nil, errUnsupported
+}
+
+func (p *process) resize(ws *shellpb.WindowSize) error {
+	return errUnsupported
+}
+
+func (p *process) signal(name string) error {
+	return errUnsupported
+}
+
+func (p *process) hangup() {}
+
+func (p *process) kill() {}
+
+func exitStatus(state *os.ProcessState) *shellpb.ExitStatus {
+	return &shellpb.ExitStatus{Code: int32(state.ExitCode())}
+}
@@ -0,0 +1,133 @@
+//go:build linux || darwin
+
+package ptyshell
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+// startProcess starts cmd in a new session under a pseudo-terminal of size ws or, if ws is nil, in a new
+// process group with pipes, so that signals reach the processes the shell starts too.
+func startProcess(cmd *exec.Cmd, ws *shellpb.WindowSize) (*process, error) {
+	if ws != nil {
+		return startPTY(cmd, ws)
+	}
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		closeAll(stdinR, stdinW)
+		return nil, err
+	}
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		closeAll(stdinR, stdinW, stdoutR, stdoutW)
+		return nil, err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdinR, stdoutW, stderrW
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	err = cmd.Start()
+	// The child has its own copies of its ends of the pipes.
+	closeAll(stdinR, stdoutW, stderrW)
+	if err != nil {
+		closeAll(stdinW, stdoutR, stderrR)
+		return nil, err
+	}
+	return &process{cmd: cmd, stdin: stdinW, outputs: []*os.File{stdoutR, stderrR}}, nil
+}
+
+func startPTY(cmd *exec.Cmd, ws *shellpb.WindowSize) (*process, error) {
+	pty, tty, err := openPTY()
+	if err != nil {
+		return nil, err
+	}
+	p := &process{cmd: cmd, stdin: pty, pty: pty, outputs: []*os.File{pty}}
+	if err := p.resize(ws); err != nil {
+		closeAll(pty, tty)
+		return nil, err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+	err = cmd.Start()
+	closeAll(tty)
+	if err != nil {
+		closeAll(pty)
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *process) resize(ws *shellpb.WindowSize) error {
+	return control(p.pty, func(fd int) error { return setWindowSize(fd, ws) })
+}
+
+// signal delivers the signal with the given name, without the SIG prefix, to the foreground process group
+// of the pseudo-terminal, or to the process group of the shell.
+func (p *process) signal(name string) error {
+	sig := unix.SignalNum("SIG" + strings.ToUpper(name))
+	if sig == 0 {
+		return unix.EINVAL
+	}
+	pgid := p.cmd.Process.Pid
+	if p.pty != nil {
+		//nolint:errcheck
+		control(p.pty, func(fd int) error {
+			fg, err := unix.IoctlGetInt(fd, unix.TIOCGPGRP)
+			if err == nil && fg > 0 {
+				pgid = fg
+			}
+			return err
+		})
+	}
+	return unix.Kill(-pgid, sig)
+}
+
+func (p *process) hangup() {
+	//nolint:errcheck
+	unix.Kill(-p.cmd.Process.Pid, unix.SIGHUP)
+}
+
+func (p *process) kill() {
+	//nolint:errcheck
+	unix.Kill(-p.cmd.Process.Pid, unix.SIGKILL)
+}
+
+func exitStatus(state *os.ProcessState) *shellpb.ExitStatus {
+	if state == nil {
+		return &shellpb.ExitStatus{Code: -1}
+	}
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return &shellpb.ExitStatus{Code: -1, Signal: strings.TrimPrefix(unix.SignalName(ws.Signal()), "SIG")}
+	}
+	return &shellpb.ExitStatus{Code: int32(state.ExitCode())}
+}
+
+// control calls fn with the descriptor of f. Unlike f.Fd, it leaves f in non-blocking mode, which read
+// deadlines need.
+func control(f *os.File, fn func(fd int) error) error {
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var fnErr error
+	if err := rc.Control(func(fd uintptr) { fnErr = fn(int(fd)) }); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+func closeAll(files ...*os.File) {
+	for _, f := range files {
+		//nolint:errcheck
+		f.Close()
+	}
+}
@@ -0,0 +1,101 @@
+// Package ptyshell implements the interactive Shell method of ShellService, on both the client and the
+// machine side.
+//
+// The first ShellRequest names the shell service. When the client has a terminal, it also carries the
+// terminal's window size and type, and the machine runs the shell under a pseudo-terminal of that size;
+// otherwise the shell runs with plain pipes and its standard error is sent separately. Later requests
+// carry input, window size changes and signals. The machine streams the shell's output back and, once
+// the shell has exited and its output is drained, sends a final response with eof and the exit status.
+//
+// Data is carried as strings, so output that is not valid UTF-8 has the invalid bytes replaced; a rune
+// split between two reads is held back and sent whole.
+package ptyshell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExitError is returned by Attach when the shell exits with a non-zero code or is killed by a signal.
+type ExitError struct {
+	// Code is the exit code of the shell, or -1 if it was killed by a signal.
+	Code int
+	// Signal is the name of the signal that killed the shell, without the SIG prefix.
+	Signal string
+}
+
+func (e *ExitError) Error() string {
+	if e.Signal != "" {
+		return "ptyshell: shell killed by SIG" + e.Signal
+	}
+	return fmt.Sprintf("ptyshell: shell exited with code %d", e.Code)
+}
+
+type options struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	env    []string
+}
+
+// Option configures a shell session.
+type Option func(*options)
+
+// WithIO sets the input and outputs of a session on the client side, which default to the standard
+// input, output and error of the process. The terminal is only put in raw mode, and its size and
+// resizes only reported, when stdin is a terminal.
+func WithIO(stdin io.Reader, stdout, stderr io.Writer) Option {
+	return func(o *options) {
+		o.stdin = stdin
+		o.stdout = stdout
+		o.stderr = stderr
+	}
+}
+
+// WithEnv adds environment variables, in the form "key=value", to the shells run by a Handler.
+func WithEnv(env ...string) Option {
+	return func(o *options) {
+		o.env = append(o.env, env...)
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// utf8Buffer turns a stream of bytes into strings of valid UTF-8, holding back a rune that is split
+// across reads until the rest of it arrives.
+type utf8Buffer struct {
+	pending []byte
+}
+
+// text returns the valid text of the pending bytes followed by p.
+func (u *utf8Buffer) text(p []byte) string {
+	b := append(u.pending, p...)
+	cut := len(b)
+	// A rune is at most utf8.UTFMax bytes long, so only the last few bytes can start an incomplete one.
+	for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+		if utf8.RuneStart(b[len(b)-i]) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				cut = len(b) - i
+			}
+			break
+		}
+	}
+	u.pending = append(u.pending[:0:0], b[cut:]...)
+	return strings.ToValidUTF8(string(b[:cut]), string(utf8.RuneError))
+}
+
+// flush returns the bytes held back, which can no longer be completed.
+func (u *utf8Buffer) flush() string {
+	s := strings.ToValidUTF8(string(u.pending), string(utf8.RuneError))
+	u.pending = nil
+	return s
+}
@@ -0,0 +1,275 @@
+package ptyshell
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+func TestUTF8Buffer(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		reads  []string
+		want   []string
+		flushd string
+	}{
+		{"ascii", []string{"ab", "c"}, []string{"ab", "c"}, ""},
+		{"split rune", []string{"a\xe2\x82", "\xac b"}, []string{"a", "€ b"}, ""},
+		{"rune split three ways", []string{"\xf0\x9f", "\x98", "\x80"}, []string{"", "", "😀"}, ""},
+		{"invalid bytes", []string{"a\xffb"}, []string{"a�b"}, ""},
+		{"incomplete at the end", []string{"a\xe2\x82"}, []string{"a"}, "�"},
+		{"continuation without start", []string{"\x82\x82"}, []string{"�"}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var u utf8Buffer
+			for i, r := range tc.reads {
+				if got := u.text([]byte(r)); got != tc.want[i] {
+					t.Errorf("read %d = %q, want %q", i, got, tc.want[i])
+				}
+			}
+			if got := u.flush(); got != tc.flushd {
+				t.Errorf("flush = %q, want %q", got, tc.flushd)
+			}
+		})
+	}
+}
+
+func TestExitError(t *testing.T) {
+	for _, tc := range []struct {
+		err  *ExitError
+		want string
+	}{
+		{&ExitError{Code: 3}, "ptyshell: shell exited with code 3"},
+		{&ExitError{Code: -1, Signal: "INT"}, "ptyshell: shell killed by SIGINT"},
+	} {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("Error() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+type shellServer struct {
+	shellpb.UnimplementedShellServiceServer
+	h *Handler
+}
+
+func (s *shellServer) Shell(stream shellpb.ShellService_ShellServer) error {
+	return s.h.Shell(stream)
+}
+
+// serve returns a client of a shell service running command.
+func serve(t *testing.T, command []string, opts ...Option) shellpb.ShellServiceClient {
+	t.Helper()
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("shells are not supported on " + runtime.GOOS)
+	}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	shellpb.RegisterShellServiceServer(srv, &shellServer{h: NewHandler(command, opts...)})
+	go func() {
+		//nolint:errcheck
+		srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		//nolint:errcheck
+		conn.Close()
+	})
+	return shellpb.NewShellServiceClient(conn)
+}
+
+// syncBuffer is a bytes.Buffer that is safe to write while being read.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.String()
+}
+
+func TestAttach(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		script  string
+		stdin   string
+		stdout  string
+		stderr  string
+		exitErr *ExitError
+	}{
+		{"output", "echo out; echo err >&2", "", "out\n", "err\n", nil},
+		{"input", "tr a-z A-Z", "hello\n", "HELLO\n", "", nil},
+		{"exit code", "exit 3", "", "", "", &ExitError{Code: 3}},
+		{"environment", `printf %s "$GREETING"`, "", "hi", "", nil},
+		{"killed", "kill -TERM $$", "", "", "", &ExitError{Code: -1, Signal: "TERM"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := serve(t, []string{"/bin/sh", "-c", tc.script}, WithEnv("GREETING=hi"))
+			var stdout, stderr syncBuffer
+			err := Attach(context.Background(), client, "shell", WithIO(strings.NewReader(tc.stdin), &stdout, &stderr))
+			var exitErr *ExitError
+			switch {
+			case tc.exitErr == nil && err != nil:
+				t.Errorf("Attach = %v", err)
+			case tc.exitErr != nil && (!errors.As(err, &exitErr) || *exitErr != *tc.exitErr):
+				t.Errorf("Attach = %v, want %v", err, tc.exitErr)
+			}
+			if stdout.String() != tc.stdout || stderr.String() != tc.stderr {
+				t.Errorf("stdout = %q, stderr = %q; want %q, %q", stdout.String(), stderr.String(), tc.stdout, tc.stderr)
+			}
+		})
+	}
+}
+
+// session runs command through the Shell stream directly, sending first.
+func session(t *testing.T, command []string, first *shellpb.ShellRequest) shellpb.ShellService_ShellClient {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream, err := serve(t, command).Shell(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(first); err != nil {
+		t.Fatal(err)
+	}
+	return stream
+}
+
+// readUntil reads output from stream until it contains want, and returns it.
+func readUntil(t *testing.T, stream shellpb.ShellService_ShellClient, want string) string {
+	t.Helper()
+	var out strings.Builder
+	for !strings.Contains(out.String(), want) {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv after %q: %v", out.String(), err)
+		}
+		if resp.GetEof() {
+			t.Fatalf("the shell exited after %q: %v", out.String(), resp.GetExitStatus())
+		}
+		out.WriteString(resp.GetDataOut())
+	}
+	return out.String()
+}
+
+// exitStatusOf reads the rest of the session and returns its exit status.
+func exitStatusOf(t *testing.T, stream shellpb.ShellService_ShellClient) *shellpb.ExitStatus {
+	t.Helper()
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetEof() {
+			return resp.GetExitStatus()
+		}
+	}
+}
+
+func TestSignal(t *testing.T) {
+	stream := session(t, []string{"/bin/sh", "-c", "echo ready; sleep 10"}, &shellpb.ShellRequest{Name: "shell"})
+	readUntil(t, stream, "ready")
+	if err := stream.Send(&shellpb.ShellRequest{Signal: "int"}); err != nil {
+		t.Fatal(err)
+	}
+	if st := exitStatusOf(t, stream); st.GetSignal() != "INT" {
+		t.Errorf("exit status = %v", st)
+	}
+}
+
+func TestPTY(t *testing.T) {
+	ws := &shellpb.WindowSize{Rows: 24, Cols: 80}
+	probe := session(t, []string{"/bin/sh", "-c", "true"}, &shellpb.ShellRequest{Name: "shell", WindowSize: ws})
+	if _, err := probe.Recv(); status.Code(err) == codes.FailedPrecondition {
+		t.Skipf("no pseudo-terminals: %v", err)
+	}
+
+	stream := session(t, []string{"/bin/sh"}, &shellpb.ShellRequest{Name: "shell", WindowSize: ws})
+	if err := stream.Send(&shellpb.ShellRequest{DataIn: "stty size; echo $TERM\n"}); err != nil {
+		t.Fatal(err)
+	}
+	if out := readUntil(t, stream, "xterm"); !strings.Contains(out, "24 80") {
+		t.Errorf("output = %q, want the window size", out)
+	}
+
+	// A resize is applied, and Ctrl-C interrupts the foreground command rather than the shell.
+	if err := stream.Send(&shellpb.ShellRequest{WindowSize: &shellpb.WindowSize{Rows: 50, Cols: 132}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&shellpb.ShellRequest{DataIn: "sleep 10\n"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := stream.Send(&shellpb.ShellRequest{DataIn: "\x03stty size\n"}); err != nil {
+		t.Fatal(err)
+	}
+	readUntil(t, stream, "50 132")
+	if err := stream.Send(&shellpb.ShellRequest{DataIn: "exit 4\n"}); err != nil {
+		t.Fatal(err)
+	}
+	if st := exitStatusOf(t, stream); st.GetCode() != 4 {
+		t.Errorf("exit status = %v", st)
+	}
+}
+
+func TestClientGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := serve(t, []string{"/bin/sh", "-c", "echo ready; sleep 10"}).Shell(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&shellpb.ShellRequest{Name: "shell"}); err != nil {
+		t.Fatal(err)
+	}
+	readUntil(t, stream, "ready")
+	start := time.Now()
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("Recv = %v", err)
+	}
+	// The handler returns once the hung up shell has exited, which lets the server stop.
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("took %v", time.Since(start))
+	}
+}
+
+func TestStartFailure(t *testing.T) {
+	stream := session(t, []string{"/nonexistent/shell"}, &shellpb.ShellRequest{Name: "shell"})
+	if _, err := stream.Recv(); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Recv = %v", err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) && status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second Recv = %v", err)
+	}
+}
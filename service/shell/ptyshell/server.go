@@ -0,0 +1,201 @@
+package ptyshell
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+const (
+	// defaultTerm is the TERM of shells run under a pseudo-terminal when the client does not send one.
+	defaultTerm = "xterm"
+	// drainTimeout bounds how long output is read after the shell exits, in case a process it started in
+	// the background keeps the terminal or pipes open.
+	drainTimeout = time.Second
+	// hangupTimeout is how long a shell has to exit after being hung up before it is killed.
+	hangupTimeout = 5 * time.Second
+)
+
+// Handler serves the Shell method by running a command for each session. A ShellServiceServer implements
+// Shell by calling a Handler.
+type Handler struct {
+	command []string
+	o       *options
+}
+
+// NewHandler returns a Handler running command, or the user's shell if command is empty: $SHELL, or
+// /bin/sh if it is not set. Only WithEnv applies.
+func NewHandler(command []string, opts ...Option) *Handler {
+	if len(command) == 0 {
+		sh := os.Getenv("SHELL")
+		if sh == "" {
+			sh = "/bin/sh"
+		}
+		command = []string{sh}
+	}
+	return &Handler{command: command, o: newOptions(opts)}
+}
+
+// process is a running shell.
+type process struct {
+	cmd *exec.Cmd
+	// stdin is the input of the shell: the pseudo-terminal, or a pipe.
+	stdin io.WriteCloser
+	// pty is the pseudo-terminal the shell runs under, if any.
+	pty *os.File
+	// outputs are the pseudo-terminal, or the pipes of the standard output and error of the shell.
+	outputs []*os.File
+}
+
+// Shell runs a session: the command is started when the first request arrives, and the session ends
+// once it has exited. If the client goes away first, the command is hung up, and killed if it does not
+// exit in time.
+func (h *Handler) Shell(stream shellpb.ShellService_ShellServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(h.command[0], h.command[1:]...)
+	cmd.Env = append(os.Environ(), h.o.env...)
+	ws := req.GetWindowSize()
+	if ws != nil {
+		term := req.GetTerm()
+		if term == "" {
+			term = defaultTerm
+		}
+		cmd.Env = append(cmd.Env, "TERM="+term)
+	}
+	p, err := startProcess(cmd, ws)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "ptyshell: %v", err)
+	}
+
+	// Output is sent from one goroutine per output, and a stream only allows one sender.
+	var mu sync.Mutex
+	send := func(resp *shellpb.ShellResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(resp)
+	}
+	var readers sync.WaitGroup
+	for i, f := range p.outputs {
+		isErr := i == 1
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			forwardOutput(f, isErr, send)
+		}()
+	}
+
+	go func(req *shellpb.ShellRequest) {
+		for {
+			p.handle(req)
+			var err error
+			req, err = stream.Recv()
+			if errors.Is(err, io.EOF) && p.pty == nil {
+				//nolint:errcheck
+				p.stdin.Close()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}(req)
+
+	exited := make(chan struct{})
+	go func() {
+		//nolint:errcheck
+		cmd.Wait()
+		close(exited)
+	}()
+	ctx := stream.Context()
+	select {
+	case <-exited:
+	case <-ctx.Done():
+		p.hangup()
+		select {
+		case <-exited:
+		case <-time.After(hangupTimeout):
+			p.kill()
+			<-exited
+		}
+	}
+
+	deadline := time.Now().Add(drainTimeout)
+	for _, f := range p.outputs {
+		//nolint:errcheck
+		f.SetReadDeadline(deadline)
+	}
+	readers.Wait()
+	p.close()
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return send(&shellpb.ShellResponse{Eof: true, ExitStatus: exitStatus(cmd.ProcessState)})
+}
+
+// handle applies a request to the shell. Signals that are unknown on this platform are ignored, since the
+// client cannot be told about them without ending the session.
+func (p *process) handle(req *shellpb.ShellRequest) {
+	if ws := req.GetWindowSize(); ws != nil && p.pty != nil {
+		//nolint:errcheck
+		p.resize(ws)
+	}
+	if s := req.GetDataIn(); s != "" {
+		//nolint:errcheck
+		io.WriteString(p.stdin, s)
+	}
+	if sig := req.GetSignal(); sig != "" {
+		//nolint:errcheck
+		p.signal(sig)
+	}
+}
+
+func (p *process) close() {
+	for _, f := range p.outputs {
+		//nolint:errcheck
+		f.Close()
+	}
+	if p.pty == nil {
+		//nolint:errcheck
+		p.stdin.Close()
+	}
+}
+
+// forwardOutput sends what is read from f until it fails, which it does once the shell and any process
+// sharing its outputs have exited, or the read deadline has passed.
+func forwardOutput(f *os.File, isErr bool, send func(*shellpb.ShellResponse) error) {
+	var u utf8Buffer
+	out := func(s string) error {
+		if isErr {
+			return send(&shellpb.ShellResponse{DataErr: s})
+		}
+		return send(&shellpb.ShellResponse{DataOut: s})
+	}
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if s := u.text(buf[:n]); s != "" {
+				if out(s) != nil {
+					return
+				}
+			}
+		}
+		if err != nil {
+			if s := u.flush(); s != "" {
+				//nolint:errcheck
+				out(s)
+			}
+			return
+		}
+	}
+}
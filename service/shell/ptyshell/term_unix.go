@@ -0,0 +1,70 @@
+//go:build linux || darwin
+
+package ptyshell
+
+import (
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+
+	shellpb "go.viam.com/api/service/shell/v1"
+)
+
+func isTerminal(fd int) bool {
+	_, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	return err == nil
+}
+
+// makeRaw puts a terminal in raw mode, as cfmakeraw does, and returns a function restoring its state.
+func makeRaw(fd int) (func(), error) {
+	old, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	raw := *old
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() {
+		//nolint:errcheck
+		unix.IoctlSetTermios(fd, ioctlSetTermios, old)
+	}, nil
+}
+
+func windowSize(fd int) (*shellpb.WindowSize, error) {
+	ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil {
+		return nil, err
+	}
+	return &shellpb.WindowSize{
+		Rows:         uint32(ws.Row),
+		Cols:         uint32(ws.Col),
+		WidthPixels:  uint32(ws.Xpixel),
+		HeightPixels: uint32(ws.Ypixel),
+	}, nil
+}
+
+func setWindowSize(fd int, ws *shellpb.WindowSize) error {
+	return unix.IoctlSetWinsize(fd, unix.TIOCSWINSZ, &unix.Winsize{
+		Row:    clampUint16(ws.GetRows()),
+		Col:    clampUint16(ws.GetCols()),
+		Xpixel: clampUint16(ws.GetWidthPixels()),
+		Ypixel: clampUint16(ws.GetHeightPixels()),
+	})
+}
+
+func clampUint16(v uint32) uint16 {
+	return uint16(min(v, 1<<16-1))
+}
+
+func notifyResize(c chan<- os.Signal) {
+	signal.Notify(c, unix.SIGWINCH)
+}